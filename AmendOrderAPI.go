@@ -0,0 +1,166 @@
+package goex
+
+import (
+	"errors"
+)
+
+// 改单, 修改未完成订单的价格和(或)数量
+// 交易所支持原子改单时(bitmex、okex)订单ID不变; kraken、币安现货为撤单后下新单, 返回新订单ID
+type AmendOrderAPI interface {
+	/**
+	 * @param ord Currency 必填, OrderID2 和 Cid 至少填一个, OrderID2 为空时按 Cid 改单
+	 *            不支持按 Cid 改单的交易所(如 kraken)只有 Cid 时返回 ErrAmendOrderId
+	 *            Price 和 Amount 为修改后的价格和委托总数量(含已成交部分), 为0时不修改
+	 * @return 改单后的订单, OrderID2 可能与原订单不同
+	 */
+	AmendOrder(ord *Order) (*Order, error)
+}
+
+// 合约改单, 约定同 AmendOrderAPI
+type AmendFutureOrderAPI interface {
+	/**
+	 * @param ord Currency、ContractName 必填, OrderID2 和 ClientOid 至少填一个, OrderID2 为空时按 ClientOid 改单
+	 *            不支持按 ClientOid 改单的交易所只有 ClientOid 时返回 ErrAmendOrderId
+	 */
+	AmendFutureOrder(ord *FutureOrder) (*FutureOrder, error)
+}
+
+var (
+	ErrAmendOrderId = errors.New("amend order: order id is required")
+	ErrAmendFilled  = errors.New("amend order: new amount is not greater than deal amount")
+)
+
+// NewAmendOrderAPI 交易所支持时返回原生实现, 否则返回撤单后重新下单的模拟实现
+// api 可以是 InstrumentAPI 包装后的对象
+func NewAmendOrderAPI(api API) AmendOrderAPI {
+	if amendApi, ok := UnwrapAPI(api).(AmendOrderAPI); ok {
+		return amendApi
+	}
+	return &cancelReplaceOrder{api: api}
+}
+
+// NewAmendFutureOrderAPI 同 NewAmendOrderAPI
+func NewAmendFutureOrderAPI(api FutureRestAPI) AmendFutureOrderAPI {
+	if amendApi, ok := UnwrapFutureRestAPI(api).(AmendFutureOrderAPI); ok {
+		return amendApi
+	}
+	return &cancelReplaceFutureOrder{api: api}
+}
+
+// cancelReplaceOrder 先撤单, 再按撤单后的已成交数量补下剩余部分, 新订单会重新排队
+// 只有 Cid 时需要 api 实现 ClientOrderAPI
+type cancelReplaceOrder struct {
+	api API
+}
+
+func (c *cancelReplaceOrder) AmendOrder(ord *Order) (*Order, error) {
+	if ord == nil {
+		return nil, ErrAmendOrderId
+	}
+
+	var (
+		cancel = func() (bool, error) { return c.api.CancelOrder(ord.OrderID2, ord.Currency) }
+		get    = func() (*Order, error) { return c.api.GetOneOrder(ord.OrderID2, ord.Currency) }
+	)
+	if ord.OrderID2 == "" {
+		cidApi, ok := UnwrapAPI(c.api).(ClientOrderAPI)
+		if !ok || ord.Cid == "" {
+			return nil, ErrAmendOrderId
+		}
+		cancel = func() (bool, error) { return cidApi.CancelOrderByCid(ord.Cid, ord.Currency) }
+		get = func() (*Order, error) { return cidApi.GetOneOrderByCid(ord.Cid, ord.Currency) }
+	}
+
+	_, err := cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := get()
+	if err != nil {
+		return nil, err
+	}
+
+	price, amount := ord.Price, ord.Amount
+	if price <= 0 {
+		price = old.Price
+	}
+	if amount <= 0 {
+		amount = old.Amount
+	}
+	if amount <= old.DealAmount {
+		return old, ErrAmendFilled
+	}
+
+	var (
+		remain  = FloatToString(amount-old.DealAmount, 8)
+		limitOp = AdaptOrderFeatureToLimitOpt(ord.OrderType)
+	)
+	switch old.Side {
+	case BUY:
+		return c.api.LimitBuy(remain, FloatToString(price, 8), ord.Currency, limitOp...)
+	case SELL:
+		return c.api.LimitSell(remain, FloatToString(price, 8), ord.Currency, limitOp...)
+	}
+
+	return old, errors.New("amend order: only support limit order")
+}
+
+// cancelReplaceFutureOrder 同 cancelReplaceOrder, 只有 ClientOid 时需要 api 实现 FutureClientOrderAPI
+type cancelReplaceFutureOrder struct {
+	api FutureRestAPI
+}
+
+func (c *cancelReplaceFutureOrder) AmendFutureOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord == nil {
+		return nil, ErrAmendOrderId
+	}
+
+	var (
+		cancel = func() (bool, error) {
+			return c.api.FutureCancelOrder(ord.Currency, ord.ContractName, ord.OrderID2)
+		}
+		get = func() (*FutureOrder, error) {
+			return c.api.GetFutureOrder(ord.OrderID2, ord.Currency, ord.ContractName)
+		}
+	)
+	if ord.OrderID2 == "" {
+		cidApi, ok := UnwrapFutureRestAPI(c.api).(FutureClientOrderAPI)
+		if !ok || ord.ClientOid == "" {
+			return nil, ErrAmendOrderId
+		}
+		cancel = func() (bool, error) {
+			return cidApi.FutureCancelOrderByCid(ord.Currency, ord.ContractName, ord.ClientOid)
+		}
+		get = func() (*FutureOrder, error) {
+			return cidApi.GetFutureOrderByCid(ord.ClientOid, ord.Currency, ord.ContractName)
+		}
+	}
+
+	_, err := cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := get()
+	if err != nil {
+		return nil, err
+	}
+
+	price, amount, oType := ord.Price, ord.Amount, ord.OType
+	if price <= 0 {
+		price = old.Price
+	}
+	if amount <= 0 {
+		amount = old.Amount
+	}
+	if oType == 0 {
+		oType = old.OType
+	}
+	if amount <= old.DealAmount {
+		return old, ErrAmendFilled
+	}
+
+	return c.api.LimitFuturesOrder(ord.Currency, ord.ContractName, FloatToString(price, 8),
+		FloatToString(amount-old.DealAmount, 8), oType, AdaptOrderFeatureToLimitOpt(ord.OrderType)...)
+}
@@ -0,0 +1,70 @@
+package goex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type amendMockSpot struct {
+	batchMockSpot
+
+	old    Order
+	amount string
+	price  string
+}
+
+func (m *amendMockSpot) GetOneOrder(orderId string, currency CurrencyPair) (*Order, error) {
+	ord := m.old
+	return &ord, nil
+}
+
+func (m *amendMockSpot) LimitSell(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	m.amount, m.price = amount, price
+	return m.limit(amount, price, currency, SELL)
+}
+
+// amendCidMockSpot 支持按 Cid 查询和撤单
+type amendCidMockSpot struct {
+	amendMockSpot
+}
+
+func (m *amendCidMockSpot) LimitOrderWithCid(ord *Order) (*Order, error) {
+	return nil, nil
+}
+
+func (m *amendCidMockSpot) GetOneOrderByCid(cid string, currency CurrencyPair) (*Order, error) {
+	return m.GetOneOrder(m.old.OrderID2, currency)
+}
+
+func (m *amendCidMockSpot) CancelOrderByCid(cid string, currency CurrencyPair) (bool, error) {
+	return m.CancelOrder("cid:"+cid, currency)
+}
+
+func TestNewAmendOrderAPI(t *testing.T) {
+	mock := &amendMockSpot{old: Order{OrderID2: "9", Side: SELL, Price: 100, Amount: 3, DealAmount: 1}}
+	api := NewAmendOrderAPI(mock)
+
+	_, err := api.AmendOrder(&Order{Currency: BTC_USDT, Price: 101})
+	assert.Equal(t, ErrAmendOrderId, err)
+
+	ord, err := api.AmendOrder(&Order{OrderID2: "9", Currency: BTC_USDT, Price: 101})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", ord.OrderID2)
+	assert.Equal(t, []string{"9"}, mock.canceled)
+	assert.Equal(t, "2", mock.amount)
+	assert.Equal(t, "101", mock.price)
+
+	_, err = api.AmendOrder(&Order{OrderID2: "9", Currency: BTC_USDT, Amount: 1})
+	assert.Equal(t, ErrAmendFilled, err)
+
+	_, err = api.AmendOrder(&Order{Cid: "c9", Currency: BTC_USDT, Price: 101})
+	assert.Equal(t, ErrAmendOrderId, err)
+
+	cidMock := &amendCidMockSpot{amendMockSpot{old: mock.old}}
+	ord, err = NewAmendOrderAPI(cidMock).AmendOrder(&Order{Cid: "c9", Currency: BTC_USDT, Price: 101})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", ord.OrderID2)
+	assert.Equal(t, []string{"cid:c9"}, cidMock.canceled)
+	assert.Equal(t, "2", cidMock.amount)
+}
@@ -28,4 +28,9 @@ var (
 	EX_ERR_INVALID_CURRENCY_PAIR = ApiError{ErrCode: "EX_ERR_0007", ErrMsg: "invalid currency pair"}
 	EX_ERR_NOT_FIND_ORDER        = ApiError{ErrCode: "EX_ERR_0008", ErrMsg: "not find order"}
 	EX_ERR_SYMBOL_ERR            = ApiError{ErrCode: "EX_ERR_0009", ErrMsg: "symbol error"}
+	EX_ERR_NOT_SUPPORT           = ApiError{ErrCode: "EX_ERR_0010", ErrMsg: "not support"}
+	EX_ERR_ORDER_STATUS_UNKNOWN  = ApiError{ErrCode: "EX_ERR_0011", ErrMsg: "order status unknown"}
+	EX_ERR_UNKNOWN_SYMBOL        = ApiError{ErrCode: "EX_ERR_0012", ErrMsg: "unknown symbol"}
+	EX_ERR_UNKNOWN_CONTRACT      = ApiError{ErrCode: "EX_ERR_0013", ErrMsg: "unknown contract"}
+	EX_ERR_CONTRACT_EXPIRED      = ApiError{ErrCode: "EX_ERR_0014", ErrMsg: "contract expired"}
 )
@@ -0,0 +1,251 @@
+package goex
+
+import (
+	"errors"
+	"sync"
+)
+
+// BatchOrderResult 批量下单/撤单/改单中单个订单的结果, 与入参按下标一一对应
+type BatchOrderResult struct {
+	OrderId   string
+	ClientOid string
+	Err       error //为nil表示该订单成功
+}
+
+// 现货批量下单/撤单/改单
+// 超过交易所单次上限时按上限拆分成多次请求, 某一批请求失败时该批所有订单的 Err 为请求错误
+type BatchOrderAPI interface {
+	/**
+	 * 批量下限价单
+	 * @param orders Currency、Side(BUY/SELL)、Price、Amount 必填, OrderType 见 ORDER_FEATURE_*, Cid 为空时自动生成
+	 *               下单成功后会回写 orders[i].OrderID2 与 orders[i].Cid
+	 */
+	BatchLimitOrders(orders []Order) ([]BatchOrderResult, error)
+
+	BatchCancelOrders(currency CurrencyPair, orderIds []string) ([]BatchOrderResult, error)
+
+	/**
+	 * 批量改单
+	 * @param orders OrderID2、Currency、Side 必填, Price 和 Amount 为修改后的价格和数量, 为0时不修改
+	 *               交易所不支持改单时同 AmendOrderAPI 撤单后按剩余数量重新下单, OrderId 为新订单ID
+	 */
+	BatchAmendOrders(orders []Order) ([]BatchOrderResult, error)
+}
+
+// 合约批量下单/撤单/改单, 约定同 BatchOrderAPI
+type BatchFutureOrderAPI interface {
+	/**
+	 * 批量下限价单
+	 * @param orders Currency、ContractName、OType、Price、Amount 必填, OrderType 见 ORDER_FEATURE_*
+	 */
+	BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error)
+
+	BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error)
+
+	/**
+	 * 批量改单
+	 * @param orders OrderID2、Currency、ContractName、OType 必填, Price 和 Amount 为0时不修改
+	 */
+	BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error)
+}
+
+var ErrBatchAmendParam = errors.New("batch amend: price and amount are required when exchange does not support amend")
+
+// NewBatchOrderAPI 交易所支持时返回原生实现, 否则返回用单个订单接口并发实现的 BatchOrderAPI
+// api 可以是 InstrumentAPI 包装后的对象
+func NewBatchOrderAPI(api API) BatchOrderAPI {
+	if batchApi, ok := UnwrapAPI(api).(BatchOrderAPI); ok {
+		return batchApi
+	}
+	return &concurrentBatchOrder{api: api}
+}
+
+// NewBatchFutureOrderAPI 同 NewBatchOrderAPI
+func NewBatchFutureOrderAPI(api FutureRestAPI) BatchFutureOrderAPI {
+	if batchApi, ok := UnwrapFutureRestAPI(api).(BatchFutureOrderAPI); ok {
+		return batchApi
+	}
+	return &concurrentBatchFutureOrder{api: api}
+}
+
+// AdaptOrderFeatureToLimitOpt ORDER_FEATURE_* 转为 LimitOrderOptionalParameter
+func AdaptOrderFeatureToLimitOpt(orderType int) []LimitOrderOptionalParameter {
+	switch orderType {
+	case ORDER_FEATURE_POST_ONLY:
+		return []LimitOrderOptionalParameter{PostOnly}
+	case ORDER_FEATURE_FOK:
+		return []LimitOrderOptionalParameter{Fok}
+	case ORDER_FEATURE_IOC:
+		return []LimitOrderOptionalParameter{Ioc}
+	}
+	return nil
+}
+
+// batchConcurrency 不支持批量接口时并发请求的上限, 避免触发交易所限频
+const batchConcurrency = 5
+
+func concurrentDo(n int, f func(i int) BatchOrderResult) []BatchOrderResult {
+	var (
+		wg      sync.WaitGroup
+		results = make([]BatchOrderResult, n)
+		sem     = make(chan struct{}, batchConcurrency)
+	)
+
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = f(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+type concurrentBatchOrder struct {
+	api API
+}
+
+// place 交易所支持 ClientOrderAPI 时按 Cid 下单, 否则 Cid 不会发送给交易所, 回写为交易所返回的值
+func (c *concurrentBatchOrder) place(ord *Order) BatchOrderResult {
+	var (
+		ret     *Order
+		err     error
+		amount  = FloatToString(ord.Amount, 8)
+		price   = FloatToString(ord.Price, 8)
+		limitOp = AdaptOrderFeatureToLimitOpt(ord.OrderType)
+	)
+
+	cidApi, withCid := UnwrapAPI(c.api).(ClientOrderAPI)
+	switch {
+	case ord.Side != BUY && ord.Side != SELL:
+		err = errors.New("batch place order only support limit order")
+	case withCid:
+		ret, err = cidApi.LimitOrderWithCid(ord)
+	case ord.Side == BUY:
+		ord.Cid = ""
+		ret, err = c.api.LimitBuy(amount, price, ord.Currency, limitOp...)
+	default:
+		ord.Cid = ""
+		ret, err = c.api.LimitSell(amount, price, ord.Currency, limitOp...)
+	}
+
+	if err != nil {
+		return BatchOrderResult{ClientOid: ord.Cid, Err: err}
+	}
+
+	ord.OrderID2 = ret.OrderID2
+	if ret.Cid != "" {
+		ord.Cid = ret.Cid
+	}
+
+	return BatchOrderResult{OrderId: ret.OrderID2, ClientOid: ord.Cid}
+}
+
+func (c *concurrentBatchOrder) BatchLimitOrders(orders []Order) ([]BatchOrderResult, error) {
+	return concurrentDo(len(orders), func(i int) BatchOrderResult {
+		return c.place(&orders[i])
+	}), nil
+}
+
+func (c *concurrentBatchOrder) BatchCancelOrders(currency CurrencyPair, orderIds []string) ([]BatchOrderResult, error) {
+	return concurrentDo(len(orderIds), func(i int) BatchOrderResult {
+		_, err := c.api.CancelOrder(orderIds[i], currency)
+		return BatchOrderResult{OrderId: orderIds[i], Err: err}
+	}), nil
+}
+
+// BatchAmendOrders 逐个使用 NewAmendOrderAPI 改单, 撤单重下时扣除原订单的已成交数量
+func (c *concurrentBatchOrder) BatchAmendOrders(orders []Order) ([]BatchOrderResult, error) {
+	amendApi := NewAmendOrderAPI(c.api)
+	return concurrentDo(len(orders), func(i int) BatchOrderResult {
+		ord := &orders[i]
+		ret, err := amendApi.AmendOrder(ord)
+		if err != nil {
+			return BatchOrderResult{OrderId: ord.OrderID2, ClientOid: ord.Cid, Err: err}
+		}
+		ord.OrderID2, ord.Cid = ret.OrderID2, ret.Cid
+		return BatchOrderResult{OrderId: ret.OrderID2, ClientOid: ret.Cid}
+	}), nil
+}
+
+type concurrentBatchFutureOrder struct {
+	api FutureRestAPI
+}
+
+// place 同 concurrentBatchOrder.place, 交易所支持 FutureClientOrderAPI 时按 ClientOid 下单
+func (c *concurrentBatchFutureOrder) place(ord *FutureOrder) BatchOrderResult {
+	var (
+		ret *FutureOrder
+		err error
+	)
+	if cidApi, ok := UnwrapFutureRestAPI(c.api).(FutureClientOrderAPI); ok {
+		ret, err = cidApi.LimitFuturesOrderWithCid(ord)
+	} else {
+		ord.ClientOid = ""
+		ret, err = c.api.LimitFuturesOrder(ord.Currency, ord.ContractName, FloatToString(ord.Price, 8),
+			FloatToString(ord.Amount, 8), ord.OType, AdaptOrderFeatureToLimitOpt(ord.OrderType)...)
+	}
+	if err != nil {
+		return BatchOrderResult{ClientOid: ord.ClientOid, Err: err}
+	}
+
+	ord.OrderID2 = ret.OrderID2
+	if ret.ClientOid != "" {
+		ord.ClientOid = ret.ClientOid
+	}
+
+	return BatchOrderResult{OrderId: ret.OrderID2, ClientOid: ord.ClientOid}
+}
+
+func (c *concurrentBatchFutureOrder) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	return concurrentDo(len(orders), func(i int) BatchOrderResult {
+		return c.place(&orders[i])
+	}), nil
+}
+
+func (c *concurrentBatchFutureOrder) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	return concurrentDo(len(orderIds), func(i int) BatchOrderResult {
+		_, err := c.api.FutureCancelOrder(currencyPair, contractType, orderIds[i])
+		return BatchOrderResult{OrderId: orderIds[i], Err: err}
+	}), nil
+}
+
+// BatchAmendFutureOrders 同 concurrentBatchOrder.BatchAmendOrders, 使用 NewAmendFutureOrderAPI
+func (c *concurrentBatchFutureOrder) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	amendApi := NewAmendFutureOrderAPI(c.api)
+	return concurrentDo(len(orders), func(i int) BatchOrderResult {
+		ord := &orders[i]
+		ret, err := amendApi.AmendFutureOrder(ord)
+		if err != nil {
+			return BatchOrderResult{OrderId: ord.OrderID2, ClientOid: ord.ClientOid, Err: err}
+		}
+		ord.OrderID2, ord.ClientOid = ret.OrderID2, ret.ClientOid
+		return BatchOrderResult{OrderId: ret.OrderID2, ClientOid: ret.ClientOid}
+	}), nil
+}
+
+// SplitBatch 按交易所单次上限拆分, 返回每批的 [start, end) 下标
+func SplitBatch(n, limit int) [][2]int {
+	var batches [][2]int
+	for start := 0; start < n; start += limit {
+		end := start + limit
+		if end > n {
+			end = n
+		}
+		batches = append(batches, [2]int{start, end})
+	}
+	return batches
+}
+
+// FailBatch 整批请求失败时设置该批每个订单的错误
+func FailBatch(results []BatchOrderResult, start, end int, err error) {
+	for i := start; i < end; i++ {
+		results[i].Err = err
+	}
+}
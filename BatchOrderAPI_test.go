@@ -0,0 +1,162 @@
+package goex
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type batchMockSpot struct {
+	API //未实现的方法不会被调用
+
+	lock     sync.Mutex
+	seq      int
+	canceled []string
+	amounts  []string
+}
+
+func (m *batchMockSpot) limit(amount, price string, currency CurrencyPair, side TradeSide) (*Order, error) {
+	if price == "0" {
+		return nil, errors.New("invalid price")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.seq++
+	m.amounts = append(m.amounts, amount)
+	return &Order{OrderID2: fmt.Sprint(m.seq), Currency: currency, Side: side}, nil
+}
+
+func (m *batchMockSpot) LimitBuy(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	return m.limit(amount, price, currency, BUY)
+}
+
+func (m *batchMockSpot) LimitSell(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	return m.limit(amount, price, currency, SELL)
+}
+
+func (m *batchMockSpot) CancelOrder(orderId string, currency CurrencyPair) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.canceled = append(m.canceled, orderId)
+	return true, nil
+}
+
+// GetOneOrder 撤单时原订单已成交 0.4
+func (m *batchMockSpot) GetOneOrder(orderId string, currency CurrencyPair) (*Order, error) {
+	return &Order{OrderID2: orderId, Currency: currency, Side: BUY, Price: 100, Amount: 1, DealAmount: 0.4}, nil
+}
+
+type batchCidMockSpot struct {
+	batchMockSpot
+	ClientOrderAPI
+}
+
+func (m *batchCidMockSpot) LimitOrderWithCid(ord *Order) (*Order, error) {
+	if ord.Cid == "" {
+		ord.Cid = "auto"
+	}
+	ret, err := m.limit(FloatToString(ord.Amount, 8), FloatToString(ord.Price, 8), ord.Currency, ord.Side)
+	if err != nil {
+		return nil, err
+	}
+	ret.Cid = ord.Cid
+	return ret, nil
+}
+
+func TestSplitBatch(t *testing.T) {
+	assert.Equal(t, [][2]int{{0, 5}, {5, 10}, {10, 12}}, SplitBatch(12, 5))
+	assert.Len(t, SplitBatch(0, 5), 0)
+}
+
+func TestNewBatchOrderAPI(t *testing.T) {
+	mock := &batchMockSpot{}
+	api := NewBatchOrderAPI(mock)
+
+	orders := []Order{
+		{Currency: BTC_USDT, Side: BUY, Price: 100, Amount: 1},
+		{Currency: BTC_USDT, Side: SELL, Price: 0, Amount: 1},
+		{Currency: BTC_USDT, Side: BUY_MARKET, Price: 100, Amount: 1},
+	}
+
+	results, err := api.BatchLimitOrders(orders)
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, results[0].OrderId, orders[0].OrderID2)
+	assert.NotNil(t, results[1].Err)
+	assert.NotNil(t, results[2].Err)
+
+	results, _ = api.BatchCancelOrders(BTC_USDT, []string{"1", "2"})
+	assert.Len(t, results, 2)
+	assert.ElementsMatch(t, []string{"1", "2"}, mock.canceled)
+
+	//不支持按 Cid 下单时 Cid 不会发送, 不回写调用方的 Cid
+	orders = []Order{{Currency: BTC_USDT, Side: BUY, Price: 100, Amount: 1, Cid: "mine"}}
+	results, _ = api.BatchLimitOrders(orders)
+	assert.Equal(t, "", results[0].ClientOid)
+	assert.Equal(t, "", orders[0].Cid)
+
+	//撤单重下时扣除已成交数量, Price、Amount 为0时使用原订单的值
+	mock.amounts = nil
+	amend := []Order{{OrderID2: "1", Currency: BTC_USDT, Side: BUY, Price: 101, Amount: 1}, {OrderID2: "3", Currency: BTC_USDT, Side: BUY}}
+	results, _ = api.BatchAmendOrders(amend)
+	assert.Nil(t, results[0].Err)
+	assert.Nil(t, results[1].Err)
+	assert.NotEqual(t, "1", amend[0].OrderID2)
+	assert.Equal(t, []string{"0.6", "0.6"}, mock.amounts)
+
+	amend = []Order{{OrderID2: "1", Currency: BTC_USDT, Side: BUY, Price: 101, Amount: 0.4}}
+	results, _ = api.BatchAmendOrders(amend)
+	assert.Equal(t, ErrAmendFilled, results[0].Err)
+}
+
+func TestConcurrentBatchOrder_Cid(t *testing.T) {
+	api := NewBatchOrderAPI(&batchCidMockSpot{})
+
+	orders := []Order{
+		{Currency: BTC_USDT, Side: BUY, Price: 100, Amount: 1, Cid: "mine"},
+		{Currency: BTC_USDT, Side: SELL, Price: 100, Amount: 1},
+	}
+	results, _ := api.BatchLimitOrders(orders)
+	assert.Equal(t, "mine", results[0].ClientOid)
+	assert.Equal(t, "auto", results[1].ClientOid)
+	assert.Equal(t, "auto", orders[1].Cid)
+}
+
+type slowMockSpot struct {
+	batchMockSpot
+
+	running, maxRunning int
+}
+
+func (m *slowMockSpot) LimitBuy(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	m.lock.Lock()
+	m.running++
+	if m.running > m.maxRunning {
+		m.maxRunning = m.running
+	}
+	m.lock.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	m.lock.Lock()
+	m.running--
+	m.lock.Unlock()
+	return m.limit(amount, price, currency, BUY)
+}
+
+func TestConcurrentBatchOrder_Limit(t *testing.T) {
+	mock := &slowMockSpot{}
+	orders := make([]Order, 3*batchConcurrency)
+	for i := range orders {
+		orders[i] = Order{Currency: BTC_USDT, Side: BUY, Price: 100, Amount: 1}
+	}
+
+	results, _ := NewBatchOrderAPI(mock).BatchLimitOrders(orders)
+	assert.Len(t, results, len(orders))
+	assert.True(t, mock.maxRunning <= batchConcurrency)
+}
@@ -0,0 +1,31 @@
+package goex
+
+// 按客户端自定义订单ID(Cid/ClientOid)下单、查询和撤单
+// 网络超时等下单结果未知时, 先用同一个ID查询订单, 查不到再用同一个ID重新下单, 交易所会拒绝重复的ID
+// 各交易所对ID格式的要求不同, 如火币合约(hbdm)只支持数字, 为空时由 goex 按交易所的要求生成
+type ClientOrderAPI interface {
+	/**
+	 * 下限价单
+	 * @param ord Currency、Side(BUY/SELL)、Price、Amount 必填, OrderType 见 ORDER_FEATURE_*, Cid 为空时自动生成
+	 *            ord.Cid 在发送请求前回写, 下单失败时也可以用来查询; 下单成功后回写 ord.OrderID2
+	 */
+	LimitOrderWithCid(ord *Order) (*Order, error)
+
+	GetOneOrderByCid(cid string, currency CurrencyPair) (*Order, error)
+
+	CancelOrderByCid(cid string, currency CurrencyPair) (bool, error)
+}
+
+// 合约按自定义订单ID下单、查询和撤单, 约定同 ClientOrderAPI
+type FutureClientOrderAPI interface {
+	/**
+	 * 下限价单
+	 * @param ord Currency、ContractName、OType、Price、Amount 必填, OrderType 见 ORDER_FEATURE_*, ClientOid 为空时自动生成
+	 *            ord.ClientOid 在发送请求前回写, 下单失败时也可以用来查询; 下单成功后回写 ord.OrderID2
+	 */
+	LimitFuturesOrderWithCid(ord *FutureOrder) (*FutureOrder, error)
+
+	GetFutureOrderByCid(cid string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error)
+
+	FutureCancelOrderByCid(currencyPair CurrencyPair, contractType, cid string) (bool, error)
+}
@@ -34,7 +34,7 @@ func (ts TradeStatus) String() string {
 	return tradeStatusSymbol[ts]
 }
 
-var tradeStatusSymbol = [...]string{"UNFINISH", "PART_FINISH", "FINISH", "CANCEL", "REJECT", "CANCEL_ING", "FAIL"}
+var tradeStatusSymbol = [...]string{"UNFINISH", "PART_FINISH", "FINISH", "CANCEL", "REJECT", "CANCEL_ING", "FAIL", "TRIGGERED"}
 
 const (
 	ORDER_UNFINISH TradeStatus = iota
@@ -44,6 +44,9 @@ const (
 	ORDER_REJECT
 	ORDER_CANCEL_ING
 	ORDER_FAIL
+	ORDER_TRIGGERED //策略委托已触发并下单, 不是终态, 成交情况需要查询委托单
+	//okex、hbdm 的策略委托和 bitmex 已触发未成交的条件单返回该状态
+	//币安条件单触发后接口没有标识, 与普通订单一样返回 UNFINISH / PART_FINISH
 )
 
 const (
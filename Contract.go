@@ -0,0 +1,350 @@
+package goex
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Contract 一个具体的交割或永续合约
+type Contract struct {
+	Id             string       // 交易所的合约 id, 比如: BTC-USD-210326、BTCUSD_210326、BTC210326, 可直接作为 contractType 参数
+	Underlying     CurrencyPair // 标的, 比如: BTC_USD
+	SettleCurrency Currency     // 结算币种, 币本位为 BTC, U本位为 USDT
+	ContractType   string       // 当前对应的合约类型(THIS_WEEK_CONTRACT、QUARTER_CONTRACT 等), 交割后会变化
+	Expiry         time.Time    // 交割时间, 永续合约为零值
+	Linear         bool         // true: U本位, false: 币本位(反向合约)
+	ContractSize   float64      // 合约面值, 币本位为美元, U本位为标的币数量
+}
+
+func (c Contract) IsPerpetual() bool {
+	return c.Expiry.IsZero()
+}
+
+func (c Contract) Expired(now time.Time) bool {
+	return !c.IsPerpetual() && !now.Before(c.Expiry)
+}
+
+// CanonicalId 统一的合约标识, 交割合约: BTC_USD:20210326, 永续合约: BTC_USD:swap
+func (c Contract) CanonicalId() string {
+	if c.IsPerpetual() {
+		return CanonicalId(c.Underlying, SWAP_CONTRACT)
+	}
+	return CanonicalId(c.Underlying, c.Expiry.UTC().Format("20060102"))
+}
+
+func (c Contract) String() string {
+	return c.Id
+}
+
+// ContractRollover 交割后合约类型指向了新的合约, 比如 this_week 由 BTC-USD-210319 变为 BTC-USD-210326
+type ContractRollover struct {
+	Underlying   CurrencyPair
+	ContractType string
+	Old          Contract
+	New          Contract // Id 为空表示该合约类型已没有对应的合约
+}
+
+// ContractLoader 请求交易所当前上线的合约
+type ContractLoader func() ([]Contract, error)
+
+const (
+	contractReloadInterval = time.Hour
+	contractRetryInterval  = 10 * time.Second
+)
+
+// ContractResolver 合约类型与具体合约的转换, 并发安全
+// 有合约到期或距上次加载超过一小时时重新加载, 合约类型指向的合约变化时通知 OnRollover 注册的回调
+type ContractResolver struct {
+	name   string
+	loader ContractLoader
+	clock  Clock
+
+	lock      sync.RWMutex
+	contracts []Contract
+	byType    map[string]Contract // CanonicalId(Underlying, ContractType) -> Contract
+	byId      map[string]Contract
+	refreshAt time.Time
+	listeners []func(ContractRollover)
+
+	started int32
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// NewContractResolver clock 用于判断合约是否到期, 为 nil 时使用本地时钟
+func NewContractResolver(name string, loader ContractLoader, clock Clock) *ContractResolver {
+	if clock == nil {
+		clock = LocalClock
+	}
+	return &ContractResolver{name: name, loader: loader, clock: clock, stop: make(chan struct{})}
+}
+
+// OnRollover 注册合约交割换月的回调, 在 Refresh 的调用方 goroutine 中执行
+func (r *ContractResolver) OnRollover(fn func(ContractRollover)) {
+	r.lock.Lock()
+	r.listeners = append(r.listeners, fn)
+	r.lock.Unlock()
+}
+
+// Refresh 重新加载合约, 首次加载不通知换月
+func (r *ContractResolver) Refresh() error {
+	contracts, err := r.loader()
+	now := r.clock.Now()
+	if err != nil {
+		r.lock.Lock()
+		if !r.refreshAt.IsZero() {
+			r.refreshAt = now.Add(contractRetryInterval)
+		}
+		r.lock.Unlock()
+		DefaultLogger().Warn("load contracts failed", KV("name", r.name), KV("error", err))
+		return err
+	}
+
+	byType := make(map[string]Contract, len(contracts))
+	byId := make(map[string]Contract, len(contracts))
+	refreshAt := now.Add(contractReloadInterval)
+	for _, c := range contracts {
+		byId[c.Id] = c
+		if c.ContractType != "" {
+			byType[CanonicalId(c.Underlying, c.ContractType)] = c
+		}
+		if c.Expired(now) {
+			//交易所还未更新到期的合约, 稍后重试
+			if t := now.Add(contractRetryInterval); t.Before(refreshAt) {
+				refreshAt = t
+			}
+		} else if !c.IsPerpetual() && c.Expiry.Before(refreshAt) {
+			refreshAt = c.Expiry
+		}
+	}
+
+	r.lock.Lock()
+	old, first := r.byType, r.refreshAt.IsZero()
+	r.contracts, r.byType, r.byId, r.refreshAt = contracts, byType, byId, refreshAt
+	listeners := r.listeners
+	r.lock.Unlock()
+
+	if first {
+		return nil
+	}
+	for _, rollover := range contractRollovers(old, byType) {
+		DefaultLogger().Info("contract rollover", KV("name", r.name), KV("contract_type", rollover.ContractType),
+			KV("old", rollover.Old.Id), KV("new", rollover.New.Id))
+		for _, fn := range listeners {
+			fn(rollover)
+		}
+	}
+	return nil
+}
+
+func contractRollovers(old, new map[string]Contract) []ContractRollover {
+	var rollovers []ContractRollover
+	for key, o := range old {
+		n := new[key]
+		if n.Id != o.Id {
+			rollovers = append(rollovers, ContractRollover{Underlying: o.Underlying, ContractType: o.ContractType, Old: o, New: n})
+		}
+	}
+	sort.Slice(rollovers, func(i, j int) bool {
+		return rollovers[i].Old.Id < rollovers[j].Old.Id
+	})
+	return rollovers
+}
+
+// ensureFresh 未加载、有合约到期或超过重新加载时间时刷新
+func (r *ContractResolver) ensureFresh() error {
+	r.lock.RLock()
+	refreshAt := r.refreshAt
+	r.lock.RUnlock()
+	if refreshAt.IsZero() {
+		return r.Refresh()
+	}
+	if !r.clock.Now().Before(refreshAt) {
+		//刷新失败时继续使用已加载的合约, Resolve 对到期的合约返回 EX_ERR_CONTRACT_EXPIRED
+		r.Refresh()
+	}
+	return nil
+}
+
+// Resolve 合约类型当前对应的合约, 比如 (BTC_USD, QUARTER_CONTRACT) -> BTC-USD-210326
+func (r *ContractResolver) Resolve(pair CurrencyPair, contractType string) (Contract, error) {
+	if err := r.ensureFresh(); err != nil {
+		return Contract{}, err
+	}
+	id := CanonicalId(pair, contractType)
+	r.lock.RLock()
+	c, ok := r.byType[id]
+	r.lock.RUnlock()
+	if !ok {
+		return Contract{}, EX_ERR_UNKNOWN_CONTRACT.OriginErr(fmt.Sprintf("unknown contract [%s]", id))
+	}
+	if c.Expired(r.clock.Now()) {
+		return Contract{}, EX_ERR_CONTRACT_EXPIRED.OriginErr(fmt.Sprintf("contract [%s] expired", c.Id))
+	}
+	return c, nil
+}
+
+// Contract 按交易所的合约 id 查询
+func (r *ContractResolver) Contract(id string) (Contract, error) {
+	if err := r.ensureFresh(); err != nil {
+		return Contract{}, err
+	}
+	r.lock.RLock()
+	c, ok := r.byId[id]
+	r.lock.RUnlock()
+	if !ok {
+		return Contract{}, EX_ERR_UNKNOWN_CONTRACT.OriginErr(fmt.Sprintf("unknown contract [%s]", id))
+	}
+	return c, nil
+}
+
+// Contracts 标的对应的所有未到期合约, 永续合约在前, 交割合约按交割时间排序
+func (r *ContractResolver) Contracts(pair CurrencyPair) ([]Contract, error) {
+	if err := r.ensureFresh(); err != nil {
+		return nil, err
+	}
+	now := r.clock.Now()
+	var contracts []Contract
+	r.lock.RLock()
+	for _, c := range r.contracts {
+		if c.Underlying.Eq(pair) && !c.Expired(now) {
+			contracts = append(contracts, c)
+		}
+	}
+	r.lock.RUnlock()
+	sort.SliceStable(contracts, func(i, j int) bool {
+		return contracts[i].Expiry.Before(contracts[j].Expiry)
+	})
+	return contracts, nil
+}
+
+// Watch 在后台每隔 interval 刷新一次, 没有调用 Resolve 等方法时也能及时通知换月
+func (r *ContractResolver) Watch(interval time.Duration) {
+	if !atomic.CompareAndSwapInt32(&r.started, 0, 1) {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.ensureFresh()
+			}
+		}
+	}()
+}
+
+// Close 停止 Watch
+func (r *ContractResolver) Close() {
+	r.once.Do(func() {
+		close(r.stop)
+	})
+}
+
+// ContractAPI 支持按具体合约交易的交割合约交易所
+type ContractAPI interface {
+	/**
+	 * 合约类型与具体合约的转换
+	 */
+	Contracts() *ContractResolver
+}
+
+// ContractFuture 在 FutureRestAPI 上增加按 Contract 调用的方法, 用于跨期套利等需要指定交割日期的策略
+// 合约 id 作为 contractType 参数传给交易所, 交割后不会变成下一期的合约
+type ContractFuture struct {
+	FutureRestAPI
+	resolver *ContractResolver
+}
+
+// NewContractFuture api 需要实现 ContractAPI(可以是 InstrumentFutureRestAPI 包装后的对象)
+func NewContractFuture(api FutureRestAPI) (*ContractFuture, error) {
+	c, ok := UnwrapFutureRestAPI(api).(ContractAPI)
+	if !ok {
+		return nil, EX_ERR_NOT_SUPPORT.OriginErr(fmt.Sprintf("%s not support contract", api.GetExchangeName()))
+	}
+	return &ContractFuture{FutureRestAPI: api, resolver: c.Contracts()}, nil
+}
+
+func (f *ContractFuture) Contracts() *ContractResolver {
+	return f.resolver
+}
+
+// Contract 合约类型当前对应的合约
+func (f *ContractFuture) Contract(pair CurrencyPair, contractType string) (Contract, error) {
+	return f.resolver.Resolve(pair, contractType)
+}
+
+// OnRollover 同 ContractResolver.OnRollover
+func (f *ContractFuture) OnRollover(fn func(ContractRollover)) {
+	f.resolver.OnRollover(fn)
+}
+
+// contractType 到期的合约返回 EX_ERR_CONTRACT_EXPIRED
+func (f *ContractFuture) contractType(c Contract) (string, error) {
+	if c.Id == "" {
+		return "", EX_ERR_UNKNOWN_CONTRACT
+	}
+	if c.Expired(f.resolver.clock.Now()) {
+		return "", EX_ERR_CONTRACT_EXPIRED.OriginErr(fmt.Sprintf("contract [%s] expired", c.Id))
+	}
+	return c.Id, nil
+}
+
+func (f *ContractFuture) GetContractTicker(c Contract) (*Ticker, error) {
+	contractType, err := f.contractType(c)
+	if err != nil {
+		return nil, err
+	}
+	return f.GetFutureTicker(c.Underlying, contractType)
+}
+
+func (f *ContractFuture) GetContractDepth(c Contract, size int) (*Depth, error) {
+	contractType, err := f.contractType(c)
+	if err != nil {
+		return nil, err
+	}
+	return f.GetFutureDepth(c.Underlying, contractType, size)
+}
+
+func (f *ContractFuture) LimitContractOrder(c Contract, price, amount string, openType int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
+	contractType, err := f.contractType(c)
+	if err != nil {
+		return nil, err
+	}
+	return f.LimitFuturesOrder(c.Underlying, contractType, price, amount, openType, opt...)
+}
+
+func (f *ContractFuture) MarketContractOrder(c Contract, amount string, openType int) (*FutureOrder, error) {
+	contractType, err := f.contractType(c)
+	if err != nil {
+		return nil, err
+	}
+	return f.MarketFuturesOrder(c.Underlying, contractType, amount, openType)
+}
+
+// 撤单、查询不检查合约是否到期
+func (f *ContractFuture) CancelContractOrder(c Contract, orderId string) (bool, error) {
+	return f.FutureCancelOrder(c.Underlying, c.Id, orderId)
+}
+
+func (f *ContractFuture) GetContractPosition(c Contract) ([]FuturePosition, error) {
+	return f.GetFuturePosition(c.Underlying, c.Id)
+}
+
+func (f *ContractFuture) GetContractOrder(orderId string, c Contract) (*FutureOrder, error) {
+	return f.GetFutureOrder(orderId, c.Underlying, c.Id)
+}
+
+func (f *ContractFuture) GetUnfinishContractOrders(c Contract) ([]FutureOrder, error) {
+	return f.GetUnfinishFutureOrders(c.Underlying, c.Id)
+}
+
+func (f *ContractFuture) GetContractKlineRecords(c Contract, period KlinePeriod, size int, optional ...OptionalParameter) ([]FutureKline, error) {
+	return f.GetKlineRecords(c.Id, c.Underlying, period, size, optional...)
+}
@@ -0,0 +1,133 @@
+package goex
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func testContracts(thisWeek, nextWeek time.Time) []Contract {
+	return []Contract{
+		{Id: "BTC-USD-SWAP", Underlying: BTC_USD, ContractType: SWAP_CONTRACT},
+		{Id: "BTC-USD-" + nextWeek.Format("060102"), Underlying: BTC_USD, ContractType: NEXT_WEEK_CONTRACT, Expiry: nextWeek},
+		{Id: "BTC-USD-" + thisWeek.Format("060102"), Underlying: BTC_USD, ContractType: THIS_WEEK_CONTRACT, Expiry: thisWeek},
+	}
+}
+
+func TestContractResolver(t *testing.T) {
+	week := 7 * 24 * time.Hour
+	expiry := time.Date(2021, 3, 19, 8, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: expiry.Add(-time.Hour)}
+
+	var loads int
+	contracts := testContracts(expiry, expiry.Add(week))
+	r := NewContractResolver("test", func() ([]Contract, error) {
+		loads++
+		return contracts, nil
+	}, clock)
+
+	var rollovers []ContractRollover
+	r.OnRollover(func(rollover ContractRollover) {
+		rollovers = append(rollovers, rollover)
+	})
+
+	c, err := r.Resolve(BTC_USD, THIS_WEEK_CONTRACT)
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC-USD-210319", c.Id)
+	assert.Equal(t, "BTC_USD:20210319", c.CanonicalId())
+
+	all, err := r.Contracts(BTC_USD)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"BTC-USD-SWAP", "BTC-USD-210319", "BTC-USD-210326"}, []string{all[0].Id, all[1].Id, all[2].Id})
+	assert.Equal(t, 1, loads)
+
+	//到期后交易所还未更新
+	clock.now = expiry
+	_, err = r.Resolve(BTC_USD, THIS_WEEK_CONTRACT)
+	assert.Equal(t, EX_ERR_CONTRACT_EXPIRED.ErrCode, err.(ApiError).ErrCode)
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 0, len(rollovers))
+
+	//稍后重试时加载到新的合约
+	contracts = testContracts(expiry.Add(week), expiry.Add(2*week))
+	clock.now = expiry.Add(contractRetryInterval)
+	c, err = r.Resolve(BTC_USD, THIS_WEEK_CONTRACT)
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC-USD-210326", c.Id)
+	assert.Equal(t, 3, loads)
+
+	assert.Equal(t, 2, len(rollovers))
+	assert.Equal(t, "BTC-USD-210319", rollovers[0].Old.Id)
+	assert.Equal(t, "BTC-USD-210326", rollovers[0].New.Id)
+	assert.Equal(t, THIS_WEEK_CONTRACT, rollovers[0].ContractType)
+	assert.Equal(t, NEXT_WEEK_CONTRACT, rollovers[1].ContractType)
+
+	c, err = r.Contract("BTC-USD-210402")
+	assert.Nil(t, err)
+	assert.Equal(t, NEXT_WEEK_CONTRACT, c.ContractType)
+	_, err = r.Resolve(BTC_USD, QUARTER_CONTRACT)
+	assert.Equal(t, EX_ERR_UNKNOWN_CONTRACT.ErrCode, err.(ApiError).ErrCode)
+}
+
+func TestContractResolver_LoadFailed(t *testing.T) {
+	r := NewContractResolver("test", func() ([]Contract, error) {
+		return nil, errors.New("timeout")
+	}, nil)
+	_, err := r.Resolve(BTC_USD, QUARTER_CONTRACT)
+	assert.EqualError(t, err, "timeout")
+}
+
+type mockContractFuture struct {
+	FutureRestAPI
+	resolver     *ContractResolver
+	contractType string
+}
+
+func (m *mockContractFuture) GetExchangeName() string {
+	return "okex.com"
+}
+
+func (m *mockContractFuture) Contracts() *ContractResolver {
+	return m.resolver
+}
+
+func (m *mockContractFuture) LimitFuturesOrder(currencyPair CurrencyPair, contractType, price, amount string, openType int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
+	m.contractType = contractType
+	return &FutureOrder{Currency: currencyPair, ContractName: contractType}, nil
+}
+
+func TestContractFuture(t *testing.T) {
+	expiry := time.Date(2021, 3, 19, 8, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: expiry.Add(-time.Hour)}
+	api := &mockContractFuture{resolver: NewContractResolver("test", func() ([]Contract, error) {
+		return testContracts(expiry, expiry.Add(7*24*time.Hour)), nil
+	}, clock)}
+
+	_, err := NewContractFuture(struct{ FutureRestAPI }{api})
+	assert.Equal(t, EX_ERR_NOT_SUPPORT.ErrCode, err.(ApiError).ErrCode)
+
+	f, err := NewContractFuture(InstrumentFutureRestAPI(api, Instrumentation{Metrics: &recordMetrics{}}))
+	assert.Nil(t, err)
+
+	next, err := f.Contract(BTC_USD, NEXT_WEEK_CONTRACT)
+	assert.Nil(t, err)
+	ord, err := f.LimitContractOrder(next, "50000", "1", OPEN_BUY)
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC-USD-210326", api.contractType)
+	assert.Equal(t, BTC_USD, ord.Currency)
+
+	this, _ := f.Contract(BTC_USD, THIS_WEEK_CONTRACT)
+	clock.now = expiry
+	_, err = f.LimitContractOrder(this, "50000", "1", OPEN_BUY)
+	assert.Equal(t, EX_ERR_CONTRACT_EXPIRED.ErrCode, err.(ApiError).ErrCode)
+}
@@ -224,7 +224,12 @@ func NewCurrencyPair2(currencyPairSymbol string) CurrencyPair {
 	return NewCurrencyPair3(currencyPairSymbol, "_")
 }
 
+// NewCurrencyPair3 sep 为空时按常见计价币后缀拆分(见 ParseCurrencyPair), 无法解析时返回 UNKNOWN_PAIR
 func NewCurrencyPair3(currencyPairSymbol string, sep string) CurrencyPair {
+	if sep == "" {
+		pair, _ := ParseCurrencyPair(currencyPairSymbol)
+		return pair
+	}
 	currencys := strings.Split(currencyPairSymbol, sep)
 	if len(currencys) >= 2 {
 		return CurrencyPair{CurrencyA: NewCurrency(currencys[0], ""),
@@ -0,0 +1,209 @@
+package goex
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Capability 交易所驱动支持的能力
+type Capability string
+
+const (
+	CAP_SPOT          Capability = "spot"
+	CAP_FUTURE        Capability = "future"
+	CAP_LINEAR_FUTURE Capability = "linear_future"
+	CAP_SPOT_WS       Capability = "spot_ws"
+	CAP_FUTURES_WS    Capability = "futures_ws"
+	CAP_WALLET        Capability = "wallet"
+)
+
+var allCapabilities = []Capability{CAP_SPOT, CAP_FUTURE, CAP_LINEAR_FUTURE, CAP_SPOT_WS, CAP_FUTURES_WS, CAP_WALLET}
+
+// Driver 交易所驱动, 由各交易所包在 init 中通过 RegisterDriver 注册, 不支持的能力留空
+// config.Endpoint 为空时使用交易所默认地址
+type Driver struct {
+	Spot         func(config *APIConfig) API
+	Future       func(config *APIConfig) FutureRestAPI
+	LinearFuture func(config *APIConfig) FutureRestAPI
+	SpotWs       func(config *APIConfig) SpotWsApi
+	FuturesWs    func(config *APIConfig) FuturesWsApi
+	Wallet       func(config *APIConfig) WalletApi
+
+	Testnet []Capability // 支持测试网(config.Env 为 ENV_TESTNET)的能力
+}
+
+// Supports 是否支持某项能力
+func (d Driver) Supports(c Capability) bool {
+	switch c {
+	case CAP_SPOT:
+		return d.Spot != nil
+	case CAP_FUTURE:
+		return d.Future != nil
+	case CAP_LINEAR_FUTURE:
+		return d.LinearFuture != nil
+	case CAP_SPOT_WS:
+		return d.SpotWs != nil
+	case CAP_FUTURES_WS:
+		return d.FuturesWs != nil
+	case CAP_WALLET:
+		return d.Wallet != nil
+	}
+	return false
+}
+
+// SupportsEnv 是否在指定环境下支持某项能力
+func (d Driver) SupportsEnv(c Capability, env Environment) bool {
+	if !d.Supports(c) {
+		return false
+	}
+	if env == ENV_PRODUCTION {
+		return true
+	}
+	return containsCapability(d.Testnet, c)
+}
+
+func containsCapability(caps []Capability, c Capability) bool {
+	for _, t := range caps {
+		if t == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Capabilities 支持的所有能力
+func (d Driver) Capabilities() []Capability {
+	var caps []Capability
+	for _, c := range allCapabilities {
+		if d.Supports(c) {
+			caps = append(caps, c)
+		}
+	}
+	return caps
+}
+
+// merge 非空的工厂方法覆盖已注册的
+func (d Driver) merge(o Driver) Driver {
+	if o.Spot != nil {
+		d.Spot = o.Spot
+	}
+	if o.Future != nil {
+		d.Future = o.Future
+	}
+	if o.LinearFuture != nil {
+		d.LinearFuture = o.LinearFuture
+	}
+	if o.SpotWs != nil {
+		d.SpotWs = o.SpotWs
+	}
+	if o.FuturesWs != nil {
+		d.FuturesWs = o.FuturesWs
+	}
+	if o.Wallet != nil {
+		d.Wallet = o.Wallet
+	}
+	for _, c := range o.Testnet {
+		if !containsCapability(d.Testnet, c) {
+			d.Testnet = append(d.Testnet, c)
+		}
+	}
+	return d
+}
+
+// UnsupportedError 交易所未注册(Unknown 为 true)或者在该环境下不支持该能力
+type UnsupportedError struct {
+	Exchange   string
+	Capability Capability
+	Env        Environment
+	Unknown    bool
+}
+
+func (e *UnsupportedError) Error() string {
+	if e.Unknown {
+		return fmt.Sprintf("unknown exchange [%s]", e.Exchange)
+	}
+	if e.Env != ENV_PRODUCTION {
+		return fmt.Sprintf("exchange [%s] does not support %s on %s", e.Exchange, e.Capability, e.Env)
+	}
+	return fmt.Sprintf("exchange [%s] does not support %s", e.Exchange, e.Capability)
+}
+
+// IsUnsupportedError 是否是 *UnsupportedError
+func IsUnsupportedError(err error) bool {
+	_, ok := err.(*UnsupportedError)
+	return ok
+}
+
+var (
+	driverLock sync.RWMutex
+	drivers    = make(map[string]Driver)
+)
+
+// RegisterDriver 注册交易所驱动, 同一个交易所多次注册时合并, 后注册的覆盖先注册的同一能力
+func RegisterDriver(exName string, d Driver) {
+	driverLock.Lock()
+	defer driverLock.Unlock()
+	drivers[exName] = drivers[exName].merge(d)
+}
+
+// GetDriver 获取已注册的驱动(生产环境), 只返回 error 类型为 *UnsupportedError
+func GetDriver(exName string, c Capability) (Driver, error) {
+	return GetDriverForEnv(exName, c, ENV_PRODUCTION)
+}
+
+// GetDriverForEnv 同 GetDriver, 同时检查是否支持指定环境
+func GetDriverForEnv(exName string, c Capability, env Environment) (Driver, error) {
+	driverLock.RLock()
+	d, ok := drivers[exName]
+	driverLock.RUnlock()
+
+	if !ok {
+		return d, &UnsupportedError{Exchange: exName, Capability: c, Env: env, Unknown: true}
+	}
+	if !d.SupportsEnv(c, env) {
+		return d, &UnsupportedError{Exchange: exName, Capability: c, Env: env}
+	}
+	return d, nil
+}
+
+// RegisteredExchanges 所有已注册的交易所, 按名称排序
+func RegisteredExchanges() []string {
+	driverLock.RLock()
+	defer driverLock.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SupportedExchanges 支持某项能力的交易所, 按名称排序
+func SupportedExchanges(c Capability) []string {
+	return SupportedExchangesForEnv(c, ENV_PRODUCTION)
+}
+
+// SupportedExchangesForEnv 在指定环境下支持某项能力的交易所, 按名称排序
+func SupportedExchangesForEnv(c Capability, env Environment) []string {
+	driverLock.RLock()
+	defer driverLock.RUnlock()
+
+	var names []string
+	for name, d := range drivers {
+		if d.SupportsEnv(c, env) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExchangeCapabilities 某个交易所支持的能力, 未注册时返回空
+func ExchangeCapabilities(exName string) []Capability {
+	driverLock.RLock()
+	d := drivers[exName]
+	driverLock.RUnlock()
+	return d.Capabilities()
+}
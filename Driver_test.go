@@ -0,0 +1,33 @@
+package goex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterDriver(t *testing.T) {
+	RegisterDriver("test_driver", Driver{
+		Spot: func(config *APIConfig) API { return nil },
+	})
+	RegisterDriver("test_driver", Driver{
+		Wallet: func(config *APIConfig) WalletApi { return nil },
+	})
+
+	assert.Equal(t, []Capability{CAP_SPOT, CAP_WALLET}, ExchangeCapabilities("test_driver"))
+	assert.Contains(t, SupportedExchanges(CAP_SPOT), "test_driver")
+	assert.NotContains(t, SupportedExchanges(CAP_FUTURE), "test_driver")
+	assert.Contains(t, RegisteredExchanges(), "test_driver")
+
+	_, err := GetDriver("test_driver", CAP_SPOT)
+	assert.Nil(t, err)
+
+	_, err = GetDriver("test_driver", CAP_FUTURE)
+	assert.True(t, IsUnsupportedError(err))
+	assert.False(t, err.(*UnsupportedError).Unknown)
+
+	_, err = GetDriver("not_exist", CAP_SPOT)
+	assert.True(t, IsUnsupportedError(err))
+	assert.True(t, err.(*UnsupportedError).Unknown)
+	assert.Nil(t, ExchangeCapabilities("not_exist"))
+}
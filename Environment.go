@@ -0,0 +1,16 @@
+package goex
+
+// Environment 交易所运行环境
+type Environment string
+
+const (
+	ENV_PRODUCTION Environment = ""        //生产环境
+	ENV_TESTNET    Environment = "testnet" //测试网(模拟盘), 只有部分交易所支持, 见 Driver.Testnet
+)
+
+func (env Environment) String() string {
+	if env == ENV_PRODUCTION {
+		return "production"
+	}
+	return string(env)
+}
@@ -0,0 +1,146 @@
+package goex
+
+import (
+	"sync"
+	"time"
+)
+
+// FeeRate 账户在某个交易对(合约)上的手续费率
+type FeeRate struct {
+	Pair         CurrencyPair
+	ContractType string  // 合约才有
+	Maker        float64 // 比如: 0.001, 负数为返佣
+	Taker        float64
+	Tier         string // 账户等级, 交易所不返回时为空
+
+	// 使用平台币(BNB、HT、OKB、GT)抵扣后的折扣, 比如: 0.75 表示按原费率的 75% 收取
+	// 0 表示未开启抵扣或交易所不返回
+	Discount         float64
+	DiscountCurrency string
+}
+
+// ActualMaker 抵扣后的 maker 费率
+func (f *FeeRate) ActualMaker() float64 {
+	if f.Discount <= 0 || f.Maker <= 0 {
+		return f.Maker
+	}
+	return f.Maker * f.Discount
+}
+
+// ActualTaker 抵扣后的 taker 费率
+func (f *FeeRate) ActualTaker() float64 {
+	if f.Discount <= 0 || f.Taker <= 0 {
+		return f.Taker
+	}
+	return f.Taker * f.Discount
+}
+
+// 现货手续费率
+type FeeAPI interface {
+	GetFeeRate(pair CurrencyPair) (*FeeRate, error)
+}
+
+// 合约手续费率
+type FutureFeeAPI interface {
+	GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error)
+}
+
+// FutureTakerFee FutureRestAPI.GetFee 没有合约参数, 实现 FutureFeeAPI 的交易所用它返回 pair、contractType 合约的 taker 费率
+// 假定费率只与账户等级有关, 按合约区分费率的账户请直接使用 GetFutureFeeRate
+func FutureTakerFee(api FutureFeeAPI, pair CurrencyPair, contractType string) (float64, error) {
+	fee, err := api.GetFutureFeeRate(pair, contractType)
+	if err != nil {
+		return 0, err
+	}
+	return fee.Taker, nil
+}
+
+type feeCacheItem struct {
+	rate     FeeRate
+	expireAt time.Time
+}
+
+// feeCache 费率一般按天调整, 缓存一段时间避免每次下单前都请求接口
+type feeCache struct {
+	lock  sync.RWMutex
+	ttl   time.Duration
+	items map[string]feeCacheItem
+}
+
+func (c *feeCache) get(key string, load func() (*FeeRate, error)) (*FeeRate, error) {
+	c.lock.RLock()
+	item, ok := c.items[key]
+	c.lock.RUnlock()
+
+	if ok && time.Now().Before(item.expireAt) {
+		rate := item.rate
+		return &rate, nil
+	}
+
+	rate, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.items[key] = feeCacheItem{rate: *rate, expireAt: time.Now().Add(c.ttl)}
+	c.lock.Unlock()
+
+	return rate, nil
+}
+
+func (c *feeCache) clear() {
+	c.lock.Lock()
+	c.items = make(map[string]feeCacheItem)
+	c.lock.Unlock()
+}
+
+func newFeeCache(ttl time.Duration) feeCache {
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return feeCache{ttl: ttl, items: make(map[string]feeCacheItem)}
+}
+
+// CachedFeeAPI 带缓存的 FeeAPI, 并发安全
+type CachedFeeAPI struct {
+	api   FeeAPI
+	cache feeCache
+}
+
+// NewCachedFeeAPI ttl <= 0 时默认缓存 1 小时
+func NewCachedFeeAPI(api FeeAPI, ttl time.Duration) *CachedFeeAPI {
+	return &CachedFeeAPI{api: api, cache: newFeeCache(ttl)}
+}
+
+func (c *CachedFeeAPI) GetFeeRate(pair CurrencyPair) (*FeeRate, error) {
+	return c.cache.get(pair.String(), func() (*FeeRate, error) {
+		return c.api.GetFeeRate(pair)
+	})
+}
+
+// Clear 清空缓存, 比如账户等级变化后
+func (c *CachedFeeAPI) Clear() {
+	c.cache.clear()
+}
+
+// CachedFutureFeeAPI 带缓存的 FutureFeeAPI, 并发安全
+type CachedFutureFeeAPI struct {
+	api   FutureFeeAPI
+	cache feeCache
+}
+
+// NewCachedFutureFeeAPI ttl <= 0 时默认缓存 1 小时
+func NewCachedFutureFeeAPI(api FutureFeeAPI, ttl time.Duration) *CachedFutureFeeAPI {
+	return &CachedFutureFeeAPI{api: api, cache: newFeeCache(ttl)}
+}
+
+func (c *CachedFutureFeeAPI) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	return c.cache.get(pair.String()+"_"+contractType, func() (*FeeRate, error) {
+		return c.api.GetFutureFeeRate(pair, contractType)
+	})
+}
+
+func (c *CachedFutureFeeAPI) Clear() {
+	c.cache.clear()
+}
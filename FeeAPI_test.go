@@ -0,0 +1,70 @@
+package goex
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockFeeAPI struct {
+	calls int
+	err   error
+}
+
+func (m *mockFeeAPI) GetFeeRate(pair CurrencyPair) (*FeeRate, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &FeeRate{Pair: pair, Maker: 0.001, Taker: 0.002, Discount: 0.75, DiscountCurrency: "BNB"}, nil
+}
+
+func TestFeeRate_Actual(t *testing.T) {
+	rate := FeeRate{Maker: -0.0001, Taker: 0.002, Discount: 0.75}
+	assert.Equal(t, -0.0001, rate.ActualMaker())
+	assert.InDelta(t, 0.0015, rate.ActualTaker(), 1e-12)
+
+	rate.Discount = 0
+	assert.Equal(t, 0.002, rate.ActualTaker())
+}
+
+func TestCachedFeeAPI(t *testing.T) {
+	mock := &mockFeeAPI{}
+	api := NewCachedFeeAPI(mock, time.Minute)
+
+	rate, err := api.GetFeeRate(BTC_USDT)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.002, rate.Taker)
+
+	//修改返回值不影响缓存
+	rate.Taker = 1
+	rate, _ = api.GetFeeRate(BTC_USDT)
+	assert.Equal(t, 0.002, rate.Taker)
+	assert.Equal(t, 1, mock.calls)
+
+	api.GetFeeRate(ETH_USDT)
+	assert.Equal(t, 2, mock.calls)
+
+	api.Clear()
+	mock.err = errors.New("timeout")
+	_, err = api.GetFeeRate(BTC_USDT)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, mock.calls)
+}
+
+func (m *mockFeeAPI) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	return m.GetFeeRate(pair)
+}
+
+func TestFutureTakerFee(t *testing.T) {
+	mock := &mockFeeAPI{}
+	fee, err := FutureTakerFee(mock, BTC_USD, SWAP_CONTRACT)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.002, fee)
+
+	mock.err = errors.New("timeout")
+	_, err = FutureTakerFee(mock, BTC_USD, SWAP_CONTRACT)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,31 @@
+package goex
+
+// Fill 个人成交记录
+type Fill struct {
+	TradeId      string
+	OrderId      string // 所属订单ID, 对应 OrderID2
+	Pair         CurrencyPair
+	ContractName string // 合约成交才有
+	Side         TradeSide
+	Price        float64
+	Amount       float64 // 合约为张数
+	Fee          float64 // 正数为支出的手续费, 负数为返佣
+	FeeCurrency  string
+	IsMaker      bool
+	Time         int64 // 毫秒
+}
+
+// 现货个人成交记录
+type MyTradesAPI interface {
+	/**
+	 * @param since 开始时间(毫秒), <=0 时由交易所决定, 一般为最近的成交
+	 * @param limit 返回数量, <=0 时使用交易所的默认值
+	 * @return 按成交时间升序
+	 */
+	GetMyTrades(pair CurrencyPair, since int64, limit int) ([]Fill, error)
+}
+
+// 合约个人成交记录, 参数约定同 MyTradesAPI
+type FutureFillsAPI interface {
+	GetFutureFills(pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error)
+}
@@ -0,0 +1,35 @@
+package goex
+
+// 策略委托单状态, 用于 GetFutureAlgoOrders 的 status 参数
+const (
+	ALGO_ORDER_PENDING   = "1" //待生效
+	ALGO_ORDER_TRIGGERED = "2" //已生效(已触发)
+	ALGO_ORDER_CANCELED  = "3" //已撤销
+)
+
+// 合约策略委托(止盈止损/计划委托)
+type FutureAlgoOrderAPI interface {
+	/**
+	 * 下计划委托单, 最新价格达到 TriggerPrice 时按 Price 下单
+	 * @param ord  Currency、ContractName(合约类型)、OType、Amount、TriggerPrice 必填
+	 *             AlgoType: 1:限价 2:市价, 市价时 Price 不必填
+	 *             触发方向(止损或止盈)由 TriggerPrice 与当前价格的关系决定
+	 * @return ord.OrderID2 为策略委托单ID
+	 */
+	PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error)
+
+	/**
+	 * 撤销计划委托单
+	 * @param orderId 下单返回的 OrderID2, 不支持自定义ID
+	 * @param contractType 合约类型, 不填时为永续合约
+	 */
+	FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error)
+
+	/**
+	 * 查询计划委托单, algoId和status必填且只能填其一
+	 * @param algoId 下单返回的 OrderID2
+	 * @param status ALGO_ORDER_PENDING / ALGO_ORDER_TRIGGERED / ALGO_ORDER_CANCELED
+	 * @param contractType 合约类型, 不填时为永续合约
+	 */
+	GetFutureAlgoOrders(algoId string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error)
+}
@@ -0,0 +1,105 @@
+package goex
+
+// 保证金模式
+type MarginMode int
+
+const (
+	MARGIN_MODE_CROSSED  MarginMode = 1 + iota //全仓
+	MARGIN_MODE_ISOLATED                       //逐仓
+)
+
+func (m MarginMode) String() string {
+	switch m {
+	case MARGIN_MODE_CROSSED:
+		return "CROSSED"
+	case MARGIN_MODE_ISOLATED:
+		return "ISOLATED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// 持仓模式
+type PositionMode int
+
+const (
+	POSITION_MODE_HEDGE   PositionMode = 1 + iota //双向持仓
+	POSITION_MODE_ONE_WAY                         //单向持仓
+)
+
+func (m PositionMode) String() string {
+	switch m {
+	case POSITION_MODE_HEDGE:
+		return "HEDGE"
+	case POSITION_MODE_ONE_WAY:
+		return "ONE_WAY"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// 持仓方向
+type PositionSide int
+
+const (
+	POSITION_SIDE_BOTH  PositionSide = iota //单向持仓
+	POSITION_SIDE_LONG                      //多仓
+	POSITION_SIDE_SHORT                     //空仓
+)
+
+func (s PositionSide) String() string {
+	switch s {
+	case POSITION_SIDE_BOTH:
+		return "BOTH"
+	case POSITION_SIDE_LONG:
+		return "LONG"
+	case POSITION_SIDE_SHORT:
+		return "SHORT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// 合约杠杆设置
+type FutureLeverage struct {
+	Pair          CurrencyPair
+	ContractType  string
+	MarginMode    MarginMode
+	LongLeverage  float64
+	ShortLeverage float64 //多空杠杆不能分别设置的交易所与 LongLeverage 相同
+}
+
+// 合约杠杆、保证金模式、持仓模式设置
+type FuturePositionSettingsAPI interface {
+	/**
+	 * 查询杠杆倍数和保证金模式
+	 */
+	GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error)
+
+	/**
+	 * 设置杠杆倍数, 多空分别设置杠杆的交易所会同时设置多空两个方向
+	 */
+	SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error
+
+	/**
+	 * 切换全仓/逐仓, 有持仓或挂单时交易所一般不允许切换
+	 */
+	SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error
+
+	/**
+	 * 查询持仓模式, 一般为账户级别的设置
+	 */
+	GetPositionMode(contractType string) (PositionMode, error)
+
+	/**
+	 * 切换双向/单向持仓
+	 */
+	SetPositionMode(contractType string, mode PositionMode) error
+
+	/**
+	 * 调整逐仓保证金
+	 * @param side   持仓方向, 单向持仓时为 POSITION_SIDE_BOTH
+	 * @param amount 大于0为追加保证金, 小于0为减少保证金
+	 */
+	AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error
+}
@@ -0,0 +1,41 @@
+package goex
+
+// 合约持仓, 双向持仓时每个方向一条记录, 单向持仓时为一条净持仓记录(Side 为 POSITION_SIDE_BOTH)
+// 交易所不提供的字段为0
+type FuturePositionV2 struct {
+	Pair             CurrencyPair
+	ContractType     string
+	ContractId       string       //交易所的合约代码
+	Side             PositionSide //POSITION_SIDE_LONG / POSITION_SIDE_SHORT / POSITION_SIDE_BOTH
+	Amount           float64      //持仓量, 单向持仓时多仓为正空仓为负, 双向持仓时为正
+	AvailAmount      float64      //可平量, 与 Amount 同号
+	AvgPrice         float64      //开仓均价
+	MarginMode       MarginMode
+	Leverage         float64
+	InitialMargin    float64 //起始保证金(持仓占用保证金)
+	MaintMargin      float64 //维持保证金
+	MarkPrice        float64
+	LiquidationPrice float64 //预估强平价
+	Notional         float64 //持仓价值, 以保证金币种计
+	UnrealizedPnl    float64 //未实现盈亏
+	RealizedPnl      float64 //已实现盈亏
+	AdlRank          int     //自动减仓排名
+	CreateTime       int64   //开仓时间(毫秒)
+	UpdateTime       int64   //更新时间(毫秒)
+}
+
+// NetAmount 带方向的净持仓, 多仓为正空仓为负
+func (pos FuturePositionV2) NetAmount() float64 {
+	if pos.Side == POSITION_SIDE_SHORT && pos.Amount > 0 {
+		return -pos.Amount
+	}
+	return pos.Amount
+}
+
+type FuturePositionV2API interface {
+	/**
+	 * 查询持仓, 与 GetFuturePosition 参数相同
+	 * 没有持仓的方向不返回记录
+	 */
+	GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error)
+}
@@ -94,7 +94,7 @@ type FutureRestAPI interface {
 	GetFutureOrderHistory(pair CurrencyPair, contractType string, optional ...OptionalParameter) ([]FutureOrder, error)
 
 	/**
-	 *获取交易费
+	 *获取交易费, 实现 FutureFeeAPI 的交易所返回 FutureTakerFee 的结果
 	 */
 	GetFee() (float64, error)
 
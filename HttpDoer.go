@@ -0,0 +1,220 @@
+package goex
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/BTreeNewBee/goex/internal/logger"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpproxy"
+)
+
+// HttpDoer 执行 http 请求, *http.Client 即为 net/http 的实现
+type HttpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type HttpDoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f HttpDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// HttpMiddleware 包装 HttpDoer, 用于日志、监控、签名、录制等, 需要修改请求时应复制后再修改
+type HttpMiddleware func(next HttpDoer) HttpDoer
+
+// ChainHttpDoer 第一个 middleware 在最外层
+func ChainHttpDoer(doer HttpDoer, middlewares ...HttpMiddleware) HttpDoer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+// NewHttpClient 使用 doer 执行请求的 http.Client, 用于 APIConfig.HttpClient, 超时等由 doer 控制
+func NewHttpClient(doer HttpDoer, middlewares ...HttpMiddleware) *http.Client {
+	return &http.Client{Transport: &HttpDoerTransport{Doer: ChainHttpDoer(doer, middlewares...)}}
+}
+
+// HttpDoerTransport 将 HttpDoer 适配为 http.RoundTripper
+type HttpDoerTransport struct {
+	Doer HttpDoer
+}
+
+func (t *HttpDoerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.Doer.Do(req)
+}
+
+// HttpLogMiddleware 以 debug 级别打印请求耗时和状态码
+func HttpLogMiddleware(next HttpDoer) HttpDoer {
+	return HttpDoerFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.Do(req)
+		if err != nil {
+			logger.Debugf("[http] %s %s error: %s, elapsed: %s", req.Method, req.URL, err.Error(), time.Since(start))
+			return resp, err
+		}
+		logger.Debugf("[http] %s %s status: %d, elapsed: %s", req.Method, req.URL, resp.StatusCode, time.Since(start))
+		return resp, err
+	})
+}
+
+// FastHttpConfig fasthttp 客户端配置, 零值字段使用默认值
+type FastHttpConfig struct {
+	Proxy           *url.URL      // 支持 socks5 和 http(CONNECT) 代理
+	Timeout         time.Duration // 整个请求的超时时间, 默认10秒
+	MaxConnsPerHost int           // 默认16
+	TLSConfig       *tls.Config
+}
+
+// FastHttpDoer 基于 fasthttp 的 HttpDoer
+type FastHttpDoer struct {
+	client  *fasthttp.Client
+	timeout time.Duration
+}
+
+func NewFastHttpDoer(config FastHttpConfig) (*FastHttpDoer, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.MaxConnsPerHost <= 0 {
+		config.MaxConnsPerHost = 16
+	}
+
+	client := &fasthttp.Client{
+		Name:                "goex-http-utils",
+		MaxConnsPerHost:     config.MaxConnsPerHost,
+		MaxIdleConnDuration: 20 * time.Second,
+		ReadTimeout:         config.Timeout,
+		WriteTimeout:        config.Timeout,
+		TLSConfig:           config.TLSConfig,
+	}
+
+	if config.Proxy != nil {
+		switch config.Proxy.Scheme {
+		case "socks5":
+			client.Dial = fasthttpproxy.FasthttpSocksDialer(config.Proxy.Host)
+		case "http":
+			client.Dial = httpConnectDialer(config.Proxy, config.Timeout)
+		default:
+			return nil, fmt.Errorf("fasthttp unsupported proxy scheme [%s]", config.Proxy.Scheme)
+		}
+	}
+
+	return &FastHttpDoer{client: client, timeout: config.Timeout}, nil
+}
+
+func (d *FastHttpDoer) Do(req *http.Request) (*http.Response, error) {
+	fReq := fasthttp.AcquireRequest()
+	fResp := fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(fReq)
+		fasthttp.ReleaseResponse(fResp)
+	}()
+
+	for k, values := range req.Header {
+		for _, v := range values {
+			fReq.Header.Add(k, v)
+		}
+	}
+	fReq.Header.SetMethod(req.Method)
+	fReq.SetRequestURI(req.URL.String())
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		fReq.SetBody(body)
+	}
+
+	if err := d.client.DoTimeout(fReq, fResp, d.timeout); err != nil {
+		return nil, err
+	}
+
+	resp := &http.Response{
+		Status:     fmt.Sprintf("%d %s", fResp.StatusCode(), http.StatusText(fResp.StatusCode())),
+		StatusCode: fResp.StatusCode(),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Request:    req,
+	}
+	fResp.Header.VisitAll(func(k, v []byte) {
+		resp.Header.Add(string(k), string(v))
+	})
+	body := append([]byte(nil), fResp.Body()...)
+	resp.ContentLength = int64(len(body))
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// httpConnectDialer 通过 http 代理的 CONNECT 方法建立隧道
+func httpConnectDialer(proxy *url.URL, timeout time.Duration) fasthttp.DialFunc {
+	return func(addr string) (net.Conn, error) {
+		conn, err := net.DialTimeout("tcp", proxy.Host, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		connectReq := fmt.Sprintf("CONNECT %s HTTP/1.1\r\nHost: %s\r\n", addr, addr)
+		if proxy.User != nil {
+			password, _ := proxy.User.Password()
+			auth := base64.StdEncoding.EncodeToString([]byte(proxy.User.Username() + ":" + password))
+			connectReq += "Proxy-Authorization: Basic " + auth + "\r\n"
+		}
+		if _, err = conn.Write([]byte(connectReq + "\r\n")); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			conn.Close()
+			return nil, errors.New("proxy connect failed: " + resp.Status)
+		}
+		return conn, nil
+	}
+}
+
+var fastHttpDoers sync.Map
+
+// fastHttpDoerFor 按 client 的代理和超时复用 FastHttpDoer
+func fastHttpDoerFor(client *http.Client) (HttpDoer, error) {
+	config := FastHttpConfig{Timeout: client.Timeout}
+	if transport, ok := client.Transport.(*http.Transport); ok && transport.Proxy != nil {
+		proxy, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https"}})
+		if err != nil {
+			return nil, err
+		}
+		config.Proxy = proxy
+	}
+
+	key := fmt.Sprintf("%s|%s", config.Proxy, config.Timeout)
+	if doer, ok := fastHttpDoers.Load(key); ok {
+		return doer.(HttpDoer), nil
+	}
+	doer, err := NewFastHttpDoer(config)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := fastHttpDoers.LoadOrStore(key, doer)
+	return actual.(HttpDoer), nil
+}
@@ -0,0 +1,93 @@
+package goex
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newEchoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.Write([]byte(r.Header.Get("X-Key") + ":" + string(body)))
+	}))
+}
+
+func TestFastHttpDoer(t *testing.T) {
+	srv := newEchoServer()
+	defer srv.Close()
+
+	doer, err := NewFastHttpDoer(FastHttpConfig{})
+	assert.Nil(t, err)
+
+	data, err := NewHttpRequest(NewHttpClient(doer), "POST", srv.URL, "a=1", map[string]string{"X-Key": "k"})
+	assert.Nil(t, err)
+	assert.Equal(t, "k:a=1", string(data))
+
+	_, err = NewFastHttpDoer(FastHttpConfig{Proxy: &url.URL{Scheme: "https", Host: "127.0.0.1:1"}})
+	assert.NotNil(t, err)
+}
+
+func TestFastHttpDoer_HttpProxy(t *testing.T) {
+	srv := newEchoServer()
+	defer srv.Close()
+
+	var connected string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connected = r.Host
+		dst, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go io.Copy(dst, conn)
+		io.Copy(conn, dst)
+		conn.Close()
+	}))
+	defer proxy.Close()
+
+	proxyUrl, _ := url.Parse(proxy.URL)
+	doer, err := NewFastHttpDoer(FastHttpConfig{Proxy: proxyUrl})
+	assert.Nil(t, err)
+
+	data, err := NewHttpRequest(NewHttpClient(doer), "GET", srv.URL, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, ":", string(data))
+	assert.Equal(t, srv.Listener.Addr().String(), connected)
+}
+
+func TestChainHttpDoer(t *testing.T) {
+	srv := newEchoServer()
+	defer srv.Close()
+
+	var order []string
+	record := func(name string) HttpMiddleware {
+		return func(next HttpDoer) HttpDoer {
+			return HttpDoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+	sign := func(next HttpDoer) HttpDoer {
+		return HttpDoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Key", "signed")
+			return next.Do(req)
+		})
+	}
+
+	client := NewHttpClient(http.DefaultClient, record("a"), record("b"), sign, HttpLogMiddleware)
+	data, err := NewHttpRequest(client, "GET", srv.URL, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "signed:", string(data))
+	assert.Equal(t, []string{"a", "b"}, order)
+}
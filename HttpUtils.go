@@ -9,76 +9,34 @@ import (
 	"log"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
-	"time"
 
 	"github.com/BTreeNewBee/goex/internal/logger"
-	"github.com/valyala/fasthttp"
-	"github.com/valyala/fasthttp/fasthttpproxy"
-)
-
-var (
-	fastHttpClient = &fasthttp.Client{
-		Name:                "goex-http-utils",
-		MaxConnsPerHost:     16,
-		MaxIdleConnDuration: 20 * time.Second,
-		ReadTimeout:         10 * time.Second,
-		WriteTimeout:        10 * time.Second,
-	}
-	socksDialer fasthttp.DialFunc
 )
 
+// NewHttpRequestWithFasthttp 使用 fasthttp 执行请求, 代理和超时取自 client
+//
+// Deprecated: 使用 APIConfig.HttpClient = NewHttpClient(NewFastHttpDoer(...)) 设置
 func NewHttpRequestWithFasthttp(client *http.Client, reqMethod, reqUrl, postData string, headers map[string]string) ([]byte, error) {
-	logger.Log.Debug("use fasthttp client")
-	transport := client.Transport
-
-	if transport != nil {
-		if proxy, err := transport.(*http.Transport).Proxy(nil); err == nil && proxy != nil {
-			proxyUrl := proxy.String()
-			logger.Log.Debug("proxy url: ", proxyUrl)
-			if proxy.Scheme != "socks5" {
-				logger.Log.Error("fasthttp only support the socks5 proxy")
-			} else if socksDialer == nil {
-				socksDialer = fasthttpproxy.FasthttpSocksDialer(strings.TrimPrefix(proxyUrl, proxy.Scheme+"://"))
-				fastHttpClient.Dial = socksDialer
-			}
-		}
-	}
-
-	req := fasthttp.AcquireRequest()
-	resp := fasthttp.AcquireResponse()
-	defer func() {
-		fasthttp.ReleaseRequest(req)
-		fasthttp.ReleaseResponse(resp)
-	}()
-
-	for k, v := range headers {
-		req.Header.Set(k, v)
-	}
-	req.Header.SetMethod(reqMethod)
-	req.SetRequestURI(reqUrl)
-	req.SetBodyString(postData)
-
-	err := fastHttpClient.Do(req, resp)
+	doer, err := fastHttpDoerFor(client)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode() != 200 {
-		return nil, errors.New(fmt.Sprintf("HttpStatusCode:%d ,Desc:%s", resp.StatusCode(), string(resp.Body())))
-	}
-	return resp.Body(), nil
+	return newHttpRequest(doer, reqMethod, reqUrl, postData, headers)
 }
 
+// NewHttpRequest client 的 Transport 决定使用 net/http 还是其他 HttpDoer, 见 NewHttpClient
 func NewHttpRequest(client *http.Client, reqType string, reqUrl string, postData string, requstHeaders map[string]string) ([]byte, error) {
+	return newHttpRequest(client, reqType, reqUrl, postData, requstHeaders)
+}
+
+func newHttpRequest(doer HttpDoer, reqType string, reqUrl string, postData string, requstHeaders map[string]string) ([]byte, error) {
 	logger.Log.Debugf("[%s] request url: %s", reqType, reqUrl)
-	lib := os.Getenv("HTTP_LIB")
-	if lib == "fasthttp" {
-		return NewHttpRequestWithFasthttp(client, reqType, reqUrl, postData, requstHeaders)
-	}
 
-	req, _ := http.NewRequest(reqType, reqUrl, strings.NewReader(postData))
+	req, err := http.NewRequest(reqType, reqUrl, strings.NewReader(postData))
+	if err != nil {
+		return nil, err
+	}
 	if req.Header.Get("User-Agent") == "" {
 		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 5.1) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/31.0.1650.63 Safari/537.36")
 	}
@@ -88,7 +46,7 @@ func NewHttpRequest(client *http.Client, reqType string, reqUrl string, postData
 		}
 	}
 
-	resp, err := client.Do(req)
+	resp, err := doer.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,175 @@
+package goex
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+// IsAmbiguousError 网络超时、连接断开、交易所5xx等错误无法判断订单是否已经到达交易所
+func IsAmbiguousError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if _, ok := err.(ApiError); ok {
+		return false
+	}
+
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"timeout", "eof", "connection reset", "broken pipe", "httpstatuscode:5"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsOrderNotFound 交易所明确返回订单不存在, 各交易所的错误码需要转换为 EX_ERR_NOT_FIND_ORDER
+func IsOrderNotFound(err error) bool {
+	apiErr, ok := err.(ApiError)
+	return ok && apiErr.ErrCode == EX_ERR_NOT_FIND_ORDER.ErrCode
+}
+
+// 幂等下单: 按自定义订单ID下单, 结果不确定时先按ID查询订单的真实状态
+// 确认订单不存在才用同一个ID重新下单, 查询也失败时返回 EX_ERR_ORDER_STATUS_UNKNOWN, 不会重复下单
+type IdempotentOrder struct {
+	api        ClientOrderAPI
+	retry      int
+	queryTimes int
+	delay      time.Duration
+}
+
+// NewIdempotentOrder retry 为结果不确定时的重新下单次数, 每次重新下单前最多查询 queryTimes 次, 间隔 delay
+func NewIdempotentOrder(api ClientOrderAPI, retry, queryTimes int, delay time.Duration) *IdempotentOrder {
+	return &IdempotentOrder{api: api, retry: retry, queryTimes: queryTimes, delay: delay}
+}
+
+func (o *IdempotentOrder) LimitOrder(ord *Order) (*Order, error) {
+	var err error
+	for i := 0; i <= o.retry; i++ {
+		var ret *Order
+		ret, err = o.api.LimitOrderWithCid(ord)
+		if err == nil {
+			return ret, nil
+		}
+
+		//第一次下单被交易所明确拒绝; 重试时被拒绝可能是ID重复, 需要再查一次
+		if ord.Cid == "" || (i == 0 && !IsAmbiguousError(err)) {
+			return nil, err
+		}
+
+		logger.Warnf("[idempotent order] cid=%s place order error: %s", ord.Cid, err.Error())
+
+		found, known := o.lookup(ord)
+		if found != nil {
+			return found, nil
+		}
+
+		if !known {
+			return nil, EX_ERR_ORDER_STATUS_UNKNOWN.OriginErr(fmt.Sprintf("order status unknown, cid=%s, %s", ord.Cid, err.Error()))
+		}
+
+		if !IsAmbiguousError(err) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// lookup 只有查询返回 EX_ERR_NOT_FIND_ORDER 才确认订单不存在, known=false 表示无法判断订单是否存在
+func (o *IdempotentOrder) lookup(ord *Order) (found *Order, known bool) {
+	for i := 0; i < o.queryTimes; i++ {
+		time.Sleep(o.delay)
+
+		ret, err := o.api.GetOneOrderByCid(ord.Cid, ord.Currency)
+		if err == nil && ret != nil {
+			return ret, true
+		}
+
+		if IsOrderNotFound(err) {
+			return nil, true
+		}
+
+		//限频、时间戳、签名等错误同样无法判断订单是否存在, 继续查询
+	}
+
+	return nil, false
+}
+
+// 合约幂等下单, 约定同 IdempotentOrder
+type IdempotentFutureOrder struct {
+	api        FutureClientOrderAPI
+	retry      int
+	queryTimes int
+	delay      time.Duration
+}
+
+func NewIdempotentFutureOrder(api FutureClientOrderAPI, retry, queryTimes int, delay time.Duration) *IdempotentFutureOrder {
+	return &IdempotentFutureOrder{api: api, retry: retry, queryTimes: queryTimes, delay: delay}
+}
+
+func (o *IdempotentFutureOrder) LimitFuturesOrder(ord *FutureOrder) (*FutureOrder, error) {
+	var err error
+	for i := 0; i <= o.retry; i++ {
+		var ret *FutureOrder
+		ret, err = o.api.LimitFuturesOrderWithCid(ord)
+		if err == nil {
+			return ret, nil
+		}
+
+		if ord.ClientOid == "" || (i == 0 && !IsAmbiguousError(err)) {
+			return nil, err
+		}
+
+		logger.Warnf("[idempotent order] cid=%s place future order error: %s", ord.ClientOid, err.Error())
+
+		found, known := o.lookup(ord)
+		if found != nil {
+			return found, nil
+		}
+
+		if !known {
+			return nil, EX_ERR_ORDER_STATUS_UNKNOWN.OriginErr(fmt.Sprintf("order status unknown, cid=%s, %s", ord.ClientOid, err.Error()))
+		}
+
+		if !IsAmbiguousError(err) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+func (o *IdempotentFutureOrder) lookup(ord *FutureOrder) (found *FutureOrder, known bool) {
+	for i := 0; i < o.queryTimes; i++ {
+		time.Sleep(o.delay)
+
+		ret, err := o.api.GetFutureOrderByCid(ord.ClientOid, ord.Currency, ord.ContractName)
+		if err == nil && ret != nil {
+			return ret, true
+		}
+
+		if IsOrderNotFound(err) {
+			return nil, true
+		}
+
+		//限频、时间戳、签名等错误同样无法判断订单是否存在, 继续查询
+	}
+
+	return nil, false
+}
@@ -0,0 +1,105 @@
+package goex
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cidMockSpot struct {
+	placeErrs []error
+	queryErrs []error
+	orders    map[string]*Order
+	placed    int
+}
+
+func (m *cidMockSpot) LimitOrderWithCid(ord *Order) (*Order, error) {
+	if ord.Cid == "" {
+		ord.Cid = GenerateOrderClientId(32)
+	}
+
+	m.placed++
+	if m.placeErrs != nil && m.placeErrs[0] != nil {
+		err := m.placeErrs[0]
+		m.placeErrs = m.placeErrs[1:]
+		return nil, err
+	}
+
+	ret := *ord
+	ret.OrderID2 = "1"
+	return &ret, nil
+}
+
+func (m *cidMockSpot) GetOneOrderByCid(cid string, currency CurrencyPair) (*Order, error) {
+	if len(m.queryErrs) > 0 {
+		err := m.queryErrs[0]
+		m.queryErrs = m.queryErrs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ord, ok := m.orders[cid]; ok {
+		return ord, nil
+	}
+	return nil, EX_ERR_NOT_FIND_ORDER
+}
+
+func (m *cidMockSpot) CancelOrderByCid(cid string, currency CurrencyPair) (bool, error) {
+	return true, nil
+}
+
+func TestIsAmbiguousError(t *testing.T) {
+	assert.True(t, IsAmbiguousError(errors.New("net/http: request canceled (Client.Timeout exceeded while awaiting headers)")))
+	assert.True(t, IsAmbiguousError(errors.New("HttpStatusCode:502 ,Desc:")))
+	assert.False(t, IsAmbiguousError(errors.New("HttpStatusCode:400 ,Desc:{\"code\":-2010}")))
+	assert.False(t, IsAmbiguousError(EX_ERR_INSUFFICIENT_BALANCE))
+	assert.False(t, IsAmbiguousError(nil))
+}
+
+func TestIsOrderNotFound(t *testing.T) {
+	assert.True(t, IsOrderNotFound(EX_ERR_NOT_FIND_ORDER.OriginErr("Order does not exist")))
+	assert.False(t, IsOrderNotFound(EX_ERR_API_LIMIT))
+	assert.False(t, IsOrderNotFound(errors.New("not found")))
+	assert.False(t, IsOrderNotFound(nil))
+}
+
+func TestIdempotentOrder_LimitOrder(t *testing.T) {
+	timeout := errors.New("i/o timeout")
+
+	//超时但订单已到达交易所, 不重复下单
+	mock := &cidMockSpot{placeErrs: []error{timeout}, orders: map[string]*Order{}}
+	ord := &Order{Cid: "goexabc", Currency: BTC_USDT, Side: BUY, Price: 1, Amount: 1}
+	mock.orders["goexabc"] = &Order{Cid: "goexabc", OrderID2: "7"}
+	ret, err := NewIdempotentOrder(mock, 2, 3, 0).LimitOrder(ord)
+	assert.Nil(t, err)
+	assert.Equal(t, "7", ret.OrderID2)
+	assert.Equal(t, 1, mock.placed)
+
+	//超时且订单不存在, 用同一个ID重新下单
+	mock = &cidMockSpot{placeErrs: []error{timeout, nil}, orders: map[string]*Order{}}
+	ord = &Order{Currency: BTC_USDT, Side: BUY, Price: 1, Amount: 1}
+	ret, err = NewIdempotentOrder(mock, 2, 3, 0).LimitOrder(ord)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", ret.OrderID2)
+	assert.Equal(t, ord.Cid, ret.Cid)
+	assert.Equal(t, 2, mock.placed)
+
+	//查询也一直超时, 不重新下单
+	mock = &cidMockSpot{placeErrs: []error{timeout}, queryErrs: []error{timeout, timeout, timeout}}
+	_, err = NewIdempotentOrder(mock, 2, 3, 0).LimitOrder(&Order{Currency: BTC_USDT, Side: BUY})
+	assert.Equal(t, EX_ERR_ORDER_STATUS_UNKNOWN.ErrCode, err.(ApiError).ErrCode)
+	assert.Equal(t, 1, mock.placed)
+
+	//查询被限频, 无法确认订单不存在, 不重新下单
+	mock = &cidMockSpot{placeErrs: []error{timeout}, queryErrs: []error{EX_ERR_API_LIMIT, EX_ERR_API_LIMIT, EX_ERR_API_LIMIT}}
+	_, err = NewIdempotentOrder(mock, 2, 3, 0).LimitOrder(&Order{Currency: BTC_USDT, Side: BUY})
+	assert.Equal(t, EX_ERR_ORDER_STATUS_UNKNOWN.ErrCode, err.(ApiError).ErrCode)
+	assert.Equal(t, 1, mock.placed)
+
+	//交易所明确拒绝
+	mock = &cidMockSpot{placeErrs: []error{EX_ERR_INSUFFICIENT_BALANCE}}
+	_, err = NewIdempotentOrder(mock, 2, 3, 0).LimitOrder(&Order{Currency: BTC_USDT, Side: BUY})
+	assert.Equal(t, EX_ERR_INSUFFICIENT_BALANCE, err)
+	assert.Equal(t, 1, mock.placed)
+}
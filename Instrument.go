@@ -0,0 +1,367 @@
+package goex
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MetricsRecorder 指标收集, 对接 prometheus、otel metrics 等, 方法需要并发安全
+type MetricsRecorder interface {
+	// ObserveRequest rest 请求耗时, 网络错误时 statusCode 为 0, endpoint 为去掉 id 等变量的 url path
+	ObserveRequest(exchange, method, endpoint string, statusCode int, latency time.Duration)
+	// IncApiError api 返回错误, 不是 ApiError 时 code 为 unknown
+	IncApiError(exchange, api, code string)
+	// IncRateLimit http 状态码为 429 或 418
+	IncRateLimit(exchange, endpoint string)
+	IncWsMessage(wsUrl string)
+	IncWsReconnect(wsUrl string)
+}
+
+// Tracer 链路追踪, oteladapter 包适配 OpenTelemetry
+type Tracer interface {
+	// Start name 格式: 交易所/方法名, 比如: binance.com/LimitBuy
+	Start(name string, fields ...LogField) Span
+}
+
+type Span interface {
+	// End err 不为 nil 时标记 span 失败
+	End(err error)
+}
+
+// Instrumentation 为 nil 的字段不收集
+type Instrumentation struct {
+	Metrics MetricsRecorder
+	Tracer  Tracer
+}
+
+func (inst Instrumentation) Enabled() bool {
+	return inst.Metrics != nil || inst.Tracer != nil
+}
+
+// NewMetricsMiddleware 记录 rest 请求耗时、状态码和限频
+func NewMetricsMiddleware(exchange string, m MetricsRecorder) HttpMiddleware {
+	return func(next HttpDoer) HttpDoer {
+		return HttpDoerFunc(func(req *http.Request) (*http.Response, error) {
+			endpoint := MetricsEndpoint(req.URL.Path)
+			start := time.Now()
+			resp, err := next.Do(req)
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			m.ObserveRequest(exchange, req.Method, endpoint, statusCode, time.Since(start))
+			if statusCode == http.StatusTooManyRequests || statusCode == http.StatusTeapot {
+				m.IncRateLimit(exchange, endpoint)
+			}
+			return resp, err
+		})
+	}
+}
+
+// MetricsEndpoint 将 path 中的订单id等替换为 :id, 避免指标的维度过多
+func MetricsEndpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if isIdSegment(s) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isIdSegment(s string) bool {
+	if s == "" {
+		return false
+	}
+	digits := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			digits++
+		} else if !unicode.IsLetter(r) && r != '-' {
+			return false
+		}
+	}
+	return digits == len(s) || (len(s) >= 16 && digits > 0)
+}
+
+type instrumenter struct {
+	exchange string
+	inst     Instrumentation
+}
+
+func (i *instrumenter) start(api string) Span {
+	if i.inst.Tracer == nil {
+		return nil
+	}
+	return i.inst.Tracer.Start(i.exchange+"/"+api, KV("exchange", i.exchange))
+}
+
+func (i *instrumenter) end(api string, span Span, err error) {
+	if span != nil {
+		span.End(err)
+	}
+	if err != nil && i.inst.Metrics != nil {
+		code := "unknown"
+		switch e := err.(type) {
+		case ApiError:
+			code = e.ErrCode
+		case *ApiError:
+			code = e.ErrCode
+		}
+		i.inst.Metrics.IncApiError(i.exchange, api, code)
+	}
+}
+
+// InstrumentAPI inst 未启用时原样返回, 可用 UnwrapAPI 取回原始对象以使用 FeeAPI 等扩展接口
+func InstrumentAPI(api API, inst Instrumentation) API {
+	if api == nil || !inst.Enabled() {
+		return api
+	}
+	return &instrumentedAPI{api: api, i: &instrumenter{exchange: api.GetExchangeName(), inst: inst}}
+}
+
+// UnwrapAPI 返回 InstrumentAPI 包装前的对象
+func UnwrapAPI(api API) API {
+	if w, ok := api.(*instrumentedAPI); ok {
+		return w.api
+	}
+	return api
+}
+
+type instrumentedAPI struct {
+	api API
+	i   *instrumenter
+}
+
+func (w *instrumentedAPI) LimitBuy(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (ord *Order, err error) {
+	span := w.i.start("LimitBuy")
+	defer func() { w.i.end("LimitBuy", span, err) }()
+	return w.api.LimitBuy(amount, price, currency, opt...)
+}
+
+func (w *instrumentedAPI) LimitSell(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (ord *Order, err error) {
+	span := w.i.start("LimitSell")
+	defer func() { w.i.end("LimitSell", span, err) }()
+	return w.api.LimitSell(amount, price, currency, opt...)
+}
+
+func (w *instrumentedAPI) MarketBuy(amount, price string, currency CurrencyPair) (ord *Order, err error) {
+	span := w.i.start("MarketBuy")
+	defer func() { w.i.end("MarketBuy", span, err) }()
+	return w.api.MarketBuy(amount, price, currency)
+}
+
+func (w *instrumentedAPI) MarketSell(amount, price string, currency CurrencyPair) (ord *Order, err error) {
+	span := w.i.start("MarketSell")
+	defer func() { w.i.end("MarketSell", span, err) }()
+	return w.api.MarketSell(amount, price, currency)
+}
+
+func (w *instrumentedAPI) CancelOrder(orderId string, currency CurrencyPair) (ok bool, err error) {
+	span := w.i.start("CancelOrder")
+	defer func() { w.i.end("CancelOrder", span, err) }()
+	return w.api.CancelOrder(orderId, currency)
+}
+
+func (w *instrumentedAPI) GetOneOrder(orderId string, currency CurrencyPair) (ord *Order, err error) {
+	span := w.i.start("GetOneOrder")
+	defer func() { w.i.end("GetOneOrder", span, err) }()
+	return w.api.GetOneOrder(orderId, currency)
+}
+
+func (w *instrumentedAPI) GetUnfinishOrders(currency CurrencyPair) (ords []Order, err error) {
+	span := w.i.start("GetUnfinishOrders")
+	defer func() { w.i.end("GetUnfinishOrders", span, err) }()
+	return w.api.GetUnfinishOrders(currency)
+}
+
+func (w *instrumentedAPI) GetOrderHistorys(currency CurrencyPair, opt ...OptionalParameter) (ords []Order, err error) {
+	span := w.i.start("GetOrderHistorys")
+	defer func() { w.i.end("GetOrderHistorys", span, err) }()
+	return w.api.GetOrderHistorys(currency, opt...)
+}
+
+func (w *instrumentedAPI) GetAccount() (acc *Account, err error) {
+	span := w.i.start("GetAccount")
+	defer func() { w.i.end("GetAccount", span, err) }()
+	return w.api.GetAccount()
+}
+
+func (w *instrumentedAPI) GetTicker(currency CurrencyPair) (ticker *Ticker, err error) {
+	span := w.i.start("GetTicker")
+	defer func() { w.i.end("GetTicker", span, err) }()
+	return w.api.GetTicker(currency)
+}
+
+func (w *instrumentedAPI) GetDepth(size int, currency CurrencyPair) (dep *Depth, err error) {
+	span := w.i.start("GetDepth")
+	defer func() { w.i.end("GetDepth", span, err) }()
+	return w.api.GetDepth(size, currency)
+}
+
+func (w *instrumentedAPI) GetKlineRecords(currency CurrencyPair, period KlinePeriod, size int, optional ...OptionalParameter) (klines []Kline, err error) {
+	span := w.i.start("GetKlineRecords")
+	defer func() { w.i.end("GetKlineRecords", span, err) }()
+	return w.api.GetKlineRecords(currency, period, size, optional...)
+}
+
+func (w *instrumentedAPI) GetTrades(currencyPair CurrencyPair, since int64) (trades []Trade, err error) {
+	span := w.i.start("GetTrades")
+	defer func() { w.i.end("GetTrades", span, err) }()
+	return w.api.GetTrades(currencyPair, since)
+}
+
+func (w *instrumentedAPI) GetExchangeName() string {
+	return w.api.GetExchangeName()
+}
+
+func (w *instrumentedAPI) GetAllCurrencyPair() (pairs []CurrencyPair, err error) {
+	span := w.i.start("GetAllCurrencyPair")
+	defer func() { w.i.end("GetAllCurrencyPair", span, err) }()
+	return w.api.GetAllCurrencyPair()
+}
+
+func (w *instrumentedAPI) GetTimestamp() (ts int64, err error) {
+	span := w.i.start("GetTimestamp")
+	defer func() { w.i.end("GetTimestamp", span, err) }()
+	return w.api.GetTimestamp()
+}
+
+// InstrumentFutureRestAPI 同 InstrumentAPI
+func InstrumentFutureRestAPI(api FutureRestAPI, inst Instrumentation) FutureRestAPI {
+	if api == nil || !inst.Enabled() {
+		return api
+	}
+	return &instrumentedFutureAPI{api: api, i: &instrumenter{exchange: api.GetExchangeName(), inst: inst}}
+}
+
+// UnwrapFutureRestAPI 返回 InstrumentFutureRestAPI 包装前的对象
+func UnwrapFutureRestAPI(api FutureRestAPI) FutureRestAPI {
+	if w, ok := api.(*instrumentedFutureAPI); ok {
+		return w.api
+	}
+	return api
+}
+
+type instrumentedFutureAPI struct {
+	api FutureRestAPI
+	i   *instrumenter
+}
+
+func (w *instrumentedFutureAPI) GetExchangeName() string {
+	return w.api.GetExchangeName()
+}
+
+func (w *instrumentedFutureAPI) GetFutureEstimatedPrice(currencyPair CurrencyPair) (price float64, err error) {
+	span := w.i.start("GetFutureEstimatedPrice")
+	defer func() { w.i.end("GetFutureEstimatedPrice", span, err) }()
+	return w.api.GetFutureEstimatedPrice(currencyPair)
+}
+
+func (w *instrumentedFutureAPI) GetFutureTicker(currencyPair CurrencyPair, contractType string) (ticker *Ticker, err error) {
+	span := w.i.start("GetFutureTicker")
+	defer func() { w.i.end("GetFutureTicker", span, err) }()
+	return w.api.GetFutureTicker(currencyPair, contractType)
+}
+
+func (w *instrumentedFutureAPI) GetFutureDepth(currencyPair CurrencyPair, contractType string, size int) (dep *Depth, err error) {
+	span := w.i.start("GetFutureDepth")
+	defer func() { w.i.end("GetFutureDepth", span, err) }()
+	return w.api.GetFutureDepth(currencyPair, contractType, size)
+}
+
+func (w *instrumentedFutureAPI) GetFutureIndex(currencyPair CurrencyPair) (index float64, err error) {
+	span := w.i.start("GetFutureIndex")
+	defer func() { w.i.end("GetFutureIndex", span, err) }()
+	return w.api.GetFutureIndex(currencyPair)
+}
+
+func (w *instrumentedFutureAPI) GetFutureUserinfo(currencyPair ...CurrencyPair) (acc *FutureAccount, err error) {
+	span := w.i.start("GetFutureUserinfo")
+	defer func() { w.i.end("GetFutureUserinfo", span, err) }()
+	return w.api.GetFutureUserinfo(currencyPair...)
+}
+
+func (w *instrumentedFutureAPI) PlaceFutureOrder(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64) (orderId string, err error) {
+	span := w.i.start("PlaceFutureOrder")
+	defer func() { w.i.end("PlaceFutureOrder", span, err) }()
+	return w.api.PlaceFutureOrder(currencyPair, contractType, price, amount, openType, matchPrice, leverRate)
+}
+
+func (w *instrumentedFutureAPI) LimitFuturesOrder(currencyPair CurrencyPair, contractType, price, amount string, openType int, opt ...LimitOrderOptionalParameter) (ord *FutureOrder, err error) {
+	span := w.i.start("LimitFuturesOrder")
+	defer func() { w.i.end("LimitFuturesOrder", span, err) }()
+	return w.api.LimitFuturesOrder(currencyPair, contractType, price, amount, openType, opt...)
+}
+
+func (w *instrumentedFutureAPI) MarketFuturesOrder(currencyPair CurrencyPair, contractType, amount string, openType int) (ord *FutureOrder, err error) {
+	span := w.i.start("MarketFuturesOrder")
+	defer func() { w.i.end("MarketFuturesOrder", span, err) }()
+	return w.api.MarketFuturesOrder(currencyPair, contractType, amount, openType)
+}
+
+func (w *instrumentedFutureAPI) FutureCancelOrder(currencyPair CurrencyPair, contractType, orderId string) (ok bool, err error) {
+	span := w.i.start("FutureCancelOrder")
+	defer func() { w.i.end("FutureCancelOrder", span, err) }()
+	return w.api.FutureCancelOrder(currencyPair, contractType, orderId)
+}
+
+func (w *instrumentedFutureAPI) GetFuturePosition(currencyPair CurrencyPair, contractType string) (positions []FuturePosition, err error) {
+	span := w.i.start("GetFuturePosition")
+	defer func() { w.i.end("GetFuturePosition", span, err) }()
+	return w.api.GetFuturePosition(currencyPair, contractType)
+}
+
+func (w *instrumentedFutureAPI) GetFutureOrders(orderIds []string, currencyPair CurrencyPair, contractType string) (ords []FutureOrder, err error) {
+	span := w.i.start("GetFutureOrders")
+	defer func() { w.i.end("GetFutureOrders", span, err) }()
+	return w.api.GetFutureOrders(orderIds, currencyPair, contractType)
+}
+
+func (w *instrumentedFutureAPI) GetFutureOrder(orderId string, currencyPair CurrencyPair, contractType string) (ord *FutureOrder, err error) {
+	span := w.i.start("GetFutureOrder")
+	defer func() { w.i.end("GetFutureOrder", span, err) }()
+	return w.api.GetFutureOrder(orderId, currencyPair, contractType)
+}
+
+func (w *instrumentedFutureAPI) GetUnfinishFutureOrders(currencyPair CurrencyPair, contractType string) (ords []FutureOrder, err error) {
+	span := w.i.start("GetUnfinishFutureOrders")
+	defer func() { w.i.end("GetUnfinishFutureOrders", span, err) }()
+	return w.api.GetUnfinishFutureOrders(currencyPair, contractType)
+}
+
+func (w *instrumentedFutureAPI) GetFutureOrderHistory(pair CurrencyPair, contractType string, optional ...OptionalParameter) (ords []FutureOrder, err error) {
+	span := w.i.start("GetFutureOrderHistory")
+	defer func() { w.i.end("GetFutureOrderHistory", span, err) }()
+	return w.api.GetFutureOrderHistory(pair, contractType, optional...)
+}
+
+func (w *instrumentedFutureAPI) GetFee() (fee float64, err error) {
+	span := w.i.start("GetFee")
+	defer func() { w.i.end("GetFee", span, err) }()
+	return w.api.GetFee()
+}
+
+func (w *instrumentedFutureAPI) GetContractValue(currencyPair CurrencyPair) (value float64, err error) {
+	span := w.i.start("GetContractValue")
+	defer func() { w.i.end("GetContractValue", span, err) }()
+	return w.api.GetContractValue(currencyPair)
+}
+
+func (w *instrumentedFutureAPI) GetDeliveryTime() (int, int, int, int) {
+	return w.api.GetDeliveryTime()
+}
+
+func (w *instrumentedFutureAPI) GetKlineRecords(contractType string, currency CurrencyPair, period KlinePeriod, size int, optional ...OptionalParameter) (klines []FutureKline, err error) {
+	span := w.i.start("GetKlineRecords")
+	defer func() { w.i.end("GetKlineRecords", span, err) }()
+	return w.api.GetKlineRecords(contractType, currency, period, size, optional...)
+}
+
+func (w *instrumentedFutureAPI) GetTrades(contractType string, currencyPair CurrencyPair, since int64) (trades []Trade, err error) {
+	span := w.i.start("GetTrades")
+	defer func() { w.i.end("GetTrades", span, err) }()
+	return w.api.GetTrades(contractType, currencyPair, since)
+}
@@ -0,0 +1,141 @@
+package goex
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordMetrics struct {
+	lock  sync.Mutex
+	calls []string
+}
+
+func (m *recordMetrics) add(format string, args ...interface{}) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.calls = append(m.calls, fmt.Sprintf(format, args...))
+}
+
+func (m *recordMetrics) ObserveRequest(exchange, method, endpoint string, statusCode int, latency time.Duration) {
+	m.add("request %s %s %s %d", exchange, method, endpoint, statusCode)
+}
+
+func (m *recordMetrics) IncApiError(exchange, api, code string) {
+	m.add("error %s %s %s", exchange, api, code)
+}
+
+func (m *recordMetrics) IncRateLimit(exchange, endpoint string) {
+	m.add("ratelimit %s %s", exchange, endpoint)
+}
+
+func (m *recordMetrics) IncWsMessage(wsUrl string) {
+	m.add("ws message %s", wsUrl)
+}
+
+func (m *recordMetrics) IncWsReconnect(wsUrl string) {
+	m.add("ws reconnect %s", wsUrl)
+}
+
+type recordTracer struct {
+	spans []string
+}
+
+type recordSpan struct {
+	t    *recordTracer
+	name string
+}
+
+func (t *recordTracer) Start(name string, fields ...LogField) Span {
+	return &recordSpan{t: t, name: name}
+}
+
+func (s *recordSpan) End(err error) {
+	s.t.spans = append(s.t.spans, fmt.Sprintf("%s %v", s.name, err))
+}
+
+// mockAPI 未实现的方法调用时 panic
+type mockAPI struct {
+	API
+	err error
+}
+
+func (m *mockAPI) GetExchangeName() string {
+	return "binance.com"
+}
+
+func (m *mockAPI) GetTicker(currency CurrencyPair) (*Ticker, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &Ticker{Pair: currency, Last: 1}, nil
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	assert.Equal(t, "/api/v3/order", MetricsEndpoint("/api/v3/order"))
+	assert.Equal(t, "/api/v5/trade/order/:id", MetricsEndpoint("/api/v5/trade/order/123456"))
+	assert.Equal(t, "/v1/order/orders/:id/submitcancel", MetricsEndpoint("/v1/order/orders/59378/submitcancel"))
+	assert.Equal(t, "/api/v1/orders/:id", MetricsEndpoint("/api/v1/orders/5bd6e9286d99522a52e458de"))
+	assert.Equal(t, "/api/v1/market/BTC-USDT", MetricsEndpoint("/api/v1/market/BTC-USDT"))
+}
+
+func TestNewMetricsMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limit" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	m := &recordMetrics{}
+	client := NewHttpClient(http.DefaultClient, NewMetricsMiddleware("binance.com", m))
+
+	_, err := NewHttpRequest(client, "GET", srv.URL+"/order/123", "", nil)
+	assert.Nil(t, err)
+	_, err = NewHttpRequest(client, "POST", srv.URL+"/limit", "", nil)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []string{
+		"request binance.com GET /order/:id 200",
+		"request binance.com POST /limit 429",
+		"ratelimit binance.com /limit",
+	}, m.calls)
+}
+
+func TestInstrumentAPI(t *testing.T) {
+	api := &mockAPI{}
+	assert.True(t, InstrumentAPI(api, Instrumentation{}) == API(api))
+
+	m := &recordMetrics{}
+	tracer := &recordTracer{}
+	wrapped := InstrumentAPI(api, Instrumentation{Metrics: m, Tracer: tracer})
+	assert.True(t, UnwrapAPI(wrapped) == API(api))
+
+	ticker, err := wrapped.GetTicker(BTC_USDT)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, ticker.Last)
+
+	api.err = ApiError{ErrCode: "-1021", ErrMsg: "timestamp"}
+	_, err = wrapped.GetTicker(BTC_USDT)
+	assert.Equal(t, api.err, err)
+
+	api.err = errors.New("timeout")
+	wrapped.GetTicker(BTC_USDT)
+
+	assert.Equal(t, []string{
+		"error binance.com GetTicker -1021",
+		"error binance.com GetTicker unknown",
+	}, m.calls)
+	assert.Equal(t, []string{
+		"binance.com/GetTicker <nil>",
+		"binance.com/GetTicker timestamp",
+		"binance.com/GetTicker timeout",
+	}, tracer.spans)
+}
@@ -0,0 +1,80 @@
+package goex
+
+import (
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+// Logger 结构化日志接口, 可使用 logadapter 包适配 slog、zap、zerolog
+type Logger = logger.Structured
+
+// LogField 日志的键值对, 使用 KV 创建
+type LogField = logger.Field
+
+func KV(key string, value interface{}) LogField {
+	return LogField{Key: key, Value: value}
+}
+
+// SetLogger 替换全局日志, 包括未设置 APIConfig.Logger 的客户端, l 为 nil 时恢复为默认的文本日志
+// 输出前自动隐藏 api key、secret、签名等
+func SetLogger(l Logger) {
+	if l == nil {
+		logger.SetStructured(nil)
+		return
+	}
+	if _, ok := l.(defaultLogger); ok {
+		return
+	}
+	logger.SetStructured(logger.NewRedactor(l))
+}
+
+// DefaultLogger 全局日志, 未调用 SetLogger 时为文本日志(级别由 GOEX_LOG_LEVEL 控制)
+func DefaultLogger() Logger {
+	return defaultLogger{}
+}
+
+// DebugEnabled l 实现了 DebugEnabled() bool 时按其判断, 否则返回 true
+// 输出请求、响应等较大的 Debug 日志前先判断, 关闭时没有格式化和隐藏敏感信息的开销
+func DebugEnabled(l Logger) bool {
+	return logger.IsDebugEnabled(l)
+}
+
+// NewRedactLogger 输出前隐藏敏感字段以及 secrets 中的值
+func NewRedactLogger(l Logger, secrets ...string) Logger {
+	return logger.NewRedactor(l, secrets...)
+}
+
+// defaultLogger 每次输出时使用当前的全局日志, SetLogger 之前创建的客户端也能生效
+type defaultLogger struct {
+	fields []LogField
+}
+
+func (d defaultLogger) target() Logger {
+	if s := logger.Current(); s != nil {
+		return s
+	}
+	return logger.NewTextStructured(logger.Log)
+}
+
+func (d defaultLogger) DebugEnabled() bool {
+	return logger.IsDebugEnabled(d.target())
+}
+
+func (d defaultLogger) Debug(msg string, fields ...LogField) {
+	d.target().Debug(msg, append(d.fields, fields...)...)
+}
+
+func (d defaultLogger) Info(msg string, fields ...LogField) {
+	d.target().Info(msg, append(d.fields, fields...)...)
+}
+
+func (d defaultLogger) Warn(msg string, fields ...LogField) {
+	d.target().Warn(msg, append(d.fields, fields...)...)
+}
+
+func (d defaultLogger) Error(msg string, fields ...LogField) {
+	d.target().Error(msg, append(d.fields, fields...)...)
+}
+
+func (d defaultLogger) With(fields ...LogField) Logger {
+	return defaultLogger{fields: append(append([]LogField(nil), d.fields...), fields...)}
+}
@@ -0,0 +1,94 @@
+package goex
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordLogger struct {
+	lines *[]string
+	with  []LogField
+}
+
+func newRecordLogger() *recordLogger {
+	return &recordLogger{lines: new([]string)}
+}
+
+func (r *recordLogger) record(level, msg string, fields []LogField) {
+	line := level + " " + msg
+	for _, f := range append(r.with, fields...) {
+		line += fmt.Sprintf(" %s=%v", f.Key, f.Value)
+	}
+	*r.lines = append(*r.lines, line)
+}
+
+func (r *recordLogger) Debug(msg string, fields ...LogField) { r.record("D", msg, fields) }
+func (r *recordLogger) Info(msg string, fields ...LogField)  { r.record("I", msg, fields) }
+func (r *recordLogger) Warn(msg string, fields ...LogField)  { r.record("W", msg, fields) }
+func (r *recordLogger) Error(msg string, fields ...LogField) { r.record("E", msg, fields) }
+func (r *recordLogger) With(fields ...LogField) Logger {
+	return &recordLogger{lines: r.lines, with: append(append([]LogField(nil), r.with...), fields...)}
+}
+
+func TestSetLogger(t *testing.T) {
+	rec := newRecordLogger()
+	SetLogger(rec)
+	defer SetLogger(nil)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	_, err := NewHttpRequest(http.DefaultClient, "GET", srv.URL+"/v1/order?AccessKeyId=abc&SignatureMethod=HmacSHA256&Signature=xyz", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "D [GET] request url: "+srv.URL+"/v1/order?AccessKeyId=******&SignatureMethod=HmacSHA256&Signature=******", (*rec.lines)[0])
+
+	DefaultLogger().With(KV("exchange", OKEX)).Info("hello")
+	assert.Equal(t, "I hello exchange=okex", (*rec.lines)[1])
+
+	//避免循环调用
+	SetLogger(DefaultLogger())
+	DefaultLogger().Warn("still works")
+	assert.Equal(t, "W still works", (*rec.lines)[2])
+}
+
+func TestAPIConfig_GetLogger(t *testing.T) {
+	rec := newRecordLogger()
+	config := &APIConfig{ApiKey: "k123", ApiSecretKey: "s456", Logger: rec}
+
+	config.GetLogger().With(KV("secret_key", "s456")).Debug("request with k123",
+		KV("apiKey", "k123"),
+		KV("body", `{"sign":"abc","amount":"1"}`),
+		KV("headers", "OK-ACCESS-KEY: k1 OK-ACCESS-SIGN: s1"),
+		KV("amount", 1.5))
+	assert.Equal(t, `D request with ****** secret_key=****** apiKey=****** body={"sign":"******","amount":"1"} headers=OK-ACCESS-KEY: ****** OK-ACCESS-SIGN: ****** amount=1.5`, (*rec.lines)[0])
+
+	assert.Equal(t, "password=******&a=1", logger.Redact("password=p&a=1"))
+}
+
+type infoLogger struct {
+	*recordLogger
+}
+
+func (infoLogger) DebugEnabled() bool { return false }
+
+func TestDebugEnabled(t *testing.T) {
+	rec := newRecordLogger()
+	assert.True(t, DebugEnabled(rec))
+
+	l := NewRedactLogger(infoLogger{rec}, "k123")
+	assert.False(t, DebugEnabled(l))
+	l.Debug("request with k123")
+	l.Info("request with k123")
+	assert.Equal(t, []string{"I request with ******"}, *rec.lines)
+
+	logger.SetLevel(logger.ERROR)
+	assert.False(t, DebugEnabled(DefaultLogger()))
+	logger.SetLevel(logger.DEBUG)
+	defer logger.SetLevel(logger.ERROR)
+	assert.True(t, DebugEnabled(DefaultLogger()))
+}
@@ -1,6 +1,7 @@
 package goex
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"time"
@@ -121,6 +122,51 @@ type APIConfig struct {
 	ClientId      string //for bitstamp.net , huobi.pro
 
 	Lever float64 //杠杆倍数 , for future
+
+	Env Environment //运行环境, Endpoint 为空时按环境选择默认地址(rest 和 ws)
+
+	Ws *WsClientConfig //ws 连接配置, 为 nil 时使用默认配置
+
+	Logger Logger //客户端日志, 为 nil 时使用全局日志(见 SetLogger)
+
+	Clock Clock //签名时间戳使用的时钟, 为 nil 时与交易所服务器时间同步(见 SharedTimeSync), LocalClock 为不同步
+}
+
+// GetLogger 客户端日志, 输出前自动隐藏 ApiKey、ApiSecretKey、ApiPassphrase 以及签名等
+// 每次调用都会创建新的对象, 客户端在构造时调用一次并保存
+func (c *APIConfig) GetLogger() Logger {
+	l := c.Logger
+	if l == nil {
+		l = DefaultLogger()
+	}
+	return NewRedactLogger(l, c.ApiKey, c.ApiSecretKey, c.ApiPassphrase)
+}
+
+// GetClock 签名时间戳使用的时钟, 未设置 Clock 时返回 key 和 HttpClient 对应的 SharedTimeSync
+func (c *APIConfig) GetClock(key string, fetch ServerTimeFunc) Clock {
+	if c.Clock != nil {
+		return c.Clock
+	}
+	return SharedTimeSync(key, c.HttpClient, fetch)
+}
+
+// GetWsClientConfig 返回 ws 连接配置, 未设置时返回只包含 Env 的默认配置
+func (c *APIConfig) GetWsClientConfig() *WsClientConfig {
+	if c.Ws != nil {
+		return c.Ws
+	}
+	return &WsClientConfig{Env: c.Env}
+}
+
+// WsClientConfig 交易所 ws 客户端的连接配置, 零值字段使用默认值
+type WsClientConfig struct {
+	WsUrl            string        //为空时按 Env 使用交易所默认地址
+	ProxyUrl         string        //为空时使用环境变量 HTTPS_PROXY
+	TLSConfig        *tls.Config   //
+	HandshakeTimeout time.Duration //默认30秒
+	LocalAddr        string        //绑定的本地ip,多出口ip时使用
+	Env              Environment
+	Metrics          MetricsRecorder //ws 消息数和重连次数, 为 nil 时不收集
 }
 
 type Kline struct {
@@ -199,7 +245,8 @@ type FuturePosition struct {
 
 type HistoricalFunding struct {
 	InstrumentId string    `json:"instrument_id"`
-	RealizedRate float64   `json:"realized_rate,string"`
+	FundingRate  float64   `json:"funding_rate,string"`
+	RealizedRate float64   `json:"realized_rate,string"` //实际结算费率
 	FundingTime  time.Time `json:"funding_time"`
 }
 
@@ -0,0 +1,54 @@
+package goex
+
+import "time"
+
+// 永续合约资金费率
+type FundingRate struct {
+	Pair            CurrencyPair
+	ContractType    string
+	FundingRate     float64   //本期资金费率, 在 FundingTime 结算
+	PredictedRate   float64   //预测的下期资金费率, 交易所不提供时为0
+	FundingTime     time.Time //本期结算时间
+	NextFundingTime time.Time //下期结算时间, 交易所不提供时为零值
+}
+
+// 合约持仓量
+type OpenInterest struct {
+	Pair         CurrencyPair
+	ContractType string
+	Amount       float64 //持仓量, 单位与该交易所下单数量一致(张或币)
+	Timestamp    int64   //毫秒
+}
+
+// 永续合约行情数据: 资金费率、标记价格、指数价格、持仓量
+type SwapMarketDataAPI interface {
+	/**
+	 * 当前资金费率及预测费率
+	 * @param contractType SWAP_CONTRACT(币本位) 或 SWAP_USDT_CONTRACT(U本位), 交易所只有一种永续时忽略
+	 */
+	GetFundingRate(currencyPair CurrencyPair, contractType string) (*FundingRate, error)
+
+	/**
+	 * 历史资金费率, 按结算时间倒序
+	 * @param size 返回条数
+	 * @param opt  分页参数, 原样传给交易所:
+	 *             binance: startTime/endTime(毫秒), okex: from/to, huobi: page_index,
+	 *             bitmex: start/startTime/endTime, bitget: pageIndex
+	 */
+	GetFundingRateHistory(currencyPair CurrencyPair, contractType string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error)
+
+	/**
+	 * 标记价格
+	 */
+	GetMarkPrice(currencyPair CurrencyPair, contractType string) (float64, error)
+
+	/**
+	 * 指数价格
+	 */
+	GetIndexPrice(currencyPair CurrencyPair, contractType string) (float64, error)
+
+	/**
+	 * 持仓量
+	 */
+	GetOpenInterest(currencyPair CurrencyPair, contractType string) (*OpenInterest, error)
+}
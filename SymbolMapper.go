@@ -0,0 +1,237 @@
+package goex
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Market 交易所的一个现货交易对或合约
+type Market struct {
+	Symbol        string // 交易所的 symbol, 比如: BTCUSDT、XXBTZUSD、tBTCUSD
+	BaseCurrency  string // 交易所的币种名称, 比如: XXBT、BCHABC
+	QuoteCurrency string
+	ContractType  string       // 合约类型(QUARTER_CONTRACT、SWAP_CONTRACT 等), 现货为空
+	Pair          CurrencyPair // 统一的交易对, 由 SymbolMapper 按币种别名转换
+}
+
+// CanonicalId 统一的市场标识, 现货: BTC_USDT, 合约: BTC_USD:quarter
+func (m Market) CanonicalId() string {
+	return CanonicalId(m.Pair, m.ContractType)
+}
+
+func CanonicalId(pair CurrencyPair, contractType string) string {
+	if contractType == "" {
+		return pair.String()
+	}
+	return pair.String() + ":" + contractType
+}
+
+// ParseCanonicalId 解析 CanonicalId 生成的标识
+func ParseCanonicalId(id string) (pair CurrencyPair, contractType string, err error) {
+	if i := strings.Index(id, ":"); i >= 0 {
+		id, contractType = id[:i], id[i+1:]
+	}
+	pair = NewCurrencyPair2(id)
+	if pair.Eq(UNKNOWN_PAIR) || pair.CurrencyA.Symbol == "" || pair.CurrencyB.Symbol == "" {
+		return UNKNOWN_PAIR, "", EX_ERR_UNKNOWN_SYMBOL.OriginErr(fmt.Sprintf("invalid canonical id [%s]", id))
+	}
+	return pair, contractType, nil
+}
+
+// CommonCurrencyAliases 各交易所通用的币种别名, 交易所名称 -> goex 名称
+var CommonCurrencyAliases = map[string]string{
+	"XBT":    "BTC",
+	"BCC":    "BCH",
+	"BCHABC": "BCH",
+	"BCHSV":  "BSV",
+	"XDG":    "DOGE",
+}
+
+// quoteCurrencies 没有分隔符的 symbol 按计价币后缀拆分, 长的在前
+var quoteCurrencies = []string{
+	"USDT", "USDC", "BUSD", "TUSD", "GUSD", "KRWB", "EURS",
+	"USD", "BTC", "ETH", "BNB", "EUR", "GBP", "JPY", "KRW", "PAX", "DAI", "EOS", "TRX", "XRP", "OKB",
+	"HT",
+}
+
+// ParseCurrencyPair 解析 BTC_USDT、BTC-USDT、BTC/USDT 或没有分隔符的 BTCUSDT(按常见计价币后缀拆分)
+func ParseCurrencyPair(symbol string) (CurrencyPair, error) {
+	for _, sep := range []string{"_", "-", "/"} {
+		if currencies := strings.Split(symbol, sep); len(currencies) == 2 && currencies[0] != "" && currencies[1] != "" {
+			return NewCurrencyPair(NewCurrency(currencies[0], ""), NewCurrency(currencies[1], "")), nil
+		}
+	}
+
+	upper := strings.ToUpper(symbol)
+	for _, quote := range quoteCurrencies {
+		if len(upper) > len(quote) && strings.HasSuffix(upper, quote) {
+			return NewCurrencyPair(NewCurrency(strings.TrimSuffix(upper, quote), ""), NewCurrency(quote, "")), nil
+		}
+	}
+	return UNKNOWN_PAIR, EX_ERR_UNKNOWN_SYMBOL.OriginErr(fmt.Sprintf("unknown symbol [%s]", symbol))
+}
+
+// MarketLoader 请求交易所的市场列表, 填充 Market 除 Pair 外的字段
+type MarketLoader func() ([]Market, error)
+
+const symbolReloadInterval = time.Minute
+
+// SymbolMapper 按交易所的市场列表在交易所 symbol 与统一交易对之间转换, 并发安全
+// 首次使用时加载市场列表, 遇到未知 symbol 时最多每分钟重新加载一次(新上线的交易对)
+type SymbolMapper struct {
+	loader  MarketLoader
+	aliases map[string]string //交易所币种 -> goex 币种, 大写
+
+	lock     sync.RWMutex
+	markets  []Market
+	bySymbol map[string]Market
+	byId     map[string]Market
+	loadedAt time.Time
+	triedAt  time.Time //最近一次加载的时间, 包括失败
+	loadErr  error
+}
+
+// NewSymbolMapper aliases 为交易所特有的币种别名, 会合并 CommonCurrencyAliases
+func NewSymbolMapper(loader MarketLoader, aliases map[string]string) *SymbolMapper {
+	m := &SymbolMapper{
+		loader:  loader,
+		aliases: make(map[string]string),
+	}
+	for _, a := range []map[string]string{CommonCurrencyAliases, aliases} {
+		for k, v := range a {
+			m.aliases[strings.ToUpper(k)] = strings.ToUpper(v)
+		}
+	}
+	return m
+}
+
+// Currency 交易所币种转为 goex 币种, 比如: XXBT -> BTC
+func (m *SymbolMapper) Currency(symbol string) Currency {
+	if c, ok := m.aliases[strings.ToUpper(symbol)]; ok {
+		return NewCurrency(c, "")
+	}
+	return NewCurrency(symbol, "")
+}
+
+// Load 重新加载市场列表
+func (m *SymbolMapper) Load() error {
+	markets, err := m.loader()
+	if err != nil {
+		m.lock.Lock()
+		m.triedAt, m.loadErr = time.Now(), err
+		m.lock.Unlock()
+		return err
+	}
+
+	bySymbol := make(map[string]Market, len(markets))
+	byId := make(map[string]Market, len(markets))
+	for i := range markets {
+		mk := &markets[i]
+		mk.Pair = NewCurrencyPair(m.Currency(mk.BaseCurrency), m.Currency(mk.QuoteCurrency))
+		bySymbol[strings.ToUpper(mk.Symbol)] = *mk
+		//同一市场有多个 symbol 时使用第一个
+		if _, ok := byId[mk.CanonicalId()]; !ok {
+			byId[mk.CanonicalId()] = *mk
+		}
+	}
+
+	m.lock.Lock()
+	m.markets, m.bySymbol, m.byId = markets, bySymbol, byId
+	m.loadedAt, m.triedAt, m.loadErr = time.Now(), time.Now(), nil
+	m.lock.Unlock()
+	return nil
+}
+
+// lookup 未找到时重新加载一次, 距上次加载不到一分钟时返回上次加载的错误
+func (m *SymbolMapper) lookup(get func() (Market, bool)) (Market, bool, error) {
+	m.lock.RLock()
+	mk, ok := get()
+	triedAt, loadErr := m.triedAt, m.loadErr
+	m.lock.RUnlock()
+	if ok {
+		return mk, true, nil
+	}
+	if !triedAt.IsZero() && time.Since(triedAt) < symbolReloadInterval {
+		return mk, false, loadErr
+	}
+
+	if err := m.Load(); err != nil {
+		return Market{}, false, err
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	mk, ok = get()
+	return mk, ok, nil
+}
+
+// Market 交易所 symbol 对应的市场, 不区分大小写, 未知 symbol 返回 EX_ERR_UNKNOWN_SYMBOL
+func (m *SymbolMapper) Market(symbol string) (Market, error) {
+	mk, ok, err := m.lookup(func() (Market, bool) {
+		mk, ok := m.bySymbol[strings.ToUpper(symbol)]
+		return mk, ok
+	})
+	if err != nil {
+		return Market{}, err
+	}
+	if !ok {
+		return Market{}, EX_ERR_UNKNOWN_SYMBOL.OriginErr(fmt.Sprintf("unknown symbol [%s]", symbol))
+	}
+	return mk, nil
+}
+
+// CurrencyPair 交易所 symbol 转为统一交易对
+func (m *SymbolMapper) CurrencyPair(symbol string) (CurrencyPair, error) {
+	mk, err := m.Market(symbol)
+	if err != nil {
+		return UNKNOWN_PAIR, err
+	}
+	return mk.Pair, nil
+}
+
+// Symbol 统一交易对转为交易所 symbol, 现货 contractType 为空, pair 中的币种可以是别名(XBT_USD)
+func (m *SymbolMapper) Symbol(pair CurrencyPair, contractType string) (string, error) {
+	pair = NewCurrencyPair(m.Currency(pair.CurrencyA.Symbol), m.Currency(pair.CurrencyB.Symbol))
+	id := CanonicalId(pair, contractType)
+	mk, ok, err := m.lookup(func() (Market, bool) {
+		mk, ok := m.byId[id]
+		return mk, ok
+	})
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", EX_ERR_UNKNOWN_SYMBOL.OriginErr(fmt.Sprintf("unknown currency pair [%s]", id))
+	}
+	return mk.Symbol, nil
+}
+
+// Markets 所有市场, 按 symbol 排序
+func (m *SymbolMapper) Markets() ([]Market, error) {
+	m.lock.RLock()
+	loaded := !m.loadedAt.IsZero()
+	m.lock.RUnlock()
+	if !loaded {
+		if err := m.Load(); err != nil {
+			return nil, err
+		}
+	}
+
+	m.lock.RLock()
+	markets := append([]Market(nil), m.markets...)
+	m.lock.RUnlock()
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].Symbol < markets[j].Symbol
+	})
+	return markets, nil
+}
+
+// SymbolAPI 支持 symbol 转换的交易所, 可通过类型断言获取
+type SymbolAPI interface {
+	/**
+	 * symbol 与统一交易对的转换
+	 */
+	Symbols() *SymbolMapper
+}
@@ -0,0 +1,100 @@
+package goex
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCurrencyPair(t *testing.T) {
+	for symbol, expected := range map[string]string{
+		"BTC_USDT": "BTC_USDT",
+		"eth-btc":  "ETH_BTC",
+		"XBT/USD":  "XBT_USD",
+		"BTCUSDT":  "BTC_USDT",
+		"ethbtc":   "ETH_BTC",
+		"BTCUSDC":  "BTC_USDC",
+		"ETHEOS":   "ETH_EOS",
+	} {
+		pair, err := ParseCurrencyPair(symbol)
+		assert.Nil(t, err, symbol)
+		assert.Equal(t, expected, pair.String(), symbol)
+	}
+
+	_, err := ParseCurrencyPair("BTCXYZ")
+	assert.Equal(t, EX_ERR_UNKNOWN_SYMBOL.ErrCode, err.(ApiError).ErrCode)
+	assert.Equal(t, "BTC_USDT", NewCurrencyPair3("BTCUSDT", "").String())
+}
+
+func TestCanonicalId(t *testing.T) {
+	assert.Equal(t, "BTC_USDT", CanonicalId(BTC_USDT, ""))
+	assert.Equal(t, "BTC_USD:quarter", CanonicalId(BTC_USD, "quarter"))
+
+	pair, contractType, err := ParseCanonicalId("BTC_USD:quarter")
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC_USD", pair.String())
+	assert.Equal(t, "quarter", contractType)
+
+	_, _, err = ParseCanonicalId("BTC")
+	assert.NotNil(t, err)
+}
+
+func TestSymbolMapper(t *testing.T) {
+	var loads int
+	markets := []Market{
+		{Symbol: "XXBTZUSD", BaseCurrency: "XXBT", QuoteCurrency: "ZUSD"},
+		{Symbol: "XBTUSD", BaseCurrency: "XXBT", QuoteCurrency: "ZUSD"},
+		{Symbol: "BCHABCUSD", BaseCurrency: "BCHABC", QuoteCurrency: "ZUSD"},
+	}
+	m := NewSymbolMapper(func() ([]Market, error) {
+		loads++
+		return markets, nil
+	}, map[string]string{"XXBT": "BTC", "ZUSD": "USD"})
+
+	pair, err := m.CurrencyPair("xbtusd")
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC_USD", pair.String())
+	pair, _ = m.CurrencyPair("BCHABCUSD")
+	assert.Equal(t, "BCH_USD", pair.String())
+
+	//同一市场使用第一个 symbol, 交易对中的币种可以是别名
+	symbol, err := m.Symbol(NewCurrencyPair2("XBT_USD"), "")
+	assert.Nil(t, err)
+	assert.Equal(t, "XXBTZUSD", symbol)
+
+	mk, err := m.Market("XBTUSD")
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC_USD", mk.CanonicalId())
+
+	//未知 symbol 距上次加载超过一分钟时才重新加载
+	_, err = m.CurrencyPair("ETHUSD")
+	assert.Equal(t, EX_ERR_UNKNOWN_SYMBOL.ErrCode, err.(ApiError).ErrCode)
+	assert.Equal(t, 1, loads)
+
+	markets = append(markets, Market{Symbol: "XETHZUSD", BaseCurrency: "XETH", QuoteCurrency: "ZUSD"})
+	m.triedAt = m.triedAt.Add(-symbolReloadInterval)
+	_, err = m.Symbol(ETH_USD, "")
+	assert.Equal(t, EX_ERR_UNKNOWN_SYMBOL.ErrCode, err.(ApiError).ErrCode)
+	pair, err = m.CurrencyPair("XETHZUSD")
+	assert.Nil(t, err)
+	assert.Equal(t, "XETH_USD", pair.String())
+	assert.Equal(t, 2, loads)
+
+	all, err := m.Markets()
+	assert.Nil(t, err)
+	assert.Equal(t, "BCHABCUSD", all[0].Symbol)
+	assert.Equal(t, 4, len(all))
+}
+
+func TestSymbolMapper_LoadFailed(t *testing.T) {
+	m := NewSymbolMapper(func() ([]Market, error) {
+		return nil, errors.New("timeout")
+	}, nil)
+
+	_, err := m.CurrencyPair("BTCUSDT")
+	assert.EqualError(t, err, "timeout")
+	_, err = m.Symbol(BTC_USDT, "")
+	assert.EqualError(t, err, "timeout")
+	assert.Equal(t, "BTC", m.Currency("XBT").Symbol)
+}
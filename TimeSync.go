@@ -0,0 +1,235 @@
+package goex
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock 签名时间戳使用的时钟
+type Clock interface {
+	Now() time.Time
+}
+
+type localClock struct{}
+
+func (localClock) Now() time.Time {
+	return time.Now()
+}
+
+// LocalClock 本地时钟, 设置到 APIConfig.Clock 可关闭服务器时间同步
+var LocalClock Clock = localClock{}
+
+// ServerTimeFunc 返回交易所服务器时间(毫秒), 不能使用需要签名的接口
+type ServerTimeFunc func() (int64, error)
+
+// ClockSkew 本地时钟与交易所服务器时间的偏差
+type ClockSkew struct {
+	Offset   time.Duration // 服务器时间 - 本地时间
+	RTT      time.Duration // 采样请求的往返耗时, Offset 的误差不超过 RTT/2
+	SyncedAt time.Time     // 最近一次同步成功的时间, 零值表示还未同步成功
+}
+
+const (
+	defaultTimeSyncInterval = 5 * time.Minute
+	timeSyncSamples         = 3
+)
+
+// TimeSync 定期请求交易所服务器时间估算时钟偏差, 实现 Clock, 并发安全
+type TimeSync struct {
+	name     string
+	fetch    ServerTimeFunc
+	interval time.Duration
+
+	lock    sync.RWMutex
+	skew    ClockSkew
+	started int32 // 曾经开始过同步
+	running int32 // 后台同步正在运行
+	usedAt  int64 // 最近一次调用 Now 的时间(纳秒)
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// NewTimeSync interval <= 0 时默认每 5 分钟同步一次, 首次调用 Now 时在后台开始同步
+// 一个周期内没有调用 Now 时后台同步自动停止, 再次调用 Now 时重新开始
+func NewTimeSync(name string, fetch ServerTimeFunc, interval time.Duration) *TimeSync {
+	if interval <= 0 {
+		interval = defaultTimeSyncInterval
+	}
+	return &TimeSync{name: name, fetch: fetch, interval: interval, stop: make(chan struct{})}
+}
+
+// Now 本地时间加上偏差, 未同步成功时为本地时间, 不会阻塞等待同步
+func (ts *TimeSync) Now() time.Time {
+	atomic.StoreInt64(&ts.usedAt, time.Now().UnixNano())
+	if atomic.CompareAndSwapInt32(&ts.running, 0, 1) {
+		atomic.StoreInt32(&ts.started, 1)
+		go ts.loop()
+	}
+	return time.Now().Add(ts.Skew().Offset)
+}
+
+// Skew 最近一次同步的结果
+func (ts *TimeSync) Skew() ClockSkew {
+	ts.lock.RLock()
+	defer ts.lock.RUnlock()
+	return ts.skew
+}
+
+// Sync 立即同步, 采样 3 次取 RTT 最小的一次
+func (ts *TimeSync) Sync() error {
+	return ts.sync(timeSyncSamples)
+}
+
+// Close 停止后台同步
+func (ts *TimeSync) Close() {
+	ts.once.Do(func() {
+		close(ts.stop)
+	})
+}
+
+func (ts *TimeSync) loop() {
+	select {
+	case <-ts.stop:
+		return
+	default:
+	}
+	ts.sync(1)
+
+	ticker := time.NewTicker(ts.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ts.stop:
+			return
+		case <-ticker.C:
+			if ts.idle() {
+				atomic.StoreInt32(&ts.running, 0)
+				//停止前又调用了 Now 且没有启动新的 loop 时继续同步
+				if ts.idle() || !atomic.CompareAndSwapInt32(&ts.running, 0, 1) {
+					return
+				}
+			}
+			ts.sync(timeSyncSamples)
+		}
+	}
+}
+
+// idle 一个周期内没有调用 Now
+func (ts *TimeSync) idle() bool {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&ts.usedAt))) > ts.interval
+}
+
+// sync 假设服务器在请求的中间时刻返回时间: offset = server - (send + rtt/2)
+func (ts *TimeSync) sync(samples int) error {
+	var (
+		best    ClockSkew
+		lastErr error
+	)
+	for i := 0; i < samples; i++ {
+		send := time.Now()
+		serverMs, err := ts.fetch()
+		rtt := time.Since(send)
+		if err == nil && serverMs <= 0 {
+			err = errors.New("invalid server time")
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if best.SyncedAt.IsZero() || rtt < best.RTT {
+			server := time.Unix(0, serverMs*int64(time.Millisecond))
+			best = ClockSkew{Offset: server.Sub(send.Add(rtt / 2)), RTT: rtt, SyncedAt: time.Now()}
+		}
+	}
+
+	if best.SyncedAt.IsZero() {
+		DefaultLogger().Warn("time sync failed", KV("name", ts.name), KV("error", lastErr))
+		return lastErr
+	}
+
+	ts.lock.Lock()
+	ts.skew = best
+	ts.lock.Unlock()
+	DefaultLogger().Debug("time synced", KV("name", ts.name), KV("offset", best.Offset), KV("rtt", best.RTT))
+	return nil
+}
+
+// timeSyncKey 服务器时间接口地址相同但 http.Client 不同(比如代理不同)时分别同步
+type timeSyncKey struct {
+	key    string
+	client *http.Client
+}
+
+type sharedTimeSync struct {
+	*TimeSync
+	refs int
+}
+
+var (
+	timeSyncLock sync.Mutex
+	timeSyncs    = make(map[timeSyncKey]*sharedTimeSync)
+)
+
+// SharedClock SharedTimeSync 返回的引用, 实现 Clock
+type SharedClock struct {
+	*TimeSync
+	key  timeSyncKey
+	once sync.Once
+}
+
+// Close 释放引用, 最后一个引用释放时停止后台同步并从共享列表中移除
+func (c *SharedClock) Close() {
+	c.once.Do(func() {
+		timeSyncLock.Lock()
+		defer timeSyncLock.Unlock()
+
+		shared := timeSyncs[c.key]
+		if shared == nil || shared.TimeSync != c.TimeSync {
+			return
+		}
+		shared.refs--
+		if shared.refs <= 0 {
+			delete(timeSyncs, c.key)
+			shared.TimeSync.Close()
+		}
+	})
+}
+
+// SharedTimeSync key(一般为交易所的服务器时间接口地址)和 client 都相同时共享一个 TimeSync, 多个客户端只同步一份
+// fetch 应使用 client 请求, 共享时使用第一个调用者的 fetch; 每次调用增加一个引用, 不再使用时调用 Close
+func SharedTimeSync(key string, client *http.Client, fetch ServerTimeFunc) *SharedClock {
+	timeSyncLock.Lock()
+	defer timeSyncLock.Unlock()
+
+	k := timeSyncKey{key: key, client: client}
+	shared := timeSyncs[k]
+	if shared == nil {
+		shared = &sharedTimeSync{TimeSync: NewTimeSync(key, fetch, defaultTimeSyncInterval)}
+		timeSyncs[k] = shared
+	}
+	shared.refs++
+
+	return &SharedClock{TimeSync: shared.TimeSync, key: k}
+}
+
+// ClockSkews 已开始同步的共享 TimeSync 测得的偏差, key 同 SharedTimeSync
+// 同一个 key 有多个 http.Client 时返回最近同步成功的
+func ClockSkews() map[string]ClockSkew {
+	timeSyncLock.Lock()
+	defer timeSyncLock.Unlock()
+
+	skews := make(map[string]ClockSkew)
+	for k, shared := range timeSyncs {
+		if atomic.LoadInt32(&shared.started) == 0 {
+			continue
+		}
+		skew := shared.Skew()
+		if old, ok := skews[k.key]; !ok || skew.SyncedAt.After(old.SyncedAt) {
+			skews[k.key] = skew
+		}
+	}
+	return skews
+}
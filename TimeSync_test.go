@@ -0,0 +1,116 @@
+package goex
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeSync(t *testing.T) {
+	var calls int
+	ts := NewTimeSync("test", func() (int64, error) {
+		calls++
+		if calls == 2 {
+			//RTT 较大的采样被丢弃
+			time.Sleep(20 * time.Millisecond)
+			return time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond), nil
+		}
+		return time.Now().Add(2*time.Second).UnixNano() / int64(time.Millisecond), nil
+	}, time.Hour)
+	defer ts.Close()
+
+	assert.True(t, ts.Skew().SyncedAt.IsZero())
+	assert.Nil(t, ts.Sync())
+	assert.Equal(t, 3, calls)
+
+	skew := ts.Skew()
+	assert.InDelta(t, float64(2*time.Second), float64(skew.Offset), float64(5*time.Millisecond))
+	assert.True(t, skew.RTT < 20*time.Millisecond)
+	assert.False(t, skew.SyncedAt.IsZero())
+	assert.InDelta(t, float64(time.Now().Add(2*time.Second).UnixNano()), float64(ts.Now().UnixNano()), float64(5*time.Millisecond))
+}
+
+func TestTimeSync_Failed(t *testing.T) {
+	ts := NewTimeSync("test", func() (int64, error) {
+		return 0, errors.New("timeout")
+	}, time.Hour)
+	defer ts.Close()
+
+	assert.InDelta(t, float64(time.Now().UnixNano()), float64(ts.Now().UnixNano()), float64(5*time.Millisecond))
+	assert.EqualError(t, ts.Sync(), "timeout")
+	assert.True(t, ts.Skew().SyncedAt.IsZero())
+}
+
+func TestTimeSync_Idle(t *testing.T) {
+	var calls int32
+	ts := NewTimeSync("test", func() (int64, error) {
+		atomic.AddInt32(&calls, 1)
+		return time.Now().UnixNano() / int64(time.Millisecond), nil
+	}, 10*time.Millisecond)
+	defer ts.Close()
+
+	ts.Now()
+	assert.Eventually(t, func() bool { return !ts.Skew().SyncedAt.IsZero() }, time.Second, time.Millisecond)
+
+	//一个周期内没有调用 Now 时停止后台同步
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&ts.running) == 0 }, time.Second, time.Millisecond)
+	n := atomic.LoadInt32(&calls)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, n, atomic.LoadInt32(&calls))
+
+	ts.Now()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) > n }, time.Second, time.Millisecond)
+}
+
+func TestSharedTimeSync(t *testing.T) {
+	const key = "https://api.test.com/time"
+	var calls int32
+	fetch := func() (int64, error) {
+		atomic.AddInt32(&calls, 1)
+		return time.Now().Add(-time.Second).UnixNano() / int64(time.Millisecond), nil
+	}
+
+	ts := SharedTimeSync(key, nil, fetch)
+	ts2 := SharedTimeSync(key, nil, fetch)
+	assert.True(t, ts.TimeSync == ts2.TimeSync)
+
+	//http.Client 不同时分别同步
+	other := SharedTimeSync(key, &http.Client{}, fetch)
+	assert.False(t, other.TimeSync == ts.TimeSync)
+	other.Close()
+
+	_, ok := ClockSkews()[key]
+	assert.False(t, ok)
+
+	//首次调用 Now 不等待同步
+	assert.InDelta(t, float64(time.Now().UnixNano()), float64(ts.Now().UnixNano()), float64(5*time.Millisecond))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return !ts.Skew().SyncedAt.IsZero() }, time.Second, time.Millisecond)
+	skew, ok := ClockSkews()[key]
+	assert.True(t, ok)
+	assert.InDelta(t, float64(-time.Second), float64(skew.Offset), float64(5*time.Millisecond))
+
+	config := &APIConfig{Clock: LocalClock}
+	assert.Equal(t, LocalClock, config.GetClock(key, fetch))
+	config.Clock = nil
+	clock := config.GetClock(key, fetch).(*SharedClock)
+	assert.True(t, clock.TimeSync == ts.TimeSync)
+	clock.Close()
+
+	//重复 Close 只释放一次, 引用全部释放后停止同步并移除
+	ts.Close()
+	ts.Close()
+	_, ok = ClockSkews()[key]
+	assert.True(t, ok)
+	ts2.Close()
+	_, ok = ClockSkews()[key]
+	assert.False(t, ok)
+
+	ts3 := SharedTimeSync(key, nil, fetch)
+	defer ts3.Close()
+	assert.False(t, ts3.TimeSync == ts.TimeSync)
+}
@@ -0,0 +1,413 @@
+package algo
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+type State int
+
+const (
+	STATE_INIT State = iota
+	STATE_RUNNING
+	STATE_PAUSED
+	STATE_CANCELED
+	STATE_FINISHED
+)
+
+func (s State) String() string {
+	switch s {
+	case STATE_INIT:
+		return "INIT"
+	case STATE_RUNNING:
+		return "RUNNING"
+	case STATE_PAUSED:
+		return "PAUSED"
+	case STATE_CANCELED:
+		return "CANCELED"
+	case STATE_FINISHED:
+		return "FINISHED"
+	}
+	return "UNKNOWN"
+}
+
+var (
+	ErrAlreadyStarted = errors.New("algo: already started")
+	ErrInvalidParams  = errors.New("algo: invalid params")
+	ErrUnsettled      = errors.New("algo: working child order is not confirmed canceled or finished")
+)
+
+// Params 所有算法通用的母单参数
+type Params struct {
+	Amount     float64 // 母单总量
+	LimitPrice float64 // 限价, <=0 表示不限价
+	MinAmount  float64 // 子单最小下单量, 小于该值的剩余量不再下单
+}
+
+// Progress 执行进度
+type Progress struct {
+	State      State
+	Amount     float64
+	DealAmount float64
+	AvgPrice   float64
+	Children   int   // 已下子单数
+	Err        error // 最近一次错误
+}
+
+type Algo interface {
+	Start() error
+	Pause()
+	Resume()
+	Cancel()
+	// Wait 阻塞直到算法结束(完成或取消)
+	Wait() Progress
+	Progress() Progress
+	OnProgress(func(Progress))
+}
+
+// base 实现 Algo 的状态管理以及子单的下单、撤单与成交汇总, 具体算法只需实现 run
+type base struct {
+	exec   Executor
+	params Params
+	run    func()
+
+	lock       sync.Mutex
+	state      State
+	dealAmount float64
+	dealValue  float64
+	children   int
+	working    *ChildOrder
+	err        error
+	onProgress func(Progress)
+
+	resumeCh   chan struct{}
+	cancelCh   chan struct{}
+	notifyCh   chan struct{}
+	doneCh     chan struct{}
+	cancelOnce sync.Once
+}
+
+func newBase(exec Executor, params Params) *base {
+	return &base{
+		exec:     exec,
+		params:   params,
+		cancelCh: make(chan struct{}),
+		notifyCh: make(chan struct{}, 1),
+		doneCh:   make(chan struct{}),
+	}
+}
+
+func (b *base) Start() error {
+	b.lock.Lock()
+	if b.state != STATE_INIT {
+		b.lock.Unlock()
+		return ErrAlreadyStarted
+	}
+	if b.exec == nil || b.params.Amount <= 0 {
+		b.lock.Unlock()
+		return ErrInvalidParams
+	}
+	b.state = STATE_RUNNING
+	b.lock.Unlock()
+
+	go func() {
+		defer close(b.doneCh)
+		b.run()
+		b.settle()
+
+		b.lock.Lock()
+		if b.state != STATE_CANCELED {
+			b.state = STATE_FINISHED
+		}
+		b.lock.Unlock()
+		b.report()
+	}()
+
+	return nil
+}
+
+// Pause 暂停后撤掉挂单, Resume 后继续执行
+func (b *base) Pause() {
+	b.lock.Lock()
+	if b.state == STATE_RUNNING {
+		b.state = STATE_PAUSED
+		b.resumeCh = make(chan struct{})
+	}
+	b.lock.Unlock()
+	b.notify()
+}
+
+func (b *base) Resume() {
+	b.lock.Lock()
+	if b.state == STATE_PAUSED {
+		b.state = STATE_RUNNING
+		close(b.resumeCh)
+	}
+	b.lock.Unlock()
+}
+
+// Cancel 取消算法, 并撤掉未成交的子单
+func (b *base) Cancel() {
+	b.lock.Lock()
+	switch b.state {
+	case STATE_INIT:
+		b.state = STATE_CANCELED
+		close(b.doneCh)
+	case STATE_RUNNING, STATE_PAUSED:
+		b.state = STATE_CANCELED
+	}
+	b.lock.Unlock()
+	b.cancelOnce.Do(func() { close(b.cancelCh) })
+}
+
+func (b *base) Wait() Progress {
+	<-b.doneCh
+	return b.Progress()
+}
+
+func (b *base) OnProgress(f func(Progress)) {
+	b.lock.Lock()
+	b.onProgress = f
+	b.lock.Unlock()
+}
+
+func (b *base) Progress() Progress {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.progress()
+}
+
+func (b *base) progress() Progress {
+	p := Progress{
+		State:      b.state,
+		Amount:     b.params.Amount,
+		DealAmount: b.dealAmount,
+		Children:   b.children,
+		Err:        b.err,
+	}
+	value := b.dealValue
+	if b.working != nil {
+		p.DealAmount += b.working.DealAmount
+		value += b.working.DealAmount * b.working.AvgPrice
+	}
+	if p.DealAmount > 0 {
+		p.AvgPrice = value / p.DealAmount
+	}
+	return p
+}
+
+func (b *base) report() {
+	b.lock.Lock()
+	f := b.onProgress
+	p := b.progress()
+	b.lock.Unlock()
+	if f != nil {
+		f(p)
+	}
+}
+
+func (b *base) notify() {
+	select {
+	case b.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (b *base) canceled() bool {
+	select {
+	case <-b.cancelCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleep 返回 false 表示已取消, 暂停会提前唤醒
+func (b *base) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-b.notifyCh:
+	case <-b.cancelCh:
+		return false
+	}
+	return true
+}
+
+// checkpoint 暂停时撤掉挂单并阻塞直到恢复, 返回 false 表示已取消
+func (b *base) checkpoint() bool {
+	b.lock.Lock()
+	state, resumeCh := b.state, b.resumeCh
+	b.lock.Unlock()
+
+	if state == STATE_PAUSED {
+		b.settle()
+		b.report()
+		select {
+		case <-resumeCh:
+		case <-b.cancelCh:
+			return false
+		}
+	}
+
+	return !b.canceled()
+}
+
+func (b *base) setErr(err error) {
+	logger.Warnf("[algo] %s", err.Error())
+	b.lock.Lock()
+	b.err = err
+	b.lock.Unlock()
+}
+
+// remain 剩余未成交数量(含挂单已成交部分)
+func (b *base) remain() float64 {
+	p := b.Progress()
+	return b.params.Amount - p.DealAmount
+}
+
+// filled 剩余数量不足一个最小下单单位
+func (b *base) filled() bool {
+	amountPrecision, _ := b.exec.Precision()
+	remain := truncate(b.remain(), amountPrecision)
+	return remain <= 0 || remain < b.params.MinAmount
+}
+
+// outstanding 挂单未成交数量
+func (b *base) outstanding() float64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.working == nil {
+		return 0
+	}
+	return b.working.Amount - b.working.DealAmount
+}
+
+func (b *base) hasWorking() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.working != nil
+}
+
+// limitPrice 取对手价, 超过限价时按限价挂单
+func (b *base) limitPrice() (float64, error) {
+	price, err := b.exec.Price()
+	if err != nil {
+		return 0, err
+	}
+
+	limit := b.params.LimitPrice
+	if limit > 0 {
+		if b.exec.IsBuy() && (price > limit || price <= 0) {
+			price = limit
+		} else if !b.exec.IsBuy() && price < limit {
+			price = limit
+		}
+	}
+
+	return price, nil
+}
+
+// place 以 price 下子单, price<=0 时取对手价; 上一个子单没有确认结束时不下单
+func (b *base) place(amount, price float64) bool {
+	if b.hasWorking() && !b.settle() {
+		return false
+	}
+
+	amountPrecision, _ := b.exec.Precision()
+	amount = truncate(math.Min(amount, b.remain()), amountPrecision)
+	if amount <= 0 || amount < b.params.MinAmount {
+		return false
+	}
+
+	if price <= 0 {
+		var err error
+		price, err = b.limitPrice()
+		if err != nil {
+			b.setErr(err)
+			return false
+		}
+	}
+
+	child, err := b.exec.Place(amount, price)
+	if err != nil {
+		b.setErr(err)
+		return false
+	}
+
+	logger.Debugf("[algo] place child order %s, price=%f, amount=%f", child.OrderId, price, amount)
+
+	b.lock.Lock()
+	b.working = child
+	b.children++
+	b.lock.Unlock()
+	b.report()
+
+	return true
+}
+
+// refresh 查询挂单成交, 挂单结束时计入已成交
+func (b *base) refresh() {
+	b.lock.Lock()
+	working := b.working
+	b.lock.Unlock()
+
+	if working == nil {
+		return
+	}
+
+	ord, err := b.exec.Query(working.OrderId)
+	if err != nil {
+		b.setErr(err)
+		return
+	}
+
+	b.lock.Lock()
+	if ord.finished() {
+		b.dealAmount += ord.DealAmount
+		b.dealValue += ord.DealAmount * ord.AvgPrice
+		b.working = nil
+	} else {
+		b.working = ord
+	}
+	b.lock.Unlock()
+	b.report()
+}
+
+// settle 撤掉挂单并汇总最终成交, 返回 false 表示挂单没有确认撤销或完成
+// 此时保留 working 继续跟踪, 下次 settle 时重试, Progress 仍计入挂单的成交
+func (b *base) settle() bool {
+	for i := 0; i < 3; i++ {
+		b.refresh()
+
+		b.lock.Lock()
+		working := b.working
+		b.lock.Unlock()
+
+		if working == nil {
+			return true
+		}
+
+		if err := b.exec.Cancel(working.OrderId); err != nil {
+			b.setErr(err)
+		}
+	}
+
+	b.refresh()
+	if b.hasWorking() {
+		b.setErr(ErrUnsettled)
+		return false
+	}
+	return true
+}
+
+// 向下截断, 避免超出剩余数量
+func truncate(v float64, precision int) float64 {
+	p := math.Pow(10, float64(precision))
+	return math.Floor(v*p+1e-9) / p
+}
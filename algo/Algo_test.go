@@ -0,0 +1,206 @@
+package algo
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockExecutor struct {
+	sync.Mutex
+	fill      bool // 下单后立即全部成交
+	seq       int
+	orders    map[string]*ChildOrder
+	canceled  int
+	cancelErr error // 撤单失败
+	klines    []Kline
+}
+
+func newMockExecutor(fill bool) *mockExecutor {
+	return &mockExecutor{fill: fill, orders: map[string]*ChildOrder{}}
+}
+
+func (m *mockExecutor) IsBuy() bool { return true }
+
+func (m *mockExecutor) Precision() (int, int) { return 4, 2 }
+
+func (m *mockExecutor) Place(amount, price float64) (*ChildOrder, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.seq++
+	ord := &ChildOrder{OrderId: fmt.Sprint(m.seq), Price: price, Amount: amount, Status: ORDER_UNFINISH}
+	if m.fill {
+		ord.DealAmount, ord.AvgPrice, ord.Status = amount, price, ORDER_FINISH
+	}
+	m.orders[ord.OrderId] = ord
+	c := *ord
+	return &c, nil
+}
+
+func (m *mockExecutor) Cancel(orderId string) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.cancelErr != nil {
+		return m.cancelErr
+	}
+	if ord := m.orders[orderId]; ord.Status == ORDER_UNFINISH {
+		ord.Status = ORDER_CANCEL
+		m.canceled++
+	}
+	return nil
+}
+
+func (m *mockExecutor) Query(orderId string) (*ChildOrder, error) {
+	m.Lock()
+	defer m.Unlock()
+	c := *m.orders[orderId]
+	return &c, nil
+}
+
+func (m *mockExecutor) Price() (float64, error) { return 100, nil }
+
+func (m *mockExecutor) Klines(period KlinePeriod, size int) ([]Kline, error) { return m.klines, nil }
+
+func (m *mockExecutor) Trades(since int64) ([]Trade, error) { return nil, nil }
+
+func TestTWAP(t *testing.T) {
+	exec := newMockExecutor(true)
+	twap := NewTWAP(exec, Params{Amount: 1}, 40*time.Millisecond, 4)
+	assert.Nil(t, twap.Start())
+	assert.Equal(t, ErrAlreadyStarted, twap.Start())
+
+	p := twap.Wait()
+	assert.Equal(t, STATE_FINISHED, p.State)
+	assert.Equal(t, 4, p.Children)
+	assert.InDelta(t, 1, p.DealAmount, 1e-9)
+	assert.Equal(t, 100.0, p.AvgPrice)
+}
+
+func TestBase_unsettled(t *testing.T) {
+	exec := newMockExecutor(false)
+	b := newBase(exec, Params{Amount: 2})
+	assert.True(t, b.place(1, 0))
+
+	//撤单一直失败时继续跟踪原挂单, 不下新单
+	exec.cancelErr = fmt.Errorf("network error")
+	assert.False(t, b.place(1, 0))
+	assert.False(t, b.settle())
+	p := b.Progress()
+	assert.Equal(t, 1, p.Children)
+	assert.Equal(t, ErrUnsettled, p.Err)
+	assert.Equal(t, "1", b.working.OrderId)
+
+	//撤单恢复后先确认撤销再下单
+	exec.Lock()
+	exec.cancelErr = nil
+	exec.orders["1"].DealAmount, exec.orders["1"].AvgPrice = 0.5, 100
+	exec.Unlock()
+	assert.True(t, b.place(1, 0))
+	p = b.Progress()
+	assert.Equal(t, 2, p.Children)
+	assert.Equal(t, 1, exec.canceled)
+	assert.Equal(t, "2", b.working.OrderId)
+	assert.InDelta(t, 0.5, p.DealAmount, 1e-9)
+}
+
+func TestVWAP_volumeProfile(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	exec := newMockExecutor(true)
+	exec.klines = []Kline{
+		{Timestamp: start.Unix() - 86400, Vol: 1},      // 前一天同一时段 -> slot 0
+		{Timestamp: start.Unix() - 86400 + 60, Vol: 3}, // slot 1
+		{Timestamp: start.Unix() + 300, Vol: 100},      // 超出执行时段
+	}
+
+	vwap := NewVWAP(exec, Params{Amount: 1}, 2*time.Minute, 2, KLINE_PERIOD_1MIN, 10)
+	assert.Equal(t, []float64{0.25, 0.75}, vwap.volumeProfile(start))
+
+	exec.klines = nil
+	assert.Equal(t, []float64{0.5, 0.5}, vwap.volumeProfile(start))
+}
+
+func TestIceberg(t *testing.T) {
+	exec := newMockExecutor(true)
+	ice := NewIceberg(exec, Params{Amount: 2.5, LimitPrice: 99}, 1, time.Millisecond)
+	assert.Nil(t, ice.Start())
+
+	p := ice.Wait()
+	assert.Equal(t, STATE_FINISHED, p.State)
+	assert.Equal(t, 3, p.Children)
+	assert.InDelta(t, 2.5, p.DealAmount, 1e-9)
+	assert.Equal(t, 99.0, p.AvgPrice)
+}
+
+func TestIceberg_PauseCancel(t *testing.T) {
+	exec := newMockExecutor(false)
+	ice := NewIceberg(exec, Params{Amount: 2}, 1, time.Millisecond)
+	assert.Nil(t, ice.Start())
+
+	time.Sleep(10 * time.Millisecond)
+	ice.Pause()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, STATE_PAUSED, ice.Progress().State)
+	exec.Lock()
+	assert.Equal(t, 1, exec.canceled)
+	exec.Unlock()
+
+	ice.Resume()
+	time.Sleep(10 * time.Millisecond)
+	ice.Cancel()
+
+	p := ice.Wait()
+	assert.Equal(t, STATE_CANCELED, p.State)
+	assert.Equal(t, 2, p.Children)
+	assert.Equal(t, 2, exec.canceled)
+}
+
+func TestPOV(t *testing.T) {
+	exec := newMockExecutor(true)
+	pov := NewPOV(exec, Params{Amount: 1}, 0.1, time.Millisecond)
+	assert.Nil(t, pov.Start())
+
+	time.Sleep(5 * time.Millisecond)
+	pov.OnTrade(&Trade{Tid: 1, Amount: 5})
+	time.Sleep(10 * time.Millisecond)
+	assert.InDelta(t, 0.5, pov.Progress().DealAmount, 1e-9)
+
+	pov.OnTrade(&Trade{Tid: 1, Amount: 5}) //重复推送
+	pov.OnTrade(&Trade{Tid: 2, Amount: 20})
+
+	p := pov.Wait()
+	assert.Equal(t, STATE_FINISHED, p.State)
+	assert.InDelta(t, 1, p.DealAmount, 1e-9)
+	assert.Equal(t, 25.0, pov.MarketVolume())
+}
+
+func TestPOV_smallDeficit(t *testing.T) {
+	exec := newMockExecutor(false)
+	pov := NewPOV(exec, Params{Amount: 10}, 0.1, time.Millisecond)
+	assert.Nil(t, pov.Start())
+
+	pov.OnTrade(&Trade{Tid: 1, Amount: 10})
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 1, pov.Progress().Children)
+
+	//缺口 0.1 不足挂单的 20%, 价格不变, 不撤单重挂
+	pov.OnTrade(&Trade{Tid: 2, Amount: 1})
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 1, pov.Progress().Children)
+	exec.Lock()
+	assert.Equal(t, 0, exec.canceled)
+	exec.Unlock()
+
+	pov.OnTrade(&Trade{Tid: 3, Amount: 10})
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 2, pov.Progress().Children)
+	exec.Lock()
+	assert.Equal(t, 1, exec.canceled)
+	exec.Unlock()
+
+	pov.Cancel()
+	assert.Equal(t, STATE_CANCELED, pov.Wait().State)
+}
@@ -0,0 +1,210 @@
+package algo
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+// ChildOrder 算法拆出的子订单
+type ChildOrder struct {
+	OrderId    string
+	Price      float64
+	Amount     float64
+	DealAmount float64
+	AvgPrice   float64
+	Status     TradeStatus
+}
+
+func (c *ChildOrder) finished() bool {
+	switch c.Status {
+	case ORDER_FINISH, ORDER_CANCEL, ORDER_REJECT, ORDER_FAIL:
+		return true
+	case ORDER_TRIGGERED: //条件单已触发, 还在成交
+		return false
+	}
+	return false
+}
+
+// Executor 屏蔽现货(API)与合约(FutureRestAPI)下单的差异
+type Executor interface {
+	IsBuy() bool
+	Precision() (amountPrecision, pricePrecision int)
+
+	Place(amount, price float64) (*ChildOrder, error)
+	Cancel(orderId string) error
+	Query(orderId string) (*ChildOrder, error)
+
+	// 对手价: 买单取卖一, 卖单取买一
+	Price() (float64, error)
+	Klines(period KlinePeriod, size int) ([]Kline, error)
+	Trades(since int64) ([]Trade, error)
+}
+
+type SpotExecutor struct {
+	api  API
+	pair CurrencyPair
+	side TradeSide
+}
+
+// NewSpotExecutor side: BUY / SELL
+func NewSpotExecutor(api API, pair CurrencyPair, side TradeSide) *SpotExecutor {
+	return &SpotExecutor{api: api, pair: pair, side: side}
+}
+
+func (e *SpotExecutor) IsBuy() bool {
+	return e.side == BUY || e.side == BUY_MARKET
+}
+
+func (e *SpotExecutor) Precision() (int, int) {
+	amountPrecision, pricePrecision := e.pair.AmountTickSize, e.pair.PriceTickSize
+	if amountPrecision <= 0 {
+		amountPrecision = 8
+	}
+	if pricePrecision <= 0 {
+		pricePrecision = 8
+	}
+	return amountPrecision, pricePrecision
+}
+
+func (e *SpotExecutor) Place(amount, price float64) (*ChildOrder, error) {
+	var (
+		ord                             *Order
+		err                             error
+		amountPrecision, pricePrecision = e.Precision()
+		amountS                         = FloatToString(amount, amountPrecision)
+		priceS                          = FloatToString(price, pricePrecision)
+	)
+
+	if e.IsBuy() {
+		ord, err = e.api.LimitBuy(amountS, priceS, e.pair)
+	} else {
+		ord, err = e.api.LimitSell(amountS, priceS, e.pair)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChildOrder{OrderId: ord.OrderID2, Price: price, Amount: amount, Status: ORDER_UNFINISH}, nil
+}
+
+func (e *SpotExecutor) Cancel(orderId string) error {
+	_, err := e.api.CancelOrder(orderId, e.pair)
+	return err
+}
+
+func (e *SpotExecutor) Query(orderId string) (*ChildOrder, error) {
+	ord, err := e.api.GetOneOrder(orderId, e.pair)
+	if err != nil {
+		return nil, err
+	}
+	return &ChildOrder{
+		OrderId:    orderId,
+		Price:      ord.Price,
+		Amount:     ord.Amount,
+		DealAmount: ord.DealAmount,
+		AvgPrice:   ord.AvgPrice,
+		Status:     ord.Status,
+	}, nil
+}
+
+func (e *SpotExecutor) Price() (float64, error) {
+	ticker, err := e.api.GetTicker(e.pair)
+	if err != nil {
+		return 0, err
+	}
+	if e.IsBuy() {
+		return ticker.Sell, nil
+	}
+	return ticker.Buy, nil
+}
+
+func (e *SpotExecutor) Klines(period KlinePeriod, size int) ([]Kline, error) {
+	return e.api.GetKlineRecords(e.pair, period, size)
+}
+
+func (e *SpotExecutor) Trades(since int64) ([]Trade, error) {
+	return e.api.GetTrades(e.pair, since)
+}
+
+type FutureExecutor struct {
+	api          FutureRestAPI
+	pair         CurrencyPair
+	contractType string
+	openType     int
+}
+
+// NewFutureExecutor openType: OPEN_BUY / OPEN_SELL / CLOSE_BUY / CLOSE_SELL
+func NewFutureExecutor(api FutureRestAPI, pair CurrencyPair, contractType string, openType int) *FutureExecutor {
+	return &FutureExecutor{api: api, pair: pair, contractType: contractType, openType: openType}
+}
+
+func (e *FutureExecutor) IsBuy() bool {
+	return e.openType == OPEN_BUY || e.openType == CLOSE_SELL
+}
+
+// Precision 合约按张下单
+func (e *FutureExecutor) Precision() (int, int) {
+	pricePrecision := e.pair.PriceTickSize
+	if pricePrecision <= 0 {
+		pricePrecision = 8
+	}
+	return 0, pricePrecision
+}
+
+func (e *FutureExecutor) Place(amount, price float64) (*ChildOrder, error) {
+	_, pricePrecision := e.Precision()
+	ord, err := e.api.LimitFuturesOrder(e.pair, e.contractType, FloatToString(price, pricePrecision), FloatToString(amount, 0), e.openType)
+	if err != nil {
+		return nil, err
+	}
+	return &ChildOrder{OrderId: ord.OrderID2, Price: price, Amount: amount, Status: ORDER_UNFINISH}, nil
+}
+
+func (e *FutureExecutor) Cancel(orderId string) error {
+	_, err := e.api.FutureCancelOrder(e.pair, e.contractType, orderId)
+	return err
+}
+
+func (e *FutureExecutor) Query(orderId string) (*ChildOrder, error) {
+	ord, err := e.api.GetFutureOrder(orderId, e.pair, e.contractType)
+	if err != nil {
+		return nil, err
+	}
+	return &ChildOrder{
+		OrderId:    orderId,
+		Price:      ord.Price,
+		Amount:     ord.Amount,
+		DealAmount: ord.DealAmount,
+		AvgPrice:   ord.AvgPrice,
+		Status:     ord.Status,
+	}, nil
+}
+
+func (e *FutureExecutor) Price() (float64, error) {
+	ticker, err := e.api.GetFutureTicker(e.pair, e.contractType)
+	if err != nil {
+		return 0, err
+	}
+	if e.IsBuy() {
+		return ticker.Sell, nil
+	}
+	return ticker.Buy, nil
+}
+
+func (e *FutureExecutor) Klines(period KlinePeriod, size int) ([]Kline, error) {
+	futureKlines, err := e.api.GetKlineRecords(e.contractType, e.pair, period, size)
+	if err != nil {
+		return nil, err
+	}
+	klines := make([]Kline, 0, len(futureKlines))
+	for _, k := range futureKlines {
+		if k.Kline != nil {
+			klines = append(klines, *k.Kline)
+		}
+	}
+	return klines, nil
+}
+
+func (e *FutureExecutor) Trades(since int64) ([]Trade, error) {
+	return e.api.GetTrades(e.contractType, e.pair, since)
+}
@@ -0,0 +1,50 @@
+package algo
+
+import (
+	"time"
+)
+
+// Iceberg 冰山委托: 每次只挂出 displayAmount, 成交后再挂出下一笔
+// 设置了 LimitPrice 时按限价挂单, 否则取对手价
+type Iceberg struct {
+	*base
+	displayAmount float64
+	pollInterval  time.Duration
+}
+
+func NewIceberg(exec Executor, params Params, displayAmount float64, pollInterval time.Duration) *Iceberg {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	iceberg := &Iceberg{
+		base:          newBase(exec, params),
+		displayAmount: displayAmount,
+		pollInterval:  pollInterval,
+	}
+	iceberg.run = iceberg.loop
+
+	return iceberg
+}
+
+func (ice *Iceberg) loop() {
+	if ice.displayAmount <= 0 {
+		ice.setErr(ErrInvalidParams)
+		return
+	}
+
+	for ice.checkpoint() {
+		ice.refresh()
+
+		if !ice.hasWorking() {
+			if ice.filled() {
+				return
+			}
+			ice.place(ice.displayAmount, ice.params.LimitPrice)
+		}
+
+		if !ice.sleep(ice.pollInterval) {
+			return
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package algo
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// POV 成交量跟随: 使累计成交量保持在市场成交量的 rate 比例
+// 市场成交可通过 OnTrade 接入 ws 推送(SpotWsApi/FuturesWsApi.TradeCallback), 否则轮询 Executor.Trades
+type POV struct {
+	*base
+	rate         float64
+	pollInterval time.Duration
+
+	tradeLock  sync.Mutex
+	marketVol  float64
+	lastTid    int64
+	startMs    int64
+	fromStream bool
+}
+
+// NewPOV rate: 参与率, 比如 0.1 表示市场成交量的10%
+func NewPOV(exec Executor, params Params, rate float64, pollInterval time.Duration) *POV {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	pov := &POV{
+		base:         newBase(exec, params),
+		rate:         rate,
+		pollInterval: pollInterval,
+	}
+	pov.run = pov.loop
+
+	return pov
+}
+
+// OnTrade 接入市场成交推送, 接入后不再轮询 Executor.Trades
+func (pov *POV) OnTrade(trade *Trade) {
+	pov.tradeLock.Lock()
+	defer pov.tradeLock.Unlock()
+
+	pov.fromStream = true
+	pov.addTrade(trade)
+	if trade != nil && trade.Tid > pov.lastTid {
+		pov.lastTid = trade.Tid
+	}
+}
+
+func (pov *POV) addTrade(trade *Trade) {
+	if trade == nil {
+		return
+	}
+	date := trade.Date
+	if date > 0 && date < 1e12 { //s
+		date *= 1000
+	}
+	if pov.startMs > 0 && date > 0 && date < pov.startMs {
+		return
+	}
+	if trade.Tid > 0 && trade.Tid <= pov.lastTid {
+		return
+	}
+	pov.marketVol += trade.Amount
+}
+
+func (pov *POV) pollTrades() {
+	pov.tradeLock.Lock()
+	fromStream := pov.fromStream
+	pov.tradeLock.Unlock()
+
+	if fromStream {
+		return
+	}
+
+	trades, err := pov.exec.Trades(0)
+	if err != nil {
+		pov.setErr(err)
+		return
+	}
+
+	// 返回的成交可能是倒序, 本轮处理完后再更新 lastTid
+	pov.tradeLock.Lock()
+	maxTid := pov.lastTid
+	for i := range trades {
+		pov.addTrade(&trades[i])
+		if trades[i].Tid > maxTid {
+			maxTid = trades[i].Tid
+		}
+	}
+	pov.lastTid = maxTid
+	pov.tradeLock.Unlock()
+}
+
+// povReplaceRatio 缺口小于挂单数量的该比例时不撤单重挂
+const povReplaceRatio = 0.2
+
+// shouldReplace 有挂单时, 只有缺口超过阈值或对手价变化才撤单重挂, 避免频繁撤单失去排队优先级
+func (pov *POV) shouldReplace(deficit float64) bool {
+	pov.lock.Lock()
+	working := pov.working
+	pov.lock.Unlock()
+
+	if working == nil || deficit >= math.Max(pov.params.MinAmount, working.Amount*povReplaceRatio) {
+		return true
+	}
+
+	price, err := pov.limitPrice()
+	if err != nil {
+		pov.setErr(err)
+		return false
+	}
+	return price != working.Price
+}
+
+// MarketVolume 开始后的市场成交量
+func (pov *POV) MarketVolume() float64 {
+	pov.tradeLock.Lock()
+	defer pov.tradeLock.Unlock()
+	return pov.marketVol
+}
+
+func (pov *POV) loop() {
+	if pov.rate <= 0 || pov.rate > 1 {
+		pov.setErr(ErrInvalidParams)
+		return
+	}
+
+	pov.tradeLock.Lock()
+	pov.startMs = time.Now().UnixNano() / int64(time.Millisecond)
+	pov.tradeLock.Unlock()
+
+	for pov.checkpoint() {
+		pov.pollTrades()
+		pov.refresh()
+
+		if !pov.hasWorking() && pov.filled() {
+			return
+		}
+
+		// 挂单未成交部分不足以跟上市场成交量时撤单重挂
+		deficit := pov.MarketVolume()*pov.rate - pov.Progress().DealAmount - pov.outstanding()
+		if deficit > 0 && deficit >= pov.params.MinAmount && pov.shouldReplace(deficit) {
+			pov.settle()
+			pov.place(pov.MarketVolume()*pov.rate-pov.Progress().DealAmount, 0)
+		}
+
+		if !pov.sleep(pov.pollInterval) {
+			return
+		}
+	}
+}
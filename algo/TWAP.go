@@ -0,0 +1,59 @@
+package algo
+
+import (
+	"time"
+)
+
+// TWAP 时间加权: 在 duration 内均匀拆成 slices 个子单
+// 每个时间片开始时撤掉上一个未成交的子单, 未成交部分滚动到下一个时间片
+type TWAP struct {
+	*base
+	interval time.Duration
+	weights  []float64
+}
+
+func NewTWAP(exec Executor, params Params, duration time.Duration, slices int) *TWAP {
+	if slices <= 0 {
+		slices = 1
+	}
+
+	weights := make([]float64, slices)
+	for i := range weights {
+		weights[i] = 1 / float64(slices)
+	}
+
+	twap := &TWAP{
+		base:     newBase(exec, params),
+		interval: duration / time.Duration(slices),
+		weights:  weights,
+	}
+	twap.run = func() { runSchedule(twap.base, twap.interval, twap.weights) }
+
+	return twap
+}
+
+// runSchedule 按 weights 累计目标量逐个时间片下单
+func runSchedule(b *base, interval time.Duration, weights []float64) {
+	var cumWeight float64
+
+	for _, w := range weights {
+		if !b.checkpoint() {
+			return
+		}
+
+		b.settle()
+
+		cumWeight += w
+		target := b.params.Amount * cumWeight
+		b.place(target-b.Progress().DealAmount, 0)
+
+		if !b.sleep(interval) {
+			return
+		}
+
+		// 被暂停唤醒时等待恢复, 当前时间片不再补单
+		if !b.checkpoint() {
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package algo
+
+import (
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// VWAP 成交量加权: 按历史K线同一时段的成交量分布分配每个时间片的下单量
+type VWAP struct {
+	*base
+	interval time.Duration
+	slices   int
+	period   KlinePeriod
+	history  int
+}
+
+// NewVWAP
+// @period  用于统计成交量分布的K线周期, 比如: KLINE_PERIOD_5MIN
+// @history 获取的K线根数, 建议覆盖多天, 比如5分钟线取 288*3
+func NewVWAP(exec Executor, params Params, duration time.Duration, slices int, period KlinePeriod, history int) *VWAP {
+	if slices <= 0 {
+		slices = 1
+	}
+
+	vwap := &VWAP{
+		base:     newBase(exec, params),
+		interval: duration / time.Duration(slices),
+		slices:   slices,
+		period:   period,
+		history:  history,
+	}
+	vwap.run = func() { runSchedule(vwap.base, vwap.interval, vwap.volumeProfile(time.Now())) }
+
+	return vwap
+}
+
+// volumeProfile 返回每个时间片的成交量权重, 获取不到K线时退化为 TWAP
+func (vwap *VWAP) volumeProfile(start time.Time) []float64 {
+	weights := make([]float64, vwap.slices)
+
+	klines, err := vwap.exec.Klines(vwap.period, vwap.history)
+	if err != nil {
+		vwap.setErr(err)
+	}
+
+	var (
+		total    float64
+		day      = int64(24 * time.Hour / time.Second)
+		startSec = start.Unix()
+		interval = int64(vwap.interval / time.Second)
+	)
+
+	if interval <= 0 {
+		interval = 1
+	}
+
+	for _, k := range klines {
+		ts := k.Timestamp
+		if ts > 1e12 { //ms
+			ts /= 1000
+		}
+
+		offset := ((ts-startSec)%day + day) % day
+		slot := offset / interval
+		if slot < int64(vwap.slices) {
+			weights[slot] += k.Vol
+			total += k.Vol
+		}
+	}
+
+	for i := range weights {
+		if total > 0 {
+			weights[i] /= total
+		} else {
+			weights[i] = 1 / float64(vwap.slices)
+		}
+	}
+
+	return weights
+}
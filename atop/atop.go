@@ -155,7 +155,17 @@ func New(client *http.Client, apiKey, secretKey string) *Atop {
 }
 
 func (at *Atop) GetExchangeName() string {
-	return "atop.com"
+	return ATOP
+}
+
+// GetAllCurrencyPair 未对接交易对列表接口
+func (at *Atop) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	return nil, EX_ERR_NOT_SUPPORT
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (at *Atop) GetTimestamp() (int64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
 }
 
 //hao
@@ -0,0 +1,13 @@
+package atop
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(ATOP, Driver{
+		Spot: func(config *APIConfig) API {
+			return New(config.HttpClient, config.ApiKey, config.ApiSecretKey)
+		},
+	})
+}
@@ -0,0 +1,230 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+// AmendOrder 现货使用 order/cancelReplace 撤单并下新单, 新订单会重新排队, 返回新订单ID
+// 新订单数量为修改后的数量减去原订单已成交数量, OrderID2 为空时按 Cid 撤原订单
+func (bn *Binance) AmendOrder(ord *Order) (*Order, error) {
+	var (
+		old    *Order
+		err    error
+		params = url.Values{}
+	)
+	switch {
+	case ord.OrderID2 != "":
+		old, err = bn.GetOneOrder(ord.OrderID2, ord.Currency)
+		params.Set("cancelOrderId", ord.OrderID2)
+	case ord.Cid != "":
+		old, err = bn.GetOneOrderByCid(ord.Cid, ord.Currency)
+		params.Set("cancelOrigClientOrderId", ord.Cid)
+	default:
+		return nil, ErrAmendOrderId
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	price, amount := ord.Price, ord.Amount
+	if price <= 0 {
+		price = old.Price
+	}
+	if amount <= 0 {
+		amount = old.Amount
+	}
+	if amount <= old.DealAmount {
+		return nil, ErrAmendFilled
+	}
+
+	params.Set("symbol", ord.Currency.ToSymbol(""))
+	params.Set("side", old.Side.String())
+	params.Set("type", "LIMIT")
+	params.Set("timeInForce", "GTC")
+	params.Set("price", FloatToString(price, 8))
+	params.Set("quantity", FloatToString(amount-old.DealAmount, 8))
+	params.Set("cancelReplaceMode", "STOP_ON_FAILURE")
+	params.Set("newOrderRespType", "ACK")
+	bn.buildParamsSigned(&params)
+
+	resp, err := HttpPostForm2(bn.httpClient, bn.apiV3+"order/cancelReplace", params,
+		map[string]string{"X-MBX-APIKEY": bn.accessKey})
+	if err != nil {
+		return nil, bn.adaptError(err)
+	}
+
+	logger.Debug(string(resp))
+
+	var response struct {
+		CancelResult   string `json:"cancelResult"`
+		NewOrderResult string `json:"newOrderResult"`
+		CancelResponse struct {
+			ExecutedQty float64 `json:"executedQty,string"`
+		} `json:"cancelResponse"`
+		NewOrderResponse struct {
+			OrderId       int64  `json:"orderId"`
+			ClientOrderId string `json:"clientOrderId"`
+			TransactTime  int64  `json:"transactTime"`
+		} `json:"newOrderResponse"`
+	}
+	err = json.Unmarshal(resp, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.NewOrderResult != "SUCCESS" {
+		return nil, errors.New(string(resp))
+	}
+
+	newOrd := &Order{
+		Currency:  ord.Currency,
+		OrderID:   int(response.NewOrderResponse.OrderId),
+		OrderID2:  fmt.Sprint(response.NewOrderResponse.OrderId),
+		Cid:       response.NewOrderResponse.ClientOrderId,
+		Price:     price,
+		Amount:    amount - old.DealAmount,
+		Side:      old.Side,
+		Status:    ORDER_UNFINISH,
+		OrderTime: int(response.NewOrderResponse.TransactTime)}
+
+	//查询之后到撤单之前原订单又有成交, 按撤单时的成交数量修正新订单
+	if dealAmount := response.CancelResponse.ExecutedQty; dealAmount > old.DealAmount {
+		return bn.reduceAmendOrder(newOrd, amount-dealAmount)
+	}
+
+	return newOrd, nil
+}
+
+// reduceAmendOrder 用 order/amend/keepPriority 把新订单数量减少到 remain, 没有剩余数量时撤销新订单
+// 出错时也返回新订单, 调用方可以按 OrderID2 继续处理
+func (bn *Binance) reduceAmendOrder(ord *Order, remain float64) (*Order, error) {
+	if remain <= 0 {
+		_, err := bn.CancelOrder(ord.OrderID2, ord.Currency)
+		if err != nil {
+			return ord, err
+		}
+		ord.Status = ORDER_CANCEL
+		return ord, ErrAmendFilled
+	}
+
+	params := url.Values{}
+	params.Set("symbol", ord.Currency.ToSymbol(""))
+	params.Set("orderId", ord.OrderID2)
+	params.Set("newQty", FloatToString(remain, 8))
+	bn.buildParamsSigned(&params)
+
+	resp, err := HttpPut(bn.httpClient, bn.apiV3+"order/amend/keepPriority", params,
+		map[string]string{"X-MBX-APIKEY": bn.accessKey})
+	if err != nil {
+		return ord, bn.adaptError(err)
+	}
+
+	logger.Debug(string(resp))
+
+	ord.Amount = remain
+	return ord, nil
+}
+
+// amendFuturesOrder 合约改单(PUT order), 订单ID不变; 价格、数量和方向都必填, OrderID2 为空时按 ClientOid 改单
+func (bn *Binance) amendFuturesOrder(symbol string, ord FutureOrder) (*FutureOrder, error) {
+	side, _ := adaptFuturesSide(ord.OType)
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("side", side)
+	params.Set("quantity", fmt.Sprint(ord.Amount))
+	params.Set("price", fmt.Sprint(ord.Price))
+	if ord.OrderID2 != "" {
+		params.Set("orderId", ord.OrderID2)
+	} else {
+		params.Set("origClientOrderId", ord.ClientOid)
+	}
+
+	var response OrderInfoResponse
+	err := bn.doSignedRequest("PUT", "order", params, &response)
+	if err != nil {
+		return nil, bn.adaptError(err)
+	}
+
+	ord.OrderID2 = fmt.Sprint(response.OrderId)
+	ord.ClientOid = response.ClientOrderId
+	ord.Price = response.Price
+	ord.Amount = response.OrigQty
+	ord.DealAmount = response.ExecutedQty
+	return &ord, nil
+}
+
+// completeAmendParam 未指定的价格、数量和方向使用原订单的值
+// getOrder 按 OrderID2 查询原订单, OrderID2 为空时按 ClientOid 查询
+func completeAmendParam(ord *FutureOrder, getOrder func() (*FutureOrder, error)) (FutureOrder, error) {
+	amend := *ord
+	if amend.OrderID2 == "" && amend.ClientOid == "" {
+		return amend, ErrAmendOrderId
+	}
+
+	if amend.Price > 0 && amend.Amount > 0 && amend.OType != 0 {
+		return amend, nil
+	}
+
+	old, err := getOrder()
+	if err != nil {
+		return amend, err
+	}
+
+	if amend.Price <= 0 {
+		amend.Price = old.Price
+	}
+	if amend.Amount <= 0 {
+		amend.Amount = old.Amount
+	}
+	if amend.OType == 0 {
+		amend.OType = old.OType
+	}
+
+	return amend, nil
+}
+
+func (bs *BinanceFutures) AmendFutureOrder(ord *FutureOrder) (*FutureOrder, error) {
+	symbol, err := bs.adaptToSymbol(ord.Currency, ord.ContractName)
+	if err != nil {
+		return nil, err
+	}
+
+	amend, err := completeAmendParam(ord, func() (*FutureOrder, error) {
+		if ord.OrderID2 != "" {
+			return bs.GetFutureOrder(ord.OrderID2, ord.Currency, ord.ContractName)
+		}
+		return bs.GetFutureOrderByCid(ord.ClientOid, ord.Currency, ord.ContractName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bs.base.amendFuturesOrder(symbol, amend)
+}
+
+func (bs *BinanceSwap) AmendFutureOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord.ContractName == SWAP_CONTRACT {
+		amend := *ord
+		amend.Currency = ord.Currency.AdaptUsdtToUsd()
+		return bs.f.AmendFutureOrder(&amend)
+	}
+
+	amend, err := completeAmendParam(ord, func() (*FutureOrder, error) {
+		if ord.OrderID2 != "" {
+			return bs.GetFutureOrder(ord.OrderID2, ord.Currency, ord.ContractName)
+		}
+		return bs.GetFutureOrderByCid(ord.ClientOid, ord.Currency, ord.ContractName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bs.amendFuturesOrder(bs.adaptCurrencyPair(ord.Currency).ToSymbol(""), amend)
+}
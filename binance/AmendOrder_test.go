@@ -0,0 +1,111 @@
+package binance
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinance_AmendOrder(t *testing.T) {
+	var (
+		form      map[string][]string
+		amendForm map[string][]string
+		canceled  []string
+		executed  = "0.4" //撤单时原订单的成交数量
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v3/order":
+			w.Write([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"abc","price":"30000.00","origQty":"1.0","executedQty":"0.4","cummulativeQuoteQty":"12000","status":"PARTIALLY_FILLED","side":"SELL","time":1600000000000,"updateTime":1600000000000}`))
+		case "POST /api/v3/order/cancelReplace":
+			form = r.PostForm
+			w.Write([]byte(`{"cancelResult":"SUCCESS","newOrderResult":"SUCCESS","cancelResponse":{"orderId":28,"origQty":"1.0","executedQty":"` + executed + `"},"newOrderResponse":{"symbol":"BTCUSDT","orderId":29,"clientOrderId":"def","transactTime":1600000001000}}`))
+		case "PUT /api/v3/order/amend/keepPriority":
+			amendForm = r.PostForm
+			w.Write([]byte(`{"transactTime":1600000002000,"amendedOrder":{"orderId":29,"origQty":"0.5"}}`))
+		case "DELETE /api/v3/order":
+			body, _ := ioutil.ReadAll(r.Body) //DELETE 的表单不会被 ParseForm 解析
+			q, _ := url.ParseQuery(string(body))
+			canceled = append(canceled, q.Get("orderId"))
+			w.Write([]byte(`{"symbol":"BTCUSDT","orderId":29,"status":"CANCELED"}`))
+		case "PUT /fapi/v1/order":
+			form = r.PostForm
+			w.Write([]byte(`{"orderId":30,"clientOrderId":"goexabc","price":"31000","origQty":"2","executedQty":"0","side":"BUY","status":"NEW"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	bn := &Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV3: srv.URL + "/api/v3/", apiV1: srv.URL + "/fapi/v1/"}
+
+	ord, err := bn.AmendOrder(&goex.Order{OrderID2: "28", Currency: goex.BTC_USDT, Price: 30100})
+	assert.Nil(t, err)
+	assert.Equal(t, "29", ord.OrderID2)
+	assert.Equal(t, goex.SELL, ord.Side)
+	assert.Equal(t, []string{"0.6"}, form["quantity"])
+	assert.Equal(t, []string{"30100"}, form["price"])
+	assert.Equal(t, []string{"28"}, form["cancelOrderId"])
+
+	_, err = bn.AmendOrder(&goex.Order{OrderID2: "28", Currency: goex.BTC_USDT, Amount: 0.4})
+	assert.Equal(t, goex.ErrAmendFilled, err)
+
+	_, err = bn.AmendOrder(&goex.Order{Currency: goex.BTC_USDT, Price: 30100})
+	assert.Equal(t, goex.ErrAmendOrderId, err)
+
+	ord, err = bn.AmendOrder(&goex.Order{Cid: "abc", Currency: goex.BTC_USDT, Price: 30100})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"abc"}, form["cancelOrigClientOrderId"])
+	assert.Nil(t, form["cancelOrderId"])
+	assert.Nil(t, amendForm)
+
+	//查询后原订单又成交了 0.1, 新订单数量减少到 0.5
+	executed = "0.5"
+	ord, err = bn.AmendOrder(&goex.Order{OrderID2: "28", Currency: goex.BTC_USDT, Price: 30100})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0.6"}, form["quantity"])
+	assert.Equal(t, []string{"29"}, amendForm["orderId"])
+	assert.Equal(t, []string{"0.5"}, amendForm["newQty"])
+	assert.Equal(t, 0.5, ord.Amount)
+
+	//撤单前原订单已全部成交, 撤销新订单
+	executed = "1.0"
+	ord, err = bn.AmendOrder(&goex.Order{OrderID2: "28", Currency: goex.BTC_USDT, Price: 30100})
+	assert.Equal(t, goex.ErrAmendFilled, err)
+	assert.Equal(t, "29", ord.OrderID2)
+	assert.Equal(t, goex.ORDER_CANCEL, ord.Status)
+	assert.Equal(t, []string{"29"}, canceled)
+
+	fOrd, err := bn.amendFuturesOrder("BTCUSDT", goex.FutureOrder{ClientOid: "abc", OType: goex.OPEN_BUY, Price: 31000, Amount: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "30", fOrd.OrderID2)
+	assert.Equal(t, []string{"abc"}, form["origClientOrderId"])
+	assert.Nil(t, form["orderId"])
+	assert.Equal(t, []string{"BUY"}, form["side"])
+
+	fOrd, err = bn.amendFuturesOrder("BTCUSDT", goex.FutureOrder{OrderID2: "30", ClientOid: "goexabc", OType: goex.OPEN_BUY, Price: 31000, Amount: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"30"}, form["orderId"])
+	assert.Nil(t, form["origClientOrderId"])
+}
+
+func TestCompleteAmendParam(t *testing.T) {
+	_, err := completeAmendParam(&goex.FutureOrder{Price: 1}, nil)
+	assert.Equal(t, goex.ErrAmendOrderId, err)
+
+	amend, err := completeAmendParam(&goex.FutureOrder{ClientOid: "abc", Price: 31000}, func() (*goex.FutureOrder, error) {
+		return &goex.FutureOrder{OrderID2: "30", Price: 30000, Amount: 2, OType: goex.OPEN_SELL}, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "", amend.OrderID2)
+	assert.Equal(t, "abc", amend.ClientOid)
+	assert.Equal(t, 31000.0, amend.Price)
+	assert.Equal(t, 2.0, amend.Amount)
+	assert.Equal(t, goex.OPEN_SELL, amend.OType)
+}
@@ -17,6 +17,10 @@ const (
 	GLOBAL_API_BASE_URL = "https://api.binance.com"
 	US_API_BASE_URL     = "https://api.binance.us"
 	JE_API_BASE_URL     = "https://api.binance.je"
+
+	//测试网
+	TESTNET_API_BASE_URL         = "https://testnet.binance.vision"
+	TESTNET_FUTURES_API_BASE_URL = "https://testnet.binancefuture.com" //fapi 和 dapi 同一个域名
 	//API_V1       = API_BASE_URL + "api/v1/"
 	//API_V3       = API_BASE_URL + "api/v3/"
 
@@ -164,13 +168,15 @@ type Binance struct {
 	apiV1      string
 	apiV3      string
 	httpClient *http.Client
-	timeOffset int64 //nanosecond
+	clock      Clock
+	log        Logger
+	symbols    *SymbolMapper
 	*ExchangeInfo
 }
 
 func (bn *Binance) buildParamsSigned(postForm *url.Values) error {
 	postForm.Set("recvWindow", "60000")
-	tonce := strconv.FormatInt(time.Now().UnixNano()+bn.timeOffset, 10)[0:13]
+	tonce := strconv.FormatInt(bn.clock.Now().UnixNano(), 10)[0:13]
 	postForm.Set("timestamp", tonce)
 	payload := postForm.Encode()
 	sign, _ := GetParamHmacSHA256Sign(bn.secretKey, payload)
@@ -189,6 +195,9 @@ func New(client *http.Client, api_key, secret_key string) *Binance {
 func NewWithConfig(config *APIConfig) *Binance {
 	if config.Endpoint == "" {
 		config.Endpoint = GLOBAL_API_BASE_URL
+		if config.Env == ENV_TESTNET {
+			config.Endpoint = TESTNET_API_BASE_URL
+		}
 	}
 
 	bn := &Binance{
@@ -197,8 +206,10 @@ func NewWithConfig(config *APIConfig) *Binance {
 		apiV3:      config.Endpoint + "/api/v3/",
 		accessKey:  config.ApiKey,
 		secretKey:  config.ApiSecretKey,
-		httpClient: config.HttpClient}
-	bn.setTimeOffset()
+		httpClient: config.HttpClient,
+		log:        config.GetLogger()}
+	bn.setClock(config, bn.apiV3+SERVER_TIME_URL)
+	bn.symbols = NewSymbolMapper(bn.loadSpotMarkets, nil)
 	return bn
 }
 
@@ -206,6 +217,14 @@ func (bn *Binance) GetExchangeName() string {
 	return BINANCE
 }
 
+// getLogger 没有通过 NewWithConfig 创建时使用全局日志
+func (bn *Binance) getLogger() Logger {
+	if bn.log == nil {
+		return DefaultLogger()
+	}
+	return bn.log
+}
+
 func (bn *Binance) Ping() bool {
 	_, err := HttpGet(bn.httpClient, bn.apiV3+"ping")
 	if err != nil {
@@ -214,18 +233,15 @@ func (bn *Binance) Ping() bool {
 	return true
 }
 
-func (bn *Binance) setTimeOffset() error {
-	respmap, err := HttpGet(bn.httpClient, bn.apiV3+SERVER_TIME_URL)
-	if err != nil {
-		return err
-	}
-
-	stime := int64(ToInt(respmap["serverTime"]))
-	st := time.Unix(stime/1000, 1000000*(stime%1000))
-	lt := time.Now()
-	offset := st.Sub(lt).Nanoseconds()
-	bn.timeOffset = int64(offset)
-	return nil
+// setClock 签名时间戳与 serverTimeUrl(现货、U本位、币本位各不相同)的服务器时间同步
+func (bn *Binance) setClock(config *APIConfig, serverTimeUrl string) {
+	bn.clock = config.GetClock(serverTimeUrl, func() (int64, error) {
+		respmap, err := HttpGet(bn.httpClient, serverTimeUrl)
+		if err != nil {
+			return 0, err
+		}
+		return ToInt64(respmap["serverTime"]), nil
+	})
 }
 
 func (bn *Binance) GetTicker(currency CurrencyPair) (*Ticker, error) {
@@ -313,13 +329,14 @@ func (bn *Binance) GetDepth(size int, currencyPair CurrencyPair) (*Depth, error)
 	return depth, nil
 }
 
-func (bn *Binance) placeOrder(amount, price string, pair CurrencyPair, orderType, orderSide string) (*Order, error) {
+func (bn *Binance) placeOrder(amount, price string, pair CurrencyPair, orderType, orderSide, cid string) (*Order, error) {
 	path := bn.apiV3 + ORDER_URI
 	params := url.Values{}
 	params.Set("symbol", pair.ToSymbol(""))
 	params.Set("side", orderSide)
 	params.Set("type", orderType)
 	params.Set("newOrderRespType", "ACK")
+	params.Set("newClientOrderId", cid)
 
 	switch orderType {
 	case "LIMIT":
@@ -327,6 +344,9 @@ func (bn *Binance) placeOrder(amount, price string, pair CurrencyPair, orderType
 		params.Set("price", price)
 		//通过买入(或卖出)想要花费(或获取)的数量
 		params.Set("quantity", amount)
+	case "LIMIT_MAKER":
+		params.Set("price", price)
+		params.Set("quantity", amount)
 	case "MARKET":
 		params.Set("newOrderRespType", "RESULT")
 		//这个参数明确的是通过买入(或卖出)想要花费(或获取)的报价资产数量
@@ -371,6 +391,7 @@ func (bn *Binance) placeOrder(amount, price string, pair CurrencyPair, orderType
 		Currency:   pair,
 		OrderID:    orderId,
 		OrderID2:   strconv.Itoa(orderId),
+		Cid:        cid,
 		Price:      ToFloat64(price),
 		Amount:     ToFloat64(amount),
 		DealAmount: dealAmount,
@@ -410,26 +431,31 @@ func (bn *Binance) GetAccount() (*Account, error) {
 }
 
 func (bn *Binance) LimitBuy(amount, price string, currencyPair CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
-	return bn.placeOrder(amount, price, currencyPair, "LIMIT", "BUY")
+	return bn.placeOrder(amount, price, currencyPair, "LIMIT", "BUY", GenerateOrderClientId(32))
 }
 
 func (bn *Binance) LimitSell(amount, price string, currencyPair CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
-	return bn.placeOrder(amount, price, currencyPair, "LIMIT", "SELL")
+	return bn.placeOrder(amount, price, currencyPair, "LIMIT", "SELL", GenerateOrderClientId(32))
 }
 
 func (bn *Binance) MarketBuy(amount, price string, currencyPair CurrencyPair) (*Order, error) {
-	return bn.placeOrder(amount, price, currencyPair, "MARKET", "BUY")
+	return bn.placeOrder(amount, price, currencyPair, "MARKET", "BUY", GenerateOrderClientId(32))
 }
 
 func (bn *Binance) MarketSell(amount, price string, currencyPair CurrencyPair) (*Order, error) {
-	return bn.placeOrder(amount, price, currencyPair, "MARKET", "SELL")
+	return bn.placeOrder(amount, price, currencyPair, "MARKET", "SELL", GenerateOrderClientId(32))
 }
 
 func (bn *Binance) CancelOrder(orderId string, currencyPair CurrencyPair) (bool, error) {
+	return bn.cancelOrder("orderId", orderId, currencyPair)
+}
+
+// cancelOrder idKey 为 orderId 或 origClientOrderId
+func (bn *Binance) cancelOrder(idKey, id string, currencyPair CurrencyPair) (bool, error) {
 	path := bn.apiV3 + ORDER_URI
 	params := url.Values{}
 	params.Set("symbol", currencyPair.ToSymbol(""))
-	params.Set("orderId", orderId)
+	params.Set(idKey, id)
 
 	bn.buildParamsSigned(&params)
 
@@ -454,12 +480,14 @@ func (bn *Binance) CancelOrder(orderId string, currencyPair CurrencyPair) (bool,
 }
 
 func (bn *Binance) GetOneOrder(orderId string, currencyPair CurrencyPair) (*Order, error) {
+	return bn.getOneOrder("orderId", orderId, currencyPair)
+}
+
+// getOneOrder idKey 为 orderId 或 origClientOrderId
+func (bn *Binance) getOneOrder(idKey, id string, currencyPair CurrencyPair) (*Order, error) {
 	params := url.Values{}
 	params.Set("symbol", currencyPair.ToSymbol(""))
-	if orderId != "" {
-		params.Set("orderId", orderId)
-	}
-	params.Set("orderId", orderId)
+	params.Set(idKey, id)
 
 	bn.buildParamsSigned(&params)
 	path := bn.apiV3 + ORDER_URI + "?" + params.Encode()
@@ -585,22 +613,6 @@ func (bn *Binance) GetOrderHistorys(currency CurrencyPair, optional ...OptionalP
 
 }
 
-func (bn *Binance) toCurrencyPair(symbol string) CurrencyPair {
-	if bn.ExchangeInfo == nil {
-		var err error
-		bn.ExchangeInfo, err = bn.GetExchangeInfo()
-		if err != nil {
-			return CurrencyPair{}
-		}
-	}
-	for _, v := range bn.ExchangeInfo.Symbols {
-		if v.Symbol == symbol {
-			return NewCurrencyPair2(v.BaseAsset + "_" + v.QuoteAsset)
-		}
-	}
-	return CurrencyPair{}
-}
-
 func (bn *Binance) GetExchangeInfo() (*ExchangeInfo, error) {
 	resp, err := HttpGet5(bn.httpClient, bn.apiV3+"exchangeInfo", nil)
 	if err != nil {
@@ -709,11 +721,7 @@ func (bn *Binance) GetTimestamp() (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	data, ok := ret["serverTime"].(int64)
-	if !ok {
-		return 0, err
-	}
-	return data, nil
+	return ToInt64(ret["serverTime"]), nil
 }
 
 func endWith(src string, end string) bool {
@@ -59,6 +59,11 @@ type PositionRiskResponse struct {
 	Leverage         float64 `json:"leverage,string"`
 	MarginType       string  `json:"marginType"`
 	PositionSide     string  `json:"positionSide"`
+	MarkPrice        float64 `json:"markPrice,string"`
+	IsolatedMargin   float64 `json:"isolatedMargin,string"`
+	Notional         string  `json:"notional"`      //fapi
+	NotionalValue    string  `json:"notionalValue"` //dapi
+	UpdateTime       int64   `json:"updateTime"`
 }
 
 type SymbolInfo struct {
@@ -77,11 +82,15 @@ type BinanceFutures struct {
 	exchangeInfo *struct {
 		Symbols []SymbolInfo `json:"symbols"`
 	}
+	contracts *ContractResolver
 }
 
 func NewBinanceFutures(config *APIConfig) *BinanceFutures {
 	if config.Endpoint == "" {
 		config.Endpoint = "https://dapi.binance.com"
+		if config.Env == ENV_TESTNET {
+			config.Endpoint = TESTNET_FUTURES_API_BASE_URL
+		}
 	}
 
 	if config.HttpClient == nil {
@@ -94,6 +103,8 @@ func NewBinanceFutures(config *APIConfig) *BinanceFutures {
 	}
 
 	bs.base.apiV1 = config.Endpoint + "/dapi/v1/"
+	bs.base.setClock(config, bs.base.apiV1+SERVER_TIME_URL)
+	bs.contracts = NewContractResolver(BINANCE_FUTURES, bs.loadContracts, bs.base.clock)
 
 	go bs.GetExchangeInfo()
 
@@ -475,20 +486,25 @@ func (bs *BinanceFutures) GetFutureOrder(orderId string, currencyPair CurrencyPa
 		return nil, err
 	}
 
-	return &FutureOrder{
+	ord := bs.adaptOrderInfo(getOrderInfoResponse, currencyPair, contractType)
+	return &ord, nil
+}
+
+func (bs *BinanceFutures) adaptOrderInfo(info OrderInfoResponse, currencyPair CurrencyPair, contractType string) FutureOrder {
+	return FutureOrder{
 		Currency:     currencyPair,
-		ClientOid:    getOrderInfoResponse.ClientOrderId,
-		OrderID2:     fmt.Sprint(getOrderInfoResponse.OrderId),
-		Price:        getOrderInfoResponse.Price,
-		Amount:       getOrderInfoResponse.OrigQty,
-		AvgPrice:     getOrderInfoResponse.AvgPrice,
-		DealAmount:   getOrderInfoResponse.ExecutedQty,
-		OrderTime:    getOrderInfoResponse.Time / 1000,
-		Status:       bs.adaptStatus(getOrderInfoResponse.Status),
-		OType:        bs.adaptOType(getOrderInfoResponse.Side, getOrderInfoResponse.PositionSide),
+		ClientOid:    info.ClientOrderId,
+		OrderID2:     fmt.Sprint(info.OrderId),
+		Price:        info.Price,
+		Amount:       info.OrigQty,
+		AvgPrice:     info.AvgPrice,
+		DealAmount:   info.ExecutedQty,
+		OrderTime:    info.Time / 1000,
+		Status:       bs.adaptStatus(info.Status),
+		OType:        bs.adaptOType(info.Side, info.PositionSide),
 		ContractName: contractType,
-		FinishedTime: getOrderInfoResponse.UpdateTime / 1000,
-	}, nil
+		FinishedTime: info.UpdateTime / 1000,
+	}
 }
 
 func (bs *BinanceFutures) GetUnfinishFutureOrders(currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
@@ -548,7 +564,7 @@ func (bs *BinanceFutures) GetFutureOrderHistory(pair CurrencyPair, contractType
 }
 
 func (bs *BinanceFutures) GetFee() (float64, error) {
-	panic("not supported.")
+	return FutureTakerFee(bs, BTC_USD, SWAP_CONTRACT)
 }
 
 func (bs *BinanceFutures) GetContractValue(currencyPair CurrencyPair) (float64, error) {
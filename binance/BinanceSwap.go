@@ -24,6 +24,9 @@ type BinanceSwap struct {
 func NewBinanceSwap(config *APIConfig) *BinanceSwap {
 	if config.Endpoint == "" {
 		config.Endpoint = baseUrl
+		if config.Env == ENV_TESTNET {
+			config.Endpoint = TESTNET_FUTURES_API_BASE_URL
+		}
 	}
 
 	bs := &BinanceSwap{
@@ -33,6 +36,7 @@ func NewBinanceSwap(config *APIConfig) *BinanceSwap {
 			apiV1:      config.Endpoint + "/fapi/v1/",
 			secretKey:  config.ApiSecretKey,
 			httpClient: config.HttpClient,
+			log:        config.GetLogger(),
 		},
 		f: NewBinanceFutures(&APIConfig{
 			Endpoint:     strings.ReplaceAll(config.Endpoint, "fapi", "dapi"),
@@ -40,9 +44,12 @@ func NewBinanceSwap(config *APIConfig) *BinanceSwap {
 			ApiKey:       config.ApiKey,
 			ApiSecretKey: config.ApiSecretKey,
 			Lever:        config.Lever,
+			Env:          config.Env,
+			Logger:       config.Logger,
 		}),
 	}
-	bs.setTimeOffset()
+	bs.setClock(config, bs.apiV1+SERVER_TIME_URL)
+	bs.symbols = NewSymbolMapper(bs.loadSwapMarkets, nil)
 	return bs
 }
 
@@ -62,20 +69,6 @@ func (bs *BinanceSwap) Ping() bool {
 	return true
 }
 
-func (bs *BinanceSwap) setTimeOffset() error {
-	respmap, err := HttpGet(bs.httpClient, bs.apiV1+SERVER_TIME_URL)
-	if err != nil {
-		return err
-	}
-
-	stime := int64(ToInt(respmap["serverTime"]))
-	st := time.Unix(stime/1000, 1000000*(stime%1000))
-	lt := time.Now()
-	offset := st.Sub(lt).Nanoseconds()
-	bs.timeOffset = int64(offset)
-	return nil
-}
-
 func (bs *BinanceSwap) GetFutureEstimatedPrice(currencyPair CurrencyPair) (float64, error) {
 	panic("not supported.")
 }
@@ -747,7 +740,7 @@ func (bs *BinanceSwap) GetUnfinishFutureOrders(currencyPair CurrencyPair, contra
 }
 
 func (bs *BinanceSwap) GetFee() (float64, error) {
-	panic("not supported.")
+	return FutureTakerFee(bs, BTC_USDT, SWAP_USDT_CONTRACT)
 }
 
 func (bs *BinanceSwap) GetContractValue(currencyPair CurrencyPair) (float64, error) {
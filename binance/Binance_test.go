@@ -82,9 +82,9 @@ func TestBinance_GetTradeSymbols(t *testing.T) {
 	t.Log(ba.GetTradeSymbol(goex.BTC_USDT))
 }
 
-func TestBinance_SetTimeOffset(t *testing.T) {
-	t.Log(ba.setTimeOffset())
-	t.Log(ba.timeOffset)
+func TestBinance_Clock(t *testing.T) {
+	t.Log(ba.clock.Now())
+	t.Log(goex.ClockSkews())
 }
 
 func TestBinance_GetOrderHistorys(t *testing.T) {
@@ -0,0 +1,176 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+//自定义订单ID: 下单用 newClientOrderId, 查询和撤单用 origClientOrderId
+
+// LimitOrderWithCid 现货只支持 POST_ONLY(LIMIT_MAKER), 其它为 GTC
+func (bn *Binance) LimitOrderWithCid(ord *Order) (*Order, error) {
+	if ord.Cid == "" {
+		ord.Cid = GenerateOrderClientId(32)
+	}
+
+	orderType := "LIMIT"
+	if ord.OrderType == ORDER_FEATURE_POST_ONLY {
+		orderType = "LIMIT_MAKER"
+	}
+
+	if ord.Side != BUY && ord.Side != SELL {
+		return nil, errors.New("side must be BUY or SELL")
+	}
+
+	ret, err := bn.placeOrder(FloatToString(ord.Amount, 8), FloatToString(ord.Price, 8), ord.Currency, orderType, ord.Side.String(), ord.Cid)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.OrderID2 = ret.OrderID2
+	return ret, nil
+}
+
+func (bn *Binance) GetOneOrderByCid(cid string, currency CurrencyPair) (*Order, error) {
+	ord, err := bn.getOneOrder("origClientOrderId", cid, currency)
+	if err != nil {
+		return nil, bn.adaptError(err)
+	}
+	return ord, nil
+}
+
+func (bn *Binance) CancelOrderByCid(cid string, currency CurrencyPair) (bool, error) {
+	return bn.cancelOrder("origClientOrderId", cid, currency)
+}
+
+func (bn *Binance) placeFuturesOrderWithCid(symbol string, ord *FutureOrder) (*FutureOrder, error) {
+	if ord.ClientOid == "" {
+		ord.ClientOid = GenerateOrderClientId(32)
+	}
+
+	side, reduceOnly := adaptFuturesSide(ord.OType)
+	if side == "" {
+		return nil, errors.New("open type is error")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("side", side)
+	params.Set("type", "LIMIT")
+	params.Set("timeInForce", adaptTimeInForce(ord.OrderType))
+	params.Set("quantity", fmt.Sprint(ord.Amount))
+	params.Set("price", fmt.Sprint(ord.Price))
+	params.Set("newClientOrderId", ord.ClientOid)
+	params.Set("newOrderRespType", "ACK")
+	if reduceOnly {
+		params.Set("reduceOnly", "true")
+	}
+
+	var response OrderInfoResponse
+	err := bn.doSignedRequest("POST", "order", params, &response)
+	if err != nil {
+		return nil, bn.adaptError(err)
+	}
+
+	if response.Code != 0 || response.OrderId <= 0 {
+		return nil, errors.New(fmt.Sprintf("%d:%s", response.Code, response.Msg))
+	}
+
+	ord.OrderID2 = fmt.Sprint(response.OrderId)
+	ret := *ord
+	ret.Status = ORDER_UNFINISH
+	return &ret, nil
+}
+
+func (bn *Binance) getFuturesOrderByCid(symbol, cid string) (*OrderInfoResponse, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("origClientOrderId", cid)
+
+	var response OrderInfoResponse
+	err := bn.doSignedRequest("GET", "order", params, &response)
+	if err != nil {
+		return nil, bn.adaptError(err)
+	}
+
+	return &response, nil
+}
+
+func (bn *Binance) cancelFuturesOrderByCid(symbol, cid string) (bool, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("origClientOrderId", cid)
+
+	err := bn.doSignedRequest("DELETE", "order", params, nil)
+	if err != nil {
+		return false, bn.adaptError(err)
+	}
+
+	return true, nil
+}
+
+func (bs *BinanceFutures) LimitFuturesOrderWithCid(ord *FutureOrder) (*FutureOrder, error) {
+	symbol, err := bs.adaptToSymbol(ord.Currency, ord.ContractName)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.placeFuturesOrderWithCid(symbol, ord)
+}
+
+func (bs *BinanceFutures) GetFutureOrderByCid(cid string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+	symbol, err := bs.adaptToSymbol(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := bs.base.getFuturesOrderByCid(symbol, cid)
+	if err != nil {
+		return nil, err
+	}
+
+	ord := bs.adaptOrderInfo(*info, currencyPair, contractType)
+	return &ord, nil
+}
+
+func (bs *BinanceFutures) FutureCancelOrderByCid(currencyPair CurrencyPair, contractType, cid string) (bool, error) {
+	symbol, err := bs.adaptToSymbol(currencyPair, contractType)
+	if err != nil {
+		return false, err
+	}
+	return bs.base.cancelFuturesOrderByCid(symbol, cid)
+}
+
+func (bs *BinanceSwap) LimitFuturesOrderWithCid(ord *FutureOrder) (*FutureOrder, error) {
+	if ord.ContractName == SWAP_CONTRACT {
+		coinOrd := *ord
+		coinOrd.Currency = ord.Currency.AdaptUsdtToUsd()
+		ret, err := bs.f.LimitFuturesOrderWithCid(&coinOrd)
+		ord.ClientOid, ord.OrderID2 = coinOrd.ClientOid, coinOrd.OrderID2
+		return ret, err
+	}
+	return bs.placeFuturesOrderWithCid(bs.adaptCurrencyPair(ord.Currency).ToSymbol(""), ord)
+}
+
+func (bs *BinanceSwap) GetFutureOrderByCid(cid string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetFutureOrderByCid(cid, currencyPair.AdaptUsdtToUsd(), contractType)
+	}
+
+	info, err := bs.getFuturesOrderByCid(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), cid)
+	if err != nil {
+		return nil, err
+	}
+
+	ord := bs.f.adaptOrderInfo(*info, currencyPair, contractType)
+	return &ord, nil
+}
+
+func (bs *BinanceSwap) FutureCancelOrderByCid(currencyPair CurrencyPair, contractType, cid string) (bool, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.FutureCancelOrderByCid(currencyPair.AdaptUsdtToUsd(), contractType, cid)
+	}
+	return bs.cancelFuturesOrderByCid(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), cid)
+}
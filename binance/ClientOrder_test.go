@@ -0,0 +1,33 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinance_GetOneOrderByCid(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("origClientOrderId") == "exist" {
+			w.Write([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"exist","price":"30000.00","origQty":"1.0","executedQty":"0","cummulativeQuoteQty":"0","status":"NEW","side":"BUY","time":1600000000000,"updateTime":1600000000000}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-2013,"msg":"Order does not exist."}`))
+	}))
+	defer srv.Close()
+
+	bn := &Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV3: srv.URL + "/api/v3/"}
+
+	ord, err := bn.GetOneOrderByCid("exist", goex.BTC_USDT)
+	assert.Nil(t, err)
+	assert.Equal(t, "28", ord.OrderID2)
+	assert.Equal(t, "exist", ord.Cid)
+
+	//-2013 映射为 EX_ERR_NOT_FIND_ORDER, 幂等下单才能确认订单不存在
+	_, err = bn.GetOneOrderByCid("absent", goex.BTC_USDT)
+	assert.True(t, goex.IsOrderNotFound(err))
+}
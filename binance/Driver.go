@@ -0,0 +1,39 @@
+package binance
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	future := func(config *APIConfig) FutureRestAPI {
+		return NewBinanceFutures(config)
+	}
+	futuresWs := func(config *APIConfig) FuturesWsApi {
+		return NewFuturesWsWithConfig(config.GetWsClientConfig())
+	}
+	//现货钱包(sapi)没有测试网
+	testnet := []Capability{CAP_SPOT, CAP_FUTURE, CAP_SPOT_WS, CAP_FUTURES_WS}
+
+	RegisterDriver(BINANCE, Driver{
+		Spot: func(config *APIConfig) API {
+			return NewWithConfig(config)
+		},
+		Future: future,
+		SpotWs: func(config *APIConfig) SpotWsApi {
+			return NewSpotWsWithConfig(config.GetWsClientConfig())
+		},
+		FuturesWs: futuresWs,
+		Wallet: func(config *APIConfig) WalletApi {
+			return NewWallet(config)
+		},
+		Testnet: testnet,
+	})
+	RegisterDriver(BINANCE_FUTURES, Driver{Future: future, FuturesWs: futuresWs, Testnet: testnet})
+	RegisterDriver(BINANCE_SWAP, Driver{
+		Future: func(config *APIConfig) FutureRestAPI {
+			return NewBinanceSwap(config)
+		},
+		FuturesWs: futuresWs,
+		Testnet:   testnet,
+	})
+}
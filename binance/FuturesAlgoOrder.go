@@ -0,0 +1,374 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+//合约(fapi/dapi)的止盈止损单与普通订单共用 order 接口, 通过 type 区分
+//撤单和查询的 orderId/algoId 为下单返回的 OrderID2
+
+type algoOrderResponse struct {
+	OrderInfoResponse
+	StopPrice  float64 `json:"stopPrice,string"`
+	OrigType   string  `json:"origType"`
+	ReduceOnly bool    `json:"reduceOnly"`
+}
+
+func isAlgoOrderType(ty string) bool {
+	switch ty {
+	case "STOP", "STOP_MARKET", "TAKE_PROFIT", "TAKE_PROFIT_MARKET":
+		return true
+	}
+	return false
+}
+
+// adaptAlgoOrderType 根据触发价与最新价的关系确定止损(STOP)或止盈(TAKE_PROFIT)
+// 买单: 触发价高于最新价为止损, 卖单: 触发价低于最新价为止损
+func adaptAlgoOrderType(ord *FutureOrder, lastPrice float64) string {
+	var ty string
+	switch ord.OType {
+	case OPEN_BUY, CLOSE_SELL:
+		ty = "TAKE_PROFIT"
+		if ord.TriggerPrice > lastPrice {
+			ty = "STOP"
+		}
+	default:
+		ty = "TAKE_PROFIT"
+		if ord.TriggerPrice < lastPrice {
+			ty = "STOP"
+		}
+	}
+
+	if ord.AlgoType == 2 {
+		ty += "_MARKET"
+	}
+
+	return ty
+}
+
+func adaptAlgoStatusToBinance(status string) []string {
+	switch status {
+	case ALGO_ORDER_PENDING:
+		return []string{"NEW"}
+	case ALGO_ORDER_TRIGGERED:
+		return []string{"PARTIALLY_FILLED", "FILLED"}
+	case ALGO_ORDER_CANCELED:
+		return []string{"CANCELED", "EXPIRED"}
+	}
+	return nil
+}
+
+func (bn *Binance) placeFuturesAlgoOrder(symbol string, ord *FutureOrder, lastPrice float64) error {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("quantity", fmt.Sprint(ord.Amount))
+	params.Set("stopPrice", fmt.Sprint(ord.TriggerPrice))
+	params.Set("type", adaptAlgoOrderType(ord, lastPrice))
+	params.Set("newOrderRespType", "ACK")
+
+	if ord.ClientOid == "" {
+		ord.ClientOid = GenerateOrderClientId(32)
+	}
+	params.Set("newClientOrderId", ord.ClientOid)
+
+	switch ord.OType {
+	case OPEN_BUY:
+		params.Set("side", "BUY")
+	case CLOSE_SELL:
+		params.Set("side", "BUY")
+		params.Set("reduceOnly", "true")
+	case OPEN_SELL:
+		params.Set("side", "SELL")
+	case CLOSE_BUY:
+		params.Set("side", "SELL")
+		params.Set("reduceOnly", "true")
+	default:
+		return errors.New("open type is error")
+	}
+
+	if ord.AlgoType != 2 {
+		params.Set("price", fmt.Sprint(ord.Price))
+		params.Set("timeInForce", "GTC")
+	}
+
+	bn.buildParamsSigned(&params)
+
+	resp, err := HttpPostForm2(bn.httpClient, bn.apiV1+ORDER_URI, params,
+		map[string]string{"X-MBX-APIKEY": bn.accessKey})
+	if err != nil {
+		return err
+	}
+
+	logger.Debug(string(resp))
+
+	var response struct {
+		BaseResponse
+		OrderId int64 `json:"orderId"`
+	}
+
+	err = json.Unmarshal(resp, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.OrderId <= 0 {
+		return errors.New(response.Msg)
+	}
+
+	ord.OrderID = response.OrderId
+	ord.OrderID2 = fmt.Sprint(response.OrderId)
+	ord.Status = ORDER_UNFINISH
+
+	return nil
+}
+
+func (bn *Binance) cancelFuturesAlgoOrders(symbol string, orderIds []string) (bool, error) {
+	if len(orderIds) == 0 {
+		return false, errors.New("invalid order id")
+	}
+
+	for _, orderId := range orderIds {
+		params := url.Values{}
+		params.Set("symbol", symbol)
+		params.Set("orderId", orderId)
+
+		bn.buildParamsSigned(&params)
+
+		resp, err := HttpDeleteForm(bn.httpClient, bn.apiV1+ORDER_URI+"?"+params.Encode(), url.Values{},
+			map[string]string{"X-MBX-APIKEY": bn.accessKey})
+		if err != nil {
+			return false, err
+		}
+
+		logger.Debug(string(resp))
+	}
+
+	return true, nil
+}
+
+func (bn *Binance) getFuturesAlgoOrders(symbol string, algoId string, status string) ([]algoOrderResponse, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	apiPath := "allOrders"
+	if algoId != "" {
+		apiPath = ORDER_URI
+		params.Set("orderId", algoId)
+	} else if status == ALGO_ORDER_PENDING {
+		apiPath = "openOrders"
+	} else if status == "" {
+		return nil, errors.New("status or algo_id is needed")
+	}
+
+	bn.buildParamsSigned(&params)
+
+	resp, err := HttpGet5(bn.httpClient, bn.apiV1+apiPath+"?"+params.Encode(),
+		map[string]string{"X-MBX-APIKEY": bn.accessKey})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug(string(resp))
+
+	var orders []algoOrderResponse
+	if algoId != "" {
+		var ord algoOrderResponse
+		err = json.Unmarshal(resp, &ord)
+		orders = append(orders, ord)
+	} else {
+		err = json.Unmarshal(resp, &orders)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result   []algoOrderResponse
+		statuses = adaptAlgoStatusToBinance(status)
+	)
+
+	for _, ord := range orders {
+		if !isAlgoOrderType(ord.Type) && !isAlgoOrderType(ord.OrigType) {
+			continue
+		}
+
+		if algoId == "" {
+			matched := false
+			for _, s := range statuses {
+				if s == ord.Status {
+					matched = true
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		result = append(result, ord)
+	}
+
+	return result, nil
+}
+
+func (bn *Binance) adaptAlgoOrder(ord algoOrderResponse, pair CurrencyPair, contractType string) FutureOrder {
+	var (
+		otype    int
+		algoType = 1
+	)
+
+	switch {
+	case ord.Side == "BUY" && ord.ReduceOnly:
+		otype = CLOSE_SELL
+	case ord.Side == "BUY":
+		otype = OPEN_BUY
+	case ord.ReduceOnly:
+		otype = CLOSE_BUY
+	default:
+		otype = OPEN_SELL
+	}
+
+	if strings.HasSuffix(ord.OrigType, "_MARKET") || strings.HasSuffix(ord.Type, "_MARKET") {
+		algoType = 2
+	}
+
+	return FutureOrder{
+		ClientOid:    ord.ClientOrderId,
+		OrderID:      ord.OrderId,
+		OrderID2:     fmt.Sprint(ord.OrderId),
+		Price:        ord.Price,
+		Amount:       ord.OrigQty,
+		AvgPrice:     ord.AvgPrice,
+		DealAmount:   ord.ExecutedQty,
+		OrderTime:    ord.Time,
+		Status:       adaptOrderStatus(ord.Status), //没有触发标识, 已触发未成交时仍为 UNFINISH
+		Currency:     pair,
+		OType:        otype,
+		ContractName: contractType,
+		FinishedTime: ord.UpdateTime,
+		TriggerPrice: ord.StopPrice,
+		AlgoType:     algoType,
+	}
+}
+
+func (bs *BinanceFutures) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord == nil {
+		return nil, errors.New("ord param is nil")
+	}
+
+	if ord.ContractName == "" {
+		ord.ContractName = SWAP_CONTRACT
+	}
+
+	symbol, err := bs.adaptToSymbol(ord.Currency, ord.ContractName)
+	if err != nil {
+		return ord, err
+	}
+
+	ticker, err := bs.GetFutureTicker(ord.Currency, ord.ContractName)
+	if err != nil {
+		return ord, err
+	}
+
+	return ord, bs.base.placeFuturesAlgoOrder(symbol, ord, ticker.Last)
+}
+
+func (bs *BinanceFutures) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error) {
+	symbol, err := bs.adaptToSymbol(currencyPair, bs.algoContractType(contractType))
+	if err != nil {
+		return false, err
+	}
+	return bs.base.cancelFuturesAlgoOrders(symbol, orderId)
+}
+
+func (bs *BinanceFutures) GetFutureAlgoOrders(algoId string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error) {
+	ct := bs.algoContractType(contractType)
+	symbol, err := bs.adaptToSymbol(currencyPair, ct)
+	if err != nil {
+		return nil, err
+	}
+
+	algoOrders, err := bs.base.getFuturesAlgoOrders(symbol, algoId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]FutureOrder, 0, len(algoOrders))
+	for _, ord := range algoOrders {
+		orders = append(orders, bs.base.adaptAlgoOrder(ord, currencyPair, ct))
+	}
+
+	return orders, nil
+}
+
+func (bs *BinanceFutures) algoContractType(contractType []string) string {
+	if len(contractType) == 0 || contractType[0] == "" {
+		return SWAP_CONTRACT
+	}
+	return contractType[0]
+}
+
+func (bs *BinanceSwap) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord == nil {
+		return nil, errors.New("ord param is nil")
+	}
+
+	if ord.ContractName == SWAP_CONTRACT {
+		pair := ord.Currency
+		ord.Currency = pair.AdaptUsdtToUsd()
+		ord, err := bs.f.PlaceFutureAlgoOrder(ord)
+		ord.Currency = pair
+		return ord, err
+	}
+
+	if ord.ContractName == "" {
+		ord.ContractName = SWAP_USDT_CONTRACT
+	}
+
+	if ord.ContractName != SWAP_USDT_CONTRACT {
+		return ord, errors.New("contract is error,please incoming SWAP_CONTRACT or SWAP_USDT_CONTRACT")
+	}
+
+	ticker, err := bs.GetFutureTicker(ord.Currency, ord.ContractName)
+	if err != nil {
+		return ord, err
+	}
+
+	return ord, bs.placeFuturesAlgoOrder(bs.adaptCurrencyPair(ord.Currency).ToSymbol(""), ord, ticker.Last)
+}
+
+func (bs *BinanceSwap) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error) {
+	if len(contractType) > 0 && contractType[0] == SWAP_CONTRACT {
+		return bs.f.FutureCancelAlgoOrder(currencyPair.AdaptUsdtToUsd(), orderId, SWAP_CONTRACT)
+	}
+	return bs.cancelFuturesAlgoOrders(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), orderId)
+}
+
+func (bs *BinanceSwap) GetFutureAlgoOrders(algoId string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error) {
+	if len(contractType) > 0 && contractType[0] == SWAP_CONTRACT {
+		orders, err := bs.f.GetFutureAlgoOrders(algoId, status, currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT)
+		for i := range orders {
+			orders[i].Currency = currencyPair
+		}
+		return orders, err
+	}
+
+	algoOrders, err := bs.getFuturesAlgoOrders(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), algoId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]FutureOrder, 0, len(algoOrders))
+	for _, ord := range algoOrders {
+		orders = append(orders, bs.adaptAlgoOrder(ord, currencyPair, SWAP_USDT_CONTRACT))
+	}
+
+	return orders, nil
+}
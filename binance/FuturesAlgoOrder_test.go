@@ -0,0 +1,64 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinanceSwap_FutureAlgoOrder(t *testing.T) {
+	var placed, canceled, queried map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Method + " " + r.URL.Path {
+		case "GET /fapi/v1/ticker/price":
+			w.Write([]byte(`{"symbol":"BTCUSDT","price":"30000.00"}`))
+		case "GET /fapi/v1/ticker/bookTicker":
+			w.Write([]byte(`{"symbol":"BTCUSDT","bidPrice":"29999.90","askPrice":"30000.10"}`))
+		case "POST /fapi/v1/order":
+			placed = r.Form
+			w.Write([]byte(`{"orderId":8886774,"symbol":"BTCUSDT","status":"NEW","clientOrderId":"` + r.Form.Get("newClientOrderId") + `"}`))
+		case "DELETE /fapi/v1/order":
+			canceled = r.Form
+			w.Write([]byte(`{"orderId":8886774,"symbol":"BTCUSDT","status":"CANCELED"}`))
+		case "GET /fapi/v1/openOrders":
+			queried = r.Form
+			w.Write([]byte(`[
+{"orderId":8886774,"symbol":"BTCUSDT","status":"NEW","clientOrderId":"goexabc","price":"0","origQty":"0.01","executedQty":"0","type":"STOP_MARKET","side":"SELL","stopPrice":"29000","reduceOnly":true,"origType":"STOP_MARKET","time":1616000000000},
+{"orderId":8886775,"symbol":"BTCUSDT","status":"NEW","clientOrderId":"goexdef","price":"28000","origQty":"0.01","executedQty":"0","type":"LIMIT","side":"BUY","stopPrice":"0","reduceOnly":false,"origType":"LIMIT","time":1616000000000}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	bs := &BinanceSwap{Binance: Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV1: srv.URL + "/fapi/v1/"}}
+
+	//平多, 触发价低于最新价为止损
+	ord, err := bs.PlaceFutureAlgoOrder(&goex.FutureOrder{Currency: goex.BTC_USDT, OType: goex.CLOSE_BUY, Amount: 0.01, TriggerPrice: 29000, AlgoType: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "8886774", ord.OrderID2)
+	assert.Equal(t, "STOP_MARKET", placed["type"][0])
+	assert.Equal(t, "SELL", placed["side"][0])
+	assert.Equal(t, "true", placed["reduceOnly"][0])
+	assert.Empty(t, placed["price"])
+
+	orders, err := bs.GetFutureAlgoOrders("", goex.ALGO_ORDER_PENDING, goex.BTC_USDT)
+	assert.Nil(t, err)
+	assert.Equal(t, "BTCUSDT", queried["symbol"][0])
+	assert.Len(t, orders, 1)
+	assert.Equal(t, goex.CLOSE_BUY, orders[0].OType)
+	assert.Equal(t, 2, orders[0].AlgoType)
+	assert.Equal(t, 29000.0, orders[0].TriggerPrice)
+	assert.Equal(t, goex.ORDER_UNFINISH, orders[0].Status)
+
+	//按交易所订单ID撤单
+	ok, err := bs.FutureCancelAlgoOrder(goex.BTC_USDT, []string{ord.OrderID2})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "8886774", canceled["orderId"][0])
+	assert.Empty(t, canceled["origClientOrderId"])
+}
@@ -0,0 +1,386 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+// 合约(fapi/dapi)批量接口 batchOrders, 下单和改单每次最多5个, 撤单每次最多10个
+const (
+	batchPlaceLimit  = 5
+	batchCancelLimit = 10
+	batchAmendLimit  = 5
+	batchOrdersUri   = "batchOrders"
+)
+
+type batchOrderResponse struct {
+	BaseResponse
+	OrderId       int64  `json:"orderId"`
+	ClientOrderId string `json:"clientOrderId"`
+}
+
+var errOrderNotInResponse = errors.New("order not found in response")
+
+func (r batchOrderResponse) err() error {
+	if r.Code != 0 {
+		return errors.New(fmt.Sprintf("%d:%s", r.Code, r.Msg))
+	}
+	if r.OrderId <= 0 {
+		return errors.New("order id not found in response")
+	}
+	return nil
+}
+
+func adaptFuturesSide(openType int) (side string, reduceOnly bool) {
+	switch openType {
+	case OPEN_BUY:
+		return "BUY", false
+	case CLOSE_SELL:
+		return "BUY", true
+	case OPEN_SELL:
+		return "SELL", false
+	case CLOSE_BUY:
+		return "SELL", true
+	}
+	return "", false
+}
+
+func adaptTimeInForce(orderType int) string {
+	switch orderType {
+	case ORDER_FEATURE_POST_ONLY:
+		return "GTX"
+	case ORDER_FEATURE_FOK:
+		return "FOK"
+	case ORDER_FEATURE_IOC:
+		return "IOC"
+	}
+	return "GTC"
+}
+
+func (bn *Binance) doBatchOrders(method string, params url.Values) ([]batchOrderResponse, error) {
+	bn.buildParamsSigned(&params)
+
+	var (
+		resp    []byte
+		err     error
+		headers = map[string]string{"X-MBX-APIKEY": bn.accessKey}
+	)
+
+	switch method {
+	case "POST":
+		resp, err = HttpPostForm2(bn.httpClient, bn.apiV1+batchOrdersUri, params, headers)
+	case "PUT":
+		resp, err = HttpPut(bn.httpClient, bn.apiV1+batchOrdersUri, params, headers)
+	default:
+		resp, err = HttpDeleteForm(bn.httpClient, bn.apiV1+batchOrdersUri+"?"+params.Encode(), url.Values{}, headers)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug(string(resp))
+
+	var response []batchOrderResponse
+	err = json.Unmarshal(resp, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// fillBatchResult 返回结果按请求顺序排列, 缺少的订单视为失败
+func fillBatchResult(results []BatchOrderResult, start, end int, response []batchOrderResponse) {
+	for i := start; i < end; i++ {
+		r := &results[i]
+		if i-start >= len(response) {
+			r.Err = errOrderNotInResponse
+			continue
+		}
+		if err := response[i-start].err(); err != nil {
+			r.Err = err
+			continue
+		}
+		r.OrderId = fmt.Sprint(response[i-start].OrderId)
+		r.ClientOid = response[i-start].ClientOrderId
+	}
+}
+
+// batchPlaceFuturesOrders symbols 与 orders 按下标对应
+func (bn *Binance) batchPlaceFuturesOrders(symbols []string, orders []FutureOrder) []BatchOrderResult {
+	results := make([]BatchOrderResult, len(orders))
+
+	for _, b := range SplitBatch(len(orders), batchPlaceLimit) {
+		var batch []map[string]string
+		for i := b[0]; i < b[1]; i++ {
+			ord := &orders[i]
+			if ord.ClientOid == "" {
+				ord.ClientOid = GenerateOrderClientId(32)
+			}
+			results[i].ClientOid = ord.ClientOid
+
+			side, reduceOnly := adaptFuturesSide(ord.OType)
+			param := map[string]string{
+				"symbol":           symbols[i],
+				"side":             side,
+				"type":             "LIMIT",
+				"timeInForce":      adaptTimeInForce(ord.OrderType),
+				"quantity":         fmt.Sprint(ord.Amount),
+				"price":            fmt.Sprint(ord.Price),
+				"newClientOrderId": ord.ClientOid,
+			}
+			if reduceOnly {
+				param["reduceOnly"] = "true"
+			}
+			batch = append(batch, param)
+		}
+
+		data, _ := json.Marshal(batch)
+		params := url.Values{}
+		params.Set("batchOrders", string(data))
+
+		response, err := bn.doBatchOrders("POST", params)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		fillBatchResult(results, b[0], b[1], response)
+		for i := b[0]; i < b[1]; i++ {
+			orders[i].OrderID2 = results[i].OrderId
+		}
+	}
+
+	return results
+}
+
+func (bn *Binance) batchCancelFuturesOrders(symbol string, orderIds []string) []BatchOrderResult {
+	results := make([]BatchOrderResult, len(orderIds))
+
+	for _, b := range SplitBatch(len(orderIds), batchCancelLimit) {
+		var (
+			ids       []int64
+			clientIds []string
+		)
+
+		for i := b[0]; i < b[1]; i++ {
+			results[i].OrderId = orderIds[i]
+			if strings.HasPrefix(orderIds[i], "goex") {
+				clientIds = append(clientIds, orderIds[i])
+			} else {
+				ids = append(ids, ToInt64(orderIds[i]))
+			}
+		}
+
+		//orderIdList 与 origClientOrderIdList 不能同时使用, 混合时分两次撤单
+		if len(ids) > 0 && len(clientIds) > 0 {
+			for i := b[0]; i < b[1]; i++ {
+				ret := bn.batchCancelFuturesOrders(symbol, orderIds[i:i+1])
+				results[i] = ret[0]
+			}
+			continue
+		}
+
+		params := url.Values{}
+		params.Set("symbol", symbol)
+		if len(ids) > 0 {
+			data, _ := json.Marshal(ids)
+			params.Set("orderIdList", string(data))
+		} else {
+			data, _ := json.Marshal(clientIds)
+			params.Set("origClientOrderIdList", string(data))
+		}
+
+		response, err := bn.doBatchOrders("DELETE", params)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		for i := b[0]; i < b[1]; i++ {
+			if i-b[0] >= len(response) {
+				results[i].Err = errOrderNotInResponse
+				continue
+			}
+			results[i].Err = response[i-b[0]].err()
+		}
+	}
+
+	return results
+}
+
+func (bn *Binance) batchAmendFuturesOrders(symbols []string, orders []FutureOrder) []BatchOrderResult {
+	results := make([]BatchOrderResult, len(orders))
+
+	for _, b := range SplitBatch(len(orders), batchAmendLimit) {
+		var batch []map[string]string
+		for i := b[0]; i < b[1]; i++ {
+			ord := orders[i]
+			side, _ := adaptFuturesSide(ord.OType)
+			param := map[string]string{
+				"symbol":   symbols[i],
+				"side":     side,
+				"quantity": fmt.Sprint(ord.Amount),
+				"price":    fmt.Sprint(ord.Price),
+			}
+			if strings.HasPrefix(ord.OrderID2, "goex") {
+				param["origClientOrderId"] = ord.OrderID2
+			} else {
+				param["orderId"] = ord.OrderID2
+			}
+			batch = append(batch, param)
+			results[i].OrderId = ord.OrderID2
+		}
+
+		data, _ := json.Marshal(batch)
+		params := url.Values{}
+		params.Set("batchOrders", string(data))
+
+		response, err := bn.doBatchOrders("PUT", params)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		fillBatchResult(results, b[0], b[1], response)
+	}
+
+	return results
+}
+
+// checkAmendParam 币安改单价格和数量都必填
+func checkAmendParam(orders []FutureOrder) error {
+	for _, ord := range orders {
+		if ord.Price <= 0 || ord.Amount <= 0 {
+			return ErrBatchAmendParam
+		}
+	}
+	return nil
+}
+
+func (bs *BinanceFutures) adaptSymbols(orders []FutureOrder) ([]string, error) {
+	symbols := make([]string, len(orders))
+	for i, ord := range orders {
+		symbol, err := bs.adaptToSymbol(ord.Currency, ord.ContractName)
+		if err != nil {
+			return nil, err
+		}
+		symbols[i] = symbol
+	}
+	return symbols, nil
+}
+
+func (bs *BinanceFutures) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	symbols, err := bs.adaptSymbols(orders)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.batchPlaceFuturesOrders(symbols, orders), nil
+}
+
+func (bs *BinanceFutures) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	symbol, err := bs.adaptToSymbol(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.batchCancelFuturesOrders(symbol, orderIds), nil
+}
+
+func (bs *BinanceFutures) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	if err := checkAmendParam(orders); err != nil {
+		return nil, err
+	}
+	symbols, err := bs.adaptSymbols(orders)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.batchAmendFuturesOrders(symbols, orders), nil
+}
+
+// splitSwapOrders 币本位永续(SWAP_CONTRACT)走 dapi, 其它走 fapi
+func (bs *BinanceSwap) splitSwapOrders(orders []FutureOrder) (coinIdx []int, coinOrders []FutureOrder, usdtIdx []int, usdtOrders []FutureOrder) {
+	for i, ord := range orders {
+		if ord.ContractName == SWAP_CONTRACT {
+			ord.Currency = ord.Currency.AdaptUsdtToUsd()
+			coinIdx = append(coinIdx, i)
+			coinOrders = append(coinOrders, ord)
+		} else {
+			usdtIdx = append(usdtIdx, i)
+			usdtOrders = append(usdtOrders, ord)
+		}
+	}
+	return
+}
+
+// mergeSwapResults 合并 dapi 与 fapi 的结果并回写订单ID
+func mergeSwapResults(orders []FutureOrder, results []BatchOrderResult, idx []int, subOrders []FutureOrder, subResults []BatchOrderResult) {
+	for j, i := range idx {
+		results[i] = subResults[j]
+		orders[i].OrderID2 = subOrders[j].OrderID2
+		orders[i].ClientOid = subOrders[j].ClientOid
+	}
+}
+
+func (bs *BinanceSwap) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	results := make([]BatchOrderResult, len(orders))
+	coinIdx, coinOrders, usdtIdx, usdtOrders := bs.splitSwapOrders(orders)
+
+	if len(coinOrders) > 0 {
+		coinResults, err := bs.f.BatchPlaceFutureOrders(coinOrders)
+		if err != nil {
+			return nil, err
+		}
+		mergeSwapResults(orders, results, coinIdx, coinOrders, coinResults)
+	}
+
+	if len(usdtOrders) > 0 {
+		symbols := make([]string, len(usdtOrders))
+		for i, ord := range usdtOrders {
+			symbols[i] = bs.adaptCurrencyPair(ord.Currency).ToSymbol("")
+		}
+		mergeSwapResults(orders, results, usdtIdx, usdtOrders, bs.batchPlaceFuturesOrders(symbols, usdtOrders))
+	}
+
+	return results, nil
+}
+
+func (bs *BinanceSwap) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.BatchCancelFutureOrders(currencyPair.AdaptUsdtToUsd(), contractType, orderIds)
+	}
+	return bs.batchCancelFuturesOrders(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), orderIds), nil
+}
+
+func (bs *BinanceSwap) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	if err := checkAmendParam(orders); err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchOrderResult, len(orders))
+	coinIdx, coinOrders, usdtIdx, usdtOrders := bs.splitSwapOrders(orders)
+
+	if len(coinOrders) > 0 {
+		coinResults, err := bs.f.BatchAmendFutureOrders(coinOrders)
+		if err != nil {
+			return nil, err
+		}
+		mergeSwapResults(orders, results, coinIdx, coinOrders, coinResults)
+	}
+
+	if len(usdtOrders) > 0 {
+		symbols := make([]string, len(usdtOrders))
+		for i, ord := range usdtOrders {
+			symbols[i] = bs.adaptCurrencyPair(ord.Currency).ToSymbol("")
+		}
+		mergeSwapResults(orders, results, usdtIdx, usdtOrders, bs.batchAmendFuturesOrders(symbols, usdtOrders))
+	}
+
+	return results, nil
+}
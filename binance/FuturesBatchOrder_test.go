@@ -0,0 +1,49 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinanceSwap_BatchPlaceFutureOrders(t *testing.T) {
+	var batch []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/fapi/v1/batchOrders" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		assert.NotEmpty(t, r.Form.Get("signature"))
+		json.Unmarshal([]byte(r.Form.Get("batchOrders")), &batch)
+		//第三个订单没有返回
+		w.Write([]byte(`[{"orderId":1001,"clientOrderId":"` + batch[0]["newClientOrderId"] + `"},{"code":-2019,"msg":"Margin is insufficient."}]`))
+	}))
+	defer srv.Close()
+
+	bs := &BinanceSwap{Binance: Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV1: srv.URL + "/fapi/v1/"}}
+	orders := []goex.FutureOrder{
+		{Currency: goex.BTC_USDT, ContractName: goex.SWAP_USDT_CONTRACT, OType: goex.OPEN_BUY, Price: 30000, Amount: 0.01},
+		{Currency: goex.BTC_USDT, ContractName: goex.SWAP_USDT_CONTRACT, OType: goex.CLOSE_BUY, Price: 31000, Amount: 0.01},
+		{Currency: goex.ETH_USDT, ContractName: goex.SWAP_USDT_CONTRACT, OType: goex.OPEN_SELL, Price: 2000, Amount: 0.1, OrderType: goex.ORDER_FEATURE_POST_ONLY},
+	}
+
+	results, err := bs.BatchPlaceFutureOrders(orders)
+	assert.Nil(t, err)
+	assert.Len(t, batch, 3)
+	assert.Equal(t, "SELL", batch[1]["side"])
+	assert.Equal(t, "true", batch[1]["reduceOnly"])
+	assert.Equal(t, "ETHUSDT", batch[2]["symbol"])
+	assert.Equal(t, "GTX", batch[2]["timeInForce"])
+
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "1001", results[0].OrderId)
+	assert.Equal(t, "1001", orders[0].OrderID2)
+	assert.EqualError(t, results[1].Err, "-2019:Margin is insufficient.")
+	assert.Equal(t, errOrderNotInResponse, results[2].Err)
+	assert.Equal(t, orders[2].ClientOid, results[2].ClientOid)
+}
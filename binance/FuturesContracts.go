@@ -0,0 +1,57 @@
+package binance
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+var futuresContractTypes = map[string]string{
+	"PERPETUAL":       SWAP_CONTRACT,
+	"CURRENT_QUARTER": QUARTER_CONTRACT,
+	"NEXT_QUARTER":    BI_QUARTER_CONTRACT,
+}
+
+// Contracts 币本位合约, 合约 id(BTCUSD_210326)可以直接作为 contractType 参数
+func (bs *BinanceFutures) Contracts() *ContractResolver {
+	return bs.contracts
+}
+
+func (bs *BinanceFutures) loadContracts() ([]Contract, error) {
+	resp, err := HttpGet5(bs.base.httpClient, bs.base.apiV1+"exchangeInfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info struct {
+		Symbols []struct {
+			SymbolInfo
+			MarginAsset string `json:"marginAsset"`
+			BaseAsset   string `json:"baseAsset"`
+			QuoteAsset  string `json:"quoteAsset"`
+		} `json:"symbols"`
+	}
+	if err = json.Unmarshal(resp, &info); err != nil {
+		return nil, err
+	}
+
+	var contracts []Contract
+	for _, s := range info.Symbols {
+		if s.ContractStatus != "TRADING" {
+			continue
+		}
+		c := Contract{
+			Id:             s.Symbol,
+			Underlying:     NewCurrencyPair2(s.BaseAsset + "_" + s.QuoteAsset),
+			SettleCurrency: NewCurrency(s.MarginAsset, ""),
+			ContractType:   futuresContractTypes[s.ContractType],
+			ContractSize:   float64(s.ContractSize),
+		}
+		//永续合约的 deliveryDate 为 2100 年
+		if s.ContractType != "PERPETUAL" {
+			c.Expiry = time.Unix(0, s.DeliveryDate*int64(time.Millisecond))
+		}
+		contracts = append(contracts, c)
+	}
+	return contracts, nil
+}
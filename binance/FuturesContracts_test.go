@@ -0,0 +1,45 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinanceFutures_Contracts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dapi/v1/exchangeInfo" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"symbols":[
+{"symbol":"BTCUSD_PERP","pair":"BTCUSD","contractType":"PERPETUAL","deliveryDate":4133404800000,"contractStatus":"TRADING","contractSize":100,"marginAsset":"BTC","baseAsset":"BTC","quoteAsset":"USD"},
+{"symbol":"BTCUSD_210326","pair":"BTCUSD","contractType":"CURRENT_QUARTER","deliveryDate":1616745600000,"contractStatus":"TRADING","contractSize":100,"marginAsset":"BTC","baseAsset":"BTC","quoteAsset":"USD"},
+{"symbol":"BTCUSD_210625","pair":"BTCUSD","contractType":"NEXT_QUARTER","deliveryDate":1624608000000,"contractStatus":"TRADING","contractSize":100,"marginAsset":"BTC","baseAsset":"BTC","quoteAsset":"USD"},
+{"symbol":"BTCUSD_201225","pair":"BTCUSD","contractType":"","deliveryDate":1608883200000,"contractStatus":"SETTLING","contractSize":100,"marginAsset":"BTC","baseAsset":"BTC","quoteAsset":"USD"}]}`))
+	}))
+	defer srv.Close()
+
+	bs := &BinanceFutures{base: &Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV1: srv.URL + "/dapi/v1/"}}
+	contracts, err := bs.loadContracts()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(contracts))
+
+	perp := contracts[0]
+	assert.True(t, perp.IsPerpetual())
+	assert.Equal(t, goex.SWAP_CONTRACT, perp.ContractType)
+
+	quarter := contracts[1]
+	assert.Equal(t, "BTCUSD_210326", quarter.Id)
+	assert.Equal(t, "BTC_USD", quarter.Underlying.String())
+	assert.Equal(t, "BTC", quarter.SettleCurrency.Symbol)
+	assert.Equal(t, goex.QUARTER_CONTRACT, quarter.ContractType)
+	assert.Equal(t, time.Date(2021, 3, 26, 8, 0, 0, 0, time.UTC), quarter.Expiry.UTC())
+	assert.Equal(t, 100.0, quarter.ContractSize)
+	assert.False(t, quarter.Linear)
+	assert.Equal(t, goex.BI_QUARTER_CONTRACT, contracts[2].ContractType)
+}
@@ -0,0 +1,247 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+//fapi 与 dapi 的杠杆、保证金模式、持仓模式接口一致; 杠杆多空相同, 持仓模式为账户级别
+
+const (
+	errNoNeedChangeMarginType   = "-4046"
+	errNoNeedChangePositionSide = "-4059"
+)
+
+func (bn *Binance) doSignedRequest(method, uri string, params url.Values, response interface{}) error {
+	bn.buildParamsSigned(&params)
+
+	var (
+		resp    []byte
+		err     error
+		headers = map[string]string{"X-MBX-APIKEY": bn.accessKey}
+	)
+
+	switch method {
+	case "GET":
+		resp, err = HttpGet5(bn.httpClient, bn.apiV1+uri+"?"+params.Encode(), headers)
+	case "PUT":
+		resp, err = HttpPut(bn.httpClient, bn.apiV1+uri, params, headers)
+	case "DELETE":
+		resp, err = HttpDeleteForm(bn.httpClient, bn.apiV1+uri+"?"+params.Encode(), url.Values{}, headers)
+	default:
+		resp, err = HttpPostForm2(bn.httpClient, bn.apiV1+uri, params, headers)
+	}
+	if err != nil {
+		return err
+	}
+
+	if log := bn.getLogger(); DebugEnabled(log) {
+		log.Debug("response", KV("method", method), KV("uri", uri), KV("body", string(resp)))
+	}
+
+	if response == nil {
+		return nil
+	}
+	return json.Unmarshal(resp, response)
+}
+
+func (bn *Binance) getLeverage(symbol string, pair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	var positions []PositionRiskResponse
+	err := bn.doSignedRequest("GET", "positionRisk", url.Values{}, &positions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range positions {
+		if p.Symbol != symbol {
+			continue
+		}
+		mode := MARGIN_MODE_CROSSED
+		if strings.ToLower(p.MarginType) == "isolated" {
+			mode = MARGIN_MODE_ISOLATED
+		}
+		return &FutureLeverage{
+			Pair:          pair,
+			ContractType:  contractType,
+			MarginMode:    mode,
+			LongLeverage:  p.Leverage,
+			ShortLeverage: p.Leverage,
+		}, nil
+	}
+
+	return nil, errors.New("not found position risk of " + symbol)
+}
+
+func (bn *Binance) setLeverage(symbol string, leverage float64) error {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("leverage", fmt.Sprint(int(leverage)))
+	return bn.doSignedRequest("POST", "leverage", params, nil)
+}
+
+func (bn *Binance) setMarginMode(symbol string, mode MarginMode) error {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	switch mode {
+	case MARGIN_MODE_CROSSED:
+		params.Set("marginType", "CROSSED")
+	case MARGIN_MODE_ISOLATED:
+		params.Set("marginType", "ISOLATED")
+	default:
+		return errors.New("margin mode is error")
+	}
+
+	err := bn.doSignedRequest("POST", "marginType", params, nil)
+	if err != nil && strings.Contains(err.Error(), errNoNeedChangeMarginType) {
+		return nil
+	}
+	return err
+}
+
+func (bn *Binance) getPositionMode() (PositionMode, error) {
+	var resp struct {
+		DualSidePosition bool `json:"dualSidePosition"`
+	}
+	err := bn.doSignedRequest("GET", "positionSide/dual", url.Values{}, &resp)
+	if err != nil {
+		return 0, err
+	}
+	if resp.DualSidePosition {
+		return POSITION_MODE_HEDGE, nil
+	}
+	return POSITION_MODE_ONE_WAY, nil
+}
+
+func (bn *Binance) setPositionMode(mode PositionMode) error {
+	params := url.Values{}
+	switch mode {
+	case POSITION_MODE_HEDGE:
+		params.Set("dualSidePosition", "true")
+	case POSITION_MODE_ONE_WAY:
+		params.Set("dualSidePosition", "false")
+	default:
+		return errors.New("position mode is error")
+	}
+
+	err := bn.doSignedRequest("POST", "positionSide/dual", params, nil)
+	if err != nil && strings.Contains(err.Error(), errNoNeedChangePositionSide) {
+		return nil
+	}
+	return err
+}
+
+func (bn *Binance) adjustMargin(symbol string, side PositionSide, amount float64) error {
+	if amount == 0 {
+		return errors.New("amount is zero")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("positionSide", side.String())
+	params.Set("amount", fmt.Sprint(math.Abs(amount)))
+	if amount > 0 {
+		params.Set("type", "1")
+	} else {
+		params.Set("type", "2")
+	}
+
+	return bn.doSignedRequest("POST", "positionMargin", params, nil)
+}
+
+func (bs *BinanceFutures) positionSymbol(currencyPair CurrencyPair, contractType string) (string, string, error) {
+	if contractType == "" {
+		contractType = SWAP_CONTRACT
+	}
+	symbol, err := bs.adaptToSymbol(currencyPair, contractType)
+	return symbol, contractType, err
+}
+
+func (bs *BinanceFutures) GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	symbol, contractType, err := bs.positionSymbol(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.getLeverage(symbol, currencyPair, contractType)
+}
+
+func (bs *BinanceFutures) SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error {
+	symbol, _, err := bs.positionSymbol(currencyPair, contractType)
+	if err != nil {
+		return err
+	}
+	return bs.base.setLeverage(symbol, leverage)
+}
+
+func (bs *BinanceFutures) SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error {
+	symbol, _, err := bs.positionSymbol(currencyPair, contractType)
+	if err != nil {
+		return err
+	}
+	return bs.base.setMarginMode(symbol, mode)
+}
+
+func (bs *BinanceFutures) GetPositionMode(contractType string) (PositionMode, error) {
+	return bs.base.getPositionMode()
+}
+
+func (bs *BinanceFutures) SetPositionMode(contractType string, mode PositionMode) error {
+	return bs.base.setPositionMode(mode)
+}
+
+func (bs *BinanceFutures) AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error {
+	symbol, _, err := bs.positionSymbol(currencyPair, contractType)
+	if err != nil {
+		return err
+	}
+	return bs.base.adjustMargin(symbol, side, amount)
+}
+
+//币本位永续(SWAP_CONTRACT)走 dapi, 其余走 fapi
+
+func (bs *BinanceSwap) GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetLeverage(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT)
+	}
+	return bs.getLeverage(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), currencyPair, SWAP_USDT_CONTRACT)
+}
+
+func (bs *BinanceSwap) SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.SetLeverage(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT, leverage)
+	}
+	return bs.setLeverage(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), leverage)
+}
+
+func (bs *BinanceSwap) SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.SetMarginMode(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT, mode)
+	}
+	return bs.setMarginMode(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), mode)
+}
+
+func (bs *BinanceSwap) GetPositionMode(contractType string) (PositionMode, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetPositionMode(SWAP_CONTRACT)
+	}
+	return bs.getPositionMode()
+}
+
+func (bs *BinanceSwap) SetPositionMode(contractType string, mode PositionMode) error {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.SetPositionMode(SWAP_CONTRACT, mode)
+	}
+	return bs.setPositionMode(mode)
+}
+
+func (bs *BinanceSwap) AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.AdjustMargin(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT, side, amount)
+	}
+	return bs.adjustMargin(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), side, amount)
+}
@@ -0,0 +1,40 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinance_PositionSettings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/positionRisk":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","positionAmt":"0","leverage":"20","marginType":"isolated","positionSide":"BOTH"}]`))
+		case "/fapi/v1/marginType":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-4046,"msg":"No need to change margin type."}`))
+		case "/fapi/v1/positionSide/dual":
+			w.Write([]byte(`{"dualSidePosition":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	fapi := &Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV1: srv.URL + "/fapi/v1/"}
+
+	lev, err := fapi.getLeverage("BTCUSDT", goex.BTC_USDT, goex.SWAP_USDT_CONTRACT)
+	assert.Nil(t, err)
+	assert.Equal(t, goex.MARGIN_MODE_ISOLATED, lev.MarginMode)
+	assert.Equal(t, 20.0, lev.ShortLeverage)
+
+	assert.Nil(t, fapi.setMarginMode("BTCUSDT", goex.MARGIN_MODE_ISOLATED))
+
+	mode, err := fapi.getPositionMode()
+	assert.Nil(t, err)
+	assert.Equal(t, goex.POSITION_MODE_HEDGE, mode)
+}
@@ -0,0 +1,106 @@
+package binance
+
+import (
+	"math"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// positionRisk 不返回维持保证金, 需要从 account 接口的 positions 补充
+func (bn *Binance) getFuturePositionV2(symbol string, pair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	var (
+		risks   []PositionRiskResponse
+		account struct {
+			Positions []struct {
+				Symbol        string  `json:"symbol"`
+				PositionSide  string  `json:"positionSide"`
+				InitialMargin float64 `json:"initialMargin,string"`
+				MaintMargin   float64 `json:"maintMargin,string"`
+			} `json:"positions"`
+		}
+	)
+
+	err := bn.doSignedRequest("GET", "positionRisk", url.Values{}, &risks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bn.doSignedRequest("GET", "account", url.Values{}, &account)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []FuturePositionV2
+	for _, r := range risks {
+		if r.Symbol != symbol || r.PositionAmt == 0 {
+			continue
+		}
+
+		pos := FuturePositionV2{
+			Pair:             pair,
+			ContractType:     contractType,
+			ContractId:       r.Symbol,
+			Side:             adaptPositionSide(r.PositionSide),
+			Amount:           r.PositionAmt,
+			AvailAmount:      r.PositionAmt,
+			AvgPrice:         r.EntryPrice,
+			MarginMode:       MARGIN_MODE_CROSSED,
+			Leverage:         r.Leverage,
+			MarkPrice:        r.MarkPrice,
+			LiquidationPrice: r.LiquidationPrice,
+			Notional:         math.Abs(ToFloat64(r.Notional) + ToFloat64(r.NotionalValue)),
+			UnrealizedPnl:    r.UnRealizedProfit,
+			UpdateTime:       r.UpdateTime,
+		}
+
+		if strings.ToLower(r.MarginType) == "isolated" {
+			pos.MarginMode = MARGIN_MODE_ISOLATED
+			pos.InitialMargin = r.IsolatedMargin
+		}
+
+		//双向持仓时空仓数量为负
+		if pos.Side == POSITION_SIDE_SHORT {
+			pos.Amount = math.Abs(pos.Amount)
+			pos.AvailAmount = pos.Amount
+		}
+
+		for _, p := range account.Positions {
+			if p.Symbol == r.Symbol && p.PositionSide == r.PositionSide {
+				pos.InitialMargin = p.InitialMargin
+				pos.MaintMargin = p.MaintMargin
+				break
+			}
+		}
+
+		positions = append(positions, pos)
+	}
+
+	return positions, nil
+}
+
+func adaptPositionSide(positionSide string) PositionSide {
+	switch positionSide {
+	case "LONG":
+		return POSITION_SIDE_LONG
+	case "SHORT":
+		return POSITION_SIDE_SHORT
+	}
+	return POSITION_SIDE_BOTH
+}
+
+func (bs *BinanceFutures) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	symbol, contractType, err := bs.positionSymbol(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.getFuturePositionV2(symbol, currencyPair, contractType)
+}
+
+func (bs *BinanceSwap) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetFuturePositionV2(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT)
+	}
+	return bs.getFuturePositionV2(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), currencyPair, SWAP_USDT_CONTRACT)
+}
@@ -0,0 +1,42 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinance_GetFuturePositionV2(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/positionRisk":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","positionAmt":"-0.5","entryPrice":"30000","markPrice":"29000","unRealizedProfit":"500","liquidationPrice":"45000","leverage":"10","marginType":"cross","positionSide":"SHORT","notional":"-14500","updateTime":1600000000000},
+{"symbol":"BTCUSDT","positionAmt":"0","leverage":"10","marginType":"cross","positionSide":"LONG"},
+{"symbol":"ETHUSDT","positionAmt":"1","leverage":"10","marginType":"cross","positionSide":"LONG"}]`))
+		case "/fapi/v1/account":
+			w.Write([]byte(`{"positions":[{"symbol":"BTCUSDT","positionSide":"SHORT","initialMargin":"1450","maintMargin":"58"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	fapi := &Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV1: srv.URL + "/fapi/v1/"}
+
+	positions, err := fapi.getFuturePositionV2("BTCUSDT", goex.BTC_USDT, goex.SWAP_USDT_CONTRACT)
+	assert.Nil(t, err)
+	assert.Len(t, positions, 1)
+
+	pos := positions[0]
+	assert.Equal(t, goex.POSITION_SIDE_SHORT, pos.Side)
+	assert.Equal(t, 0.5, pos.Amount)
+	assert.Equal(t, -0.5, pos.NetAmount())
+	assert.Equal(t, goex.MARGIN_MODE_CROSSED, pos.MarginMode)
+	assert.Equal(t, 29000.0, pos.MarkPrice)
+	assert.Equal(t, 14500.0, pos.Notional)
+	assert.Equal(t, 1450.0, pos.InitialMargin)
+	assert.Equal(t, 58.0, pos.MaintMargin)
+}
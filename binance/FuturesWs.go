@@ -7,7 +7,6 @@ import (
 	"github.com/BTreeNewBee/goex/internal/logger"
 	"net/http"
 	"net/url"
-	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -23,27 +22,56 @@ type FuturesWs struct {
 	f         *goex.WsConn
 	d         *goex.WsConn
 
+	fWsUrl string //U本位
+	dWsUrl string //币本位
+
 	depthCallFn  func(depth *goex.Depth)
 	tickerCallFn func(ticker *goex.FutureTicker)
 	tradeCalFn   func(trade *goex.Trade, contract string)
 }
 
 func NewFuturesWs() *FuturesWs {
+	return NewFuturesWsWithEnv(goex.ENV_PRODUCTION)
+}
+
+// NewFuturesWsWithEnv env 为 ENV_TESTNET 时连接测试网
+func NewFuturesWsWithEnv(env goex.Environment) *FuturesWs {
+	return NewFuturesWsWithConfig(&goex.WsClientConfig{Env: env})
+}
+
+// NewFuturesWsWithConfig config.WsUrl 为U本位地址, 币本位地址将其中的 fstream 替换为 dstream
+func NewFuturesWsWithConfig(config *goex.WsClientConfig) *FuturesWs {
+	if config == nil {
+		config = &goex.WsClientConfig{}
+	}
 	futuresWs := new(FuturesWs)
+	futuresWs.fWsUrl = "wss://fstream.binance.com/ws"
+	futuresWs.dWsUrl = "wss://dstream.binance.com/ws"
+	if config.Env == goex.ENV_TESTNET {
+		futuresWs.fWsUrl = "wss://stream.binancefuture.com/ws"
+		futuresWs.dWsUrl = "wss://dstream.binancefuture.com/ws"
+	}
+	if config.WsUrl != "" {
+		futuresWs.fWsUrl = config.WsUrl
+		futuresWs.dWsUrl = strings.Replace(config.WsUrl, "fstream", "dstream", 1)
+	}
 
 	futuresWs.wsBuilder = goex.NewWsBuilder().
-		ProxyUrl(os.Getenv("HTTPS_PROXY")).
+		ClientConfig(config).
 		ProtoHandleFunc(futuresWs.handle).AutoReconnect()
 
+	//未设置代理时与 ws 连接一样使用环境变量中的代理
+	proxyUrl := config.ProxyUrl
+
 	httpCli := &http.Client{
 		Timeout: 10 * time.Second,
 	}
 
-	if os.Getenv("HTTPS_PROXY") != "" {
+	if proxyUrl != "" {
 		httpCli = &http.Client{
 			Transport: &http.Transport{
 				Proxy: func(r *http.Request) (*url.URL, error) {
-					return url.Parse(os.Getenv("HTTPS_PROXY"))
+					return url.Parse(proxyUrl)
 				},
 			},
 			Timeout: 10 * time.Second,
@@ -52,6 +80,7 @@ func NewFuturesWs() *FuturesWs {
 
 	futuresWs.base = NewBinanceFutures(&goex.APIConfig{
 		HttpClient: httpCli,
+		Env:        config.Env,
 	})
 
 	return futuresWs
@@ -59,13 +88,13 @@ func NewFuturesWs() *FuturesWs {
 
 func (s *FuturesWs) connectUsdtFutures() {
 	s.fOnce.Do(func() {
-		s.f = s.wsBuilder.WsUrl("wss://fstream.binance.com/ws").Build()
+		s.f = s.wsBuilder.WsUrl(s.fWsUrl).Build()
 	})
 }
 
 func (s *FuturesWs) connectFutures() {
 	s.dOnce.Do(func() {
-		s.d = s.wsBuilder.WsUrl("wss://dstream.binance.com/ws").Build()
+		s.d = s.wsBuilder.WsUrl(s.dWsUrl).Build()
 	})
 }
 
@@ -0,0 +1,110 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+type myTradeResponse struct {
+	Id              int64   `json:"id"`
+	OrderId         int64   `json:"orderId"`
+	Price           float64 `json:"price,string"`
+	Qty             float64 `json:"qty,string"`
+	Commission      float64 `json:"commission,string"`
+	CommissionAsset string  `json:"commissionAsset"`
+	Time            int64   `json:"time"`
+	IsBuyer         bool    `json:"isBuyer"`
+	IsMaker         bool    `json:"isMaker"`
+	Side            string  `json:"side"`  //合约
+	Maker           bool    `json:"maker"` //合约
+}
+
+func (t myTradeResponse) toFill(pair CurrencyPair, contractType string) Fill {
+	fill := Fill{
+		TradeId:      fmt.Sprint(t.Id),
+		OrderId:      fmt.Sprint(t.OrderId),
+		Pair:         pair,
+		ContractName: contractType,
+		Side:         SELL,
+		Price:        t.Price,
+		Amount:       t.Qty,
+		Fee:          t.Commission,
+		FeeCurrency:  t.CommissionAsset,
+		IsMaker:      t.IsMaker || t.Maker,
+		Time:         t.Time,
+	}
+	if t.IsBuyer || t.Side == "BUY" {
+		fill.Side = BUY
+	}
+	return fill
+}
+
+func myTradesParams(symbol string, since int64, limit int) url.Values {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	if since > 0 {
+		params.Set("startTime", fmt.Sprint(since))
+	}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(limit))
+	}
+	return params
+}
+
+func (bn *Binance) GetMyTrades(pair CurrencyPair, since int64, limit int) ([]Fill, error) {
+	params := myTradesParams(pair.ToSymbol(""), since, limit)
+	bn.buildParamsSigned(&params)
+
+	resp, err := HttpGet5(bn.httpClient, bn.apiV3+"myTrades?"+params.Encode(),
+		map[string]string{"X-MBX-APIKEY": bn.accessKey})
+	if err != nil {
+		return nil, bn.adaptError(err)
+	}
+
+	logger.Debug(string(resp))
+
+	var trades []myTradeResponse
+	err = json.Unmarshal(resp, &trades)
+	if err != nil {
+		return nil, err
+	}
+
+	fills := make([]Fill, 0, len(trades))
+	for _, t := range trades {
+		fills = append(fills, t.toFill(pair, ""))
+	}
+	return fills, nil
+}
+
+func (bn *Binance) getFuturesFills(symbol string, pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	var trades []myTradeResponse
+	err := bn.doSignedRequest("GET", "userTrades", myTradesParams(symbol, since, limit), &trades)
+	if err != nil {
+		return nil, bn.adaptError(err)
+	}
+
+	fills := make([]Fill, 0, len(trades))
+	for _, t := range trades {
+		fills = append(fills, t.toFill(pair, contractType))
+	}
+	return fills, nil
+}
+
+func (bs *BinanceFutures) GetFutureFills(pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	symbol, err := bs.adaptToSymbol(pair, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.getFuturesFills(symbol, pair, contractType, since, limit)
+}
+
+func (bs *BinanceSwap) GetFutureFills(pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetFutureFills(pair.AdaptUsdtToUsd(), contractType, since, limit)
+	}
+	return bs.getFuturesFills(bs.adaptCurrencyPair(pair).ToSymbol(""), pair, contractType, since, limit)
+}
@@ -0,0 +1,45 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinance_GetMyTrades(t *testing.T) {
+	var query map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v3/myTrades":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","id":28457,"orderId":100234,"price":"4.00000100","qty":"12.00000000","commission":"10.10000000","commissionAsset":"BNB","time":1499865549590,"isBuyer":true,"isMaker":false}]`))
+		case "GET /fapi/v1/userTrades":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","id":698759,"orderId":25851813,"side":"SELL","price":"7819.01","qty":"0.002","commission":"-0.01","commissionAsset":"USDT","time":1569514978020,"maker":true}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	bn := &Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV3: srv.URL + "/api/v3/", apiV1: srv.URL + "/fapi/v1/"}
+
+	fills, err := bn.GetMyTrades(goex.BTC_USDT, 1499865549000, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1499865549000"}, query["startTime"])
+	assert.Len(t, fills, 1)
+	assert.Equal(t, "28457", fills[0].TradeId)
+	assert.Equal(t, goex.BUY, fills[0].Side)
+	assert.Equal(t, 10.1, fills[0].Fee)
+	assert.False(t, fills[0].IsMaker)
+
+	fills, err = bn.getFuturesFills("BTCUSDT", goex.BTC_USDT, goex.SWAP_USDT_CONTRACT, 0, 0)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 1)
+	assert.Equal(t, goex.SELL, fills[0].Side)
+	assert.Equal(t, -0.01, fills[0].Fee)
+	assert.True(t, fills[0].IsMaker)
+	assert.Equal(t, goex.SWAP_USDT_CONTRACT, fills[0].ContractName)
+}
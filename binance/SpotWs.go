@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/BTreeNewBee/goex"
 	"github.com/BTreeNewBee/goex/internal/logger"
-	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -42,12 +41,30 @@ type SpotWs struct {
 }
 
 func NewSpotWs() *SpotWs {
+	return NewSpotWsWithEnv(goex.ENV_PRODUCTION)
+}
+
+// NewSpotWsWithEnv env 为 ENV_TESTNET 时连接测试网
+func NewSpotWsWithEnv(env goex.Environment) *SpotWs {
+	return NewSpotWsWithConfig(&goex.WsClientConfig{Env: env})
+}
+
+// NewSpotWsWithConfig 按 config 设置连接地址、代理等, config 为 nil 时使用默认配置
+// config.ProxyUrl 为空时由 ws 连接按 http.ProxyFromEnvironment 选择代理
+func NewSpotWsWithConfig(config *goex.WsClientConfig) *SpotWs {
+	if config == nil {
+		config = &goex.WsClientConfig{}
+	}
 	spotWs := &SpotWs{}
-	logger.Debugf("proxy url: %s", os.Getenv("HTTPS_PROXY"))
+
+	wsUrl := "wss://stream.binance.com:9443/stream?streams=depth/miniTicker/ticker/trade"
+	if config.Env == goex.ENV_TESTNET {
+		wsUrl = "wss://testnet.binance.vision/stream?streams=depth/miniTicker/ticker/trade"
+	}
 
 	spotWs.wsBuilder = goex.NewWsBuilder().
-		WsUrl("wss://stream.binance.com:9443/stream?streams=depth/miniTicker/ticker/trade").
-		ProxyUrl(os.Getenv("HTTPS_PROXY")).
+		WsUrl(wsUrl).
+		ClientConfig(config).
 		ProtoHandleFunc(spotWs.handle).AutoReconnect()
 
 	spotWs.reqId = 1
@@ -0,0 +1,226 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// fapi 与 dapi 的行情接口只有前缀不同, 交割合约的 lastFundingRate 为空字符串
+type premiumIndexResponse struct {
+	Symbol          string `json:"symbol"`
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	LastFundingRate string `json:"lastFundingRate"`
+	NextFundingTime int64  `json:"nextFundingTime"`
+	Time            int64  `json:"time"`
+}
+
+func (bn *Binance) getPremiumIndex(symbol string) (*premiumIndexResponse, error) {
+	resp, err := HttpGet5(bn.httpClient, bn.apiV1+"premiumIndex?symbol="+symbol, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	//dapi 返回数组, fapi 返回对象
+	var ret []premiumIndexResponse
+	if len(resp) > 0 && resp[0] == '[' {
+		err = json.Unmarshal(resp, &ret)
+	} else {
+		var r premiumIndexResponse
+		err = json.Unmarshal(resp, &r)
+		ret = append(ret, r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		if ret[i].Symbol == symbol {
+			return &ret[i], nil
+		}
+	}
+
+	return nil, errors.New("premium index not found: " + symbol)
+}
+
+func (bn *Binance) getFundingRate(symbol string, pair CurrencyPair, contractType string) (*FundingRate, error) {
+	premium, err := bn.getPremiumIndex(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FundingRate{
+		Pair:         pair,
+		ContractType: contractType,
+		FundingRate:  ToFloat64(premium.LastFundingRate),
+		FundingTime:  time.Unix(0, premium.NextFundingTime*int64(time.Millisecond)),
+	}, nil
+}
+
+func (bn *Binance) getFundingRateHistory(symbol string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	if size > 0 {
+		params.Set("limit", fmt.Sprint(size))
+	}
+	MergeOptionalParameter(&params, opt...)
+
+	resp, err := HttpGet5(bn.httpClient, bn.apiV1+"fundingRate?"+params.Encode(), map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []struct {
+		Symbol      string  `json:"symbol"`
+		FundingRate float64 `json:"fundingRate,string"`
+		FundingTime int64   `json:"fundingTime"`
+	}
+	err = json.Unmarshal(resp, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []HistoricalFunding
+	for _, r := range ret {
+		history = append(history, HistoricalFunding{
+			InstrumentId: r.Symbol,
+			FundingRate:  r.FundingRate,
+			RealizedRate: r.FundingRate,
+			FundingTime:  time.Unix(0, r.FundingTime*int64(time.Millisecond)),
+		})
+	}
+
+	//binance 按时间正序返回
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].FundingTime.After(history[j].FundingTime)
+	})
+
+	return history, nil
+}
+
+func (bn *Binance) getOpenInterest(symbol string, pair CurrencyPair, contractType string) (*OpenInterest, error) {
+	var ret struct {
+		Symbol       string  `json:"symbol"`
+		OpenInterest float64 `json:"openInterest,string"`
+		Time         int64   `json:"time"`
+	}
+
+	err := HttpGet4(bn.httpClient, bn.apiV1+"openInterest?symbol="+symbol, map[string]string{}, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenInterest{
+		Pair:         pair,
+		ContractType: contractType,
+		Amount:       ret.OpenInterest,
+		Timestamp:    ret.Time,
+	}, nil
+}
+
+func (bs *BinanceFutures) marketDataSymbol(currencyPair CurrencyPair, contractType string) (string, string, error) {
+	if contractType == "" {
+		contractType = SWAP_CONTRACT
+	}
+	symbol, err := bs.adaptToSymbol(currencyPair, contractType)
+	return symbol, contractType, err
+}
+
+func (bs *BinanceFutures) GetFundingRate(currencyPair CurrencyPair, contractType string) (*FundingRate, error) {
+	symbol, contractType, err := bs.marketDataSymbol(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.getFundingRate(symbol, currencyPair, contractType)
+}
+
+func (bs *BinanceFutures) GetFundingRateHistory(currencyPair CurrencyPair, contractType string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	symbol, _, err := bs.marketDataSymbol(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.getFundingRateHistory(symbol, size, opt...)
+}
+
+func (bs *BinanceFutures) GetMarkPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	symbol, _, err := bs.marketDataSymbol(currencyPair, contractType)
+	if err != nil {
+		return 0, err
+	}
+	premium, err := bs.base.getPremiumIndex(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return ToFloat64(premium.MarkPrice), nil
+}
+
+func (bs *BinanceFutures) GetIndexPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	symbol, _, err := bs.marketDataSymbol(currencyPair, contractType)
+	if err != nil {
+		return 0, err
+	}
+	premium, err := bs.base.getPremiumIndex(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return ToFloat64(premium.IndexPrice), nil
+}
+
+func (bs *BinanceFutures) GetOpenInterest(currencyPair CurrencyPair, contractType string) (*OpenInterest, error) {
+	symbol, contractType, err := bs.marketDataSymbol(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.getOpenInterest(symbol, currencyPair, contractType)
+}
+
+//币本位永续(SWAP_CONTRACT)走 dapi, 其余走 fapi
+
+func (bs *BinanceSwap) GetFundingRate(currencyPair CurrencyPair, contractType string) (*FundingRate, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetFundingRate(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT)
+	}
+	return bs.getFundingRate(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), currencyPair, SWAP_USDT_CONTRACT)
+}
+
+func (bs *BinanceSwap) GetFundingRateHistory(currencyPair CurrencyPair, contractType string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetFundingRateHistory(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT, size, opt...)
+	}
+	return bs.getFundingRateHistory(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), size, opt...)
+}
+
+func (bs *BinanceSwap) GetMarkPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetMarkPrice(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT)
+	}
+	premium, err := bs.getPremiumIndex(bs.adaptCurrencyPair(currencyPair).ToSymbol(""))
+	if err != nil {
+		return 0, err
+	}
+	return ToFloat64(premium.MarkPrice), nil
+}
+
+func (bs *BinanceSwap) GetIndexPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetIndexPrice(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT)
+	}
+	premium, err := bs.getPremiumIndex(bs.adaptCurrencyPair(currencyPair).ToSymbol(""))
+	if err != nil {
+		return 0, err
+	}
+	return ToFloat64(premium.IndexPrice), nil
+}
+
+func (bs *BinanceSwap) GetOpenInterest(currencyPair CurrencyPair, contractType string) (*OpenInterest, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetOpenInterest(currencyPair.AdaptUsdtToUsd(), SWAP_CONTRACT)
+	}
+	return bs.getOpenInterest(bs.adaptCurrencyPair(currencyPair).ToSymbol(""), currencyPair, SWAP_USDT_CONTRACT)
+}
@@ -0,0 +1,53 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMarketDataTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"11793.6","indexPrice":"11781.8","lastFundingRate":"0.00038","nextFundingTime":1597392000000,"time":1597370495002}`))
+		case "/dapi/v1/premiumIndex":
+			w.Write([]byte(`[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","markPrice":"11029.7","indexPrice":"11025.6","lastFundingRate":"0.0001","nextFundingTime":1596096000000,"time":1596094042000}]`))
+		case "/fapi/v1/fundingRate":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","fundingRate":"-0.0003","fundingTime":1570608000000},{"symbol":"BTCUSDT","fundingRate":"0.0001","fundingTime":1570636800000}]`))
+		case "/fapi/v1/openInterest":
+			w.Write([]byte(`{"openInterest":"10659.509","symbol":"BTCUSDT","time":1589437530011}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestBinance_SwapMarketData(t *testing.T) {
+	srv := newMarketDataTestServer()
+	defer srv.Close()
+
+	fapi := &Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV1: srv.URL + "/fapi/v1/"}
+	dapi := &Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV1: srv.URL + "/dapi/v1/"}
+
+	rate, err := fapi.getFundingRate("BTCUSDT", goex.BTC_USDT, goex.SWAP_USDT_CONTRACT)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.00038, rate.FundingRate)
+	assert.Equal(t, int64(1597392000000), rate.FundingTime.UnixNano()/1e6)
+
+	premium, err := dapi.getPremiumIndex("BTCUSD_PERP")
+	assert.Nil(t, err)
+	assert.Equal(t, 11025.6, goex.ToFloat64(premium.IndexPrice))
+
+	history, err := fapi.getFundingRateHistory("BTCUSDT", 2)
+	assert.Nil(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, 0.0001, history[0].FundingRate)
+
+	oi, err := fapi.getOpenInterest("BTCUSDT", goex.BTC_USDT, goex.SWAP_USDT_CONTRACT)
+	assert.Nil(t, err)
+	assert.Equal(t, 10659.509, oi.Amount)
+}
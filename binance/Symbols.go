@@ -0,0 +1,52 @@
+package binance
+
+import (
+	"encoding/json"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// Symbols symbol 与统一交易对的转换, 现货使用 /api/v3/exchangeInfo, BinanceSwap 使用 /fapi/v1/exchangeInfo
+func (bn *Binance) Symbols() *SymbolMapper {
+	return bn.symbols
+}
+
+func (bn *Binance) loadSpotMarkets() ([]Market, error) {
+	info, err := bn.GetExchangeInfo()
+	if err != nil {
+		return nil, err
+	}
+	markets := make([]Market, 0, len(info.Symbols))
+	for _, s := range info.Symbols {
+		markets = append(markets, Market{Symbol: s.Symbol, BaseCurrency: s.BaseAsset, QuoteCurrency: s.QuoteAsset})
+	}
+	return markets, nil
+}
+
+// loadSwapMarkets U本位永续合约, 交割合约由 BinanceFutures 处理
+func (bs *BinanceSwap) loadSwapMarkets() ([]Market, error) {
+	resp, err := HttpGet5(bs.httpClient, bs.apiV1+"exchangeInfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info struct {
+		Symbols []struct {
+			Symbol       string `json:"symbol"`
+			ContractType string `json:"contractType"`
+			BaseAsset    string `json:"baseAsset"`
+			QuoteAsset   string `json:"quoteAsset"`
+		} `json:"symbols"`
+	}
+	if err = json.Unmarshal(resp, &info); err != nil {
+		return nil, err
+	}
+
+	var markets []Market
+	for _, s := range info.Symbols {
+		if s.ContractType != "PERPETUAL" {
+			continue
+		}
+		markets = append(markets, Market{Symbol: s.Symbol, BaseCurrency: s.BaseAsset, QuoteCurrency: s.QuoteAsset, ContractType: SWAP_USDT_CONTRACT})
+	}
+	return markets, nil
+}
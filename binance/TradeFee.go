@@ -0,0 +1,99 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+// 开启 BNB 抵扣后现货手续费 75 折
+const bnbSpotDiscount = 0.75
+
+func (bn *Binance) sapiGet(uri string, params url.Values, response interface{}) error {
+	bn.buildParamsSigned(&params)
+	resp, err := HttpGet5(bn.httpClient, bn.baseUrl+"/sapi/v1/"+uri+"?"+params.Encode(),
+		map[string]string{"X-MBX-APIKEY": bn.accessKey})
+	if err != nil {
+		return bn.adaptError(err)
+	}
+	logger.Debug(string(resp))
+	return json.Unmarshal(resp, response)
+}
+
+func (bn *Binance) GetFeeRate(pair CurrencyPair) (*FeeRate, error) {
+	symbol := pair.ToSymbol("")
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	var fees []struct {
+		Symbol          string  `json:"symbol"`
+		MakerCommission float64 `json:"makerCommission,string"`
+		TakerCommission float64 `json:"takerCommission,string"`
+	}
+	err := bn.sapiGet("asset/tradeFee", params, &fees)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fees {
+		if f.Symbol != symbol {
+			continue
+		}
+
+		rate := &FeeRate{Pair: pair, Maker: f.MakerCommission, Taker: f.TakerCommission}
+
+		var burn struct {
+			SpotBNBBurn bool `json:"spotBNBBurn"`
+		}
+		//查询失败不影响费率
+		if err = bn.sapiGet("bnbBurn", url.Values{}, &burn); err != nil {
+			logger.Warn("[binance] get bnb burn status error:", err)
+		} else if burn.SpotBNBBurn {
+			rate.Discount = bnbSpotDiscount
+			rate.DiscountCurrency = BNB.Symbol
+		}
+
+		return rate, nil
+	}
+
+	return nil, errors.New("not found the fee rate of " + symbol)
+}
+
+func (bn *Binance) getFuturesFeeRate(symbol string, pair CurrencyPair, contractType string) (*FeeRate, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	var resp struct {
+		MakerCommissionRate float64 `json:"makerCommissionRate,string"`
+		TakerCommissionRate float64 `json:"takerCommissionRate,string"`
+	}
+	err := bn.doSignedRequest("GET", "commissionRate", params, &resp)
+	if err != nil {
+		return nil, bn.adaptError(err)
+	}
+
+	return &FeeRate{
+		Pair:         pair,
+		ContractType: contractType,
+		Maker:        resp.MakerCommissionRate,
+		Taker:        resp.TakerCommissionRate,
+	}, nil
+}
+
+func (bs *BinanceFutures) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	symbol, err := bs.adaptToSymbol(pair, contractType)
+	if err != nil {
+		return nil, err
+	}
+	return bs.base.getFuturesFeeRate(symbol, pair, contractType)
+}
+
+func (bs *BinanceSwap) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	if contractType == SWAP_CONTRACT {
+		return bs.f.GetFutureFeeRate(pair.AdaptUsdtToUsd(), contractType)
+	}
+	return bs.getFuturesFeeRate(bs.adaptCurrencyPair(pair).ToSymbol(""), pair, contractType)
+}
@@ -0,0 +1,27 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goex "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinanceSwap_GetFee(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/commissionRate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "BTCUSDT", r.URL.Query().Get("symbol"))
+		w.Write([]byte(`{"symbol":"BTCUSDT","makerCommissionRate":"0.0002","takerCommissionRate":"0.0004"}`))
+	}))
+	defer srv.Close()
+
+	bs := &BinanceSwap{Binance: Binance{httpClient: http.DefaultClient, clock: goex.LocalClock, apiV1: srv.URL + "/fapi/v1/"}}
+	fee, err := bs.GetFee()
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0004, fee)
+}
@@ -0,0 +1,58 @@
+package bitfinex
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// currencyAliases bitfinex 使用 3 位的币种简称
+var currencyAliases = map[string]string{
+	"UST": "USDT",
+	"DSH": "DASH",
+	"IOT": "IOTA",
+	"QTM": "QTUM",
+	"MNA": "MANA",
+	"DAT": "DATA",
+	"QSH": "QASH",
+	"YYW": "YOYOW",
+}
+
+// Symbols symbol 与统一交易对的转换, 使用 /v2/conf/pub:list:pair:exchange
+func (bfx *Bitfinex) Symbols() *SymbolMapper {
+	return bfx.symbols
+}
+
+func (bfx *Bitfinex) loadMarkets() ([]Market, error) {
+	resp, err := HttpGet5(bfx.httpClient, apiURLV2+"/conf/pub:list:pair:exchange", nil)
+	if err != nil {
+		return nil, err
+	}
+	var lists [][]string
+	if err = json.Unmarshal(resp, &lists); err != nil {
+		return nil, err
+	}
+	if len(lists) == 0 {
+		return nil, nil
+	}
+
+	markets := make([]Market, 0, len(lists[0]))
+	for _, symbol := range lists[0] {
+		base, quote := splitSymbol(symbol)
+		markets = append(markets, Market{Symbol: symbol, BaseCurrency: base, QuoteCurrency: quote})
+	}
+	return markets, nil
+}
+
+// splitSymbol 6 位的 symbol 按 3+3 拆分, 更长的币种使用 : 分隔, 比如: TESTBTC:TESTUSD
+func splitSymbol(symbol string) (base, quote string) {
+	symbol = strings.ToUpper(symbol)
+	if i := strings.Index(symbol, ":"); i >= 0 {
+		return symbol[:i], symbol[i+1:]
+	}
+	if len(symbol) < 6 {
+		return symbol, ""
+	}
+	return symbol[:3], symbol[3:]
+}
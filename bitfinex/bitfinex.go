@@ -16,6 +16,7 @@ type Bitfinex struct {
 	httpClient *http.Client
 	accessKey,
 	secretKey string
+	symbols *SymbolMapper
 }
 
 const (
@@ -25,13 +26,33 @@ const (
 )
 
 func New(client *http.Client, accessKey, secretKey string) *Bitfinex {
-	return &Bitfinex{client, accessKey, secretKey}
+	bfx := &Bitfinex{httpClient: client, accessKey: accessKey, secretKey: secretKey}
+	bfx.symbols = NewSymbolMapper(bfx.loadMarkets, currencyAliases)
+	return bfx
 }
 
 func (bfx *Bitfinex) GetExchangeName() string {
 	return BITFINEX
 }
 
+func (bfx *Bitfinex) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	markets, err := bfx.symbols.Markets()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]CurrencyPair, 0, len(markets))
+	for _, m := range markets {
+		pairs = append(pairs, m.Pair)
+	}
+	return pairs, nil
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (bfx *Bitfinex) GetTimestamp() (int64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
+}
+
 func (bfx *Bitfinex) GetTicker(currencyPair CurrencyPair) (*Ticker, error) {
 	//pubticker
 	currencyPair = bfx.adaptCurrencyPair(currencyPair)
@@ -256,7 +277,7 @@ func (bfx *Bitfinex) CancelOrder(orderId string, currencyPair CurrencyPair) (boo
 
 func (bfx *Bitfinex) toOrder(respmap map[string]interface{}) *Order {
 	order := new(Order)
-	order.Currency = symbolToCurrencyPair(respmap["symbol"].(string))
+	order.Currency = bfx.symbolToCurrencyPair(respmap["symbol"].(string))
 	order.OrderID = ToInt(respmap["id"])
 	order.OrderID2 = fmt.Sprint(ToInt(respmap["id"]))
 	order.Amount = ToFloat64(respmap["original_amount"])
@@ -379,10 +400,24 @@ func (bfx *Bitfinex) adaptCurrencyPair(pair CurrencyPair) CurrencyPair {
 	return NewCurrencyPair(currencyA, currencyB)
 }
 
+// symbolToCurrencyPair 优先使用市场列表, 加载失败时按 symbol 拆分
+func (bfx *Bitfinex) symbolToCurrencyPair(symbol string) CurrencyPair {
+	if pair, err := bfx.symbols.CurrencyPair(symbol); err == nil {
+		return pair
+	}
+	return symbolToCurrencyPair(symbol)
+}
+
 func symbolToCurrencyPair(symbol string) CurrencyPair {
-	currencyA := strings.ToUpper(symbol[0:3])
-	currencyB := strings.ToUpper(symbol[3:])
-	return NewCurrencyPair(NewCurrency(currencyA, ""), NewCurrency(currencyB, ""))
+	base, quote := splitSymbol(symbol)
+	return NewCurrencyPair(adaptCurrency(base), adaptCurrency(quote))
+}
+
+func adaptCurrency(symbol string) Currency {
+	if c, ok := currencyAliases[symbol]; ok {
+		return NewCurrency(c, "")
+	}
+	return NewCurrency(symbol, "")
 }
 
 var klinePeriods = map[KlinePeriod]string{
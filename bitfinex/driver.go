@@ -0,0 +1,13 @@
+package bitfinex
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(BITFINEX, Driver{
+		Spot: func(config *APIConfig) API {
+			return New(config.HttpClient, config.ApiKey, config.ApiSecretKey)
+		},
+	})
+}
@@ -21,7 +21,7 @@ type BitgetSwap struct {
 	passphrase string
 	baseUrl    string
 	httpClient *http.Client
-	timeOffset int64
+	clock      Clock
 }
 
 func NewSwap(config *APIConfig) *BitgetSwap {
@@ -35,7 +35,7 @@ func NewSwap(config *APIConfig) *BitgetSwap {
 		passphrase: config.ApiPassphrase,
 		httpClient: config.HttpClient,
 	}
-	bs.setTimeOffset()
+	bs.clock = config.GetClock(bs.baseUrl+"/api/swap/v3/market/time", bs.GetServerTime)
 	return bs
 }
 
@@ -47,16 +47,6 @@ func (bs *BitgetSwap) GetExchangeName() string {
 	return BITGET_SWAP
 }
 
-func (bs *BitgetSwap) setTimeOffset() error {
-	stime, err := bs.GetServerTime()
-	if err != nil {
-		return err
-	}
-	lt := time.Now().UnixNano() / 1000000
-	bs.timeOffset = lt - stime
-	return nil
-}
-
 /**
  *获取交割预估价
  */
@@ -121,7 +111,7 @@ func (bs *BitgetSwap) GetFutureIndex(currencyPair CurrencyPair) (float64, error)
 }
 
 func (bs *BitgetSwap) doAuthRequest(method, uri string, param map[string]interface{}) ([]byte, error) {
-	timestamp := time.Now().Unix() * 1000
+	timestamp := bs.clock.Now().UnixNano() / int64(time.Millisecond)
 	headers := make(map[string]string)
 	headers["Content-Type"] = "application/json"
 	headers["ACCESS-KEY"] = bs.accessKey
@@ -390,6 +380,70 @@ func (bs *BitgetSwap) GetFuturePosition(currencyPair CurrencyPair, contractType
 	return positions, nil
 }
 
+func (bs *BitgetSwap) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	symbol := bs.adaptSymbol(currencyPair)
+
+	resp, err := bs.doAuthRequest(http.MethodGet, "/api/swap/v3/position/singlePosition?symbol="+symbol, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pos struct {
+		Holding []struct {
+			AvailPosition    float64 `json:"avail_position,string"`
+			AvgCost          float64 `json:"avg_cost,string"`
+			Leverage         float64 `json:"leverage,string"`
+			LiquidationPrice float64 `json:"liquidation_price,string"`
+			Margin           string  `json:"margin"`
+			Position         float64 `json:"position,string"`
+			RealizedPnl      float64 `json:"realized_pnl,string"`
+			Side             string  `json:"side"`
+			Symbol           string  `json:"symbol"`
+			Timestamp        string  `json:"timestamp"`
+		} `json:"holding"`
+		MarginMode string `json:"margin_mode"`
+	}
+	err = json.Unmarshal(resp, &pos)
+	if err != nil {
+		return nil, err
+	}
+
+	marginMode := MARGIN_MODE_CROSSED
+	if pos.MarginMode == "fixed" {
+		marginMode = MARGIN_MODE_ISOLATED
+	}
+
+	var positions []FuturePositionV2
+	for _, info := range pos.Holding {
+		if info.Symbol != symbol || info.Position == 0 {
+			continue
+		}
+
+		side := POSITION_SIDE_LONG
+		if info.Side != "long" {
+			side = POSITION_SIDE_SHORT
+		}
+
+		positions = append(positions, FuturePositionV2{
+			Pair:             currencyPair,
+			ContractType:     contractType,
+			ContractId:       info.Symbol,
+			Side:             side,
+			Amount:           info.Position,
+			AvailAmount:      info.AvailPosition,
+			AvgPrice:         info.AvgCost,
+			MarginMode:       marginMode,
+			Leverage:         info.Leverage,
+			InitialMargin:    ToFloat64(info.Margin),
+			LiquidationPrice: info.LiquidationPrice,
+			RealizedPnl:      info.RealizedPnl,
+			UpdateTime:       ToInt64(info.Timestamp),
+		})
+	}
+
+	return positions, nil
+}
+
 /**
 *获取订单信息
  */
@@ -499,8 +553,12 @@ func (bs *BitgetSwap) GetUnfinishFutureOrders(currencyPair CurrencyPair, contrac
 /**
 *获取交易费
  */
+func (bs *BitgetSwap) GetFutureOrderHistory(pair CurrencyPair, contractType string, optional ...OptionalParameter) ([]FutureOrder, error) {
+	return nil, EX_ERR_NOT_SUPPORT
+}
+
 func (bs *BitgetSwap) GetFee() (float64, error) {
-	panic("not supported.")
+	return 0, EX_ERR_NOT_SUPPORT
 }
 
 /**
@@ -520,8 +578,8 @@ func (bs *BitgetSwap) GetDeliveryTime() (int, int, int, int) {
 /**
 * 获取K线数据
  */
-func (bs *BitgetSwap) GetKlineRecords(contractType string, currency CurrencyPair, period, size, since int) ([]FutureKline, error) {
-	panic("not supported.")
+func (bs *BitgetSwap) GetKlineRecords(contractType string, currency CurrencyPair, period KlinePeriod, size int, optional ...OptionalParameter) ([]FutureKline, error) {
+	return nil, EX_ERR_NOT_SUPPORT
 }
 
 func (bs *BitgetSwap) GetServerTime() (int64, error) {
@@ -0,0 +1,92 @@
+package bitget
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+func (bs *BitgetSwap) GetFundingRate(currencyPair CurrencyPair, contractType string) (*FundingRate, error) {
+	symbol := bs.adaptSymbol(currencyPair)
+
+	rateMap, err := HttpGet(bs.httpClient, fmt.Sprintf("%s/api/swap/v3/market/current_fundRate?symbol=%s", bs.baseUrl, symbol))
+	if err != nil {
+		return nil, err
+	}
+
+	timeMap, err := HttpGet(bs.httpClient, fmt.Sprintf("%s/api/swap/v3/market/funding_time?symbol=%s", bs.baseUrl, symbol))
+	if err != nil {
+		return nil, err
+	}
+
+	return &FundingRate{
+		Pair:         currencyPair,
+		ContractType: contractType,
+		FundingRate:  ToFloat64(rateMap["fundingRate"]),
+		FundingTime:  msToTime(ToInt64(timeMap["fundingTime"])),
+	}, nil
+}
+
+func (bs *BitgetSwap) GetFundingRateHistory(currencyPair CurrencyPair, contractType string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	params := url.Values{}
+	params.Set("symbol", bs.adaptSymbol(currencyPair))
+	params.Set("pageIndex", "1")
+	if size > 0 {
+		params.Set("pageSize", fmt.Sprint(size))
+	}
+	MergeOptionalParameter(&params, opt...)
+
+	resp, err := HttpGet3(bs.httpClient, fmt.Sprintf("%s/api/swap/v3/market/historical_funding_rate?%s", bs.baseUrl, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []HistoricalFunding
+	for _, v := range resp {
+		m := v.(map[string]interface{})
+		history = append(history, HistoricalFunding{
+			InstrumentId: fmt.Sprint(m["symbol"]),
+			FundingRate:  ToFloat64(m["fundingRate"]),
+			RealizedRate: ToFloat64(m["fundingRate"]),
+			FundingTime:  msToTime(ToInt64(m["settleTime"])),
+		})
+	}
+
+	return history, nil
+}
+
+func (bs *BitgetSwap) GetMarkPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	respmap, err := HttpGet(bs.httpClient, fmt.Sprintf("%s/api/swap/v3/market/mark_price?symbol=%s", bs.baseUrl, bs.adaptSymbol(currencyPair)))
+	if err != nil {
+		return 0, err
+	}
+	return ToFloat64(respmap["mark_price"]), nil
+}
+
+func (bs *BitgetSwap) GetIndexPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	respmap, err := HttpGet(bs.httpClient, fmt.Sprintf("%s/api/swap/v3/market/index?symbol=%s", bs.baseUrl, bs.adaptSymbol(currencyPair)))
+	if err != nil {
+		return 0, err
+	}
+	return ToFloat64(respmap["index"]), nil
+}
+
+func (bs *BitgetSwap) GetOpenInterest(currencyPair CurrencyPair, contractType string) (*OpenInterest, error) {
+	respmap, err := HttpGet(bs.httpClient, fmt.Sprintf("%s/api/swap/v3/market/open_interest?symbol=%s", bs.baseUrl, bs.adaptSymbol(currencyPair)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenInterest{
+		Pair:         currencyPair,
+		ContractType: contractType,
+		Amount:       ToFloat64(respmap["amount"]),
+		Timestamp:    ToInt64(respmap["timestamp"]),
+	}, nil
+}
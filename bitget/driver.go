@@ -0,0 +1,13 @@
+package bitget
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(BITGET_SWAP, Driver{
+		Future: func(config *APIConfig) FutureRestAPI {
+			return NewSwap(config)
+		},
+	})
+}
@@ -0,0 +1,261 @@
+package bitmex
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+const bitmexBatchLimit = 100 //bulk 接口单次请求的订单数量, 按订单数计算限频
+
+type bitmexAmendParam struct {
+	OrderID     string  `json:"orderID,omitempty"`
+	OrigClOrdID string  `json:"origClOrdID,omitempty"`
+	Price       float64 `json:"price,omitempty"`
+	OrderQty    int     `json:"orderQty,omitempty"`
+}
+
+// buildOrderParameter 限价单参数, 平仓单带 Close 执行指令, 只减仓
+func (bm *Bitmex) buildOrderParameter(ord *FutureOrder) (BitmexOrder, error) {
+	if ord.ClientOid == "" {
+		ord.ClientOid = GenerateOrderClientId(32)
+	}
+
+	param := BitmexOrder{
+		Text:        "github.com/BTreeNewBee/goex/tree/master/bitmex",
+		Symbol:      bm.adaptCurrencyPairToSymbol(ord.Currency, ord.ContractName),
+		ClOrdID:     ord.ClientOid,
+		OrdType:     "Limit",
+		TimeInForce: "GoodTillCancel",
+		Price:       ord.Price,
+		OrderQty:    int(ord.Amount),
+	}
+
+	var execInst []string
+	switch ord.OrderType {
+	case ORDER_FEATURE_POST_ONLY:
+		execInst = append(execInst, "ParticipateDoNotInitiate")
+	case ORDER_FEATURE_FOK:
+		param.TimeInForce = "FillOrKill"
+	case ORDER_FEATURE_IOC:
+		param.TimeInForce = "ImmediateOrCancel"
+	}
+
+	switch ord.OType {
+	case OPEN_BUY:
+		param.Side = "Buy"
+	case OPEN_SELL:
+		param.Side = "Sell"
+	case CLOSE_SELL:
+		param.Side = "Buy"
+		execInst = append(execInst, "Close")
+	case CLOSE_BUY:
+		param.Side = "Sell"
+		execInst = append(execInst, "Close")
+	default:
+		return param, errors.New("open type is error")
+	}
+	param.ExecInst = strings.Join(execInst, ",")
+
+	return param, nil
+}
+
+func (bm *Bitmex) adaptAmendParam(ord *FutureOrder) bitmexAmendParam {
+	param := bitmexAmendParam{
+		OrderID:  ord.OrderID2,
+		Price:    ord.Price,
+		OrderQty: int(ord.Amount),
+	}
+	if param.OrderID == "" {
+		param.OrigClOrdID = ord.ClientOid
+	}
+	return param
+}
+
+// indexBitmexOrders 按 orderID 和 clOrdID 索引批量接口的返回
+func indexBitmexOrders(response []BitmexOrder) map[string]BitmexOrder {
+	ret := make(map[string]BitmexOrder, len(response)*2)
+	for _, o := range response {
+		if o.OrderID != "" {
+			ret[o.OrderID] = o
+		}
+		if o.ClOrdID != "" {
+			ret[o.ClOrdID] = o
+		}
+	}
+	return ret
+}
+
+func (o BitmexOrder) err() error {
+	if o.Error != "" {
+		return errors.New(o.Error)
+	}
+	if o.OrdStatus == "Rejected" {
+		return errors.New(o.OrdRejReason)
+	}
+	return nil
+}
+
+/**
+ * 改单, 修改价格和(或)数量, 订单保留原有的排队位置(只减少数量时)
+ * @param ord OrderID2 或 ClientOid 必填, Price 和 Amount 为修改后的价格和数量, 为0时不修改
+ */
+func (bm *Bitmex) AmendFutureOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord == nil || (ord.OrderID2 == "" && ord.ClientOid == "") {
+		return nil, ErrAmendOrderId
+	}
+
+	var response BitmexOrder
+	err := bm.doAuthRequest("PUT", "/api/v1/order", bm.toJson(bm.adaptAmendParam(ord)), &response)
+	if err != nil {
+		return ord, err
+	}
+
+	ret := bm.adaptOrder(response)
+	ret.Currency = ord.Currency
+	ret.ContractName = ord.ContractName
+	ret.OType = ord.OType
+	ret.OrderType = ord.OrderType
+
+	return &ret, nil
+}
+
+func (bm *Bitmex) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	results := make([]BatchOrderResult, len(orders))
+
+	for _, b := range SplitBatch(len(orders), bitmexBatchLimit) {
+		var (
+			param struct {
+				Orders []BitmexOrder `json:"orders"`
+			}
+			response []BitmexOrder
+		)
+
+		idx := make([]int, 0, b[1]-b[0])
+		for i := b[0]; i < b[1]; i++ {
+			p, err := bm.buildOrderParameter(&orders[i])
+			results[i].ClientOid = orders[i].ClientOid
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			param.Orders = append(param.Orders, p)
+			idx = append(idx, i)
+		}
+
+		if len(idx) == 0 {
+			continue
+		}
+
+		err := bm.doAuthRequest("POST", "/api/v1/order/bulk", bm.toJson(param), &response)
+		if err != nil {
+			for _, i := range idx {
+				results[i].Err = err
+			}
+			continue
+		}
+
+		ret := indexBitmexOrders(response)
+
+		for _, i := range idx {
+			o, found := ret[orders[i].ClientOid]
+			if !found {
+				results[i].Err = errors.New("order not found in response")
+				continue
+			}
+			orders[i].OrderID2 = o.OrderID
+			results[i].OrderId = o.OrderID
+			results[i].Err = o.err()
+		}
+	}
+
+	return results, nil
+}
+
+// BatchCancelFutureOrders 以 goex 开头的ID按 clOrdID 撤单
+func (bm *Bitmex) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	results := make([]BatchOrderResult, len(orderIds))
+
+	for _, b := range SplitBatch(len(orderIds), bitmexBatchLimit) {
+		var (
+			param struct {
+				OrderID []string `json:"orderID,omitempty"`
+				ClOrdID []string `json:"clOrdID,omitempty"`
+			}
+			response []BitmexOrder
+		)
+
+		for i := b[0]; i < b[1]; i++ {
+			results[i].OrderId = orderIds[i]
+			if strings.HasPrefix(orderIds[i], "goex") {
+				param.ClOrdID = append(param.ClOrdID, orderIds[i])
+			} else {
+				param.OrderID = append(param.OrderID, orderIds[i])
+			}
+		}
+
+		err := bm.doAuthRequest("DELETE", "/api/v1/order", bm.toJson(param), &response)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		ret := indexBitmexOrders(response)
+
+		for i := b[0]; i < b[1]; i++ {
+			o, found := ret[orderIds[i]]
+			if !found {
+				results[i].Err = errors.New("order not found in response")
+				continue
+			}
+			results[i].ClientOid = o.ClOrdID
+			results[i].Err = o.err()
+		}
+	}
+
+	return results, nil
+}
+
+func (bm *Bitmex) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	results := make([]BatchOrderResult, len(orders))
+
+	for _, b := range SplitBatch(len(orders), bitmexBatchLimit) {
+		var (
+			param struct {
+				Orders []bitmexAmendParam `json:"orders"`
+			}
+			response []BitmexOrder
+		)
+
+		for i := b[0]; i < b[1]; i++ {
+			param.Orders = append(param.Orders, bm.adaptAmendParam(&orders[i]))
+			results[i] = BatchOrderResult{OrderId: orders[i].OrderID2, ClientOid: orders[i].ClientOid}
+		}
+
+		err := bm.doAuthRequest("PUT", "/api/v1/order/bulk", bm.toJson(param), &response)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		ret := indexBitmexOrders(response)
+
+		for i := b[0]; i < b[1]; i++ {
+			id := orders[i].OrderID2
+			if id == "" {
+				id = orders[i].ClientOid
+			}
+			o, found := ret[id]
+			if !found {
+				results[i].Err = errors.New("order not found in response")
+				continue
+			}
+			results[i].OrderId = o.OrderID
+			results[i].ClientOid = o.ClOrdID
+			results[i].Err = o.err()
+		}
+	}
+
+	return results, nil
+}
@@ -14,30 +14,63 @@ import (
 )
 
 const (
-	baseUrl = "https://www.bitmex.com"
+	baseUrl        = "https://www.bitmex.com"
+	testnetBaseUrl = "https://testnet.bitmex.com"
 )
 
-type bitmex struct {
+type Bitmex struct {
 	*APIConfig
+	clock Clock
+	log   Logger
 }
 
-func (bm *bitmex) GetFutureOrderHistory(pair CurrencyPair, contractType string, optional ...OptionalParameter) ([]FutureOrder, error) {
-	panic("implement me")
+/**
+ * 历史委托(已完成、已撤销和已拒绝的订单), 按时间倒序
+ * @param optional 透传给 /api/v1/order, 如 start(偏移量)、count(默认100, 最大500)、startTime、endTime
+ */
+func (bm *Bitmex) GetFutureOrderHistory(pair CurrencyPair, contractType string, optional ...OptionalParameter) ([]FutureOrder, error) {
+	param := url.Values{}
+	param.Set("symbol", bm.adaptCurrencyPairToSymbol(pair, contractType))
+	param.Set("filter", `{"ordStatus":["Filled","Canceled","Rejected"]}`)
+	param.Set("reverse", "true")
+	param.Set("count", "100")
+	MergeOptionalParameter(&param, optional...)
+
+	var response []BitmexOrder
+	err := bm.doAuthRequest("GET", "/api/v1/order?"+param.Encode(), "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return bm.adaptOrders(response, pair, contractType), nil
 }
 
-func New(config *APIConfig) *bitmex {
-	bm := &bitmex{config}
+func New(config *APIConfig) *Bitmex {
+	bm := &Bitmex{APIConfig: config, log: config.GetLogger()}
 	if bm.Endpoint == "" {
 		bm.Endpoint = baseUrl
+		if bm.Env == ENV_TESTNET {
+			bm.Endpoint = testnetBaseUrl
+		}
 	}
 	if strings.HasSuffix(bm.Endpoint, "/") {
 		bm.Endpoint = bm.Endpoint[0 : len(bm.Endpoint)-1]
 	}
 	Log.Debug("endpoint=", bm.Endpoint)
+	bm.clock = config.GetClock(bm.Endpoint+"/api/v1", bm.GetServerTime)
 	return bm
 }
 
-func (bm *bitmex) generateSignature(httpMethod, uri, data, nonce string) string {
+// GetServerTime 服务器时间(毫秒)
+func (bm *Bitmex) GetServerTime() (int64, error) {
+	ret, err := HttpGet(bm.HttpClient, bm.Endpoint+"/api/v1")
+	if err != nil {
+		return 0, err
+	}
+	return ToInt64(ret["timestamp"]), nil
+}
+
+func (bm *Bitmex) generateSignature(httpMethod, uri, data, nonce string) string {
 	payload := strings.ToUpper(httpMethod) + uri + nonce + data
 	//println(payload)
 	sign, _ := GetParamHmacSHA256Sign(bm.ApiSecretKey, payload)
@@ -45,9 +78,9 @@ func (bm *bitmex) generateSignature(httpMethod, uri, data, nonce string) string
 	return sign
 }
 
-func (bm *bitmex) doAuthRequest(m, uri, param string, r interface{}) error {
+func (bm *Bitmex) doAuthRequest(m, uri, param string, r interface{}) error {
 
-	nonce := time.Now().UTC().Unix() + 3600
+	nonce := bm.clock.Now().Unix() + 3600
 	sign := bm.generateSignature(m, uri, param, fmt.Sprint(nonce))
 
 	resp, err := NewHttpRequest(bm.HttpClient, m, bm.Endpoint+uri, param, map[string]string{
@@ -57,7 +90,9 @@ func (bm *bitmex) doAuthRequest(m, uri, param string, r interface{}) error {
 		"api-expires":   fmt.Sprint(nonce),
 		"api-key":       bm.ApiKey,
 		"api-signature": sign})
-	Log.Debug("response:", string(resp))
+	if DebugEnabled(bm.log) {
+		bm.log.Debug("response", KV("method", m), KV("uri", uri), KV("body", string(resp)))
+	}
 	if err != nil {
 		return err
 	} else {
@@ -67,12 +102,12 @@ func (bm *bitmex) doAuthRequest(m, uri, param string, r interface{}) error {
 	return nil
 }
 
-func (bm *bitmex) toJson(param interface{}) string {
+func (bm *Bitmex) toJson(param interface{}) string {
 	dataJson, _ := json.Marshal(param)
 	return string(dataJson)
 }
 
-func (bm *bitmex) GetFutureUserinfo(currencyPair ...CurrencyPair) (*FutureAccount, error) {
+func (bm *Bitmex) GetFutureUserinfo(currencyPair ...CurrencyPair) (*FutureAccount, error) {
 	uri := "/api/v1/user/margin?currency=XBt"
 	var resp struct {
 		Currency           string  `json:"currency"`
@@ -109,7 +144,7 @@ func (bm *bitmex) GetFutureUserinfo(currencyPair ...CurrencyPair) (*FutureAccoun
 
 type BitmexOrder struct {
 	Symbol      string    `json:"symbol"`
-	OrderID     string    `json:"OrderID"`
+	OrderID     string    `json:"orderID,omitempty"`
 	ClOrdID     string    `json:"clOrdID"`
 	Price       float64   `json:"price,omitempty"`
 	OrderQty    int       `json:"orderQty"`
@@ -121,14 +156,20 @@ type BitmexOrder struct {
 	Side        string    `json:"side"`
 	OrdStatus   string    `json:"ordStatus"`
 	Timestamp   time.Time `json:"timestamp"`
+	StopPx      float64   `json:"stopPx,omitempty"`
+	ExecInst    string    `json:"execInst,omitempty"`
+	Triggered   string    `json:"triggered,omitempty"`
+
+	OrdRejReason string `json:"ordRejReason,omitempty"`
+	Error        string `json:"error,omitempty"` //批量接口中单个订单的错误
 }
 
-func (bm *bitmex) PlaceFutureOrder(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64) (string, error) {
+func (bm *Bitmex) PlaceFutureOrder(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64) (string, error) {
 	fOrder, err := bm.PlaceFutureOrder2(currencyPair, contractType, price, amount, openType, matchPrice, leverRate)
 	return fOrder.OrderID2, err
 }
 
-func (bm *bitmex) PlaceFutureOrder2(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64) (*FutureOrder, error) {
+func (bm *Bitmex) PlaceFutureOrder2(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64) (*FutureOrder, error) {
 	var createOrderParameter BitmexOrder
 
 	var resp struct {
@@ -180,15 +221,15 @@ func (bm *bitmex) PlaceFutureOrder2(currencyPair CurrencyPair, contractType, pri
 	return fOrder, nil
 }
 
-func (bm *bitmex) LimitFuturesOrder(currencyPair CurrencyPair, contractType, price, amount string, openType int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
+func (bm *Bitmex) LimitFuturesOrder(currencyPair CurrencyPair, contractType, price, amount string, openType int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
 	return bm.PlaceFutureOrder2(currencyPair, contractType, price, amount, openType, 0, 10)
 }
 
-func (bm *bitmex) MarketFuturesOrder(currencyPair CurrencyPair, contractType, amount string, openType int) (*FutureOrder, error) {
+func (bm *Bitmex) MarketFuturesOrder(currencyPair CurrencyPair, contractType, amount string, openType int) (*FutureOrder, error) {
 	return bm.PlaceFutureOrder2(currencyPair, contractType, "0", amount, openType, 1, 10)
 }
 
-func (bm *bitmex) FutureCancelOrder(currencyPair CurrencyPair, contractType, orderId string) (bool, error) {
+func (bm *Bitmex) FutureCancelOrder(currencyPair CurrencyPair, contractType, orderId string) (bool, error) {
 	var param struct {
 		OrderID string `json:"orderID,omitempty"`
 		ClOrdID string `json:"clOrdID,omitempty"`
@@ -206,7 +247,7 @@ func (bm *bitmex) FutureCancelOrder(currencyPair CurrencyPair, contractType, ord
 	return true, nil
 }
 
-func (bm *bitmex) GetFuturePosition(currencyPair CurrencyPair, contractType string) ([]FuturePosition, error) {
+func (bm *Bitmex) GetFuturePosition(currencyPair CurrencyPair, contractType string) ([]FuturePosition, error) {
 	var (
 		response []struct {
 			Symbol            string    `json:"symbol"`
@@ -259,11 +300,25 @@ func (bm *bitmex) GetFuturePosition(currencyPair CurrencyPair, contractType stri
 	return postions, nil
 }
 
-func (bm *bitmex) GetFutureOrders(orderIds []string, currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
-	panic("no support")
+func (bm *Bitmex) GetFutureOrders(orderIds []string, currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
+	if len(orderIds) == 0 {
+		return nil, errors.New("order ids is empty")
+	}
+
+	var response []BitmexOrder
+	param := url.Values{}
+	param.Set("symbol", bm.adaptCurrencyPairToSymbol(currencyPair, contractType))
+	param.Set("filter", bm.toJson(map[string][]string{"orderID": orderIds}))
+	param.Set("count", fmt.Sprint(len(orderIds)))
+	err := bm.doAuthRequest("GET", "/api/v1/order?"+param.Encode(), "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return bm.adaptOrders(response, currencyPair, contractType), nil
 }
 
-func (bm *bitmex) GetFutureOrder(orderId string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+func (bm *Bitmex) GetFutureOrder(orderId string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
 	var response []BitmexOrder
 	filters := fmt.Sprintf(`{"orderID":"%s"}`, orderId)
 	param := url.Values{}
@@ -283,7 +338,7 @@ func (bm *bitmex) GetFutureOrder(orderId string, currencyPair CurrencyPair, cont
 	return &ord, nil
 }
 
-func (bm *bitmex) GetUnfinishFutureOrders(currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
+func (bm *Bitmex) GetUnfinishFutureOrders(currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
 	var response []BitmexOrder
 
 	query := url.Values{}
@@ -295,22 +350,14 @@ func (bm *bitmex) GetUnfinishFutureOrders(currencyPair CurrencyPair, contractTyp
 		return nil, errr
 	}
 
-	var orders []FutureOrder
-	for _, v := range response {
-		ord := bm.adaptOrder(v)
-		ord.Currency = currencyPair
-		ord.ContractName = contractType
-		orders = append(orders, ord)
-	}
-
-	return orders, nil
+	return bm.adaptOrders(response, currencyPair, contractType), nil
 }
 
-func (bm *bitmex) GetFee() (float64, error) {
-	panic("no support")
+func (bm *Bitmex) GetFee() (float64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
 }
 
-func (bm *bitmex) GetFutureDepth(currencyPair CurrencyPair, contractType string, size int) (*Depth, error) {
+func (bm *Bitmex) GetFutureDepth(currencyPair CurrencyPair, contractType string, size int) (*Depth, error) {
 	sym := bm.adaptCurrencyPairToSymbol(currencyPair, contractType)
 	uri := fmt.Sprintf("/api/v1/orderBook/L2?symbol=%s&depth=%d", sym, size)
 
@@ -339,7 +386,7 @@ func (bm *bitmex) GetFutureDepth(currencyPair CurrencyPair, contractType string,
 	return dep, nil
 }
 
-func (bm *bitmex) GetFutureTicker(currencyPair CurrencyPair, contractType string) (*Ticker, error) {
+func (bm *Bitmex) GetFutureTicker(currencyPair CurrencyPair, contractType string) (*Ticker, error) {
 	uri := fmt.Sprintf("/api/v1/instrument?symbol=%s", bm.adaptCurrencyPairToSymbol(currencyPair, contractType))
 	resp, err := HttpGet3(bm.HttpClient, bm.Endpoint+uri, nil)
 	if err != nil {
@@ -369,7 +416,7 @@ func (bm *bitmex) GetFutureTicker(currencyPair CurrencyPair, contractType string
 	}, nil
 }
 
-func (bm *bitmex) GetIndicativeFundingRate(symbol string) (float64, *time.Time, error) {
+func (bm *Bitmex) GetIndicativeFundingRate(symbol string) (float64, *time.Time, error) {
 	//indicativeFundingRate
 	uri := fmt.Sprintf("/api/v1/instrument?symbol=%s", symbol)
 	resp, err := HttpGet3(bm.HttpClient, bm.Endpoint+uri, nil)
@@ -391,27 +438,53 @@ func (bm *bitmex) GetIndicativeFundingRate(symbol string) (float64, *time.Time,
 	return ToFloat64(retmap["indicativeFundingRate"]), &t, nil
 }
 
-func (bm *bitmex) GetExchangeName() string {
+func (bm *Bitmex) GetExchangeName() string {
 	return BITMEX
 }
 
-func (bm *bitmex) GetFutureIndex(currencyPair CurrencyPair) (float64, error) {
-	panic("no support")
+// GetFutureIndex 现货指数, BTC 为 .BXBT, 其他币种为 .B{币种}, 如 .BETH
+func (bm *Bitmex) GetFutureIndex(currencyPair CurrencyPair) (float64, error) {
+	return bm.getIndexLastPrice(bm.adaptIndexSymbol(currencyPair))
 }
 
-func (bm *bitmex) GetContractValue(currencyPair CurrencyPair) (float64, error) {
+func (bm *Bitmex) GetContractValue(currencyPair CurrencyPair) (float64, error) {
 	return 1.0, nil
 }
 
-func (bm *bitmex) GetDeliveryTime() (int, int, int, int) {
-	panic("no support")
+func (bm *Bitmex) GetDeliveryTime() (int, int, int, int) {
+	return 4, 12, 0, 0 //星期五，UTC 12点交割
+}
+
+// GetFutureEstimatedPrice 交割合约按指数交割前30分钟的均价结算, 即 .BXBT30M
+func (bm *Bitmex) GetFutureEstimatedPrice(currencyPair CurrencyPair) (float64, error) {
+	return bm.getIndexLastPrice(bm.adaptIndexSymbol(currencyPair) + "30M")
+}
+
+func (bm *Bitmex) adaptIndexSymbol(currencyPair CurrencyPair) string {
+	if currencyPair.CurrencyA.Eq(BTC) || currencyPair.CurrencyA.Eq(XBT) {
+		return ".BXBT"
+	}
+	return ".B" + currencyPair.CurrencyA.Symbol
 }
 
-func (bm *bitmex) GetFutureEstimatedPrice(currencyPair CurrencyPair) (float64, error) {
-	panic("no support")
+func (bm *Bitmex) getIndexLastPrice(symbol string) (float64, error) {
+	var resp []struct {
+		Symbol    string  `json:"symbol"`
+		LastPrice float64 `json:"lastPrice"`
+	}
+	err := HttpGet4(bm.HttpClient, bm.Endpoint+"/api/v1/instrument?symbol="+url.QueryEscape(symbol), nil, &resp)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(resp) == 0 {
+		return 0, errors.New("get index response is null")
+	}
+
+	return resp[0].LastPrice, nil
 }
 
-func (bm *bitmex) GetKlineRecords(contract_type string, currency CurrencyPair, period KlinePeriod, size int, optional ...OptionalParameter) ([]FutureKline, error) {
+func (bm *Bitmex) GetKlineRecords(contract_type string, currency CurrencyPair, period KlinePeriod, size int, optional ...OptionalParameter) ([]FutureKline, error) {
 	urlPath := "/api/v1/trade/bucketed?binSize=%s&partial=false&symbol=%s&count=%d&startTime=%s&reverse=true"
 	contractId := bm.adaptCurrencyPairToSymbol(currency, contract_type)
 
@@ -458,7 +531,7 @@ func (bm *bitmex) GetKlineRecords(contract_type string, currency CurrencyPair, p
 	return klines, nil
 }
 
-func (bm *bitmex) GetTrades(contract_type string, currency CurrencyPair, since int64) ([]Trade, error) {
+func (bm *Bitmex) GetTrades(contract_type string, currency CurrencyPair, since int64) ([]Trade, error) {
 	var urlPath = "/api/v1/trade?symbol=%s&startTime=%s&reverse=true"
 	contractId := bm.adaptCurrencyPairToSymbol(currency, contract_type)
 	sinceTime := time.Unix(int64(since), 0).UTC()
@@ -494,7 +567,7 @@ func (bm *bitmex) GetTrades(contract_type string, currency CurrencyPair, since i
 	return trades, nil
 }
 
-func (bm *bitmex) adaptCurrencyPairToSymbol(pair CurrencyPair, contract string) string {
+func (bm *Bitmex) adaptCurrencyPairToSymbol(pair CurrencyPair, contract string) string {
 	if contract == "" || contract == SWAP_CONTRACT {
 		if pair.CurrencyA.Eq(BTC) {
 			pair = NewCurrencyPair(XBT, USD)
@@ -512,13 +585,35 @@ func (bm *bitmex) adaptCurrencyPairToSymbol(pair CurrencyPair, contract string)
 	return fmt.Sprintf("%s%s", coin, strings.ToUpper(contract))
 }
 
-func (bm *bitmex) adaptOrder(o BitmexOrder) FutureOrder {
+func (bm *Bitmex) adaptOrder(o BitmexOrder) FutureOrder {
 	status := ORDER_UNFINISH
-	if o.OrdStatus == "Filled" {
+	switch o.OrdStatus {
+	case "Filled":
 		status = ORDER_FINISH
-	} else if o.OrdStatus == "Canceled" {
+	case "Canceled":
 		status = ORDER_CANCEL
+	case "Rejected":
+		status = ORDER_REJECT
+	case "PartiallyFilled":
+		status = ORDER_PART_FINISH
+	case "New":
+		if o.Triggered == "StopOrderTriggered" {
+			status = ORDER_TRIGGERED
+		}
+	}
+
+	//bitmex 为单向持仓, 只有带 Close 执行指令的订单才能确定是平仓单
+	oType := OPEN_BUY
+	isClose := strings.Contains(o.ExecInst, "Close") || strings.Contains(o.ExecInst, "ReduceOnly")
+	switch {
+	case o.Side == "Buy" && isClose:
+		oType = CLOSE_SELL
+	case o.Side == "Sell" && isClose:
+		oType = CLOSE_BUY
+	case o.Side == "Sell":
+		oType = OPEN_SELL
 	}
+
 	return FutureOrder{
 		OrderID2:   o.OrderID,
 		ClientOid:  o.ClOrdID,
@@ -527,5 +622,17 @@ func (bm *bitmex) adaptOrder(o BitmexOrder) FutureOrder {
 		DealAmount: float64(o.CumQty),
 		AvgPrice:   o.AvgPx,
 		Status:     status,
+		OType:      oType,
 		OrderTime:  o.Timestamp.Unix()}
 }
+
+func (bm *Bitmex) adaptOrders(response []BitmexOrder, currencyPair CurrencyPair, contractType string) []FutureOrder {
+	var orders []FutureOrder
+	for _, v := range response {
+		ord := bm.adaptOrder(v)
+		ord.Currency = currencyPair
+		ord.ContractName = contractType
+		orders = append(orders, ord)
+	}
+	return orders
+}
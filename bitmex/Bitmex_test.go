@@ -33,7 +33,7 @@ func init() {
 	})
 }
 
-var mex *bitmex
+var mex *Bitmex
 
 func TestBitmex_GetFutureDepth(t *testing.T) {
 	dep, err := mex.GetFutureDepth(goex.ETH_USDT, goex.SWAP_CONTRACT, 5)
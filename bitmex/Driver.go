@@ -0,0 +1,28 @@
+package bitmex
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(BITMEX, Driver{
+		Future: func(config *APIConfig) FutureRestAPI {
+			return New(config)
+		},
+		FuturesWs: func(config *APIConfig) FuturesWsApi {
+			return NewSwapWsWithConfig(config.GetWsClientConfig())
+		},
+		Wallet: func(config *APIConfig) WalletApi {
+			return NewWallet(config)
+		},
+		Testnet: []Capability{CAP_FUTURE, CAP_FUTURES_WS, CAP_WALLET},
+	})
+	//兼容旧的 BITMEX_TEST, 建议使用 BITMEX + ENV_TESTNET
+	RegisterDriver(BITMEX_TEST, Driver{
+		Future: func(config *APIConfig) FutureRestAPI {
+			c := *config
+			c.Endpoint = testnetBaseUrl
+			return New(&c)
+		},
+	})
+}
@@ -0,0 +1,97 @@
+package bitmex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// bitmex 只支持单向持仓, 杠杆为0表示全仓, 设置大于0的杠杆会切换为逐仓
+
+type bitmexPositionSettings struct {
+	Symbol      string  `json:"symbol"`
+	Leverage    float64 `json:"leverage"`
+	CrossMargin bool    `json:"crossMargin"`
+}
+
+func (bm *Bitmex) GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	var response []bitmexPositionSettings
+
+	param := url.Values{}
+	param.Set("filter", fmt.Sprintf(`{"symbol":"%s"}`, bm.adaptCurrencyPairToSymbol(currencyPair, contractType)))
+	err := bm.doAuthRequest("GET", "/api/v1/position?"+param.Encode(), "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response) == 0 {
+		return nil, errors.New("not found position")
+	}
+
+	mode := MARGIN_MODE_ISOLATED
+	if response[0].CrossMargin {
+		mode = MARGIN_MODE_CROSSED
+	}
+
+	return &FutureLeverage{
+		Pair:          currencyPair,
+		ContractType:  contractType,
+		MarginMode:    mode,
+		LongLeverage:  response[0].Leverage,
+		ShortLeverage: response[0].Leverage,
+	}, nil
+}
+
+func (bm *Bitmex) SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error {
+	param := map[string]interface{}{
+		"symbol":   bm.adaptCurrencyPairToSymbol(currencyPair, contractType),
+		"leverage": leverage,
+	}
+
+	var response bitmexPositionSettings
+	return bm.doAuthRequest("POST", "/api/v1/position/leverage", bm.toJson(param), &response)
+}
+
+func (bm *Bitmex) SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error {
+	switch mode {
+	case MARGIN_MODE_CROSSED:
+		return bm.SetLeverage(currencyPair, contractType, 0)
+	case MARGIN_MODE_ISOLATED:
+		param := map[string]interface{}{
+			"symbol":  bm.adaptCurrencyPairToSymbol(currencyPair, contractType),
+			"enabled": true,
+		}
+		var response bitmexPositionSettings
+		return bm.doAuthRequest("POST", "/api/v1/position/isolate", bm.toJson(param), &response)
+	}
+	return errors.New("margin mode is error")
+}
+
+func (bm *Bitmex) GetPositionMode(contractType string) (PositionMode, error) {
+	return POSITION_MODE_ONE_WAY, nil
+}
+
+func (bm *Bitmex) SetPositionMode(contractType string, mode PositionMode) error {
+	if mode != POSITION_MODE_ONE_WAY {
+		return EX_ERR_NOT_SUPPORT
+	}
+	return nil
+}
+
+// AdjustMargin amount 单位为 XBT
+func (bm *Bitmex) AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error {
+	if amount == 0 {
+		return errors.New("amount is zero")
+	}
+
+	param := map[string]interface{}{
+		"symbol": bm.adaptCurrencyPairToSymbol(currencyPair, contractType),
+		"amount": int64(math.Round(amount * 1e8)), //XBt
+	}
+
+	var response bitmexPositionSettings
+	return bm.doAuthRequest("POST", "/api/v1/position/transferMargin", bm.toJson(param), &response)
+}
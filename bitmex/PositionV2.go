@@ -0,0 +1,78 @@
+package bitmex
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// bitmex 为单向(净)持仓, 保证金和盈亏单位为 XBt(聪)
+const satoshi = 1e8
+
+func (bm *Bitmex) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	var (
+		response []struct {
+			Symbol               string    `json:"symbol"`
+			CurrentQty           float64   `json:"currentQty"`
+			AvgEntryPrice        float64   `json:"avgEntryPrice"`
+			Leverage             float64   `json:"leverage"`
+			CrossMargin          bool      `json:"crossMargin"`
+			PosInit              float64   `json:"posInit"`
+			PosMaint             float64   `json:"posMaint"`
+			MarkPrice            float64   `json:"markPrice"`
+			MarkValue            float64   `json:"markValue"`
+			LiquidationPrice     float64   `json:"liquidationPrice"`
+			UnrealisedPnl        float64   `json:"unrealisedPnl"`
+			RealisedPnl          float64   `json:"realisedPnl"`
+			DeleveragePercentile float64   `json:"deleveragePercentile"`
+			OpeningTimestamp     time.Time `json:"openingTimestamp"`
+			Timestamp            time.Time `json:"timestamp"`
+		}
+		param = url.Values{}
+	)
+
+	param.Set("filter", fmt.Sprintf(`{"symbol":"%s"}`, bm.adaptCurrencyPairToSymbol(currencyPair, contractType)))
+	err := bm.doAuthRequest("GET", "/api/v1/position?"+param.Encode(), "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []FuturePositionV2
+	for _, p := range response {
+		if p.CurrentQty == 0 {
+			continue
+		}
+
+		marginMode := MARGIN_MODE_ISOLATED
+		if p.CrossMargin {
+			marginMode = MARGIN_MODE_CROSSED
+		}
+
+		positions = append(positions, FuturePositionV2{
+			Pair:             currencyPair,
+			ContractType:     contractType,
+			ContractId:       p.Symbol,
+			Side:             POSITION_SIDE_BOTH,
+			Amount:           p.CurrentQty,
+			AvailAmount:      p.CurrentQty,
+			AvgPrice:         p.AvgEntryPrice,
+			MarginMode:       marginMode,
+			Leverage:         p.Leverage,
+			InitialMargin:    p.PosInit / satoshi,
+			MaintMargin:      p.PosMaint / satoshi,
+			MarkPrice:        p.MarkPrice,
+			LiquidationPrice: p.LiquidationPrice,
+			Notional:         math.Abs(p.MarkValue) / satoshi,
+			UnrealizedPnl:    p.UnrealisedPnl / satoshi,
+			RealizedPnl:      p.RealisedPnl / satoshi,
+			AdlRank:          int(math.Ceil(p.DeleveragePercentile * 5)), //deleveragePercentile 折算为1-5
+			CreateTime:       p.OpeningTimestamp.UnixNano() / int64(time.Millisecond),
+			UpdateTime:       p.Timestamp.UnixNano() / int64(time.Millisecond),
+		})
+	}
+
+	return positions, nil
+}
@@ -0,0 +1,252 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+// 使用 testdata 下录制的响应, 不访问网络
+
+type recordedRequest struct {
+	Method string
+	Query  string
+	Body   map[string]interface{}
+}
+
+// newRecordedBitmex 按 method + path 返回 testdata 中的响应, /api/v1/instrument 按 symbol 区分, 未完成订单为 order_open
+func newRecordedBitmex(t *testing.T) (*Bitmex, *[]recordedRequest, func()) {
+	var requests []recordedRequest
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := recordedRequest{Method: r.Method, Query: r.URL.RawQuery}
+		body, _ := ioutil.ReadAll(r.Body)
+		if len(body) > 0 {
+			json.Unmarshal(body, &req.Body)
+		}
+		requests = append(requests, req)
+
+		name := strings.Replace(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/", "_", -1)
+		switch {
+		case name == "instrument":
+			name += "_" + strings.TrimPrefix(r.URL.Query().Get("symbol"), ".")
+		case name == "order" && r.Method == http.MethodGet && strings.Contains(r.URL.Query().Get("filter"), `"open":true`):
+			name += "_open"
+		case name == "order" && r.Method == http.MethodGet:
+			name += "_history"
+		case name == "order" || name == "order_bulk":
+			name += "_" + strings.ToLower(r.Method)
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join("testdata", name+".json"))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"message":"Not Found","name":"HTTPError"}}`))
+			return
+		}
+		w.Write(data)
+	}))
+
+	bm := New(&goex.APIConfig{
+		Endpoint:     srv.URL,
+		HttpClient:   http.DefaultClient,
+		ApiKey:       "key",
+		ApiSecretKey: "secret",
+		Clock:        goex.LocalClock,
+	})
+
+	return bm, &requests, srv.Close
+}
+
+func TestBitmex_GetFutureOrderHistory_Recorded(t *testing.T) {
+	bm, requests, closeFn := newRecordedBitmex(t)
+	defer closeFn()
+
+	orders, err := bm.GetFutureOrderHistory(goex.BTC_USD, goex.SWAP_CONTRACT, goex.OptionalParameter{}.Optional("start", 100))
+	assert.Nil(t, err)
+	assert.Len(t, orders, 3)
+
+	assert.Equal(t, goex.ORDER_FINISH, orders[0].Status)
+	assert.Equal(t, goex.CLOSE_BUY, orders[0].OType)
+	assert.Equal(t, 39001.0, orders[0].AvgPrice)
+	assert.Equal(t, goex.ORDER_CANCEL, orders[1].Status)
+	assert.Equal(t, goex.OPEN_BUY, orders[1].OType)
+	assert.Equal(t, 50.0, orders[1].DealAmount)
+	assert.Equal(t, goex.ORDER_REJECT, orders[2].Status)
+	assert.Equal(t, goex.BTC_USD, orders[2].Currency)
+
+	assert.Contains(t, (*requests)[0].Query, "start=100")
+	assert.Contains(t, (*requests)[0].Query, "symbol=XBTUSD")
+	assert.Contains(t, (*requests)[0].Query, "reverse=true")
+}
+
+func TestBitmex_GetFutureOrders_Recorded(t *testing.T) {
+	bm, requests, closeFn := newRecordedBitmex(t)
+	defer closeFn()
+
+	orders, err := bm.GetFutureOrders([]string{"5f9d1b6a-4cc1-4a43-9a5f-0b0e4bba9b5c"}, goex.BTC_USD, goex.SWAP_CONTRACT)
+	assert.Nil(t, err)
+	assert.Len(t, orders, 3)
+	assert.Contains(t, (*requests)[0].Query, "filter=%7B%22orderID%22%3A%5B%225f9d1b6a")
+}
+
+func TestBitmex_AmendFutureOrder_Recorded(t *testing.T) {
+	bm, requests, closeFn := newRecordedBitmex(t)
+	defer closeFn()
+
+	ord, err := bm.AmendFutureOrder(&goex.FutureOrder{
+		ClientOid:    "goexbulk0001",
+		Price:        35800,
+		Currency:     goex.BTC_USD,
+		ContractName: goex.SWAP_CONTRACT,
+		OType:        goex.OPEN_BUY,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "0e1d2c3b-aaaa-4b5c-8d9e-000000000001", ord.OrderID2)
+	assert.Equal(t, 35800.0, ord.Price)
+
+	req := (*requests)[0]
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "goexbulk0001", req.Body["origClOrdID"])
+	assert.Equal(t, 35800.0, req.Body["price"])
+	assert.NotContains(t, req.Body, "orderQty")
+}
+
+func TestBitmex_BatchFutureOrders_Recorded(t *testing.T) {
+	bm, requests, closeFn := newRecordedBitmex(t)
+	defer closeFn()
+
+	orders := []goex.FutureOrder{
+		{ClientOid: "goexbulk0001", Currency: goex.BTC_USD, ContractName: goex.SWAP_CONTRACT, OType: goex.OPEN_BUY, Price: 35000, Amount: 100},
+		{ClientOid: "goexbulk0002", Currency: goex.BTC_USD, ContractName: goex.SWAP_CONTRACT, OType: goex.CLOSE_BUY, Price: 41000, Amount: 100, OrderType: goex.ORDER_FEATURE_POST_ONLY},
+		{Currency: goex.BTC_USD, ContractName: goex.SWAP_CONTRACT, OType: 0, Price: 41000, Amount: 100},
+	}
+	results, err := bm.BatchPlaceFutureOrders(orders)
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "0e1d2c3b-aaaa-4b5c-8d9e-000000000001", orders[0].OrderID2)
+	assert.EqualError(t, results[1].Err, "Order had execInst of ParticipateDoNotInitiate")
+	assert.NotNil(t, results[2].Err)
+
+	place := (*requests)[0].Body["orders"].([]interface{})
+	assert.Len(t, place, 2)
+	assert.Equal(t, "ParticipateDoNotInitiate,Close", place[1].(map[string]interface{})["execInst"])
+	assert.Equal(t, "Sell", place[1].(map[string]interface{})["side"])
+
+	results, err = bm.BatchAmendFutureOrders([]goex.FutureOrder{{OrderID2: "0e1d2c3b-aaaa-4b5c-8d9e-000000000001", Price: 35500, Amount: 150}})
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "goexbulk0001", results[0].ClientOid)
+
+	results, err = bm.BatchCancelFutureOrders(goex.BTC_USD, goex.SWAP_CONTRACT,
+		[]string{"goexbulk0001", "0e1d2c3b-aaaa-4b5c-8d9e-000000000009"})
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Err)
+	assert.EqualError(t, results[1].Err, "Unable to cancel order due to existing state: Filled")
+
+	cancel := (*requests)[2].Body
+	assert.Equal(t, []interface{}{"goexbulk0001"}, cancel["clOrdID"])
+	assert.Equal(t, []interface{}{"0e1d2c3b-aaaa-4b5c-8d9e-000000000009"}, cancel["orderID"])
+}
+
+func TestBitmex_FutureAlgoOrder_Recorded(t *testing.T) {
+	bm, requests, closeFn := newRecordedBitmex(t)
+	defer closeFn()
+
+	//平多, 触发价低于最新价为止损
+	ord, err := bm.PlaceFutureAlgoOrder(&goex.FutureOrder{Currency: goex.BTC_USD, ContractName: goex.SWAP_CONTRACT,
+		OType: goex.CLOSE_BUY, Amount: 100, TriggerPrice: 35000, AlgoType: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "7a3c5f2e-bbbb-4c1d-9e8f-000000000021", ord.OrderID2)
+
+	place := (*requests)[1].Body
+	assert.Equal(t, "Stop", place["ordType"])
+	assert.Equal(t, "Sell", place["side"])
+	assert.Equal(t, "LastPrice,Close", place["execInst"])
+	assert.Equal(t, 35000.0, place["stopPx"])
+
+	//未触发的止损单, 已触发的和普通订单被过滤
+	orders, err := bm.GetFutureAlgoOrders("", goex.ALGO_ORDER_PENDING, goex.BTC_USD)
+	assert.Nil(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, ord.OrderID2, orders[0].OrderID2)
+	assert.Equal(t, 35000.0, orders[0].TriggerPrice)
+	assert.Equal(t, 2, orders[0].AlgoType)
+
+	ok, err := bm.FutureCancelAlgoOrder(goex.BTC_USD, []string{ord.OrderID2})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []interface{}{ord.OrderID2}, (*requests)[3].Body["orderID"])
+	assert.NotContains(t, (*requests)[3].Body, "clOrdID")
+}
+
+func TestBitmex_adaptOrder_Triggered(t *testing.T) {
+	bm := &Bitmex{}
+	assert.Equal(t, goex.ORDER_TRIGGERED, bm.adaptOrder(BitmexOrder{OrdStatus: "New", Triggered: "StopOrderTriggered"}).Status)
+	assert.Equal(t, goex.ORDER_UNFINISH, bm.adaptOrder(BitmexOrder{OrdStatus: "New"}).Status)
+	assert.Equal(t, goex.ORDER_PART_FINISH, bm.adaptOrder(BitmexOrder{OrdStatus: "PartiallyFilled", Triggered: "StopOrderTriggered"}).Status)
+}
+
+func TestBitmex_GetFutureIndex_Recorded(t *testing.T) {
+	bm, _, closeFn := newRecordedBitmex(t)
+	defer closeFn()
+
+	index, err := bm.GetFutureIndex(goex.BTC_USD)
+	assert.Nil(t, err)
+	assert.Equal(t, 36612.55, index)
+
+	estimated, err := bm.GetFutureEstimatedPrice(goex.BTC_USD)
+	assert.Nil(t, err)
+	assert.Equal(t, 36580.12, estimated)
+
+	_, err = bm.GetFutureIndex(goex.ETH_USD)
+	assert.NotNil(t, err)
+}
+
+func TestWallet_Recorded(t *testing.T) {
+	bm, requests, closeFn := newRecordedBitmex(t)
+	defer closeFn()
+
+	w := &Wallet{bm: bm}
+
+	acc, err := w.GetAccount()
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, acc.SubAccounts[goex.BTC].Amount)
+	assert.Equal(t, 0.3, acc.SubAccounts[goex.BTC].ForzenAmount)
+	assert.Equal(t, 2500.0, acc.SubAccounts[goex.USDT].Amount)
+
+	history, err := w.GetWalletHistory(goex.BTC)
+	assert.Nil(t, err)
+	assert.Len(t, history, 3)
+	assert.Equal(t, -0.5, history[1].Amount)
+	assert.False(t, history[0].TransactTime.IsZero())
+
+	withdraws, err := w.GetWithDrawHistory(&goex.BTC)
+	assert.Nil(t, err)
+	assert.Len(t, withdraws, 1)
+	assert.Equal(t, 0.5, withdraws[0].Amount)
+	assert.Equal(t, "0.001", withdraws[0].Fee)
+	assert.Equal(t, 2, withdraws[0].Status)
+
+	deposits, err := w.GetDepositHistory(nil)
+	assert.Nil(t, err)
+	assert.Len(t, deposits, 1)
+	assert.Equal(t, 1.0, deposits[0].Amount)
+
+	err = w.Transfer(goex.TransferParameter{Currency: "BTC", To: goex.SUB_ACCOUNT, SubAccount: "654321", Amount: 0.01})
+	assert.Nil(t, err)
+	transfer := (*requests)[len(*requests)-1].Body
+	assert.Equal(t, "XBt", transfer["currency"])
+	assert.Equal(t, 1000000.0, transfer["amount"])
+	assert.Equal(t, 654321.0, transfer["targetUserId"])
+
+	assert.Equal(t, goex.EX_ERR_NOT_SUPPORT, w.Transfer(goex.TransferParameter{Currency: "BTC", From: goex.SPOT, To: goex.SWAP}))
+}
@@ -0,0 +1,174 @@
+package bitmex
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+//止损/止盈委托, 对应 bitmex 的 Stop/StopLimit/MarketIfTouched/LimitIfTouched
+
+func (bm *Bitmex) isStopOrdType(ordType string) bool {
+	switch ordType {
+	case "Stop", "StopLimit", "MarketIfTouched", "LimitIfTouched":
+		return true
+	}
+	return false
+}
+
+// adaptStopOrdType 买单触发价高于最新价或卖单触发价低于最新价为止损(Stop), 否则为止盈(IfTouched)
+func (bm *Bitmex) adaptStopOrdType(ord *FutureOrder, lastPrice float64) string {
+	isStop := ord.TriggerPrice < lastPrice
+	if ord.OType == OPEN_BUY || ord.OType == CLOSE_SELL {
+		isStop = ord.TriggerPrice > lastPrice
+	}
+
+	switch {
+	case isStop && ord.AlgoType == 2:
+		return "Stop"
+	case isStop:
+		return "StopLimit"
+	case ord.AlgoType == 2:
+		return "MarketIfTouched"
+	default:
+		return "LimitIfTouched"
+	}
+}
+
+func (bm *Bitmex) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord == nil {
+		return nil, errors.New("ord param is nil")
+	}
+
+	ticker, err := bm.GetFutureTicker(ord.Currency, ord.ContractName)
+	if err != nil {
+		return ord, err
+	}
+
+	var (
+		createOrderParameter BitmexOrder
+		resp                 struct {
+			OrderId string `json:"orderID"`
+		}
+	)
+
+	if ord.ClientOid == "" {
+		ord.ClientOid = GenerateOrderClientId(32)
+	}
+
+	createOrderParameter.Text = "github.com/BTreeNewBee/goex/tree/master/bitmex"
+	createOrderParameter.Symbol = bm.adaptCurrencyPairToSymbol(ord.Currency, ord.ContractName)
+	createOrderParameter.OrdType = bm.adaptStopOrdType(ord, ticker.Last)
+	createOrderParameter.ClOrdID = ord.ClientOid
+	createOrderParameter.OrderQty = int(ord.Amount)
+	createOrderParameter.StopPx = ord.TriggerPrice
+	createOrderParameter.ExecInst = "LastPrice"
+
+	if ord.AlgoType != 2 {
+		createOrderParameter.Price = ord.Price
+		createOrderParameter.TimeInForce = "GoodTillCancel"
+	}
+
+	switch ord.OType {
+	case OPEN_BUY:
+		createOrderParameter.Side = "Buy"
+	case OPEN_SELL:
+		createOrderParameter.Side = "Sell"
+	case CLOSE_SELL:
+		createOrderParameter.Side = "Buy"
+		createOrderParameter.ExecInst += ",Close"
+	case CLOSE_BUY:
+		createOrderParameter.Side = "Sell"
+		createOrderParameter.ExecInst += ",Close"
+	default:
+		return ord, errors.New("open type is error")
+	}
+
+	err = bm.doAuthRequest("POST", "/api/v1/order", bm.toJson(createOrderParameter), &resp)
+	if err != nil {
+		return ord, err
+	}
+
+	ord.OrderID2 = resp.OrderId
+	ord.Status = ORDER_UNFINISH
+
+	return ord, nil
+}
+
+func (bm *Bitmex) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error) {
+	if len(orderId) == 0 {
+		return false, errors.New("invalid order id")
+	}
+
+	var param struct {
+		OrderID []string `json:"orderID"`
+	}
+	param.OrderID = orderId
+
+	var response []BitmexOrder
+	err := bm.doAuthRequest("DELETE", "/api/v1/order", bm.toJson(param), &response)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (bm *Bitmex) GetFutureAlgoOrders(algoId string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error) {
+	contract := SWAP_CONTRACT
+	if len(contractType) > 0 && contractType[0] != "" {
+		contract = contractType[0]
+	}
+
+	var filter string
+	switch {
+	case algoId != "":
+		filter = fmt.Sprintf(`{"orderID":"%s"}`, algoId)
+	case status == ALGO_ORDER_PENDING:
+		filter = `{"open":true}`
+	case status == ALGO_ORDER_TRIGGERED:
+		filter = `{"ordStatus":["New","PartiallyFilled","Filled"]}`
+	case status == ALGO_ORDER_CANCELED:
+		filter = `{"ordStatus":"Canceled"}`
+	default:
+		return nil, errors.New("status or algo_id is needed")
+	}
+
+	param := url.Values{}
+	param.Set("symbol", bm.adaptCurrencyPairToSymbol(currencyPair, contract))
+	param.Set("filter", filter)
+	param.Set("reverse", "true")
+	param.Set("count", "100")
+
+	var response []BitmexOrder
+	err := bm.doAuthRequest("GET", "/api/v1/order?"+param.Encode(), "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []FutureOrder
+	for _, o := range response {
+		if !bm.isStopOrdType(o.OrdType) {
+			continue
+		}
+
+		triggered := o.Triggered == "StopOrderTriggered" || o.OrdStatus == "Filled" || o.OrdStatus == "PartiallyFilled"
+		if (status == ALGO_ORDER_PENDING && triggered) || (status == ALGO_ORDER_TRIGGERED && !triggered) {
+			continue
+		}
+
+		ord := bm.adaptOrder(o)
+		ord.Currency = currencyPair
+		ord.ContractName = contract
+		ord.TriggerPrice = o.StopPx
+		ord.AlgoType = 1
+		if o.OrdType == "Stop" || o.OrdType == "MarketIfTouched" {
+			ord.AlgoType = 2
+		}
+		orders = append(orders, ord)
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,119 @@
+package bitmex
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+type bitmexInstrument struct {
+	Symbol                string    `json:"symbol"`
+	FundingRate           float64   `json:"fundingRate"`
+	IndicativeFundingRate float64   `json:"indicativeFundingRate"`
+	FundingTimestamp      time.Time `json:"fundingTimestamp"`
+	FundingInterval       time.Time `json:"fundingInterval"`
+	MarkPrice             float64   `json:"markPrice"`
+	IndicativeSettlePrice float64   `json:"indicativeSettlePrice"` //永续合约为指数价格
+	OpenInterest          float64   `json:"openInterest"`
+	Timestamp             time.Time `json:"timestamp"`
+}
+
+func (bm *Bitmex) getInstrument(currencyPair CurrencyPair, contractType string) (*bitmexInstrument, error) {
+	var resp []bitmexInstrument
+	uri := fmt.Sprintf("/api/v1/instrument?symbol=%s", bm.adaptCurrencyPairToSymbol(currencyPair, contractType))
+	err := HttpGet4(bm.HttpClient, bm.Endpoint+uri, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp) == 0 {
+		return nil, errors.New("get instrument response is null")
+	}
+
+	return &resp[0], nil
+}
+
+// GetFundingRate fundingRate 为本期费率, indicativeFundingRate 为预测的下期费率
+func (bm *Bitmex) GetFundingRate(currencyPair CurrencyPair, contractType string) (*FundingRate, error) {
+	ins, err := bm.getInstrument(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+
+	//fundingInterval 形如 2000-01-01T08:00:00.000Z, 表示8小时
+	interval := ins.FundingInterval.Sub(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	return &FundingRate{
+		Pair:            currencyPair,
+		ContractType:    SWAP_CONTRACT,
+		FundingRate:     ins.FundingRate,
+		PredictedRate:   ins.IndicativeFundingRate,
+		FundingTime:     ins.FundingTimestamp,
+		NextFundingTime: ins.FundingTimestamp.Add(interval),
+	}, nil
+}
+
+func (bm *Bitmex) GetFundingRateHistory(currencyPair CurrencyPair, contractType string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	params := url.Values{}
+	params.Set("symbol", bm.adaptCurrencyPairToSymbol(currencyPair, contractType))
+	params.Set("reverse", "true")
+	if size > 0 {
+		params.Set("count", fmt.Sprint(size))
+	}
+	MergeOptionalParameter(&params, opt...)
+
+	var resp []struct {
+		Timestamp   time.Time `json:"timestamp"`
+		Symbol      string    `json:"symbol"`
+		FundingRate float64   `json:"fundingRate"`
+	}
+	err := HttpGet4(bm.HttpClient, bm.Endpoint+"/api/v1/funding?"+params.Encode(), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []HistoricalFunding
+	for _, r := range resp {
+		history = append(history, HistoricalFunding{
+			InstrumentId: r.Symbol,
+			FundingRate:  r.FundingRate,
+			RealizedRate: r.FundingRate,
+			FundingTime:  r.Timestamp,
+		})
+	}
+
+	return history, nil
+}
+
+func (bm *Bitmex) GetMarkPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	ins, err := bm.getInstrument(currencyPair, contractType)
+	if err != nil {
+		return 0, err
+	}
+	return ins.MarkPrice, nil
+}
+
+func (bm *Bitmex) GetIndexPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	ins, err := bm.getInstrument(currencyPair, contractType)
+	if err != nil {
+		return 0, err
+	}
+	return ins.IndicativeSettlePrice, nil
+}
+
+func (bm *Bitmex) GetOpenInterest(currencyPair CurrencyPair, contractType string) (*OpenInterest, error) {
+	ins, err := bm.getInstrument(currencyPair, contractType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenInterest{
+		Pair:         currencyPair,
+		ContractType: contractType,
+		Amount:       ins.OpenInterest,
+		Timestamp:    ins.Timestamp.UnixNano() / int64(time.Millisecond),
+	}, nil
+}
@@ -54,8 +54,23 @@ type SwapWs struct {
 }
 
 func NewSwapWs() *SwapWs {
+	return NewSwapWsWithEnv(ENV_PRODUCTION)
+}
+
+// NewSwapWsWithEnv env 为 ENV_TESTNET 时连接测试网
+func NewSwapWsWithEnv(env Environment) *SwapWs {
+	return NewSwapWsWithConfig(&WsClientConfig{Env: env})
+}
+
+// NewSwapWsWithConfig config 为 nil 时使用默认配置
+func NewSwapWsWithConfig(config *WsClientConfig) *SwapWs {
+	wsUrl := "wss://www.bitmex.com/realtime"
+	if config != nil && config.Env == ENV_TESTNET {
+		wsUrl = "wss://testnet.bitmex.com/realtime"
+	}
+
 	s := new(SwapWs)
-	s.wsBuilder = NewWsBuilder().DisableEnableCompression().WsUrl("wss://www.bitmex.com/realtime")
+	s.wsBuilder = NewWsBuilder().DisableEnableCompression().WsUrl(wsUrl).ClientConfig(config)
 	s.wsBuilder = s.wsBuilder.Heartbeat(func() []byte { return []byte("ping") }, 5*time.Second)
 	s.wsBuilder = s.wsBuilder.ProtoHandleFunc(s.handle).AutoReconnect()
 	//s.c = wsBuilder.Build()
@@ -0,0 +1,239 @@
+package bitmex
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// Wallet bitmex 只有一个保证金钱包, 划转只支持转到关联的子账户(SubAccount 为目标 userId)
+type Wallet struct {
+	bm *Bitmex
+}
+
+// WalletTransaction 钱包流水, Amount 和 Fee 已换算为币的数量, 转出为负数
+type WalletTransaction struct {
+	TransactID     string
+	Currency       Currency
+	TransactType   string //Deposit、Withdrawal、Transfer、RealisedPNL 等
+	TransactStatus string //Completed、Pending、Canceled
+	Amount         float64
+	Fee            float64
+	Address        string
+	Tx             string
+	Text           string
+	TransactTime   time.Time
+}
+
+type bitmexTransaction struct {
+	TransactID     string    `json:"transactID"`
+	Currency       string    `json:"currency"`
+	TransactType   string    `json:"transactType"`
+	Amount         float64   `json:"amount"`
+	Fee            float64   `json:"fee"`
+	TransactStatus string    `json:"transactStatus"`
+	Address        string    `json:"address"`
+	Tx             string    `json:"tx"`
+	Text           string    `json:"text"`
+	TransactTime   time.Time `json:"transactTime"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func NewWallet(c *APIConfig) *Wallet {
+	return &Wallet{bm: New(c)}
+}
+
+// adaptWalletCurrency 钱包接口的币种及其最小单位, 如 XBt 为聪
+func adaptWalletCurrency(currency Currency) (string, float64) {
+	switch {
+	case currency.Eq(BTC), currency.Eq(XBT):
+		return "XBt", 1e8
+	case currency.Eq(USDT):
+		return "USDt", 1e6
+	}
+	return strings.ToLower(currency.Symbol), 1
+}
+
+func adaptCurrency(currency string) (Currency, float64) {
+	switch strings.ToLower(currency) {
+	case "xbt":
+		return BTC, 1e8
+	case "usdt":
+		return USDT, 1e6
+	}
+	return NewCurrency(strings.ToUpper(currency), ""), 1
+}
+
+func (w *Wallet) GetAccount() (*Account, error) {
+	var response []struct {
+		Currency           string  `json:"currency"`
+		WalletBalance      float64 `json:"walletBalance"`
+		WithdrawableMargin float64 `json:"withdrawableMargin"`
+	}
+
+	err := w.bm.doAuthRequest("GET", "/api/v1/user/margin?currency=all", "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &Account{
+		Exchange:    BITMEX,
+		SubAccounts: make(map[Currency]SubAccount, len(response)),
+	}
+	for _, m := range response {
+		currency, scale := adaptCurrency(m.Currency)
+		frozen := m.WalletBalance - m.WithdrawableMargin
+		if frozen < 0 {
+			frozen = 0
+		}
+		acc.SubAccounts[currency] = SubAccount{
+			Currency:     currency,
+			Amount:       m.WalletBalance / scale,
+			ForzenAmount: frozen / scale,
+		}
+	}
+
+	return acc, nil
+}
+
+// Withdrawal 开启了两步验证时 TradePwd 为 otpToken
+func (w *Wallet) Withdrawal(param WithdrawParameter) (withdrawId string, err error) {
+	currency, scale := adaptWalletCurrency(NewCurrency(param.Currency, ""))
+
+	var (
+		request = map[string]interface{}{
+			"currency": currency,
+			"amount":   int64(param.Amount * scale),
+			"address":  param.ToAddress,
+		}
+		response bitmexTransaction
+	)
+	if param.Fee != "" {
+		request["fee"] = int64(ToFloat64(param.Fee) * scale)
+	}
+	if param.TradePwd != "" {
+		request["otpToken"] = param.TradePwd
+	}
+
+	err = w.bm.doAuthRequest("POST", "/api/v1/user/requestWithdrawal", w.bm.toJson(request), &response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.TransactID, nil
+}
+
+func (w *Wallet) Transfer(param TransferParameter) error {
+	if param.To != SUB_ACCOUNT || param.SubAccount == "" {
+		return EX_ERR_NOT_SUPPORT
+	}
+
+	currency, scale := adaptWalletCurrency(NewCurrency(param.Currency, ""))
+
+	var response bitmexTransaction
+	return w.bm.doAuthRequest("POST", "/api/v1/user/walletTransfer", w.bm.toJson(map[string]interface{}{
+		"currency":     currency,
+		"amount":       int64(param.Amount * scale),
+		"targetUserId": ToInt64(param.SubAccount),
+	}), &response)
+}
+
+/**
+ * 钱包流水, 按时间倒序
+ * @param opt 透传给 /api/v1/user/walletHistory, 如 count(默认100)、start(偏移量)、startTime、endTime
+ */
+func (w *Wallet) GetWalletHistory(currency Currency, opt ...OptionalParameter) ([]WalletTransaction, error) {
+	walletCurrency, _ := adaptWalletCurrency(currency)
+
+	param := url.Values{}
+	param.Set("currency", walletCurrency)
+	param.Set("count", "100")
+	MergeOptionalParameter(&param, opt...)
+
+	var response []bitmexTransaction
+	err := w.bm.doAuthRequest("GET", "/api/v1/user/walletHistory?"+param.Encode(), "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []WalletTransaction
+	for _, t := range response {
+		c, scale := adaptCurrency(t.Currency)
+		transactTime := t.TransactTime
+		if transactTime.IsZero() {
+			transactTime = t.Timestamp
+		}
+		history = append(history, WalletTransaction{
+			TransactID:     t.TransactID,
+			Currency:       c,
+			TransactType:   t.TransactType,
+			TransactStatus: t.TransactStatus,
+			Amount:         t.Amount / scale,
+			Fee:            t.Fee / scale,
+			Address:        t.Address,
+			Tx:             t.Tx,
+			Text:           t.Text,
+			TransactTime:   transactTime,
+		})
+	}
+
+	return history, nil
+}
+
+func (w *Wallet) GetWithDrawHistory(currency *Currency) ([]DepositWithdrawHistory, error) {
+	return w.getDepositWithdrawHistory(currency, "Withdrawal")
+}
+
+func (w *Wallet) GetDepositHistory(currency *Currency) ([]DepositWithdrawHistory, error) {
+	return w.getDepositWithdrawHistory(currency, "Deposit")
+}
+
+func (w *Wallet) getDepositWithdrawHistory(currency *Currency, transactType string) ([]DepositWithdrawHistory, error) {
+	c := BTC
+	if currency != nil {
+		c = *currency
+	}
+
+	history, err := w.GetWalletHistory(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DepositWithdrawHistory
+	for _, t := range history {
+		if t.TransactType != transactType {
+			continue
+		}
+
+		//状态与 okex 一致: 0 等待中, 2 已完成, -2 已撤销
+		status := 0
+		switch t.TransactStatus {
+		case "Completed":
+			status = 2
+		case "Canceled":
+			status = -2
+		}
+
+		r := DepositWithdrawHistory{
+			Currency:  t.Currency.Symbol,
+			Txid:      t.Tx,
+			Amount:    t.Amount,
+			To:        t.Address,
+			Memo:      t.Text,
+			Fee:       fmt.Sprint(t.Fee),
+			Status:    status,
+			Timestamp: t.TransactTime,
+		}
+		if transactType == "Withdrawal" {
+			r.WithdrawalId = t.TransactID
+			r.Amount = math.Abs(t.Amount)
+		}
+		records = append(records, r)
+	}
+
+	return records, nil
+}
@@ -465,3 +465,13 @@ func (bitstamp *Bitstamp) GetTrades(currencyPair CurrencyPair, since int64) ([]T
 func (bitstamp *Bitstamp) GetExchangeName() string {
 	return BITSTAMP
 }
+
+// GetAllCurrencyPair 未对接交易对列表接口
+func (bitstamp *Bitstamp) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	return nil, EX_ERR_NOT_SUPPORT
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (bitstamp *Bitstamp) GetTimestamp() (int64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
+}
@@ -0,0 +1,13 @@
+package bitstamp
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(BITSTAMP, Driver{
+		Spot: func(config *APIConfig) API {
+			return NewBitstamp(config.HttpClient, config.ApiKey, config.ApiSecretKey, config.ClientId)
+		},
+	})
+}
@@ -117,3 +117,13 @@ func (bx *Bittrex) GetTrades(currencyPair CurrencyPair, since int64) ([]Trade, e
 func (bx *Bittrex) GetExchangeName() string {
 	return BITTREX
 }
+
+// GetAllCurrencyPair 未对接交易对列表接口
+func (bx *Bittrex) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	return nil, EX_ERR_NOT_SUPPORT
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (bx *Bittrex) GetTimestamp() (int64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
+}
@@ -0,0 +1,13 @@
+package bittrex
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(BITTREX, Driver{
+		Spot: func(config *APIConfig) API {
+			return New(config.HttpClient, config.ApiKey, config.ApiSecretKey)
+		},
+	})
+}
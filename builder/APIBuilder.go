@@ -2,23 +2,36 @@ package builder
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	. "github.com/BTreeNewBee/goex"
-	"github.com/BTreeNewBee/goex/binance"
-	"github.com/BTreeNewBee/goex/bitmex"
-	"github.com/BTreeNewBee/goex/coinbene"
-	"github.com/BTreeNewBee/goex/gateio"
-	"github.com/BTreeNewBee/goex/mxc"
-
-	//"github.com/BTreeNewBee/goex/coin58"
 	"net"
 	"net/http"
 	"net/url"
 	"time"
 
-	"github.com/BTreeNewBee/goex/huobi"
-	"github.com/BTreeNewBee/goex/okex"
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+
+	//交易所包在 init 中注册驱动, 以下包没有注册:
+	//bigone、bithumb、gdax 不能编译; allcoin、coinbig 已停止运营且没有交易所常量
+	_ "github.com/BTreeNewBee/goex/atop"
+	_ "github.com/BTreeNewBee/goex/binance"
+	_ "github.com/BTreeNewBee/goex/bitfinex"
+	_ "github.com/BTreeNewBee/goex/bitget"
+	_ "github.com/BTreeNewBee/goex/bitmex"
+	_ "github.com/BTreeNewBee/goex/bitstamp"
+	_ "github.com/BTreeNewBee/goex/bittrex"
+	_ "github.com/BTreeNewBee/goex/coinbene"
+	_ "github.com/BTreeNewBee/goex/coinex"
+	_ "github.com/BTreeNewBee/goex/exx"
+	_ "github.com/BTreeNewBee/goex/gateio"
+	_ "github.com/BTreeNewBee/goex/hitbtc"
+	_ "github.com/BTreeNewBee/goex/huobi"
+	_ "github.com/BTreeNewBee/goex/kraken"
+	_ "github.com/BTreeNewBee/goex/kucoin"
+	_ "github.com/BTreeNewBee/goex/mxc"
+	_ "github.com/BTreeNewBee/goex/okex"
+	_ "github.com/BTreeNewBee/goex/poloniex"
+	_ "github.com/BTreeNewBee/goex/zb"
 )
 
 type APIBuilder struct {
@@ -31,6 +44,20 @@ type APIBuilder struct {
 	apiPassphrase    string
 	futuresEndPoint  string
 	endPoint         string
+	env              Environment
+
+	wsEndPoint        string
+	futuresWsEndPoint string
+	wsConfig          *WsClientConfig
+
+	httpDoer        HttpDoer
+	fastHttp        bool
+	httpMiddlewares []HttpMiddleware
+
+	log   Logger
+	clock Clock
+
+	inst Instrumentation
 }
 
 type HttpClientConfig struct {
@@ -74,12 +101,14 @@ var (
 )
 
 func NewAPIBuilder() (builder *APIBuilder) {
-	return NewAPIBuilder2(DefaultHttpClientConfig)
+	return NewAPIBuilder2(nil)
 }
 
+// NewAPIBuilder2 config 为 nil 时复制一份 DefaultHttpClientConfig, 各 builder 的代理等设置互不影响
 func NewAPIBuilder2(config *HttpClientConfig) *APIBuilder {
 	if config == nil {
-		config = DefaultHttpClientConfig
+		defaultConfig := *DefaultHttpClientConfig
+		config = &defaultConfig
 	}
 
 	return &APIBuilder{
@@ -111,6 +140,14 @@ func (builder *APIBuilder) GetHttpClientConfig() *HttpClientConfig {
 	return builder.HttpClientConfig
 }
 
+// httpProxy NewCustomAPIBuilder 创建的 builder 没有 HttpClientConfig, 此时没有代理
+func (builder *APIBuilder) httpProxy() *url.URL {
+	if builder.HttpClientConfig == nil {
+		return nil
+	}
+	return builder.HttpClientConfig.Proxy
+}
+
 func (builder *APIBuilder) GetHttpClient() *http.Client {
 	return builder.client
 }
@@ -124,8 +161,9 @@ func (builder *APIBuilder) HttpProxy(proxyUrl string) (_builder *APIBuilder) {
 		return builder
 	}
 	builder.HttpClientConfig.Proxy = proxy
-	transport := builder.client.Transport.(*http.Transport)
-	transport.Proxy = http.ProxyURL(proxy)
+	if transport := builder.httpTransport(); transport != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
 	return builder
 }
 
@@ -133,7 +171,7 @@ func (builder *APIBuilder) HttpTimeout(timeout time.Duration) (_builder *APIBuil
 	builder.HttpClientConfig.HttpTimeout = timeout
 	builder.httpTimeout = timeout
 	builder.client.Timeout = timeout
-	transport := builder.client.Transport.(*http.Transport)
+	transport := builder.httpTransport()
 	if transport != nil {
 		//transport.ResponseHeaderTimeout = timeout
 		//transport.TLSHandshakeTimeout = timeout
@@ -175,225 +213,232 @@ func (builder *APIBuilder) Endpoint(endpoint string) (_builer *APIBuilder) {
 	return builder
 }
 
-func (builder *APIBuilder) Build(exName string) (api API) {
-	var _api API
-	switch exName {
-	//case KUCOIN:
-	//	_api = kucoin.New(builder.apiKey, builder.secretkey, builder.apiPassphrase)
-	////case OKCOIN_CN:
-	////	_api = okcoin.New(builder.client, builder.apiKey, builder.secretkey)
-	//case POLONIEX:
-	//	_api = poloniex.New(builder.client, builder.apiKey, builder.secretkey)
-	////case OKCOIN_COM:
-	////	_api = okcoin.NewCOM(builder.client, builder.apiKey, builder.secretkey)
-	//case BITSTAMP:
-	//	_api = bitstamp.NewBitstamp(builder.client, builder.apiKey, builder.secretkey, builder.clientId)
-	case HUOBI_PRO:
-		//_api = huobi.NewHuoBiProSpot(builder.client, builder.apiKey, builder.secretkey)
-		_api = huobi.NewHuobiWithConfig(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.endPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey})
-	case OKEX_V3, OKEX:
-		_api = okex.NewOKEx(&APIConfig{
-			HttpClient:    builder.client,
-			ApiKey:        builder.apiKey,
-			ApiSecretKey:  builder.secretkey,
-			ApiPassphrase: builder.apiPassphrase,
-			Endpoint:      builder.endPoint,
+func (builder *APIBuilder) WsEndpoint(endpoint string) (_builder *APIBuilder) {
+	builder.wsEndPoint = endpoint
+	return builder
+}
+
+func (builder *APIBuilder) FuturesWsEndpoint(endpoint string) (_builder *APIBuilder) {
+	builder.futuresWsEndPoint = endpoint
+	return builder
+}
+
+// WsConfig ws 连接的 tls、握手超时、本地ip等配置, 其中 WsUrl 和 Env 由 WsEndpoint 和 Env 设置
+func (builder *APIBuilder) WsConfig(c WsClientConfig) (_builder *APIBuilder) {
+	builder.wsConfig = &c
+	return builder
+}
+
+// HttpDoer 使用自定义的 HttpDoer 执行 rest 请求, 此时 HttpProxy、HttpTimeout 由 doer 自行处理
+func (builder *APIBuilder) HttpDoer(doer HttpDoer) (_builder *APIBuilder) {
+	builder.httpDoer = doer
+	return builder
+}
+
+// FastHttp 使用 fasthttp 执行 rest 请求, 代理和超时同 HttpProxy、HttpTimeout, 代理只支持 socks5 和 http
+func (builder *APIBuilder) FastHttp() (_builder *APIBuilder) {
+	builder.fastHttp = true
+	return builder
+}
+
+// HttpMiddleware 添加 rest 请求的中间件, 按添加顺序由外到内执行
+func (builder *APIBuilder) HttpMiddleware(middlewares ...HttpMiddleware) (_builder *APIBuilder) {
+	builder.httpMiddlewares = append(builder.httpMiddlewares, middlewares...)
+	return builder
+}
+
+// Logger 创建的客户端使用的日志, 不设置时使用全局日志(goex.SetLogger)
+func (builder *APIBuilder) Logger(l Logger) (_builder *APIBuilder) {
+	builder.log = l
+	return builder
+}
+
+// Clock 签名时间戳使用的时钟, 不设置时与交易所服务器时间同步, goex.LocalClock 为不同步
+func (builder *APIBuilder) Clock(c Clock) (_builder *APIBuilder) {
+	builder.clock = c
+	return builder
+}
+
+// Instrument 收集请求耗时、错误、限频、ws 消息等指标以及链路追踪, 未设置时没有额外开销
+// TryBuild、TryBuildFuture 等返回包装后的对象, 使用 UnwrapAPI、UnwrapFutureRestAPI 取回原始对象
+// NewAmendOrderAPI、NewBatchOrderAPI、trigger 等会自动取回原始对象判断交易所是否原生支持
+func (builder *APIBuilder) Instrument(inst Instrumentation) (_builder *APIBuilder) {
+	builder.inst = inst
+	return builder
+}
+
+// Env 运行环境, ENV_TESTNET 时未设置 Endpoint 的 rest 和 ws 都连接测试网, 交易所没有测试网时 TryBuild* 返回 *UnsupportedError
+func (builder *APIBuilder) Env(env Environment) (_builder *APIBuilder) {
+	builder.env = env
+	return builder
+}
+
+// httpClient 设置了 HttpDoer、FastHttp、HttpMiddleware 或 Instrument 时使用 NewHttpClient 包装
+func (builder *APIBuilder) httpClient(exName string) (*http.Client, error) {
+	middlewares := builder.httpMiddlewares
+	if builder.inst.Metrics != nil {
+		//放在最内层, 不包含限频等待的时间
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], NewMetricsMiddleware(exName, builder.inst.Metrics))
+	}
+
+	doer := builder.httpDoer
+	if doer == nil && builder.fastHttp {
+		fastHttpDoer, err := NewFastHttpDoer(FastHttpConfig{
+			Proxy:   builder.httpProxy(),
+			Timeout: builder.client.Timeout,
 		})
-	//case BITFINEX:
-	//	_api = bitfinex.New(builder.client, builder.apiKey, builder.secretkey)
-	//case KRAKEN:
-	//	_api = kraken.New(builder.client, builder.apiKey, builder.secretkey)
-	case BINANCE:
-		//_api = binance.New(builder.client, builder.apiKey, builder.secretkey)
-		_api = binance.NewWithConfig(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.endPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey})
-	case GATEIO:
-		//_api = binance.New(builder.client, builder.apiKey, builder.secretkey)
-		_api = gateio.NewGateioWithConfig(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.endPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey})
-	case MXC:
-		//_api = binance.New(builder.client, builder.apiKey, builder.secretkey)
-		_api = mxc.NewMxcWithConfig(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.endPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey})
-	//case BITTREX:
-	//	_api = bittrex.New(builder.client, builder.apiKey, builder.secretkey)
-	//case BITHUMB:
-	//	_api = bithumb.New(builder.client, builder.apiKey, builder.secretkey)
-	//case GDAX:
-	//	_api = gdax.New(builder.client, builder.apiKey, builder.secretkey)
-	//case ZB:
-	//	_api = zb.New(builder.client, builder.apiKey, builder.secretkey)
-	//case COINEX:
-	//	_api = coinex.New(builder.client, builder.apiKey, builder.secretkey)
-	//case BIGONE:
-	//	_api = bigone.New(builder.client, builder.apiKey, builder.secretkey)
-	//case HITBTC:
-	//	_api = hitbtc.New(builder.client, builder.apiKey, builder.secretkey)
-	//case ATOP:
-	//	_api = atop.New(builder.client, builder.apiKey, builder.secretkey)
-	default:
-		println("exchange name error [" + exName + "].")
+		if err != nil {
+			return nil, err
+		}
+		doer = fastHttpDoer
+	}
 
+	if doer == nil {
+		if len(middlewares) == 0 {
+			return builder.client, nil
+		}
+		doer = builder.client
 	}
-	return _api
+
+	return NewHttpClient(doer, middlewares...), nil
 }
 
-func (builder *APIBuilder) BuildFuture(exName string) (api FutureRestAPI) {
-	switch exName {
-	case BITMEX:
-		return bitmex.New(&APIConfig{
-			//Endpoint:     "https://www.bitmex.com/",
-			Endpoint:     builder.futuresEndPoint,
-			HttpClient:   builder.client,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey})
-	case BITMEX_TEST:
-		return bitmex.New(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     "https://testnet.bitmex.com",
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey,
-		})
-	case OKEX_FUTURE, OKEX_V3:
-		//return okcoin.NewOKEx(builder.client, builder.apiKey, builder.secretkey)
-		return okex.NewOKEx(&APIConfig{
-			HttpClient: builder.client,
-			//	Endpoint:      "https://www.okex.com",
-			Endpoint:      builder.futuresEndPoint,
-			ApiKey:        builder.apiKey,
-			ApiSecretKey:  builder.secretkey,
-			ApiPassphrase: builder.apiPassphrase}).OKExFuture
-	case HBDM:
-		return huobi.NewHbdm(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.futuresEndPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey})
-	case HBDM_SWAP:
-		return huobi.NewHbdmSwap(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.endPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey,
-		})
-	case OKEX_SWAP:
-		return okex.NewOKEx(&APIConfig{
-			HttpClient:    builder.client,
-			Endpoint:      builder.futuresEndPoint,
-			ApiKey:        builder.apiKey,
-			ApiSecretKey:  builder.secretkey,
-			ApiPassphrase: builder.apiPassphrase}).OKExSwap
-	case COINBENE:
-		return coinbene.NewCoinbeneSwap(APIConfig{
-			HttpClient: builder.client,
-			//	Endpoint:     "http://openapi-contract.coinbene.com",
-			Endpoint:     builder.futuresEndPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey,
-		})
+func (builder *APIBuilder) apiConfig(exName, endpoint string) (*APIConfig, error) {
+	client, err := builder.httpClient(exName)
+	if err != nil {
+		return nil, err
+	}
+	return &APIConfig{
+		HttpClient:    client,
+		Endpoint:      endpoint,
+		ApiKey:        builder.apiKey,
+		ApiSecretKey:  builder.secretkey,
+		ApiPassphrase: builder.apiPassphrase,
+		ClientId:      builder.clientId,
+		Env:           builder.env,
+		Logger:        builder.log,
+		Clock:         builder.clock,
+	}, nil
+}
 
-	case BINANCE_SWAP:
-		return binance.NewBinanceSwap(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.futuresEndPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey,
-		})
-	case BINANCE, BINANCE_FUTURES:
-		return binance.NewBinanceFutures(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.futuresEndPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey,
-		})
-	default:
-		println(fmt.Sprintf("%s not support future", exName))
-		return nil
+// wsApiConfig ws 连接使用 builder 的代理, 不同 builder 创建的连接互不影响
+func (builder *APIBuilder) wsApiConfig(exName, endpoint, wsEndpoint string) (*APIConfig, error) {
+	config, err := builder.apiConfig(exName, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	ws := WsClientConfig{}
+	if builder.wsConfig != nil {
+		ws = *builder.wsConfig
+	}
+	ws.WsUrl = wsEndpoint
+	ws.Env = builder.env
+	if proxy := builder.httpProxy(); ws.ProxyUrl == "" && proxy != nil {
+		ws.ProxyUrl = proxy.String()
 	}
+	ws.Metrics = builder.inst.Metrics
+	config.Ws = &ws
+	return config, nil
 }
 
-func (builder *APIBuilder) BuildFuturesWs(exName string) (FuturesWsApi, error) {
-	switch exName {
-	case OKEX_V3, OKEX, OKEX_FUTURE:
-		return okex.NewOKExV3FuturesWs(okex.NewOKEx(&APIConfig{
-			HttpClient: builder.client,
-			Endpoint:   builder.futuresEndPoint,
-		})), nil
-	case HBDM:
-		return huobi.NewHbdmWs(), nil
-	case HBDM_SWAP:
-		return huobi.NewHbdmSwapWs(), nil
-	case BINANCE, BINANCE_FUTURES, BINANCE_SWAP:
-		return binance.NewFuturesWs(), nil
-	case BITMEX:
-		return bitmex.NewSwapWs(), nil
+// TryBuild 现货 api, 交易所未注册或者不支持时返回 *UnsupportedError
+func (builder *APIBuilder) TryBuild(exName string) (API, error) {
+	d, err := GetDriverForEnv(exName, CAP_SPOT, builder.env)
+	if err != nil {
+		return nil, err
+	}
+	config, err := builder.apiConfig(exName, builder.endPoint)
+	if err != nil {
+		return nil, err
+	}
+	return InstrumentAPI(d.Spot(config), builder.inst), nil
+}
+
+// Build 不支持时返回 nil, 需要错误信息请使用 TryBuild
+func (builder *APIBuilder) Build(exName string) (api API) {
+	api, err := builder.TryBuild(exName)
+	if err != nil {
+		logger.Error(err)
+	}
+	return api
+}
+
+// TryBuildFuture 合约 api, 使用 FuturesEndpoint 设置的地址
+func (builder *APIBuilder) TryBuildFuture(exName string) (FutureRestAPI, error) {
+	d, err := GetDriverForEnv(exName, CAP_FUTURE, builder.env)
+	if err != nil {
+		return nil, err
+	}
+	config, err := builder.apiConfig(exName, builder.futuresEndPoint)
+	if err != nil {
+		return nil, err
+	}
+	return InstrumentFutureRestAPI(d.Future(config), builder.inst), nil
+}
+
+// BuildFuture 不支持时返回 nil, 需要错误信息请使用 TryBuildFuture
+func (builder *APIBuilder) BuildFuture(exName string) (api FutureRestAPI) {
+	api, err := builder.TryBuildFuture(exName)
+	if err != nil {
+		logger.Error(err)
 	}
-	return nil, errors.New("not support the exchange " + exName)
+	return api
 }
 
+// TryBuildLinearFuture U本位合约 api, 使用 FuturesEndpoint 设置的地址
+func (builder *APIBuilder) TryBuildLinearFuture(exName string) (FutureRestAPI, error) {
+	d, err := GetDriverForEnv(exName, CAP_LINEAR_FUTURE, builder.env)
+	if err != nil {
+		return nil, err
+	}
+	config, err := builder.apiConfig(exName, builder.futuresEndPoint)
+	if err != nil {
+		return nil, err
+	}
+	return InstrumentFutureRestAPI(d.LinearFuture(config), builder.inst), nil
+}
+
+// BuildLinearFuture 不支持时返回 nil, 需要错误信息请使用 TryBuildLinearFuture
 func (builder *APIBuilder) BuildLinearFuture(exName string) (api FutureRestAPI) {
-	switch exName {
-	case HBDM_LINEAR_SWAP:
-		return huobi.NewHbdmLinearSwap(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.endPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey,
-		})
-	default:
-		println(fmt.Sprintf("%s not support future", exName))
-		return nil
+	api, err := builder.TryBuildLinearFuture(exName)
+	if err != nil {
+		logger.Error(err)
 	}
+	return api
+}
+
+func (builder *APIBuilder) BuildFuturesWs(exName string) (FuturesWsApi, error) {
+	d, err := GetDriverForEnv(exName, CAP_FUTURES_WS, builder.env)
+	if err != nil {
+		return nil, err
+	}
+	config, err := builder.wsApiConfig(exName, builder.futuresEndPoint, builder.futuresWsEndPoint)
+	if err != nil {
+		return nil, err
+	}
+	return d.FuturesWs(config), nil
 }
 
 func (builder *APIBuilder) BuildSpotWs(exName string) (SpotWsApi, error) {
-	switch exName {
-	//case OKEX_V3, OKEX:
-	//	return okex.NewOKExSpotV3Ws(nil), nil
-	case HUOBI_PRO, HUOBI:
-		return huobi.NewSpotWs(), nil
-	case BINANCE:
-		return binance.NewSpotWs(), nil
+	d, err := GetDriverForEnv(exName, CAP_SPOT_WS, builder.env)
+	if err != nil {
+		return nil, err
+	}
+	config, err := builder.wsApiConfig(exName, builder.endPoint, builder.wsEndPoint)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("not support the exchange " + exName)
+	return d.SpotWs(config), nil
 }
 
 func (builder *APIBuilder) BuildWallet(exName string) (WalletApi, error) {
-	switch exName {
-	case OKEX_V3, OKEX:
-		return okex.NewOKEx(&APIConfig{
-			HttpClient:    builder.client,
-			ApiKey:        builder.apiKey,
-			ApiSecretKey:  builder.secretkey,
-			ApiPassphrase: builder.apiPassphrase,
-		}).OKExWallet, nil
-	case HUOBI_PRO:
-		return huobi.NewWallet(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.endPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey,
-		}), nil
-	case BINANCE:
-		return binance.NewWallet(&APIConfig{
-			HttpClient:   builder.client,
-			Endpoint:     builder.endPoint,
-			ApiKey:       builder.apiKey,
-			ApiSecretKey: builder.secretkey,
-		}), nil
+	d, err := GetDriverForEnv(exName, CAP_WALLET, builder.env)
+	if err != nil {
+		return nil, err
+	}
+	config, err := builder.apiConfig(exName, builder.endPoint)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("not support the wallet api for  " + exName)
+	return d.Wallet(config), nil
 }
@@ -2,9 +2,13 @@ package builder
 
 import (
 	"github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/binance"
+	"github.com/BTreeNewBee/goex/bitmex"
 	"github.com/BTreeNewBee/goex/internal/logger"
 	"github.com/stretchr/testify/assert"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -16,15 +20,174 @@ func init() {
 }
 
 func TestAPIBuilder_Build(t *testing.T) {
-	assert.Equal(t, builder.APIKey("").APISecretkey("").Build(goex.OKCOIN_COM).GetExchangeName(), goex.OKCOIN_COM)
-	assert.Equal(t, builder.APIKey("").APISecretkey("").Build(goex.HUOBI_PRO).GetExchangeName(), goex.HUOBI_PRO)
-	assert.Equal(t, builder.APIKey("").APISecretkey("").Build(goex.ZB).GetExchangeName(), goex.ZB)
-	assert.Equal(t, builder.APIKey("").APISecretkey("").Build(goex.BIGONE).GetExchangeName(), goex.BIGONE)
-	assert.Equal(t, builder.APIKey("").APISecretkey("").Build(goex.OKEX).GetExchangeName(), goex.OKEX)
-	assert.Equal(t, builder.APIKey("").APISecretkey("").Build(goex.POLONIEX).GetExchangeName(), goex.POLONIEX)
-	assert.Equal(t, builder.APIKey("").APISecretkey("").Build(goex.KRAKEN).GetExchangeName(), goex.KRAKEN)
-	assert.Equal(t, builder.APIKey("").APISecretkey("").Build(goex.FCOIN_MARGIN).GetExchangeName(), goex.FCOIN_MARGIN)
+	//huobi 创建时会请求交易对列表, 不在这里测试
+	for _, exName := range []string{goex.ZB, goex.OKEX, goex.POLONIEX, goex.KRAKEN, goex.BITSTAMP,
+		goex.BITFINEX, goex.BITTREX, goex.COINEX, goex.HITBTC, goex.ATOP} {
+		api := builder.APIKey("").APISecretkey("").Build(exName)
+		if assert.NotNil(t, api, exName) {
+			assert.Equal(t, exName, api.GetExchangeName())
+		}
+	}
 	assert.Equal(t, builder.APIKey("").APISecretkey("").BuildFuture(goex.HBDM).GetExchangeName(), goex.HBDM)
+	assert.Equal(t, builder.APIKey("").APISecretkey("").BuildFuture(goex.BITGET_SWAP).GetExchangeName(), goex.BITGET_SWAP)
+
+	//未注册的交易所返回 nil
+	assert.Nil(t, builder.Build(goex.OKCOIN_COM))
+	assert.Nil(t, builder.Build(goex.BIGONE))
+}
+
+func TestAPIBuilder_TryBuild(t *testing.T) {
+	api, err := builder.TryBuild(goex.KRAKEN)
+	assert.Nil(t, err)
+	assert.Equal(t, goex.KRAKEN, api.GetExchangeName())
+
+	_, err = builder.TryBuild("not_exist")
+	assert.True(t, goex.IsUnsupportedError(err))
+
+	_, err = builder.BuildSpotWs(goex.BITMEX)
+	assert.Equal(t, &goex.UnsupportedError{Exchange: goex.BITMEX, Capability: goex.CAP_SPOT_WS}, err)
+
+	assert.Contains(t, goex.SupportedExchanges(goex.CAP_LINEAR_FUTURE), goex.HBDM_LINEAR_SWAP)
+	assert.Contains(t, goex.ExchangeCapabilities(goex.HUOBI_PRO), goex.CAP_WALLET)
+}
+
+func TestAPIBuilder_Env(t *testing.T) {
+	testnet := NewAPIBuilder().Env(goex.ENV_TESTNET)
+
+	api, err := testnet.TryBuildFuture(goex.BITMEX)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://testnet.bitmex.com", api.(*bitmex.Bitmex).Endpoint)
+
+	_, err = testnet.TryBuild(goex.HUOBI_PRO)
+	assert.Equal(t, &goex.UnsupportedError{Exchange: goex.HUOBI_PRO, Capability: goex.CAP_SPOT, Env: goex.ENV_TESTNET}, err)
+
+	_, err = testnet.BuildWallet(goex.BINANCE)
+	assert.True(t, goex.IsUnsupportedError(err))
+
+	assert.Contains(t, goex.SupportedExchangesForEnv(goex.CAP_SPOT, goex.ENV_TESTNET), goex.KUCOIN)
+	assert.NotContains(t, goex.SupportedExchangesForEnv(goex.CAP_SPOT, goex.ENV_TESTNET), goex.HUOBI_PRO)
+	assert.Contains(t, goex.SupportedExchangesForEnv(goex.CAP_FUTURE, goex.ENV_TESTNET), goex.OKEX_SWAP)
+
+	//okex 模拟盘没有 ws
+	_, err = testnet.BuildFuturesWs(goex.OKEX_V3)
+	assert.True(t, goex.IsUnsupportedError(err))
+}
+
+func TestAPIBuilder_WsConfig(t *testing.T) {
+	proxied := NewAPIBuilder().HttpProxy("socks5://127.0.0.1:1080").
+		WsEndpoint("wss://spot.example.com/ws").
+		FuturesWsEndpoint("wss://futures.example.com/ws").
+		WsConfig(goex.WsClientConfig{LocalAddr: "10.0.0.2", HandshakeTimeout: 5 * time.Second})
+	direct := NewAPIBuilder()
+
+	config, _ := proxied.wsApiConfig(goex.BINANCE, "", proxied.wsEndPoint)
+	assert.Equal(t, "socks5://127.0.0.1:1080", config.Ws.ProxyUrl)
+	assert.Equal(t, "wss://spot.example.com/ws", config.Ws.WsUrl)
+	assert.Equal(t, "10.0.0.2", config.Ws.LocalAddr)
+	config, _ = proxied.wsApiConfig(goex.BINANCE, "", proxied.futuresWsEndPoint)
+	assert.Equal(t, "wss://futures.example.com/ws", config.Ws.WsUrl)
+
+	//不同 builder 的代理互不影响
+	assert.Nil(t, direct.HttpClientConfig.Proxy)
+	config, _ = direct.wsApiConfig(goex.BINANCE, "", "")
+	assert.Equal(t, "", config.Ws.ProxyUrl)
+}
+
+func TestAPIBuilder_CustomClient(t *testing.T) {
+	custom := NewCustomAPIBuilder(http.DefaultClient)
+
+	spotWs, err := custom.BuildSpotWs(goex.BINANCE)
+	assert.Nil(t, err)
+	assert.NotNil(t, spotWs)
+	futuresWs, err := custom.BuildFuturesWs(goex.BINANCE)
+	assert.Nil(t, err)
+	assert.NotNil(t, futuresWs)
+
+	api, err := custom.FastHttp().TryBuild(goex.BINANCE)
+	assert.Nil(t, err)
+	assert.NotNil(t, api)
+}
+
+func TestAPIBuilder_HttpDoer(t *testing.T) {
+	var requests int
+	counter := func(next goex.HttpDoer) goex.HttpDoer {
+		return goex.HttpDoerFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return next.Do(req)
+		})
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	config, err := NewAPIBuilder().FastHttp().HttpMiddleware(counter).apiConfig(goex.BINANCE, "")
+	assert.Nil(t, err)
+	_, err = goex.NewHttpRequest(config.HttpClient, "GET", srv.URL, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+
+	_, err = NewAPIBuilder().HttpProxy("ftp://127.0.0.1:21").FastHttp().TryBuild(goex.KRAKEN)
+	assert.EqualError(t, err, "fasthttp unsupported proxy scheme [ftp]")
+
+	config, _ = NewAPIBuilder().apiConfig(goex.BINANCE, "")
+	assert.IsType(t, &http.Transport{}, config.HttpClient.Transport)
+}
+
+type countMetrics struct {
+	requests int
+}
+
+func (m *countMetrics) ObserveRequest(exchange, method, endpoint string, statusCode int, latency time.Duration) {
+	m.requests++
+}
+func (m *countMetrics) IncApiError(exchange, api, code string) {}
+func (m *countMetrics) IncRateLimit(exchange, endpoint string) {}
+func (m *countMetrics) IncWsMessage(wsUrl string)              {}
+func (m *countMetrics) IncWsReconnect(wsUrl string)            {}
+
+func TestAPIBuilder_Instrument(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	m := &countMetrics{}
+	b := NewAPIBuilder().Instrument(goex.Instrumentation{Metrics: m})
+	config, err := b.apiConfig(goex.BINANCE, "")
+	assert.Nil(t, err)
+	_, err = goex.NewHttpRequest(config.HttpClient, "GET", srv.URL, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, m.requests)
+
+	config, _ = b.wsApiConfig(goex.BINANCE, "", "")
+	assert.True(t, config.Ws.Metrics == m)
+
+	api, err := b.TryBuild(goex.BINANCE)
+	assert.Nil(t, err)
+	assert.IsType(t, &binance.Binance{}, goex.UnwrapAPI(api))
+	assert.False(t, goex.UnwrapAPI(api) == api)
+
+	//未启用时不包装
+	api, _ = NewAPIBuilder().TryBuild(goex.BINANCE)
+	assert.IsType(t, &binance.Binance{}, api)
+	config, _ = NewAPIBuilder().apiConfig(goex.BINANCE, "")
+	assert.IsType(t, &http.Transport{}, config.HttpClient.Transport)
+}
+
+func TestAPIBuilder_InstrumentNative(t *testing.T) {
+	b := NewAPIBuilder().Instrument(goex.Instrumentation{Metrics: &countMetrics{}})
+
+	//包装后仍使用交易所原生的改单、批量下单
+	api, err := b.TryBuild(goex.BINANCE)
+	assert.Nil(t, err)
+	assert.IsType(t, &binance.Binance{}, goex.NewAmendOrderAPI(api))
+
+	future, err := b.TryBuildFuture(goex.BITMEX)
+	assert.Nil(t, err)
+	assert.IsType(t, &bitmex.Bitmex{}, goex.NewAmendFutureOrderAPI(future))
+	assert.IsType(t, &bitmex.Bitmex{}, goex.NewBatchFutureOrderAPI(future))
+}
+
+func TestAPIBuilder_Clock(t *testing.T) {
+	config, _ := NewAPIBuilder().Clock(goex.LocalClock).apiConfig(goex.BINANCE, "")
+	assert.Equal(t, goex.LocalClock, config.Clock)
 }
 
 func TestAPIBuilder_BuildSpotWs(t *testing.T) {
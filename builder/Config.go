@@ -0,0 +1,386 @@
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// AccountConfig 单个账户的配置
+// ApiKey、ApiSecretKey、ApiPassphrase、ClientId、Proxy 支持引用:
+//
+//	env:NAME  读取环境变量 NAME
+//	file:PATH 读取文件内容(去掉首尾空白), 比如 docker/k8s 挂载的 secret
+type AccountConfig struct {
+	Exchange          string  `json:"exchange" yaml:"exchange" toml:"exchange"` // 驱动名称, 比如: binance.com, 见 goex.RegisteredExchanges
+	ApiKey            string  `json:"api_key" yaml:"api_key" toml:"api_key"`
+	ApiSecretKey      string  `json:"api_secret" yaml:"api_secret" toml:"api_secret"`
+	ApiPassphrase     string  `json:"passphrase" yaml:"passphrase" toml:"passphrase"`
+	ClientId          string  `json:"client_id" yaml:"client_id" toml:"client_id"`
+	Endpoint          string  `json:"endpoint" yaml:"endpoint" toml:"endpoint"`
+	FuturesEndpoint   string  `json:"futures_endpoint" yaml:"futures_endpoint" toml:"futures_endpoint"`
+	WsEndpoint        string  `json:"ws_endpoint" yaml:"ws_endpoint" toml:"ws_endpoint"`
+	FuturesWsEndpoint string  `json:"futures_ws_endpoint" yaml:"futures_ws_endpoint" toml:"futures_ws_endpoint"`
+	Proxy             string  `json:"proxy" yaml:"proxy" toml:"proxy"`
+	HttpLib           string  `json:"http_lib" yaml:"http_lib" toml:"http_lib"`       // net/http(默认) 或 fasthttp
+	Timeout           string  `json:"timeout" yaml:"timeout" toml:"timeout"`          // 比如: 5s, 默认 DefaultHttpClientConfig.HttpTimeout
+	RateLimit         float64 `json:"rate_limit" yaml:"rate_limit" toml:"rate_limit"` // 每秒最多请求数, 0 不限制
+	Testnet           bool    `json:"testnet" yaml:"testnet" toml:"testnet"`          // 连接测试网, 交易所不支持时创建失败
+
+	// 需要创建的客户端: spot, future, linear_future, wallet, spot_ws, futures_ws
+	// 为空时创建交易所支持的所有 rest 客户端(不含 ws)
+	Clients []Capability `json:"clients" yaml:"clients" toml:"clients"`
+}
+
+// Config 多账户配置, 账户名称 => 配置
+type Config struct {
+	Accounts map[string]*AccountConfig `json:"accounts" yaml:"accounts" toml:"accounts"`
+}
+
+var restCapabilities = []Capability{CAP_SPOT, CAP_FUTURE, CAP_LINEAR_FUTURE, CAP_WALLET}
+
+// LoadConfig 按扩展名(.yaml .yml .json .toml)解析配置文件
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data, strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// ParseConfig format: yaml, yml, json, toml
+func ParseConfig(data []byte, format string) (*Config, error) {
+	var (
+		c   Config
+		err error
+	)
+
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &c)
+	case "json":
+		err = json.Unmarshal(data, &c)
+	case "toml":
+		err = toml.Unmarshal(data, &c)
+	default:
+		return nil, errors.New("unsupported config format [" + format + "]")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Accounts == nil {
+		c.Accounts = make(map[string]*AccountConfig)
+	}
+	return &c, nil
+}
+
+// 环境变量字段名, 较长的在前, 避免 FUTURES_ENDPOINT 匹配到 ENDPOINT
+var envFields = []string{"FUTURES_WS_ENDPOINT", "FUTURES_ENDPOINT", "WS_ENDPOINT", "API_SECRET", "PASSPHRASE", "RATE_LIMIT", "CLIENT_ID", "EXCHANGE", "ENDPOINT", "HTTP_LIB", "API_KEY", "TIMEOUT", "TESTNET", "CLIENTS", "PROXY"}
+
+// LoadEnv 从环境变量添加或覆盖账户配置, 格式: <PREFIX>_<账户名>_<字段>, 比如:
+//
+//	GOEX_OKEX_MAIN_API_KEY=xxx 对应账户 okex_main 的 api_key
+//
+// 账户名不区分大小写, 新账户的名称为小写; CLIENTS 以逗号分隔
+func (c *Config) LoadEnv(prefix string) error {
+	prefix = strings.ToUpper(prefix) + "_"
+
+	for _, kv := range os.Environ() {
+		i := strings.Index(kv, "=")
+		if i <= 0 || !strings.HasPrefix(kv[:i], prefix) {
+			continue
+		}
+		key, value := strings.TrimPrefix(kv[:i], prefix), kv[i+1:]
+
+		for _, field := range envFields {
+			if !strings.HasSuffix(key, "_"+field) || len(key) == len(field)+1 {
+				continue
+			}
+			name := strings.TrimSuffix(key, "_"+field)
+			if err := c.account(name).set(field, value); err != nil {
+				return fmt.Errorf("%s: %s", kv[:i], err.Error())
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// account 按名称(不区分大小写)查找账户, 不存在时创建
+func (c *Config) account(name string) *AccountConfig {
+	for n, ac := range c.Accounts {
+		if strings.EqualFold(n, name) {
+			return ac
+		}
+	}
+	ac := &AccountConfig{}
+	c.Accounts[strings.ToLower(name)] = ac
+	return ac
+}
+
+func (ac *AccountConfig) set(field, value string) error {
+	switch field {
+	case "EXCHANGE":
+		ac.Exchange = value
+	case "API_KEY":
+		ac.ApiKey = value
+	case "API_SECRET":
+		ac.ApiSecretKey = value
+	case "PASSPHRASE":
+		ac.ApiPassphrase = value
+	case "CLIENT_ID":
+		ac.ClientId = value
+	case "ENDPOINT":
+		ac.Endpoint = value
+	case "FUTURES_ENDPOINT":
+		ac.FuturesEndpoint = value
+	case "WS_ENDPOINT":
+		ac.WsEndpoint = value
+	case "FUTURES_WS_ENDPOINT":
+		ac.FuturesWsEndpoint = value
+	case "PROXY":
+		ac.Proxy = value
+	case "HTTP_LIB":
+		ac.HttpLib = value
+	case "TIMEOUT":
+		ac.Timeout = value
+	case "RATE_LIMIT":
+		ac.RateLimit = ToFloat64(value)
+	case "TESTNET":
+		testnet, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		ac.Testnet = testnet
+	case "CLIENTS":
+		ac.Clients = nil
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				ac.Clients = append(ac.Clients, Capability(s))
+			}
+		}
+	default:
+		return errors.New("unknown field " + field)
+	}
+	return nil
+}
+
+// resolveSecret 解析 env: 和 file: 引用
+func resolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "env:"):
+		name := strings.TrimPrefix(value, "env:")
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", errors.New("env " + name + " not set")
+		}
+		return v, nil
+	case strings.HasPrefix(value, "file:"):
+		data, err := ioutil.ReadFile(strings.TrimPrefix(value, "file:"))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return value, nil
+}
+
+func (ac *AccountConfig) env() Environment {
+	if ac.Testnet {
+		return ENV_TESTNET
+	}
+	return ENV_PRODUCTION
+}
+
+// NewAPIBuilder 按账户配置创建独立的 APIBuilder(独立的 http.Client)
+func (ac *AccountConfig) NewAPIBuilder() (*APIBuilder, error) {
+	var secrets [5]string
+	for i, v := range []string{ac.ApiKey, ac.ApiSecretKey, ac.ApiPassphrase, ac.ClientId, ac.Proxy} {
+		s, err := resolveSecret(v)
+		if err != nil {
+			return nil, err
+		}
+		secrets[i] = s
+	}
+
+	httpConfig := *DefaultHttpClientConfig
+	if ac.Timeout != "" {
+		timeout, err := time.ParseDuration(ac.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		httpConfig.HttpTimeout = timeout
+	}
+	if secrets[4] != "" {
+		proxy, err := url.Parse(secrets[4])
+		if err != nil {
+			return nil, err
+		}
+		httpConfig.Proxy = proxy
+	}
+
+	builder := NewAPIBuilder2(&httpConfig).Env(ac.env())
+	switch ac.HttpLib {
+	case "", "net/http":
+	case "fasthttp":
+		builder.FastHttp()
+	default:
+		return nil, fmt.Errorf("unsupported http lib [%s]", ac.HttpLib)
+	}
+	if ac.RateLimit > 0 {
+		limiter := &rateLimiter{interval: time.Duration(float64(time.Second) / ac.RateLimit)}
+		builder.HttpMiddleware(limiter.middleware)
+	}
+
+	return builder.APIKey(secrets[0]).
+		APISecretkey(secrets[1]).
+		ApiPassphrase(secrets[2]).
+		ClientID(secrets[3]).
+		Endpoint(ac.Endpoint).
+		FuturesEndpoint(ac.FuturesEndpoint).
+		WsEndpoint(ac.WsEndpoint).
+		FuturesWsEndpoint(ac.FuturesWsEndpoint), nil
+}
+
+// AccountClients 按配置创建的客户端, 未启用的为 nil
+type AccountClients struct {
+	Name         string
+	Exchange     string
+	Builder      *APIBuilder
+	Spot         API
+	Future       FutureRestAPI
+	LinearFuture FutureRestAPI
+	Wallet       WalletApi
+	SpotWs       SpotWsApi
+	FuturesWs    FuturesWsApi
+}
+
+// BuildAccount 创建单个账户的客户端, 显式配置了交易所不支持的客户端时返回 *UnsupportedError
+func (ac *AccountConfig) BuildAccount(name string) (*AccountClients, error) {
+	exName := ac.Exchange
+	if exName == "" {
+		return nil, errors.New("exchange is required")
+	}
+
+	clients := ac.Clients
+	if len(clients) == 0 {
+		for _, c := range restCapabilities {
+			if _, err := GetDriverForEnv(exName, c, ac.env()); err == nil {
+				clients = append(clients, c)
+			}
+		}
+		if len(clients) == 0 {
+			_, err := GetDriverForEnv(exName, CAP_SPOT, ac.env())
+			return nil, err
+		}
+	}
+
+	builder, err := ac.NewAPIBuilder()
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &AccountClients{Name: name, Exchange: exName, Builder: builder}
+	for _, c := range clients {
+		switch c {
+		case CAP_SPOT:
+			acc.Spot, err = builder.TryBuild(exName)
+		case CAP_FUTURE:
+			acc.Future, err = builder.TryBuildFuture(exName)
+		case CAP_LINEAR_FUTURE:
+			acc.LinearFuture, err = builder.TryBuildLinearFuture(exName)
+		case CAP_WALLET:
+			acc.Wallet, err = builder.BuildWallet(exName)
+		case CAP_SPOT_WS:
+			acc.SpotWs, err = builder.BuildSpotWs(exName)
+		case CAP_FUTURES_WS:
+			acc.FuturesWs, err = builder.BuildFuturesWs(exName)
+		default:
+			err = errors.New("unknown client [" + string(c) + "]")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return acc, nil
+}
+
+// Build 创建所有账户, 任意一个账户失败时返回错误(包含账户名)
+func (c *Config) Build() (map[string]*AccountClients, error) {
+	names := make([]string, 0, len(c.Accounts))
+	for name := range c.Accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	accounts := make(map[string]*AccountClients, len(names))
+	for _, name := range names {
+		acc, err := c.Accounts[name].BuildAccount(name)
+		if err != nil {
+			return nil, fmt.Errorf("account [%s]: %s", name, err.Error())
+		}
+		accounts[name] = acc
+	}
+
+	return accounts, nil
+}
+
+// rateLimiter 按固定间隔发送请求, 超出的请求排队等待
+type rateLimiter struct {
+	interval time.Duration
+
+	lock sync.Mutex
+	next time.Time
+}
+
+// middleware 同一个 builder 创建的所有 api 共用一个 rateLimiter
+func (l *rateLimiter) middleware(next HttpDoer) HttpDoer {
+	return HttpDoerFunc(func(req *http.Request) (*http.Response, error) {
+		l.lock.Lock()
+		now := time.Now()
+		if l.next.Before(now) {
+			l.next = now
+		}
+		wait := l.next.Sub(now)
+		l.next = l.next.Add(l.interval)
+		l.lock.Unlock()
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+		}
+
+		return next.Do(req)
+	})
+}
+
+// httpTransport 自定义 client 或者 Transport 不是 *http.Transport 时返回 nil
+func (builder *APIBuilder) httpTransport() *http.Transport {
+	if builder.client == nil {
+		return nil
+	}
+	transport, _ := builder.client.Transport.(*http.Transport)
+	return transport
+}
@@ -0,0 +1,114 @@
+package builder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+const yamlConfig = `
+accounts:
+  kraken_main:
+    exchange: kraken.com
+    api_key: env:TEST_KRAKEN_KEY
+    api_secret: file:%s
+    proxy: socks5://127.0.0.1:1080
+    timeout: 3s
+    rate_limit: 5
+  bitmex_test:
+    exchange: bitmex.com
+    testnet: true
+    clients: [future]
+`
+
+func TestLoadConfig(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "goex")
+	defer os.RemoveAll(dir)
+
+	secretFile := filepath.Join(dir, "secret")
+	ioutil.WriteFile(secretFile, []byte("s3cret\n"), 0600)
+	configFile := filepath.Join(dir, "accounts.yaml")
+	ioutil.WriteFile(configFile, []byte(fmt.Sprintf(yamlConfig, secretFile)), 0600)
+
+	os.Setenv("TEST_KRAKEN_KEY", "k1")
+	os.Setenv("GOEXTEST_KRAKEN_MAIN_FUTURES_ENDPOINT", "https://futures.kraken.com")
+	os.Setenv("GOEXTEST_GATE_EXCHANGE", goex.GATEIO)
+	os.Setenv("GOEXTEST_GATE_CLIENTS", "spot, wallet")
+	defer func() {
+		os.Unsetenv("TEST_KRAKEN_KEY")
+		os.Unsetenv("GOEXTEST_KRAKEN_MAIN_FUTURES_ENDPOINT")
+		os.Unsetenv("GOEXTEST_GATE_EXCHANGE")
+		os.Unsetenv("GOEXTEST_GATE_CLIENTS")
+	}()
+
+	c, err := LoadConfig(configFile)
+	assert.Nil(t, err)
+	assert.Nil(t, c.LoadEnv("goextest"))
+	assert.Len(t, c.Accounts, 3)
+	assert.Equal(t, "https://futures.kraken.com", c.Accounts["kraken_main"].FuturesEndpoint)
+	assert.Equal(t, []goex.Capability{goex.CAP_SPOT, goex.CAP_WALLET}, c.Accounts["gate"].Clients)
+
+	//gateio 不支持 wallet
+	_, err = c.Build()
+	assert.EqualError(t, err, "account [gate]: exchange [gateio] does not support wallet")
+
+	delete(c.Accounts, "gate")
+	accounts, err := c.Build()
+	assert.Nil(t, err)
+
+	kraken := accounts["kraken_main"]
+	assert.Equal(t, goex.KRAKEN, kraken.Spot.GetExchangeName())
+	assert.Nil(t, kraken.Future)
+	assert.Equal(t, "k1", kraken.Builder.apiKey)
+	assert.Equal(t, "s3cret", kraken.Builder.secretkey)
+	assert.Equal(t, 3*time.Second, kraken.Builder.client.Timeout)
+	assert.Equal(t, "socks5://127.0.0.1:1080", kraken.Builder.HttpClientConfig.Proxy.String())
+	assert.NotNil(t, kraken.Builder.httpTransport())
+
+	assert.Equal(t, goex.ENV_TESTNET, accounts["bitmex_test"].Builder.env)
+	assert.NotNil(t, accounts["bitmex_test"].Future)
+}
+
+func TestParseConfig(t *testing.T) {
+	c, err := ParseConfig([]byte(`
+[accounts.kraken]
+exchange = "kraken.com"
+api_key = "k"
+rate_limit = 2.5
+clients = ["spot"]
+`), "toml")
+	assert.Nil(t, err)
+	assert.Equal(t, 2.5, c.Accounts["kraken"].RateLimit)
+	assert.Equal(t, []goex.Capability{goex.CAP_SPOT}, c.Accounts["kraken"].Clients)
+
+	c, err = ParseConfig([]byte(`{"accounts":{"kraken":{"exchange":"kraken.com","testnet":true}}}`), "json")
+	assert.Nil(t, err)
+	_, err = c.Build()
+	assert.EqualError(t, err, "account [kraken]: exchange [kraken.com] does not support spot on testnet")
+
+	_, err = ParseConfig(nil, "ini")
+	assert.NotNil(t, err)
+}
+
+func TestRateLimiter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	limiter := &rateLimiter{interval: 50 * time.Millisecond}
+	client := goex.NewHttpClient(http.DefaultClient, limiter.middleware)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(srv.URL)
+		assert.Nil(t, err)
+		resp.Body.Close()
+	}
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+}
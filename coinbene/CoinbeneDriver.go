@@ -0,0 +1,13 @@
+package coinbene
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(COINBENE, Driver{
+		Future: func(config *APIConfig) FutureRestAPI {
+			return NewCoinbeneSwap(*config)
+		},
+	})
+}
@@ -271,6 +271,74 @@ func (swap *CoinbeneSwap) GetFuturePosition(currencyPair CurrencyPair, contractT
 	return positions, nil
 }
 
+func (swap *CoinbeneSwap) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	uri := fmt.Sprintf("/api/swap/v2/position/list?symbol=%s", currencyPair.ToSymbol(""))
+	var data []struct {
+		Quantity             float64   `json:"quantity,string"`
+		AvailableQuantity    float64   `json:"availableQuantity,string"`
+		AveragePrice         float64   `json:"averagePrice,string"`
+		CreateTime           time.Time `json:"createTime"`
+		Leverage             float64   `json:"leverage,string"`
+		LiquidationPrice     float64   `json:"liquidationPrice,string"`
+		RealisedPnl          float64   `json:"realisedPnl,string"`
+		UnrealisedPnl        float64   `json:"unrealisedPnl,string"`
+		Side                 string    `json:"side"`
+		Symbol               string    `json:"symbol"`
+		MarkPrice            string    `json:"markPrice"`
+		MarginMode           string    `json:"marginMode"`
+		PositionMargin       string    `json:"positionMargin"`
+		PositionValue        string    `json:"positionValue"`
+		DeleveragePercentile string    `json:"deleveragePercentile"`
+	}
+	resp, err := swap.doAuthRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(resp.Data, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []FuturePositionV2
+	for _, pos := range data {
+		if pos.Quantity == 0 {
+			continue
+		}
+
+		side := POSITION_SIDE_LONG
+		if pos.Side != "long" {
+			side = POSITION_SIDE_SHORT
+		}
+
+		marginMode := MARGIN_MODE_CROSSED
+		if pos.MarginMode == "fixed" {
+			marginMode = MARGIN_MODE_ISOLATED
+		}
+
+		positions = append(positions, FuturePositionV2{
+			Pair:             currencyPair,
+			ContractType:     contractType,
+			ContractId:       pos.Symbol,
+			Side:             side,
+			Amount:           pos.Quantity,
+			AvailAmount:      pos.AvailableQuantity,
+			AvgPrice:         pos.AveragePrice,
+			MarginMode:       marginMode,
+			Leverage:         pos.Leverage,
+			InitialMargin:    ToFloat64(pos.PositionMargin),
+			MarkPrice:        ToFloat64(pos.MarkPrice),
+			LiquidationPrice: pos.LiquidationPrice,
+			Notional:         ToFloat64(pos.PositionValue),
+			UnrealizedPnl:    pos.UnrealisedPnl,
+			RealizedPnl:      pos.RealisedPnl,
+			AdlRank:          ToInt(pos.DeleveragePercentile),
+			CreateTime:       pos.CreateTime.UnixNano() / int64(time.Millisecond),
+		})
+	}
+
+	return positions, nil
+}
+
 func (swap *CoinbeneSwap) GetFutureOrders(orderIds []string, currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
 	panic("")
 }
@@ -352,7 +420,7 @@ func (swap *CoinbeneSwap) GetUnfinishFutureOrders(currencyPair CurrencyPair, con
 
 	return unFinishOrders, nil
 }
-func (swap *CoinbeneSwap) GetFee() (float64, error)                                    { panic("") }
+func (swap *CoinbeneSwap) GetFee() (float64, error)                                    { return 0, EX_ERR_NOT_SUPPORT }
 func (swap *CoinbeneSwap) GetContractValue(currencyPair CurrencyPair) (float64, error) { panic("") }
 func (swap *CoinbeneSwap) GetDeliveryTime() (int, int, int, int)                       { panic("") }
 func (swap *CoinbeneSwap) GetKlineRecords(contract_type string, currency CurrencyPair, period KlinePeriod, size int, opt ...OptionalParameter) ([]FutureKline, error) {
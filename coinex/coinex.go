@@ -33,6 +33,16 @@ func (coinex *CoinEx) GetExchangeName() string {
 	return COINEX
 }
 
+// GetAllCurrencyPair 未对接交易对列表接口
+func (coinex *CoinEx) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	return nil, EX_ERR_NOT_SUPPORT
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (coinex *CoinEx) GetTimestamp() (int64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
+}
+
 func (coinex *CoinEx) GetTicker(currency CurrencyPair) (*Ticker, error) {
 	params := url.Values{}
 	params.Set("market", currency.ToSymbol(""))
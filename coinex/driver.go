@@ -0,0 +1,13 @@
+package coinex
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(COINEX, Driver{
+		Spot: func(config *APIConfig) API {
+			return New(config.HttpClient, config.ApiKey, config.ApiSecretKey)
+		},
+	})
+}
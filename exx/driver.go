@@ -0,0 +1,13 @@
+package exx
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(EXX, Driver{
+		Spot: func(config *APIConfig) API {
+			return New(config.HttpClient, config.ApiKey, config.ApiSecretKey)
+		},
+	})
+}
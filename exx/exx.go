@@ -45,6 +45,16 @@ func (exx *Exx) GetExchangeName() string {
 	return EXX
 }
 
+// GetAllCurrencyPair 未对接交易对列表接口
+func (exx *Exx) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	return nil, EX_ERR_NOT_SUPPORT
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (exx *Exx) GetTimestamp() (int64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
+}
+
 func (exx *Exx) GetTicker(currency CurrencyPair) (*Ticker, error) {
 	symbol := currency.ToLower().ToSymbol("_")
 	path := MARKET_URL + fmt.Sprintf(TICKER_API, symbol)
@@ -383,8 +393,8 @@ func (exx *Exx) GetOrderHistorys(currency CurrencyPair, optional ...OptionalPara
 	return nil, nil
 }
 
-func (exx *Exx) GetKlineRecords(currency CurrencyPair, period, size, since int) ([]Kline, error) {
-	return nil, nil
+func (exx *Exx) GetKlineRecords(currency CurrencyPair, period KlinePeriod, size int, optional ...OptionalParameter) ([]Kline, error) {
+	return nil, EX_ERR_NOT_SUPPORT
 }
 
 func (exx *Exx) Withdraw(amount string, currency Currency, fees, receiveAddr, safePwd string) (string, error) {
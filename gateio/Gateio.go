@@ -48,6 +48,8 @@ type Gateio struct {
 	accountId  string
 	accessKey  string
 	secretKey  string
+	log        Logger
+	clock      Clock
 }
 
 func NewGateioWithConfig(config *APIConfig) *Gateio {
@@ -60,6 +62,8 @@ func NewGateioWithConfig(config *APIConfig) *Gateio {
 	gateio.httpClient = config.HttpClient
 	gateio.accessKey = config.ApiKey
 	gateio.secretKey = config.ApiSecretKey
+	gateio.log = config.GetLogger()
+	gateio.setClock(config)
 	return gateio
 }
 
@@ -69,9 +73,16 @@ func NewGateio(httpClient *http.Client, apiKey string, apiSecretKey string) *Gat
 	gateio.httpClient = httpClient
 	gateio.accessKey = apiKey
 	gateio.secretKey = apiSecretKey
+	gateio.log = NewRedactLogger(DefaultLogger(), apiKey, apiSecretKey)
+	gateio.setClock(&APIConfig{})
 	return gateio
 }
 
+// setClock 签名时间戳与服务器时间同步
+func (gateio *Gateio) setClock(config *APIConfig) {
+	gateio.clock = config.GetClock(gateio.baseUrl+"/api/v4/spot/time", gateio.GetTimestamp)
+}
+
 func (gateio *Gateio) GetAccountInfo(acc string) (AccountInfo, error) {
 	path := "/wallet/sub_account_balances"
 	params := &url.Values{}
@@ -550,8 +561,12 @@ type ecdsaSignature struct {
 }
 
 func (gateio *Gateio) buildSign(reqMethod, reqUrl string, queryString string, headers *(map[string]string)) error {
-	sha512ReqPayload, _ := GetSHA512("")
-	timestampStr := strconv.Itoa(int(time.Now().Unix()))
+	return gateio.buildSignWithBody(reqMethod, reqUrl, queryString, "", headers)
+}
+
+func (gateio *Gateio) buildSignWithBody(reqMethod, reqUrl, queryString, body string, headers *(map[string]string)) error {
+	sha512ReqPayload, _ := GetSHA512(body)
+	timestampStr := strconv.Itoa(int(gateio.clock.Now().Unix()))
 	payload := fmt.Sprintf("%s\n%s\n%s\n%s\n%s", reqMethod, reqUrl, queryString, sha512ReqPayload, timestampStr)
 	sign, _ := GetParamHmacSHA512Sign(gateio.secretKey, payload)
 	(*headers)["Timestamp"] = timestampStr
@@ -564,7 +579,7 @@ func (gateio *Gateio) buildPostForm(reqMethod, path string, postForm *url.Values
 	postForm.Set("AccessKeyId", gateio.accessKey)
 	postForm.Set("SignatureMethod", "HmacSHA256")
 	postForm.Set("SignatureVersion", "2")
-	postForm.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
+	postForm.Set("Timestamp", gateio.clock.Now().UTC().Format("2006-01-02T15:04:05"))
 	domain := strings.Replace(gateio.baseUrl, "https://", "", len(gateio.baseUrl))
 	payload := fmt.Sprintf("%s\n%s\n%s\n%s", reqMethod, domain, path, postForm.Encode())
 	sign, _ := GetParamHmacSHA256Base64Sign(gateio.secretKey, payload)
@@ -677,14 +692,10 @@ func (gateio *Gateio) GetAllCurrencyPair() ([]CurrencyPair, error) {
 }
 
 func (gateio *Gateio) GetTimestamp() (int64, error) {
-	url := gateio.baseUrl + "/v1/common/timestamp"
+	url := gateio.baseUrl + "/api/v4/spot/time"
 	ret, err := HttpGet(gateio.httpClient, url)
 	if err != nil {
 		return 0, err
 	}
-	data, ok := ret["data"].(int64)
-	if !ok {
-		return 0, err
-	}
-	return data, nil
+	return ToInt64(ret["server_time"]), nil
 }
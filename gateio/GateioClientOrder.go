@@ -0,0 +1,197 @@
+package gateio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+//v4 接口用 text 字段作为自定义订单ID, 必须以 t- 开头, 查询和撤单时可以代替订单ID
+
+type gateioOrder struct {
+	Id           string  `json:"id"`
+	Text         string  `json:"text"`
+	CreateTimeMs float64 `json:"create_time_ms"`
+	Status       string  `json:"status"`
+	CurrencyPair string  `json:"currency_pair"`
+	Side         string  `json:"side"`
+	Amount       float64 `json:"amount,string"`
+	Price        float64 `json:"price,string"`
+	Left         float64 `json:"left,string"`
+	FilledTotal  float64 `json:"filled_total,string"`
+	Fee          float64 `json:"fee,string"`
+	Label        string  `json:"label"`
+	Message      string  `json:"message"`
+}
+
+func adaptClientOrderId(cid string) string {
+	if strings.HasPrefix(cid, "t-") {
+		return cid
+	}
+	return "t-" + cid
+}
+
+func (gateio *Gateio) doRequest(method, path string, params url.Values, body interface{}, response interface{}) error {
+	var bodyStr string
+	if body != nil {
+		data, _ := json.Marshal(body)
+		bodyStr = string(data)
+	}
+
+	queryStr := params.Encode()
+	headers := map[string]string{"Content-Type": "application/json", "Accept": "application/json"}
+	gateio.buildSignWithBody(method, path, queryStr, bodyStr, &headers)
+
+	reqUrl := gateio.baseUrl + path
+	if queryStr != "" {
+		reqUrl += "?" + queryStr
+	}
+
+	req, _ := http.NewRequest(method, reqUrl, strings.NewReader(bodyStr))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := gateio.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if DebugEnabled(gateio.log) {
+		gateio.log.Debug("response", KV("method", method), KV("url", reqUrl), KV("body", string(respData)))
+	}
+
+	//下单成功返回 201
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New(fmt.Sprintf("HttpStatusCode:%d ,Desc:%s", resp.StatusCode, string(respData)))
+	}
+
+	return json.Unmarshal(respData, response)
+}
+
+func (gateio *Gateio) adaptOrder(pair CurrencyPair, o gateioOrder) Order {
+	ord := Order{
+		Currency:   pair,
+		OrderID:    ToInt(o.Id),
+		OrderID2:   o.Id,
+		Cid:        o.Text,
+		Price:      o.Price,
+		Amount:     o.Amount,
+		DealAmount: o.Amount - o.Left,
+		Fee:        o.Fee,
+		OrderTime:  int(o.CreateTimeMs),
+	}
+
+	if ord.DealAmount > 0 {
+		ord.AvgPrice = o.FilledTotal / ord.DealAmount
+	}
+
+	switch o.Side {
+	case "buy":
+		ord.Side = BUY
+	case "sell":
+		ord.Side = SELL
+	}
+
+	switch o.Status {
+	case "closed":
+		ord.Status = ORDER_FINISH
+	case "cancelled":
+		ord.Status = ORDER_CANCEL
+	default:
+		ord.Status = ORDER_UNFINISH
+		if ord.DealAmount > 0 {
+			ord.Status = ORDER_PART_FINISH
+		}
+	}
+
+	return ord
+}
+
+func (gateio *Gateio) LimitOrderWithCid(ord *Order) (*Order, error) {
+	if ord.Side != BUY && ord.Side != SELL {
+		return nil, errors.New("side must be BUY or SELL")
+	}
+
+	if ord.Cid == "" {
+		ord.Cid = GenerateOrderClientId(28)
+	}
+	ord.Cid = adaptClientOrderId(ord.Cid)
+
+	timeInForce := "gtc"
+	switch ord.OrderType {
+	case ORDER_FEATURE_POST_ONLY:
+		timeInForce = "poc"
+	case ORDER_FEATURE_IOC:
+		timeInForce = "ioc"
+	case ORDER_FEATURE_FOK:
+		timeInForce = "fok"
+	}
+
+	param := map[string]string{
+		"text":          ord.Cid,
+		"currency_pair": ord.Currency.ToSymbol("_"),
+		"type":          "limit",
+		"side":          strings.ToLower(ord.Side.String()),
+		"amount":        FloatToString(ord.Amount, 8),
+		"price":         FloatToString(ord.Price, 8),
+		"time_in_force": timeInForce,
+	}
+
+	var response gateioOrder
+	err := gateio.doRequest("POST", "/api/v4/spot/orders", url.Values{}, param, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Id == "" {
+		return nil, errors.New(fmt.Sprintf("%s:%s", response.Label, response.Message))
+	}
+
+	ord.OrderID = ToInt(response.Id)
+	ord.OrderID2 = response.Id
+	ret := gateio.adaptOrder(ord.Currency, response)
+	ret.OrderType = ord.OrderType
+	return &ret, nil
+}
+
+func (gateio *Gateio) GetOneOrderByCid(cid string, currency CurrencyPair) (*Order, error) {
+	params := url.Values{}
+	params.Set("currency_pair", currency.ToSymbol("_"))
+
+	var response gateioOrder
+	err := gateio.doRequest("GET", "/api/v4/spot/orders/"+adaptClientOrderId(cid), params, nil, &response)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORDER_NOT_FOUND") {
+			return nil, EX_ERR_NOT_FIND_ORDER.OriginErr(err.Error())
+		}
+		return nil, err
+	}
+
+	ord := gateio.adaptOrder(currency, response)
+	return &ord, nil
+}
+
+func (gateio *Gateio) CancelOrderByCid(cid string, currency CurrencyPair) (bool, error) {
+	params := url.Values{}
+	params.Set("currency_pair", currency.ToSymbol("_"))
+
+	var response gateioOrder
+	err := gateio.doRequest("DELETE", "/api/v4/spot/orders/"+adaptClientOrderId(cid), params, nil, &response)
+	if err != nil {
+		return false, err
+	}
+
+	return response.Status == "cancelled", nil
+}
@@ -0,0 +1,56 @@
+package gateio
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGateio_ClientOrder(t *testing.T) {
+	var body map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("SIGN"))
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v4/spot/orders":
+			json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"1001","text":"` + body["text"] + `","status":"open","side":"buy","amount":"1","price":"30000","left":"1","filled_total":"0","fee":"0"}`))
+		case "GET /api/v4/spot/orders/t-abc":
+			assert.Equal(t, "BTC_USDT", r.URL.Query().Get("currency_pair"))
+			w.Write([]byte(`{"id":"1001","text":"t-abc","status":"open","side":"buy","amount":"1","price":"30000","left":"0.4","filled_total":"18000","fee":"0.001"}`))
+		case "DELETE /api/v4/spot/orders/t-abc":
+			w.Write([]byte(`{"id":"1001","text":"t-abc","status":"cancelled","side":"buy","amount":"1","price":"30000","left":"0.4","filled_total":"18000","fee":"0.001"}`))
+		case "GET /api/v4/spot/orders/t-missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"label":"ORDER_NOT_FOUND","message":"Order not found"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	api := NewGateioWithConfig(&goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Clock: goex.LocalClock})
+
+	ord, err := api.LimitOrderWithCid(&goex.Order{Currency: goex.BTC_USDT, Side: goex.BUY, Price: 30000, Amount: 1, OrderType: goex.ORDER_FEATURE_POST_ONLY})
+	assert.Nil(t, err)
+	assert.Equal(t, "1001", ord.OrderID2)
+	assert.Equal(t, "poc", body["time_in_force"])
+	assert.Regexp(t, "^t-goex", body["text"])
+	assert.Equal(t, body["text"], ord.Cid)
+
+	ord, err = api.GetOneOrderByCid("abc", goex.BTC_USDT)
+	assert.Nil(t, err)
+	assert.Equal(t, goex.ORDER_PART_FINISH, ord.Status)
+	assert.InDelta(t, 30000, ord.AvgPrice, 1e-8)
+
+	_, err = api.GetOneOrderByCid("missing", goex.BTC_USDT)
+	assert.True(t, goex.IsOrderNotFound(err))
+
+	ok, err := api.CancelOrderByCid("t-abc", goex.BTC_USDT)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,13 @@
+package gateio
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(GATEIO, Driver{
+		Spot: func(config *APIConfig) API {
+			return NewGateioWithConfig(config)
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package gateio
+
+import (
+	"fmt"
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+type gateioTrade struct {
+	Id           string  `json:"id"`
+	CreateTimeMs float64 `json:"create_time_ms,string"`
+	Side         string  `json:"side"`
+	Role         string  `json:"role"`
+	Amount       float64 `json:"amount,string"`
+	Price        float64 `json:"price,string"`
+	OrderId      string  `json:"order_id"`
+	Fee          float64 `json:"fee,string"`
+	FeeCurrency  string  `json:"fee_currency"`
+}
+
+// GetMyTrades v4 接口按时间倒序返回, from 参数为秒
+func (gateio *Gateio) GetMyTrades(pair CurrencyPair, since int64, limit int) ([]Fill, error) {
+	params := url.Values{}
+	params.Set("currency_pair", pair.ToSymbol("_"))
+	if since > 0 {
+		params.Set("from", fmt.Sprint(since/1000))
+	}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(limit))
+	}
+
+	var trades []gateioTrade
+	err := gateio.doRequest("GET", "/api/v4/spot/my_trades", params, nil, &trades)
+	if err != nil {
+		return nil, err
+	}
+
+	fills := make([]Fill, 0, len(trades))
+	for i := len(trades) - 1; i >= 0; i-- {
+		t := trades[i]
+		if int64(t.CreateTimeMs) < since {
+			continue
+		}
+		fill := Fill{
+			TradeId:     t.Id,
+			OrderId:     t.OrderId,
+			Pair:        pair,
+			Side:        SELL,
+			Price:       t.Price,
+			Amount:      t.Amount,
+			Fee:         t.Fee,
+			FeeCurrency: t.FeeCurrency,
+			IsMaker:     t.Role == "maker",
+			Time:        int64(t.CreateTimeMs),
+		}
+		if t.Side == "buy" {
+			fill.Side = BUY
+		}
+		fills = append(fills, fill)
+	}
+
+	return fills, nil
+}
@@ -0,0 +1,47 @@
+package gateio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGateio_GetMyTrades(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "GET /api/v4/spot/my_trades" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NotEmpty(t, r.Header.Get("SIGN"))
+		assert.Equal(t, "BTC_USDT", r.URL.Query().Get("currency_pair"))
+		assert.Equal(t, "1616000100", r.URL.Query().Get("from"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		//from 只精确到秒, 同一秒内 since 之前的成交在本地过滤
+		w.Write([]byte(`[
+{"id":"1232893232","create_time":"1616000200","create_time_ms":"1616000200123.456","order_id":"4128442424","side":"buy","role":"maker","amount":"0.15","price":"30100","fee":"0.0003","fee_currency":"BTC","point_fee":"0","gt_fee":"0"},
+{"id":"1232893231","create_time":"1616000100","create_time_ms":"1616000100600.000","order_id":"4128442423","side":"sell","role":"taker","amount":"0.2","price":"30000","fee":"12","fee_currency":"USDT","point_fee":"0","gt_fee":"0"},
+{"id":"1232893230","create_time":"1616000100","create_time_ms":"1616000100100.000","order_id":"4128442422","side":"sell","role":"taker","amount":"0.1","price":"29900","fee":"6","fee_currency":"USDT","point_fee":"0","gt_fee":"0"}]`))
+	}))
+	defer srv.Close()
+
+	api := NewGateioWithConfig(&goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Clock: goex.LocalClock})
+
+	fills, err := api.GetMyTrades(goex.BTC_USDT, 1616000100500, 10)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 2)
+	assert.Equal(t, "1232893231", fills[0].TradeId)
+	assert.Equal(t, "4128442423", fills[0].OrderId)
+	assert.Equal(t, goex.SELL, fills[0].Side)
+	assert.Equal(t, 0.2, fills[0].Amount)
+	assert.Equal(t, 30000.0, fills[0].Price)
+	assert.Equal(t, 12.0, fills[0].Fee)
+	assert.Equal(t, "USDT", fills[0].FeeCurrency)
+	assert.False(t, fills[0].IsMaker)
+	assert.Equal(t, int64(1616000100600), fills[0].Time)
+	assert.Equal(t, goex.BUY, fills[1].Side)
+	assert.Equal(t, "BTC", fills[1].FeeCurrency)
+	assert.True(t, fills[1].IsMaker)
+}
@@ -0,0 +1,35 @@
+package gateio
+
+import (
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+type gateioFee struct {
+	TakerFee   float64 `json:"taker_fee,string"`
+	MakerFee   float64 `json:"maker_fee,string"`
+	GtDiscount bool    `json:"gt_discount"`
+	GtTakerFee float64 `json:"gt_taker_fee,string"`
+	GtMakerFee float64 `json:"gt_maker_fee,string"`
+}
+
+// GetFeeRate gt_discount 为 true 时按 GT 抵扣后的费率收取
+func (gateio *Gateio) GetFeeRate(pair CurrencyPair) (*FeeRate, error) {
+	params := url.Values{}
+	params.Set("currency_pair", pair.ToSymbol("_"))
+
+	var fee gateioFee
+	err := gateio.doRequest("GET", "/api/v4/wallet/fee", params, nil, &fee)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := &FeeRate{Pair: pair, Maker: fee.MakerFee, Taker: fee.TakerFee}
+	if fee.GtDiscount && fee.TakerFee > 0 && fee.GtTakerFee > 0 {
+		rate.Discount = fee.GtTakerFee / fee.TakerFee
+		rate.DiscountCurrency = "GT"
+	}
+
+	return rate, nil
+}
@@ -3,13 +3,19 @@ module github.com/BTreeNewBee/goex
 go 1.12
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/Kucoin/kucoin-go-sdk v1.2.7
 	github.com/go-openapi/errors v0.19.4
 	github.com/google/uuid v1.1.1
 	github.com/gorilla/websocket v1.4.1
 	github.com/konsorten/go-windows-terminal-sequences v1.0.2 // indirect
 	github.com/nubo/jwt v0.0.0-20150918093313-da5b79c3bbaf
-	github.com/stretchr/testify v1.4.0
+	github.com/rs/zerolog v1.20.0
+	github.com/stretchr/testify v1.7.0
 	github.com/valyala/fasthttp v1.6.0
+	go.opentelemetry.io/otel v1.0.0
+	go.opentelemetry.io/otel/trace v1.0.0
+	go.uber.org/zap v1.16.0
 	golang.org/x/sys v0.0.0-20200124204421-9fbb57f87de9 // indirect
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -40,16 +40,37 @@ type Hitbtc struct {
 	accessKey,
 	secretKey string
 	httpClient *http.Client
+	symbols    *goex.SymbolMapper
 }
 
 func New(client *http.Client, accessKey, secretKey string) *Hitbtc {
-	return &Hitbtc{accessKey, secretKey, client}
+	hitbtc := &Hitbtc{accessKey: accessKey, secretKey: secretKey, httpClient: client}
+	hitbtc.symbols = goex.NewSymbolMapper(hitbtc.loadMarkets, nil)
+	return hitbtc
 }
 
 func (hitbtc *Hitbtc) GetExchangeName() string {
 	return EXCHANGE_NAME
 }
 
+func (hitbtc *Hitbtc) GetAllCurrencyPair() ([]goex.CurrencyPair, error) {
+	markets, err := hitbtc.symbols.Markets()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]goex.CurrencyPair, 0, len(markets))
+	for _, m := range markets {
+		pairs = append(pairs, m.Pair)
+	}
+	return pairs, nil
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (hitbtc *Hitbtc) GetTimestamp() (int64, error) {
+	return 0, goex.EX_ERR_NOT_SUPPORT
+}
+
 // https://api.hitbtc.com/#symbols
 /*
 curl "https://api.hitbtc.com/api/2/public/symbol"
@@ -84,6 +105,29 @@ func (hitbtc *Hitbtc) GetSymbols() ([]goex.CurrencyPair, error) {
 	return pairs, nil
 }
 
+// Symbols symbol 与统一交易对的转换
+func (hitbtc *Hitbtc) Symbols() *goex.SymbolMapper {
+	return hitbtc.symbols
+}
+
+func (hitbtc *Hitbtc) loadMarkets() ([]goex.Market, error) {
+	var resp []struct {
+		Id            string `json:"id"`
+		BaseCurrency  string `json:"baseCurrency"`
+		QuoteCurrency string `json:"quoteCurrency"`
+	}
+	err := hitbtc.doRequest("GET", SYMBOLS_URI, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	markets := make([]goex.Market, 0, len(resp))
+	for _, e := range resp {
+		markets = append(markets, goex.Market{Symbol: e.Id, BaseCurrency: e.BaseCurrency, QuoteCurrency: e.QuoteCurrency})
+	}
+	return markets, nil
+}
+
 // https://api.hitbtc.com/#tickers
 
 /*
@@ -591,32 +635,13 @@ func (hitbtc *Hitbtc) adaptCurrencyPair(pair goex.CurrencyPair) goex.CurrencyPai
 	return pair.AdaptUsdtToUsd()
 }
 
+// adaptSymbolToCurrencyPair 优先使用市场列表, 加载失败时按计价币后缀拆分
 func (hitbtc *Hitbtc) adaptSymbolToCurrencyPair(pair string) goex.CurrencyPair {
-	pair = strings.ToUpper(pair)
-	if strings.HasSuffix(pair, "BTC") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "BTC"), "BTC"))
-	} else if strings.HasSuffix(pair, "TUSD") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "TUSD"), "TUSD"))
-	} else if strings.HasSuffix(pair, "GUSD") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "GUSD"), "GUSD"))
-	} else if strings.HasSuffix(pair, "USDC") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "USDC"), "USDC"))
-	} else if strings.HasSuffix(pair, "USD") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "USD"), "USD"))
-	} else if strings.HasSuffix(pair, "ETH") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "ETH"), "ETH"))
-	} else if strings.HasSuffix(pair, "KRWB") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "KRWB"), "KRWB"))
-	} else if strings.HasSuffix(pair, "PAX") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "PAX"), "PAX"))
-	} else if strings.HasSuffix(pair, "DAI") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "DAI"), "DAI"))
-	} else if strings.HasSuffix(pair, "EOS") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "EOS"), "EOS"))
-	} else if strings.HasSuffix(pair, "EURS") {
-		return goex.NewCurrencyPair2(fmt.Sprintf("%s_%s", strings.TrimSuffix(pair, "EURS"), "EURS"))
-	}
-	return goex.UNKNOWN_PAIR
+	if currencyPair, err := hitbtc.symbols.CurrencyPair(pair); err == nil {
+		return currencyPair
+	}
+	currencyPair, _ := goex.ParseCurrencyPair(pair)
+	return currencyPair
 }
 
 func parseTime(timeStr string) int64 {
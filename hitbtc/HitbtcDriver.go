@@ -0,0 +1,13 @@
+package hitbtc
+
+import (
+	"github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	goex.RegisterDriver(goex.HITBTC, goex.Driver{
+		Spot: func(config *goex.APIConfig) goex.API {
+			return New(config.HttpClient, config.ApiKey, config.ApiSecretKey)
+		},
+	})
+}
@@ -15,7 +15,10 @@ import (
 )
 
 type Hbdm struct {
-	config *APIConfig
+	config    *APIConfig
+	clock     Clock
+	contracts *ContractResolver
+	log       Logger
 }
 
 type OrderInfo struct {
@@ -126,7 +129,19 @@ func NewHbdm(conf *APIConfig) *Hbdm {
 		conf.Lever = 10
 	}
 	hbdmInit()
-	return &Hbdm{conf}
+	dm := &Hbdm{config: conf, log: conf.GetLogger()}
+	dm.clock = conf.GetClock(conf.Endpoint+"/api/v1/timestamp", dm.GetServerTime)
+	dm.contracts = NewContractResolver(HBDM, dm.loadContracts, dm.clock)
+	return dm
+}
+
+// GetServerTime 服务器时间(毫秒)
+func (dm *Hbdm) GetServerTime() (int64, error) {
+	ret, err := HttpGet(dm.config.HttpClient, dm.config.Endpoint+"/api/v1/timestamp")
+	if err != nil {
+		return 0, err
+	}
+	return ToInt64(ret["ts"]), nil
 }
 
 func (dm *Hbdm) GetExchangeName() string {
@@ -210,7 +225,7 @@ func (dm *Hbdm) GetFuturePosition(currencyPair CurrencyPair, contractType string
 			d.ContractType = BI_QUARTER_CONTRACT
 		}
 
-		if d.ContractType != contractType {
+		if d.ContractType != contractType && d.ContractCode != contractType {
 			continue
 		}
 
@@ -278,6 +293,10 @@ func (dm *Hbdm) PlaceFutureOrder(currencyPair CurrencyPair, contractType, price,
 }
 
 func (dm *Hbdm) PlaceFutureOrder2(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
+	return dm.placeFutureOrder(fmt.Sprint(time.Now().UnixNano()), currencyPair, contractType, price, amount, openType, matchPrice, leverRate, opt...)
+}
+
+func (dm *Hbdm) placeFutureOrder(cid string, currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
 	var data struct {
 		OrderId  int64 `json:"order_id"`
 		COrderId int64 `json:"client_order_id"`
@@ -286,12 +305,16 @@ func (dm *Hbdm) PlaceFutureOrder2(currencyPair CurrencyPair, contractType, price
 	params := &url.Values{}
 	path := "/api/v1/contract_order"
 
-	params.Add("client_order_id", fmt.Sprint(time.Now().UnixNano()))
-	params.Add("contract_type", contractType)
+	params.Add("client_order_id", cid)
 	params.Add("symbol", currencyPair.CurrencyA.Symbol)
 	params.Add("volume", amount)
 	params.Add("lever_rate", fmt.Sprint(leverRate))
-	params.Add("contract_code", "")
+	if isContractCode(contractType) {
+		params.Add("contract_code", contractType)
+	} else {
+		params.Add("contract_type", contractType)
+		params.Add("contract_code", "")
+	}
 
 	if matchPrice == 1 {
 		params.Set("order_price_type", "opponent") //对手价下单
@@ -344,6 +367,10 @@ func (dm *Hbdm) MarketFuturesOrder(currencyPair CurrencyPair, contractType, amou
 }
 
 func (dm *Hbdm) FutureCancelOrder(currencyPair CurrencyPair, contractType, orderId string) (bool, error) {
+	return dm.cancelFutureOrder("order_id", orderId, currencyPair)
+}
+
+func (dm *Hbdm) cancelFutureOrder(idKey, id string, currencyPair CurrencyPair) (bool, error) {
 	var data struct {
 		Successes string `json:"successes"`
 		Errors    []struct {
@@ -355,7 +382,7 @@ func (dm *Hbdm) FutureCancelOrder(currencyPair CurrencyPair, contractType, order
 	path := "/api/v1/contract_cancel"
 	params := &url.Values{}
 
-	params.Add("order_id", orderId)
+	params.Add(idKey, id)
 	params.Add("symbol", currencyPair.CurrencyA.Symbol)
 
 	err := dm.doRequest(path, params, &data)
@@ -423,11 +450,15 @@ func (dm *Hbdm) GetFutureOrder(orderId string, currencyPair CurrencyPair, contra
 }
 
 func (dm *Hbdm) GetFutureOrders(orderIds []string, currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
+	return dm.getFutureOrders("order_id", strings.Join(orderIds, ","), currencyPair, contractType)
+}
+
+func (dm *Hbdm) getFutureOrders(idKey, ids string, currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
 	var data []OrderInfo
 	path := "/api/v1/contract_order_info"
 	params := &url.Values{}
 
-	params.Add("order_id", strings.Join(orderIds, ","))
+	params.Add(idKey, ids)
 	params.Add("symbol", currencyPair.CurrencyA.Symbol)
 
 	err := dm.doRequest(path, params, &data)
@@ -442,6 +473,7 @@ func (dm *Hbdm) GetFutureOrders(orderIds []string, currencyPair CurrencyPair, co
 			ContractName: contractType,
 			Currency:     currencyPair,
 			OType:        dm.adaptOffsetDirectionToOpenType(ord.Offset, ord.Direction),
+			ClientOid:    fmt.Sprint(ord.ClientOrderId),
 			OrderID2:     fmt.Sprint(ord.OrderId),
 			OrderID:      ord.OrderId,
 			Amount:       ord.Volume,
@@ -682,6 +714,12 @@ func (dm *Hbdm) adaptSymbol(pair CurrencyPair, contractType string) string {
 		symbol += "NW"
 	case QUARTER_CONTRACT:
 		symbol += "CQ"
+	case BI_QUARTER_CONTRACT:
+		symbol += "NQ"
+	default:
+		if isContractCode(contractType) {
+			return contractType
+		}
 	}
 	return symbol
 }
@@ -767,7 +805,7 @@ func (dm *Hbdm) buildPostForm(reqMethod, path string, postForm *url.Values) erro
 	postForm.Set("AccessKeyId", dm.config.ApiKey)
 	postForm.Set("SignatureMethod", "HmacSHA256")
 	postForm.Set("SignatureVersion", "2")
-	postForm.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
+	postForm.Set("Timestamp", dm.clock.Now().UTC().Format("2006-01-02T15:04:05"))
 	domain := strings.Replace(dm.config.Endpoint, "https://", "", len(dm.config.Endpoint))
 	payload := fmt.Sprintf("%s\n%s\n%s\n%s", reqMethod, domain, path, postForm.Encode())
 	sign, _ := GetParamHmacSHA256Base64Sign(dm.config.ApiSecretKey, payload)
@@ -790,7 +828,9 @@ func (dm *Hbdm) doRequest(path string, params *url.Values, data interface{}) err
 		return err
 	}
 
-	logger.Debugf("response body: %s", string(resp))
+	if DebugEnabled(dm.log) {
+		dm.log.Debug("response", KV("path", path), KV("body", string(resp)))
+	}
 	//log.Println(string(resp))
 	err = json.Unmarshal(resp, &ret)
 	if err != nil {
@@ -0,0 +1,290 @@
+package huobi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+// 批量下单每次最多10个, 撤单的 order_id 最多10个; 合约不支持改单, 批量改单为批量撤单后再批量下单
+const hbdmBatchLimit = 10
+
+type batchApiPaths struct {
+	place  string
+	cancel string
+}
+
+var (
+	contractBatchApiPaths   = batchApiPaths{"/api/v1/contract_batchorder", "/api/v1/contract_cancel"}
+	swapBatchApiPaths       = batchApiPaths{"/swap-api/v1/swap_batchorder", "/swap-api/v1/swap_cancel"}
+	linearSwapBatchApiPaths = batchApiPaths{"/linear-swap-api/v1/swap_batchorder", "/linear-swap-api/v1/swap_cancel"}
+)
+
+// doJsonRequest 请求参数不能用 url.Values 表示时使用, 签名参数只放在 url 上
+func (dm *Hbdm) doJsonRequest(path string, body interface{}, data interface{}) error {
+	params := url.Values{}
+	dm.buildPostForm("POST", path, &params)
+
+	reqBody, _ := json.Marshal(body)
+	resp, err := HttpPostForm3(dm.config.HttpClient, dm.config.Endpoint+path+"?"+params.Encode(), string(reqBody),
+		map[string]string{"Content-Type": "application/json", "Accept-Language": "zh-cn"})
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("response body: %s", string(resp))
+
+	var ret BaseResponse
+	err = json.Unmarshal(resp, &ret)
+	if err != nil {
+		return err
+	}
+
+	if ret.Status != "ok" {
+		return errors.New(fmt.Sprintf("%d:[%s]", ret.ErrCode, ret.ErrMsg))
+	}
+
+	return json.Unmarshal(ret.Data, data)
+}
+
+func (dm *Hbdm) adaptOrderPriceType(orderType int) string {
+	switch orderType {
+	case ORDER_FEATURE_POST_ONLY:
+		return "post_only"
+	case ORDER_FEATURE_FOK:
+		return "fok"
+	case ORDER_FEATURE_IOC:
+		return "ioc"
+	}
+	return "limit"
+}
+
+// batchPlaceOrders contractParams 为每个订单的合约参数(symbol/contract_type 或 contract_code), 与 orders 按下标对应
+func (dm *Hbdm) batchPlaceOrders(path string, orders []FutureOrder, contractParams []map[string]interface{}) []BatchOrderResult {
+	results := make([]BatchOrderResult, len(orders))
+
+	for _, b := range SplitBatch(len(orders), hbdmBatchLimit) {
+		var (
+			param struct {
+				OrdersData []map[string]interface{} `json:"orders_data"`
+			}
+			response struct {
+				Errors []struct {
+					Index   int    `json:"index"`
+					ErrCode int    `json:"err_code"`
+					ErrMsg  string `json:"err_msg"`
+				} `json:"errors"`
+				Success []struct {
+					Index         int    `json:"index"`
+					OrderIdStr    string `json:"order_id_str"`
+					ClientOrderId int64  `json:"client_order_id"`
+				} `json:"success"`
+			}
+		)
+
+		for i := b[0]; i < b[1]; i++ {
+			ord := orders[i]
+			direction, offset := dm.adaptOpenType(ord.OType)
+			leverRate := ord.LeverRate
+			if leverRate <= 0 {
+				leverRate = dm.config.Lever
+			}
+
+			data := map[string]interface{}{
+				"price":            ord.Price,
+				"volume":           ord.Amount,
+				"direction":        direction,
+				"offset":           offset,
+				"lever_rate":       int(leverRate),
+				"order_price_type": dm.adaptOrderPriceType(ord.OrderType),
+			}
+			for k, v := range contractParams[i] {
+				data[k] = v
+			}
+			param.OrdersData = append(param.OrdersData, data)
+		}
+
+		err := dm.doJsonRequest(path, param, &response)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		//index 从1开始
+		for _, e := range response.Errors {
+			if i := b[0] + e.Index - 1; i >= b[0] && i < b[1] {
+				results[i].Err = errors.New(fmt.Sprintf("%d:[%s]", e.ErrCode, e.ErrMsg))
+			}
+		}
+
+		for _, s := range response.Success {
+			if i := b[0] + s.Index - 1; i >= b[0] && i < b[1] {
+				results[i].OrderId = s.OrderIdStr
+				results[i].ClientOid = fmt.Sprint(s.ClientOrderId)
+				orders[i].OrderID2 = s.OrderIdStr
+				orders[i].OrderID = ToInt64(s.OrderIdStr)
+			}
+		}
+	}
+
+	return results
+}
+
+func (dm *Hbdm) batchCancelOrders(path string, param url.Values, orderIds []string) []BatchOrderResult {
+	results := make([]BatchOrderResult, len(orderIds))
+
+	for _, b := range SplitBatch(len(orderIds), hbdmBatchLimit) {
+		var response struct {
+			Errors []struct {
+				OrderId string `json:"order_id"`
+				ErrCode int    `json:"err_code"`
+				ErrMsg  string `json:"err_msg"`
+			} `json:"errors"`
+			Successes string `json:"successes"`
+		}
+
+		p := url.Values{}
+		for k, v := range param {
+			p[k] = v
+		}
+		p.Set("order_id", strings.Join(orderIds[b[0]:b[1]], ","))
+
+		err := dm.doRequest(path, &p, &response)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		failed := make(map[string]error, len(response.Errors))
+		for _, e := range response.Errors {
+			failed[e.OrderId] = errors.New(fmt.Sprintf("%d:[%s]", e.ErrCode, e.ErrMsg))
+		}
+
+		for i := b[0]; i < b[1]; i++ {
+			results[i] = BatchOrderResult{OrderId: orderIds[i], Err: failed[orderIds[i]]}
+		}
+	}
+
+	return results
+}
+
+// batchAmendOrders 按合约分组批量撤单, 撤单成功的再批量下单
+func (dm *Hbdm) batchAmendOrders(orders []FutureOrder,
+	cancel func(pair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error),
+	place func(orders []FutureOrder) ([]BatchOrderResult, error)) ([]BatchOrderResult, error) {
+	var (
+		results   = make([]BatchOrderResult, len(orders))
+		groups    = make(map[string][]int, 1)
+		newOrders []FutureOrder
+		newIdx    []int
+	)
+
+	for i, ord := range orders {
+		if ord.Price <= 0 || ord.Amount <= 0 {
+			return nil, ErrBatchAmendParam
+		}
+		key := ord.Currency.String() + ":" + ord.ContractName
+		groups[key] = append(groups[key], i)
+	}
+
+	for _, idx := range groups {
+		orderIds := make([]string, 0, len(idx))
+		for _, i := range idx {
+			orderIds = append(orderIds, orders[i].OrderID2)
+		}
+
+		cancelResults, err := cancel(orders[idx[0]].Currency, orders[idx[0]].ContractName, orderIds)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, i := range idx {
+			if cancelResults[j].Err != nil {
+				results[i] = cancelResults[j]
+				continue
+			}
+			newOrders = append(newOrders, orders[i])
+			newIdx = append(newIdx, i)
+		}
+	}
+
+	if len(newOrders) == 0 {
+		return results, nil
+	}
+
+	placeResults, err := place(newOrders)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range newIdx {
+		results[i] = placeResults[j]
+		orders[i].OrderID2 = newOrders[j].OrderID2
+		orders[i].OrderID = newOrders[j].OrderID
+	}
+
+	return results, nil
+}
+
+func (dm *Hbdm) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	contractParams := make([]map[string]interface{}, len(orders))
+	for i, ord := range orders {
+		contractParams[i] = map[string]interface{}{
+			"symbol":        ord.Currency.CurrencyA.Symbol,
+			"contract_type": ord.ContractName,
+			"price":         ToFloat64(dm.formatPriceSize(ord.ContractName, ord.Currency.CurrencyA, fmt.Sprint(ord.Price))),
+		}
+	}
+	return dm.batchPlaceOrders(contractBatchApiPaths.place, orders, contractParams), nil
+}
+
+func (dm *Hbdm) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	param := url.Values{}
+	param.Set("symbol", currencyPair.CurrencyA.Symbol)
+	return dm.batchCancelOrders(contractBatchApiPaths.cancel, param, orderIds), nil
+}
+
+func (dm *Hbdm) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	return dm.batchAmendOrders(orders, dm.BatchCancelFutureOrders, dm.BatchPlaceFutureOrders)
+}
+
+func swapContractParams(orders []FutureOrder) []map[string]interface{} {
+	contractParams := make([]map[string]interface{}, len(orders))
+	for i, ord := range orders {
+		contractParams[i] = map[string]interface{}{"contract_code": ord.Currency.ToSymbol("-")}
+	}
+	return contractParams
+}
+
+func (swap *HbdmSwap) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	return swap.base.batchPlaceOrders(swapBatchApiPaths.place, orders, swapContractParams(orders)), nil
+}
+
+func (swap *HbdmSwap) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	param := url.Values{}
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+	return swap.base.batchCancelOrders(swapBatchApiPaths.cancel, param, orderIds), nil
+}
+
+func (swap *HbdmSwap) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	return swap.base.batchAmendOrders(orders, swap.BatchCancelFutureOrders, swap.BatchPlaceFutureOrders)
+}
+
+func (swap *HbdmLinearSwap) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	return swap.base.batchPlaceOrders(linearSwapBatchApiPaths.place, orders, swapContractParams(orders)), nil
+}
+
+func (swap *HbdmLinearSwap) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	param := url.Values{}
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+	return swap.base.batchCancelOrders(linearSwapBatchApiPaths.cancel, param, orderIds), nil
+}
+
+func (swap *HbdmLinearSwap) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	return swap.base.batchAmendOrders(orders, swap.BatchCancelFutureOrders, swap.BatchPlaceFutureOrders)
+}
@@ -0,0 +1,76 @@
+package huobi
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+var hbdmContractTypes = map[string]string{
+	"this_week":    THIS_WEEK_CONTRACT,
+	"next_week":    NEXT_WEEK_CONTRACT,
+	"quarter":      QUARTER_CONTRACT,
+	"next_quarter": BI_QUARTER_CONTRACT,
+}
+
+// isContractCode contractType 为合约代码, 比如: BTC210326
+func isContractCode(contractType string) bool {
+	switch contractType {
+	case "", THIS_WEEK_CONTRACT, NEXT_WEEK_CONTRACT, QUARTER_CONTRACT, BI_QUARTER_CONTRACT, "next_quarter":
+		return false
+	}
+	return true
+}
+
+// Contracts 币本位交割合约, 合约代码(BTC210326)可以直接作为 contractType 参数
+func (dm *Hbdm) Contracts() *ContractResolver {
+	return dm.contracts
+}
+
+func (dm *Hbdm) loadContracts() ([]Contract, error) {
+	var response struct {
+		Status string `json:"status"`
+		ErrMsg string `json:"err_msg"`
+		Data   []struct {
+			Symbol         string  `json:"symbol"`
+			ContractCode   string  `json:"contract_code"`
+			ContractType   string  `json:"contract_type"`
+			ContractSize   float64 `json:"contract_size"`
+			DeliveryDate   string  `json:"delivery_date"`
+			ContractStatus int     `json:"contract_status"`
+		} `json:"data"`
+	}
+	respBody, err := HttpGet5(dm.config.HttpClient, dm.config.Endpoint+"/api/v1/contract_contract_info", nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(respBody, &response); err != nil {
+		return nil, err
+	}
+	if response.Status != "ok" {
+		return nil, EX_ERR_SYMBOL_ERR.OriginErr(response.ErrMsg)
+	}
+
+	var contracts []Contract
+	for _, info := range response.Data {
+		//1: 上市
+		if info.ContractStatus != 1 {
+			continue
+		}
+		//交割时间为交割日的 16:00(UTC+8)
+		delivery, err := time.Parse("20060102", info.DeliveryDate)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, Contract{
+			Id:             info.ContractCode,
+			Underlying:     NewCurrencyPair(NewCurrency(info.Symbol, ""), USD),
+			SettleCurrency: NewCurrency(info.Symbol, ""),
+			ContractType:   hbdmContractTypes[info.ContractType],
+			Expiry:         delivery.Add(8 * time.Hour),
+			ContractSize:   info.ContractSize,
+		})
+	}
+	return contracts, nil
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	. "github.com/BTreeNewBee/goex"
-	"github.com/BTreeNewBee/goex/internal/logger"
 	"net/url"
 	"time"
 )
@@ -17,6 +16,7 @@ type HbdmLinearSwap struct {
 const (
 	linearAccountApiPath      = "/linear-swap-api/v1/swap_account_info"
 	linearCrossAccountApiPath = "/linear-swap-api/v1/swap_cross_account_info"
+	linearTickerApiPath       = "/linear-swap-ex/market/detail/merged"
 )
 
 func NewHbdmLinearSwap(c *APIConfig) *HbdmLinearSwap {
@@ -35,7 +35,7 @@ func (swap *HbdmLinearSwap) GetExchangeName() string {
 }
 
 func (swap *HbdmLinearSwap) GetFutureTicker(currencyPair CurrencyPair, contractType string) (*Ticker, error) {
-	return nil, errors.New("not implement")
+	return swap.base.getSwapTicker(linearTickerApiPath, currencyPair)
 }
 
 func (swap *HbdmLinearSwap) GetFutureDepth(currencyPair CurrencyPair, contractType string, size int) (*Depth, error) {
@@ -101,35 +101,7 @@ func (swap *HbdmLinearSwap) GetFutureUserinfo(currencyPair ...CurrencyPair) (*Fu
 }
 
 func (swap *HbdmLinearSwap) PlaceFutureOrder(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64) (string, error) {
-	param := url.Values{}
-	param.Set("contract_code", currencyPair.ToSymbol("-"))
-	param.Set("client_order_id", fmt.Sprint(time.Now().UnixNano()))
-	param.Set("price", price)
-	param.Set("volume", amount)
-	param.Set("lever_rate", fmt.Sprintf("%.0f", leverRate))
-
-	direction, offset := swap.base.adaptOpenType(openType)
-	param.Set("direction", direction)
-	param.Set("offset", offset)
-	logger.Info(direction, offset)
-
-	if matchPrice == 1 {
-		param.Set("order_price_type", "opponent")
-	} else {
-		param.Set("order_price_type", "limit")
-	}
-
-	var orderResponse struct {
-		OrderId       string `json:"order_id_str"`
-		ClientOrderId int64  `json:"client_order_id"`
-	}
-
-	err := swap.base.doRequest(placeOrderApiPath, &param, &orderResponse)
-	if err != nil {
-		return "", err
-	}
-
-	return orderResponse.OrderId, nil
+	return swap.base.placeSwapOrder(fmt.Sprint(time.Now().UnixNano()), currencyPair, price, amount, openType, matchPrice, leverRate)
 }
 
 func (swap *HbdmLinearSwap) LimitFuturesOrder(currencyPair CurrencyPair, contractType, price, amount string, openType int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
@@ -156,27 +128,7 @@ func (swap *HbdmLinearSwap) MarketFuturesOrder(currencyPair CurrencyPair, contra
 }
 
 func (swap *HbdmLinearSwap) FutureCancelOrder(currencyPair CurrencyPair, contractType, orderId string) (bool, error) {
-	param := url.Values{}
-	param.Set("order_id", orderId)
-	param.Set("contract_code", currencyPair.ToSymbol("-"))
-
-	var cancelResponse struct {
-		Errors []struct {
-			ErrMsg    string `json:"err_msg"`
-			Successes string `json:"successes,omitempty"`
-		} `json:"errors"`
-	}
-
-	err := swap.base.doRequest(cancelOrderApiPath, &param, &cancelResponse)
-	if err != nil {
-		return false, err
-	}
-
-	if len(cancelResponse.Errors) > 0 {
-		return false, errors.New(cancelResponse.Errors[0].ErrMsg)
-	}
-
-	return true, nil
+	return swap.base.cancelSwapOrder("order_id", orderId, currencyPair)
 }
 
 func (swap *HbdmLinearSwap) GetFuturePosition(currencyPair CurrencyPair, contractType string) ([]FuturePosition, error) {
@@ -248,41 +200,7 @@ func (swap *HbdmLinearSwap) GetFutureOrders(orderIds []string, currencyPair Curr
 }
 
 func (swap *HbdmLinearSwap) GetFutureOrder(orderId string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
-	var (
-		orderInfoResponse []OrderInfo
-		param             = url.Values{}
-	)
-
-	param.Set("contract_code", currencyPair.ToSymbol("-"))
-	param.Set("order_id", orderId)
-
-	err := swap.base.doRequest(getOrderInfoApiPath, &param, &orderInfoResponse)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(orderInfoResponse) == 0 {
-		return nil, errors.New("not found")
-	}
-
-	orderInfo := orderInfoResponse[0]
-
-	return &FutureOrder{
-		Currency:     currencyPair,
-		ClientOid:    fmt.Sprint(orderInfo.ClientOrderId),
-		OrderID2:     fmt.Sprint(orderInfo.OrderId),
-		Price:        orderInfo.Price,
-		Amount:       orderInfo.Volume,
-		AvgPrice:     orderInfo.TradeAvgPrice,
-		DealAmount:   orderInfo.TradeVolume,
-		OrderID:      orderInfo.OrderId,
-		Status:       swap.base.adaptOrderStatus(orderInfo.Status),
-		OType:        swap.base.adaptOffsetDirectionToOpenType(orderInfo.Offset, orderInfo.Direction),
-		LeverRate:    orderInfo.LeverRate,
-		Fee:          orderInfo.Fee,
-		ContractName: orderInfo.ContractCode,
-		OrderTime:    orderInfo.CreatedAt,
-	}, nil
+	return swap.base.getSwapOrder("order_id", orderId, currencyPair)
 }
 
 func (swap *HbdmLinearSwap) GetFutureOrderHistory(pair CurrencyPair, contractType string, optional ...OptionalParameter) ([]FutureOrder, error) {
@@ -387,7 +305,7 @@ func (swap *HbdmLinearSwap) GetTrades(contractType string, currencyPair Currency
 }
 
 func (swap *HbdmLinearSwap) GetFee() (float64, error) {
-	panic("not implement")
+	return FutureTakerFee(swap, BTC_USDT, SWAP_USDT_CONTRACT)
 }
 
 func (swap *HbdmLinearSwap) GetFutureIndex(currencyPair CurrencyPair) (float64, error) {
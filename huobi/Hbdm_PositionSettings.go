@@ -0,0 +1,161 @@
+package huobi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// 火币合约只支持双向持仓, 不支持调整逐仓保证金; 本包下单均走逐仓接口
+// 下单时使用 APIConfig.Lever 作为杠杆倍数, 设置杠杆成功后同步更新
+
+type positionSettingsApiPaths struct {
+	accountInfo     string
+	switchLeverRate string
+}
+
+var (
+	contractPositionSettingsPaths   = positionSettingsApiPaths{"/api/v1/contract_account_info", "/api/v1/contract_switch_lever_rate"}
+	swapPositionSettingsPaths       = positionSettingsApiPaths{"/swap-api/v1/swap_account_info", "/swap-api/v1/swap_switch_lever_rate"}
+	linearSwapPositionSettingsPaths = positionSettingsApiPaths{"/linear-swap-api/v1/swap_account_info", "/linear-swap-api/v1/swap_switch_lever_rate"}
+)
+
+func (dm *Hbdm) getLeverage(path string, param url.Values, currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	var data []struct {
+		Symbol       string  `json:"symbol"`
+		ContractCode string  `json:"contract_code"`
+		LeverRate    float64 `json:"lever_rate"`
+	}
+
+	err := dm.doRequest(path, &param, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("not found account info")
+	}
+
+	return &FutureLeverage{
+		Pair:          currencyPair,
+		ContractType:  contractType,
+		MarginMode:    MARGIN_MODE_ISOLATED,
+		LongLeverage:  data[0].LeverRate,
+		ShortLeverage: data[0].LeverRate,
+	}, nil
+}
+
+func (dm *Hbdm) setLeverage(path string, param url.Values, leverage float64) error {
+	param.Set("lever_rate", fmt.Sprint(int(leverage)))
+
+	var data struct {
+		LeverRate int `json:"lever_rate"`
+	}
+	err := dm.doRequest(path, &param, &data)
+	if err != nil {
+		return err
+	}
+
+	dm.config.Lever = leverage
+
+	return nil
+}
+
+func setHbdmMarginMode(mode MarginMode) error {
+	if mode != MARGIN_MODE_ISOLATED {
+		return EX_ERR_NOT_SUPPORT
+	}
+	return nil
+}
+
+func setHbdmPositionMode(mode PositionMode) error {
+	if mode != POSITION_MODE_HEDGE {
+		return EX_ERR_NOT_SUPPORT
+	}
+	return nil
+}
+
+func contractCodeParam(currencyPair CurrencyPair) url.Values {
+	param := url.Values{}
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+	return param
+}
+
+func (dm *Hbdm) GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	param := url.Values{}
+	param.Set("symbol", currencyPair.CurrencyA.Symbol)
+	return dm.getLeverage(contractPositionSettingsPaths.accountInfo, param, currencyPair, contractType)
+}
+
+// SetLeverage 交割合约按品种设置杠杆, 对该品种所有交割合约生效
+func (dm *Hbdm) SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error {
+	param := url.Values{}
+	param.Set("symbol", currencyPair.CurrencyA.Symbol)
+	return dm.setLeverage(contractPositionSettingsPaths.switchLeverRate, param, leverage)
+}
+
+func (dm *Hbdm) SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error {
+	return setHbdmMarginMode(mode)
+}
+
+func (dm *Hbdm) GetPositionMode(contractType string) (PositionMode, error) {
+	return POSITION_MODE_HEDGE, nil
+}
+
+func (dm *Hbdm) SetPositionMode(contractType string, mode PositionMode) error {
+	return setHbdmPositionMode(mode)
+}
+
+func (dm *Hbdm) AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error {
+	return EX_ERR_NOT_SUPPORT
+}
+
+func (swap *HbdmSwap) GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	return swap.base.getLeverage(swapPositionSettingsPaths.accountInfo, contractCodeParam(currencyPair), currencyPair, SWAP_CONTRACT)
+}
+
+func (swap *HbdmSwap) SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error {
+	return swap.base.setLeverage(swapPositionSettingsPaths.switchLeverRate, contractCodeParam(currencyPair), leverage)
+}
+
+func (swap *HbdmSwap) SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error {
+	return setHbdmMarginMode(mode)
+}
+
+func (swap *HbdmSwap) GetPositionMode(contractType string) (PositionMode, error) {
+	return POSITION_MODE_HEDGE, nil
+}
+
+func (swap *HbdmSwap) SetPositionMode(contractType string, mode PositionMode) error {
+	return setHbdmPositionMode(mode)
+}
+
+func (swap *HbdmSwap) AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error {
+	return EX_ERR_NOT_SUPPORT
+}
+
+func (swap *HbdmLinearSwap) GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	return swap.base.getLeverage(linearSwapPositionSettingsPaths.accountInfo, contractCodeParam(currencyPair), currencyPair, SWAP_USDT_CONTRACT)
+}
+
+func (swap *HbdmLinearSwap) SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error {
+	return swap.base.setLeverage(linearSwapPositionSettingsPaths.switchLeverRate, contractCodeParam(currencyPair), leverage)
+}
+
+func (swap *HbdmLinearSwap) SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error {
+	return setHbdmMarginMode(mode)
+}
+
+func (swap *HbdmLinearSwap) GetPositionMode(contractType string) (PositionMode, error) {
+	return POSITION_MODE_HEDGE, nil
+}
+
+func (swap *HbdmLinearSwap) SetPositionMode(contractType string, mode PositionMode) error {
+	return setHbdmPositionMode(mode)
+}
+
+func (swap *HbdmLinearSwap) AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error {
+	return EX_ERR_NOT_SUPPORT
+}
@@ -0,0 +1,142 @@
+package huobi
+
+import (
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// 火币合约只支持双向持仓, 强平价需要从账户信息接口获取
+
+type positionV2ApiPaths struct {
+	position    string
+	accountInfo string
+}
+
+var (
+	contractPositionV2Paths   = positionV2ApiPaths{"/api/v1/contract_position_info", "/api/v1/contract_account_info"}
+	swapPositionV2Paths       = positionV2ApiPaths{"/swap-api/v1/swap_position_info", "/swap-api/v1/swap_account_info"}
+	linearSwapPositionV2Paths = positionV2ApiPaths{"/linear-swap-api/v1/swap_position_info", "/linear-swap-api/v1/swap_account_info"}
+)
+
+type hbdmPositionInfo struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	ContractType   string  `json:"contract_type"` //交割合约
+	Volume         float64 `json:"volume"`
+	Available      float64 `json:"available"`
+	CostOpen       float64 `json:"cost_open"`
+	ProfitUnreal   float64 `json:"profit_unreal"`
+	PositionMargin float64 `json:"position_margin"`
+	LeverRate      float64 `json:"lever_rate"`
+	Direction      string  `json:"direction"`
+}
+
+func (dm *Hbdm) getFuturePositionV2(paths positionV2ApiPaths, param url.Values) ([]hbdmPositionInfo, map[string]float64, error) {
+	var (
+		positions []hbdmPositionInfo
+		accounts  []struct {
+			Symbol           string  `json:"symbol"`
+			ContractCode     string  `json:"contract_code"`
+			LiquidationPrice float64 `json:"liquidation_price"`
+		}
+	)
+
+	positionParam := url.Values{}
+	accountParam := url.Values{}
+	for k, v := range param {
+		positionParam[k] = v
+		accountParam[k] = v
+	}
+
+	err := dm.doRequest(paths.position, &positionParam, &positions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = dm.doRequest(paths.accountInfo, &accountParam, &accounts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//交割合约按品种(symbol)计算强平价, 永续按合约(contract_code)
+	liquidationPrices := make(map[string]float64, len(accounts))
+	for _, acc := range accounts {
+		liquidationPrices[acc.Symbol] = acc.LiquidationPrice
+		if acc.ContractCode != "" {
+			liquidationPrices[acc.ContractCode] = acc.LiquidationPrice
+		}
+	}
+
+	return positions, liquidationPrices, nil
+}
+
+func adaptHbdmPositionV2(pos hbdmPositionInfo, currencyPair CurrencyPair, contractType string, liquidationPrice float64) FuturePositionV2 {
+	side := POSITION_SIDE_LONG
+	if pos.Direction == "sell" {
+		side = POSITION_SIDE_SHORT
+	}
+
+	return FuturePositionV2{
+		Pair:             currencyPair,
+		ContractType:     contractType,
+		ContractId:       pos.ContractCode,
+		Side:             side,
+		Amount:           pos.Volume,
+		AvailAmount:      pos.Available,
+		AvgPrice:         pos.CostOpen,
+		MarginMode:       MARGIN_MODE_ISOLATED,
+		Leverage:         pos.LeverRate,
+		InitialMargin:    pos.PositionMargin,
+		LiquidationPrice: liquidationPrice,
+		UnrealizedPnl:    pos.ProfitUnreal,
+	}
+}
+
+func (dm *Hbdm) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	param := url.Values{}
+	param.Set("symbol", currencyPair.CurrencyA.Symbol)
+
+	data, liquidationPrices, err := dm.getFuturePositionV2(contractPositionV2Paths, param)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []FuturePositionV2
+	for _, pos := range data {
+		if pos.ContractType == "next_quarter" {
+			pos.ContractType = BI_QUARTER_CONTRACT
+		}
+		if pos.ContractType != contractType || pos.Volume == 0 {
+			continue
+		}
+		positions = append(positions, adaptHbdmPositionV2(pos, currencyPair, contractType, liquidationPrices[pos.Symbol]))
+	}
+
+	return positions, nil
+}
+
+func (dm *Hbdm) getSwapPositionV2(paths positionV2ApiPaths, currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	data, liquidationPrices, err := dm.getFuturePositionV2(paths, contractCodeParam(currencyPair))
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []FuturePositionV2
+	for _, pos := range data {
+		if pos.Volume == 0 {
+			continue
+		}
+		positions = append(positions, adaptHbdmPositionV2(pos, currencyPair, contractType, liquidationPrices[pos.ContractCode]))
+	}
+
+	return positions, nil
+}
+
+func (swap *HbdmSwap) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	return swap.base.getSwapPositionV2(swapPositionV2Paths, currencyPair, SWAP_CONTRACT)
+}
+
+func (swap *HbdmLinearSwap) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	return swap.base.getSwapPositionV2(linearSwapPositionV2Paths, currencyPair, SWAP_USDT_CONTRACT)
+}
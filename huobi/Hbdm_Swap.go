@@ -46,8 +46,13 @@ func (swap *HbdmSwap) GetExchangeName() string {
 }
 
 func (swap *HbdmSwap) GetFutureTicker(currencyPair CurrencyPair, contractType string) (*Ticker, error) {
-	tickerUrl := fmt.Sprintf("%s%s?contract_code=%s", swap.base.config.Endpoint, tickerApiPath, currencyPair.ToSymbol("-"))
-	responseBody, err := HttpGet5(swap.base.config.HttpClient, tickerUrl, map[string]string{})
+	return swap.base.getSwapTicker(tickerApiPath, currencyPair)
+}
+
+//getSwapTicker 币本位与U本位永续的 ticker 接口只有路径不同
+func (dm *Hbdm) getSwapTicker(apiPath string, currencyPair CurrencyPair) (*Ticker, error) {
+	tickerUrl := fmt.Sprintf("%s%s?contract_code=%s", dm.config.Endpoint, apiPath, currencyPair.ToSymbol("-"))
+	responseBody, err := HttpGet5(dm.config.HttpClient, tickerUrl, map[string]string{})
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +88,7 @@ func (swap *HbdmSwap) GetFutureTicker(currencyPair CurrencyPair, contractType st
 
 	return &Ticker{
 		Pair: currencyPair,
-		Last: 0,
+		Last: tickResponse.Tick.Close,
 		Buy:  tickResponse.Tick.Bid[0],
 		Sell: tickResponse.Tick.Ask[0],
 		High: tickResponse.Tick.High,
@@ -198,35 +203,7 @@ func (swap *HbdmSwap) GetFutureUserinfo(currencyPair ...CurrencyPair) (*FutureAc
 }
 
 func (swap *HbdmSwap) PlaceFutureOrder(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64) (string, error) {
-	param := url.Values{}
-	param.Set("contract_code", currencyPair.ToSymbol("-"))
-	param.Set("client_order_id", fmt.Sprint(time.Now().UnixNano()))
-	param.Set("price", price)
-	param.Set("volume", amount)
-	param.Set("lever_rate", fmt.Sprintf("%.0f", leverRate))
-
-	direction, offset := swap.base.adaptOpenType(openType)
-	param.Set("direction", direction)
-	param.Set("offset", offset)
-	logger.Info(direction, offset)
-
-	if matchPrice == 1 {
-		param.Set("order_price_type", "opponent")
-	} else {
-		param.Set("order_price_type", "limit")
-	}
-
-	var orderResponse struct {
-		OrderId       string `json:"order_id_str"`
-		ClientOrderId int64  `json:"client_order_id"`
-	}
-
-	err := swap.base.doRequest(placeOrderApiPath, &param, &orderResponse)
-	if err != nil {
-		return "", err
-	}
-
-	return orderResponse.OrderId, nil
+	return swap.base.placeSwapOrder(fmt.Sprint(time.Now().UnixNano()), currencyPair, price, amount, openType, matchPrice, leverRate)
 }
 
 func (swap *HbdmSwap) LimitFuturesOrder(currencyPair CurrencyPair, contractType, price, amount string, openType int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
@@ -253,27 +230,7 @@ func (swap *HbdmSwap) MarketFuturesOrder(currencyPair CurrencyPair, contractType
 }
 
 func (swap *HbdmSwap) FutureCancelOrder(currencyPair CurrencyPair, contractType, orderId string) (bool, error) {
-	param := url.Values{}
-	param.Set("order_id", orderId)
-	param.Set("contract_code", currencyPair.ToSymbol("-"))
-
-	var cancelResponse struct {
-		Errors []struct {
-			ErrMsg    string `json:"err_msg"`
-			Successes string `json:"successes,omitempty"`
-		} `json:"errors"`
-	}
-
-	err := swap.base.doRequest(cancelOrderApiPath, &param, &cancelResponse)
-	if err != nil {
-		return false, err
-	}
-
-	if len(cancelResponse.Errors) > 0 {
-		return false, errors.New(cancelResponse.Errors[0].ErrMsg)
-	}
-
-	return true, nil
+	return swap.base.cancelSwapOrder("order_id", orderId, currencyPair)
 }
 
 func (swap *HbdmSwap) GetFuturePosition(currencyPair CurrencyPair, contractType string) ([]FuturePosition, error) {
@@ -345,41 +302,7 @@ func (swap *HbdmSwap) GetFutureOrders(orderIds []string, currencyPair CurrencyPa
 }
 
 func (swap *HbdmSwap) GetFutureOrder(orderId string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
-	var (
-		orderInfoResponse []OrderInfo
-		param             = url.Values{}
-	)
-
-	param.Set("contract_code", currencyPair.ToSymbol("-"))
-	param.Set("order_id", orderId)
-
-	err := swap.base.doRequest(getOrderInfoApiPath, &param, &orderInfoResponse)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(orderInfoResponse) == 0 {
-		return nil, errors.New("not found")
-	}
-
-	orderInfo := orderInfoResponse[0]
-
-	return &FutureOrder{
-		Currency:     currencyPair,
-		ClientOid:    fmt.Sprint(orderInfo.ClientOrderId),
-		OrderID2:     fmt.Sprint(orderInfo.OrderId),
-		Price:        orderInfo.Price,
-		Amount:       orderInfo.Volume,
-		AvgPrice:     orderInfo.TradeAvgPrice,
-		DealAmount:   orderInfo.TradeVolume,
-		OrderID:      orderInfo.OrderId,
-		Status:       swap.base.adaptOrderStatus(orderInfo.Status),
-		OType:        swap.base.adaptOffsetDirectionToOpenType(orderInfo.Offset, orderInfo.Direction),
-		LeverRate:    orderInfo.LeverRate,
-		Fee:          orderInfo.Fee,
-		ContractName: orderInfo.ContractCode,
-		OrderTime:    orderInfo.CreatedAt,
-	}, nil
+	return swap.base.getSwapOrder("order_id", orderId, currencyPair)
 }
 
 func (swap *HbdmSwap) GetFutureOrderHistory(pair CurrencyPair, contractType string, optional ...OptionalParameter) ([]FutureOrder, error) {
@@ -484,7 +407,7 @@ func (swap *HbdmSwap) GetTrades(contractType string, currencyPair CurrencyPair,
 }
 
 func (swap *HbdmSwap) GetFee() (float64, error) {
-	panic("not implement")
+	return FutureTakerFee(swap, BTC_USD, SWAP_CONTRACT)
 }
 
 func (swap *HbdmSwap) GetFutureIndex(currencyPair CurrencyPair) (float64, error) {
@@ -498,3 +421,109 @@ func (swap *HbdmSwap) GetDeliveryTime() (int, int, int, int) {
 func (swap *HbdmSwap) GetFutureEstimatedPrice(currencyPair CurrencyPair) (float64, error) {
 	panic("not implement")
 }
+
+// placeSwapOrder 币本位和U本位永续共用, cid 必须是数字
+func (dm *Hbdm) placeSwapOrder(cid string, currencyPair CurrencyPair, price, amount string, openType, matchPrice int, leverRate float64, opt ...LimitOrderOptionalParameter) (string, error) {
+	param := url.Values{}
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+	param.Set("client_order_id", cid)
+	param.Set("price", price)
+	param.Set("volume", amount)
+	param.Set("lever_rate", fmt.Sprintf("%.0f", leverRate))
+
+	direction, offset := dm.adaptOpenType(openType)
+	param.Set("direction", direction)
+	param.Set("offset", offset)
+	logger.Info(direction, offset)
+
+	if matchPrice == 1 {
+		param.Set("order_price_type", "opponent")
+	} else {
+		orderPriceType := "limit"
+		if len(opt) > 0 {
+			switch opt[0] {
+			case Fok:
+				orderPriceType = "fok"
+			case Ioc:
+				orderPriceType = "ioc"
+			case PostOnly:
+				orderPriceType = "post_only"
+			}
+		}
+		param.Set("order_price_type", orderPriceType)
+	}
+
+	var orderResponse struct {
+		OrderId       string `json:"order_id_str"`
+		ClientOrderId int64  `json:"client_order_id"`
+	}
+
+	err := dm.doRequest(placeOrderApiPath, &param, &orderResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return orderResponse.OrderId, nil
+}
+
+func (dm *Hbdm) cancelSwapOrder(idKey, id string, currencyPair CurrencyPair) (bool, error) {
+	param := url.Values{}
+	param.Set(idKey, id)
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+
+	var cancelResponse struct {
+		Errors []struct {
+			ErrMsg    string `json:"err_msg"`
+			Successes string `json:"successes,omitempty"`
+		} `json:"errors"`
+	}
+
+	err := dm.doRequest(cancelOrderApiPath, &param, &cancelResponse)
+	if err != nil {
+		return false, err
+	}
+
+	if len(cancelResponse.Errors) > 0 {
+		return false, errors.New(cancelResponse.Errors[0].ErrMsg)
+	}
+
+	return true, nil
+}
+
+func (dm *Hbdm) getSwapOrder(idKey, id string, currencyPair CurrencyPair) (*FutureOrder, error) {
+	var (
+		orderInfoResponse []OrderInfo
+		param             = url.Values{}
+	)
+
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+	param.Set(idKey, id)
+
+	err := dm.doRequest(getOrderInfoApiPath, &param, &orderInfoResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orderInfoResponse) == 0 {
+		return nil, EX_ERR_NOT_FIND_ORDER.OriginErr("not found")
+	}
+
+	orderInfo := orderInfoResponse[0]
+
+	return &FutureOrder{
+		Currency:     currencyPair,
+		ClientOid:    fmt.Sprint(orderInfo.ClientOrderId),
+		OrderID2:     fmt.Sprint(orderInfo.OrderId),
+		Price:        orderInfo.Price,
+		Amount:       orderInfo.Volume,
+		AvgPrice:     orderInfo.TradeAvgPrice,
+		DealAmount:   orderInfo.TradeVolume,
+		OrderID:      orderInfo.OrderId,
+		Status:       dm.adaptOrderStatus(orderInfo.Status),
+		OType:        dm.adaptOffsetDirectionToOpenType(orderInfo.Offset, orderInfo.Direction),
+		LeverRate:    orderInfo.LeverRate,
+		Fee:          orderInfo.Fee,
+		ContractName: orderInfo.ContractCode,
+		OrderTime:    orderInfo.CreatedAt,
+	}, nil
+}
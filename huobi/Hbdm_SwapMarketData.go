@@ -0,0 +1,238 @@
+package huobi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+type swapMarketDataApiPaths struct {
+	fundingRate           string
+	historicalFundingRate string
+	index                 string
+	openInterest          string
+	markPriceKline        string
+}
+
+var (
+	swapMarketDataPaths = swapMarketDataApiPaths{
+		fundingRate:           "/swap-api/v1/swap_funding_rate",
+		historicalFundingRate: "/swap-api/v1/swap_historical_funding_rate",
+		index:                 "/swap-api/v1/swap_index",
+		openInterest:          "/swap-api/v1/swap_open_interest",
+		markPriceKline:        "/index/market/history/swap_mark_price_kline",
+	}
+	linearSwapMarketDataPaths = swapMarketDataApiPaths{
+		fundingRate:           "/linear-swap-api/v1/swap_funding_rate",
+		historicalFundingRate: "/linear-swap-api/v1/swap_historical_funding_rate",
+		index:                 "/linear-swap-api/v1/swap_index",
+		openInterest:          "/linear-swap-api/v1/swap_open_interest",
+		markPriceKline:        "/index/market/history/linear_swap_mark_price_kline",
+	}
+)
+
+func (dm *Hbdm) doPublicRequest(path string, params url.Values, data interface{}) error {
+	resp, err := HttpGet5(dm.config.HttpClient, dm.config.Endpoint+path+"?"+params.Encode(), map[string]string{})
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("response body: %s", string(resp))
+
+	var ret BaseResponse
+	err = json.Unmarshal(resp, &ret)
+	if err != nil {
+		return err
+	}
+
+	if ret.Status != "ok" {
+		return errors.New(fmt.Sprintf("%d:[%s]", ret.ErrCode, ret.ErrMsg))
+	}
+
+	return json.Unmarshal(ret.Data, data)
+}
+
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+func (dm *Hbdm) getFundingRate(paths swapMarketDataApiPaths, currencyPair CurrencyPair, contractType string) (*FundingRate, error) {
+	var data struct {
+		ContractCode    string `json:"contract_code"`
+		FundingRate     string `json:"funding_rate"`
+		EstimatedRate   string `json:"estimated_rate"`
+		FundingTime     string `json:"funding_time"`
+		NextFundingTime string `json:"next_funding_time"`
+	}
+
+	params := url.Values{}
+	params.Set("contract_code", currencyPair.ToSymbol("-"))
+	err := dm.doPublicRequest(paths.fundingRate, params, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FundingRate{
+		Pair:            currencyPair,
+		ContractType:    contractType,
+		FundingRate:     ToFloat64(data.FundingRate),
+		PredictedRate:   ToFloat64(data.EstimatedRate),
+		FundingTime:     msToTime(ToInt64(data.FundingTime)),
+		NextFundingTime: msToTime(ToInt64(data.NextFundingTime)),
+	}, nil
+}
+
+func (dm *Hbdm) getFundingRateHistory(paths swapMarketDataApiPaths, currencyPair CurrencyPair, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	var data struct {
+		TotalPage   int `json:"total_page"`
+		CurrentPage int `json:"current_page"`
+		Data        []struct {
+			ContractCode string `json:"contract_code"`
+			FundingRate  string `json:"funding_rate"`
+			RealizedRate string `json:"realized_rate"`
+			FundingTime  string `json:"funding_time"`
+		} `json:"data"`
+	}
+
+	params := url.Values{}
+	params.Set("contract_code", currencyPair.ToSymbol("-"))
+	if size > 0 {
+		params.Set("page_size", fmt.Sprint(size))
+	}
+	MergeOptionalParameter(&params, opt...)
+
+	err := dm.doPublicRequest(paths.historicalFundingRate, params, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []HistoricalFunding
+	for _, d := range data.Data {
+		history = append(history, HistoricalFunding{
+			InstrumentId: d.ContractCode,
+			FundingRate:  ToFloat64(d.FundingRate),
+			RealizedRate: ToFloat64(d.RealizedRate),
+			FundingTime:  msToTime(ToInt64(d.FundingTime)),
+		})
+	}
+
+	return history, nil
+}
+
+// getMarkPrice 只有标记价格K线接口, 取最新一根1分钟K线的收盘价
+func (dm *Hbdm) getMarkPrice(paths swapMarketDataApiPaths, currencyPair CurrencyPair) (float64, error) {
+	var data []struct {
+		Id    int64       `json:"id"`
+		Close interface{} `json:"close"`
+	}
+
+	params := url.Values{}
+	params.Set("contract_code", currencyPair.ToSymbol("-"))
+	params.Set("period", "1min")
+	params.Set("size", "1")
+
+	err := dm.doPublicRequest(paths.markPriceKline, params, &data)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(data) == 0 {
+		return 0, errors.New("mark price kline is empty")
+	}
+
+	return ToFloat64(data[len(data)-1].Close), nil
+}
+
+func (dm *Hbdm) getIndexPrice(paths swapMarketDataApiPaths, currencyPair CurrencyPair) (float64, error) {
+	var data []struct {
+		ContractCode string  `json:"contract_code"`
+		IndexPrice   float64 `json:"index_price"`
+		IndexTs      int64   `json:"index_ts"`
+	}
+
+	params := url.Values{}
+	params.Set("contract_code", currencyPair.ToSymbol("-"))
+
+	err := dm.doPublicRequest(paths.index, params, &data)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(data) == 0 {
+		return 0, errors.New("index price is empty")
+	}
+
+	return data[0].IndexPrice, nil
+}
+
+func (dm *Hbdm) getOpenInterest(paths swapMarketDataApiPaths, currencyPair CurrencyPair, contractType string) (*OpenInterest, error) {
+	var data []struct {
+		ContractCode string  `json:"contract_code"`
+		Volume       float64 `json:"volume"` //张
+		Amount       float64 `json:"amount"` //币
+	}
+
+	params := url.Values{}
+	params.Set("contract_code", currencyPair.ToSymbol("-"))
+
+	err := dm.doPublicRequest(paths.openInterest, params, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("open interest is empty")
+	}
+
+	return &OpenInterest{
+		Pair:         currencyPair,
+		ContractType: contractType,
+		Amount:       data[0].Volume,
+		Timestamp:    time.Now().UnixNano() / int64(time.Millisecond),
+	}, nil
+}
+
+func (swap *HbdmSwap) GetFundingRate(currencyPair CurrencyPair, contractType string) (*FundingRate, error) {
+	return swap.base.getFundingRate(swapMarketDataPaths, currencyPair, SWAP_CONTRACT)
+}
+
+func (swap *HbdmSwap) GetFundingRateHistory(currencyPair CurrencyPair, contractType string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	return swap.base.getFundingRateHistory(swapMarketDataPaths, currencyPair, size, opt...)
+}
+
+func (swap *HbdmSwap) GetMarkPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	return swap.base.getMarkPrice(swapMarketDataPaths, currencyPair)
+}
+
+func (swap *HbdmSwap) GetIndexPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	return swap.base.getIndexPrice(swapMarketDataPaths, currencyPair)
+}
+
+func (swap *HbdmSwap) GetOpenInterest(currencyPair CurrencyPair, contractType string) (*OpenInterest, error) {
+	return swap.base.getOpenInterest(swapMarketDataPaths, currencyPair, SWAP_CONTRACT)
+}
+
+func (swap *HbdmLinearSwap) GetFundingRate(currencyPair CurrencyPair, contractType string) (*FundingRate, error) {
+	return swap.base.getFundingRate(linearSwapMarketDataPaths, currencyPair, SWAP_USDT_CONTRACT)
+}
+
+func (swap *HbdmLinearSwap) GetFundingRateHistory(currencyPair CurrencyPair, contractType string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	return swap.base.getFundingRateHistory(linearSwapMarketDataPaths, currencyPair, size, opt...)
+}
+
+func (swap *HbdmLinearSwap) GetMarkPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	return swap.base.getMarkPrice(linearSwapMarketDataPaths, currencyPair)
+}
+
+func (swap *HbdmLinearSwap) GetIndexPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	return swap.base.getIndexPrice(linearSwapMarketDataPaths, currencyPair)
+}
+
+func (swap *HbdmLinearSwap) GetOpenInterest(currencyPair CurrencyPair, contractType string) (*OpenInterest, error) {
+	return swap.base.getOpenInterest(linearSwapMarketDataPaths, currencyPair, SWAP_USDT_CONTRACT)
+}
@@ -23,22 +23,27 @@ type HbdmSwapWs struct {
 }
 
 func NewHbdmSwapWs() *HbdmSwapWs {
-	ws := &HbdmSwapWs{WsBuilder: NewWsBuilder()}
-	ws.WsBuilder = ws.WsBuilder.
-		WsUrl("wss://api.hbdm.com/swap-ws").
-		//ProxyUrl("socks5://127.0.0.1:2341").
-		AutoReconnect().
-		DecompressFunc(GzipDecompress).
-		ProtoHandleFunc(ws.handle)
-	return ws
+	return NewHbdmSwapWsWithConfig(nil)
+}
+
+func NewHbdmSwapWsWithConfig(config *WsClientConfig) *HbdmSwapWs {
+	return newHbdmSwapWs("wss://api.hbdm.com/swap-ws", config)
 }
 
 //构建usdt本位永续合约ws
 func NewHbdmLinearSwapWs() *HbdmSwapWs {
+	return NewHbdmLinearSwapWsWithConfig(nil)
+}
+
+func NewHbdmLinearSwapWsWithConfig(config *WsClientConfig) *HbdmSwapWs {
+	return newHbdmSwapWs("wss://api.hbdm.com/linear-swap-ws", config)
+}
+
+func newHbdmSwapWs(wsUrl string, config *WsClientConfig) *HbdmSwapWs {
 	ws := &HbdmSwapWs{WsBuilder: NewWsBuilder()}
 	ws.WsBuilder = ws.WsBuilder.
-		WsUrl("wss://api.hbdm.com/linear-swap-ws").
-		//ProxyUrl("socks5://127.0.0.1:2341").
+		WsUrl(wsUrl).
+		ClientConfig(config).
 		AutoReconnect().
 		DecompressFunc(GzipDecompress).
 		ProtoHandleFunc(ws.handle)
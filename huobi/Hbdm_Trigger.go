@@ -0,0 +1,360 @@
+package huobi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// 计划委托(trigger order), 交割合约、币本位永续与U本位永续只有接口路径和合约参数不同
+type triggerApiPaths struct {
+	place      string
+	cancel     string
+	openOrders string
+	hisOrders  string
+}
+
+var (
+	contractTriggerApiPaths = triggerApiPaths{
+		place:      "/api/v1/contract_trigger_order",
+		cancel:     "/api/v1/contract_trigger_cancel",
+		openOrders: "/api/v1/contract_trigger_openorders",
+		hisOrders:  "/api/v1/contract_trigger_hisorders",
+	}
+	swapTriggerApiPaths = triggerApiPaths{
+		place:      "/swap-api/v1/swap_trigger_order",
+		cancel:     "/swap-api/v1/swap_trigger_cancel",
+		openOrders: "/swap-api/v1/swap_trigger_openorders",
+		hisOrders:  "/swap-api/v1/swap_trigger_hisorders",
+	}
+	linearSwapTriggerApiPaths = triggerApiPaths{
+		place:      "/linear-swap-api/v1/swap_trigger_order",
+		cancel:     "/linear-swap-api/v1/swap_trigger_cancel",
+		openOrders: "/linear-swap-api/v1/swap_trigger_openorders",
+		hisOrders:  "/linear-swap-api/v1/swap_trigger_hisorders",
+	}
+)
+
+type TriggerOrderInfo struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	ContractType   string  `json:"contract_type"`
+	TriggerType    string  `json:"trigger_type"`
+	Volume         float64 `json:"volume"`
+	Direction      string  `json:"direction"`
+	Offset         string  `json:"offset"`
+	LeverRate      float64 `json:"lever_rate"`
+	OrderId        int64   `json:"order_id"`
+	OrderIdStr     string  `json:"order_id_str"`
+	TriggerPrice   float64 `json:"trigger_price"`
+	TriggeredPrice float64 `json:"triggered_price"`
+	OrderPrice     float64 `json:"order_price"`
+	OrderPriceType string  `json:"order_price_type"`
+	CreatedAt      int64   `json:"created_at"`
+	UpdateTime     int64   `json:"update_time"`
+	Status         int     `json:"status"`
+}
+
+// adaptTriggerType 触发价高于最新价时 ge(大于等于触发), 否则 le
+func (dm *Hbdm) adaptTriggerType(triggerPrice, lastPrice float64) string {
+	if triggerPrice >= lastPrice {
+		return "ge"
+	}
+	return "le"
+}
+
+// adaptTriggerOrderStatus 1:准备提交 2:提交中 3:提交完成 4:下单成功 5:下单失败 6:已撤单
+func (dm *Hbdm) adaptTriggerOrderStatus(s int) TradeStatus {
+	switch s {
+	case 4:
+		return ORDER_TRIGGERED
+	case 5:
+		return ORDER_FAIL
+	case 6:
+		return ORDER_CANCEL
+	default:
+		return ORDER_UNFINISH
+	}
+}
+
+func (dm *Hbdm) placeTriggerOrder(path string, param url.Values, ord *FutureOrder, lastPrice float64) error {
+	direction, offset := dm.adaptOpenType(ord.OType)
+	if direction == "" {
+		return errors.New("open type is error")
+	}
+
+	param.Set("trigger_type", dm.adaptTriggerType(ord.TriggerPrice, lastPrice))
+	param.Set("trigger_price", fmt.Sprint(ord.TriggerPrice))
+	param.Set("volume", fmt.Sprint(ord.Amount))
+	param.Set("direction", direction)
+	param.Set("offset", offset)
+
+	leverRate := ord.LeverRate
+	if leverRate <= 0 {
+		leverRate = dm.config.Lever
+	}
+	param.Set("lever_rate", fmt.Sprintf("%.0f", leverRate))
+
+	if ord.AlgoType == 2 {
+		param.Set("order_price_type", "optimal_5") //最优5档
+	} else {
+		param.Set("order_price_type", "limit")
+		param.Set("order_price", fmt.Sprint(ord.Price))
+	}
+
+	var orderResponse struct {
+		OrderId    int64  `json:"order_id"`
+		OrderIdStr string `json:"order_id_str"`
+	}
+
+	err := dm.doRequest(path, &param, &orderResponse)
+	if err != nil {
+		return err
+	}
+
+	ord.OrderID = orderResponse.OrderId
+	ord.OrderID2 = orderResponse.OrderIdStr
+	ord.Status = ORDER_UNFINISH
+
+	return nil
+}
+
+func (dm *Hbdm) cancelTriggerOrders(path string, param url.Values, orderIds []string) (bool, error) {
+	if len(orderIds) == 0 {
+		return false, errors.New("invalid order id")
+	}
+
+	param.Set("order_id", strings.Join(orderIds, ","))
+
+	var cancelResponse struct {
+		Errors []struct {
+			OrderId string `json:"order_id"`
+			ErrCode int    `json:"err_code"`
+			ErrMsg  string `json:"err_msg"`
+		} `json:"errors"`
+		Successes string `json:"successes"`
+	}
+
+	err := dm.doRequest(path, &param, &cancelResponse)
+	if err != nil {
+		return false, err
+	}
+
+	if len(cancelResponse.Errors) > 0 {
+		return false, errors.New(cancelResponse.Errors[0].ErrMsg)
+	}
+
+	return true, nil
+}
+
+// getTriggerOrders 未触发的计划委托在 openorders 接口, 已触发和已撤销的在 hisorders 接口
+// 按 algoId 查询时先查 openorders 再查最近7天的 hisorders
+func (dm *Hbdm) getTriggerOrders(paths triggerApiPaths, param url.Values, algoId string, status string) ([]TriggerOrderInfo, error) {
+	var response struct {
+		Orders []TriggerOrderInfo `json:"orders"`
+	}
+
+	switch {
+	case algoId != "":
+	case status == ALGO_ORDER_PENDING:
+		err := dm.doRequest(paths.openOrders, dm.triggerPageParam(param, ""), &response)
+		return response.Orders, err
+	case status == ALGO_ORDER_TRIGGERED:
+		err := dm.doRequest(paths.hisOrders, dm.triggerPageParam(param, "4"), &response)
+		return response.Orders, err
+	case status == ALGO_ORDER_CANCELED:
+		err := dm.doRequest(paths.hisOrders, dm.triggerPageParam(param, "6"), &response)
+		return response.Orders, err
+	default:
+		return nil, errors.New("status or algo_id is needed")
+	}
+
+	err := dm.doRequest(paths.openOrders, dm.triggerPageParam(param, ""), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ord := range response.Orders {
+		if ord.OrderIdStr == algoId {
+			return []TriggerOrderInfo{ord}, nil
+		}
+	}
+
+	err = dm.doRequest(paths.hisOrders, dm.triggerPageParam(param, "0"), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ord := range response.Orders {
+		if ord.OrderIdStr == algoId {
+			return []TriggerOrderInfo{ord}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (dm *Hbdm) triggerPageParam(param url.Values, status string) *url.Values {
+	p := url.Values{}
+	for k, v := range param {
+		p[k] = v
+	}
+
+	p.Set("page_size", "50")
+	if status != "" {
+		p.Set("trade_type", "0")
+		p.Set("status", status)
+		p.Set("create_date", "7")
+	}
+
+	return &p
+}
+
+func (dm *Hbdm) adaptTriggerOrder(ord TriggerOrderInfo, pair CurrencyPair, contractType string) FutureOrder {
+	algoType := 1
+	if ord.OrderPriceType != "limit" {
+		algoType = 2
+	}
+
+	return FutureOrder{
+		OrderID:      ord.OrderId,
+		OrderID2:     ord.OrderIdStr,
+		Price:        ord.OrderPrice,
+		Amount:       ord.Volume,
+		OrderTime:    ord.CreatedAt,
+		FinishedTime: ord.UpdateTime,
+		Status:       dm.adaptTriggerOrderStatus(ord.Status),
+		Currency:     pair,
+		OType:        dm.adaptOffsetDirectionToOpenType(ord.Offset, ord.Direction),
+		LeverRate:    ord.LeverRate,
+		ContractName: contractType,
+		TriggerPrice: ord.TriggerPrice,
+		AlgoType:     algoType,
+	}
+}
+
+func (dm *Hbdm) adaptTriggerOrders(orders []TriggerOrderInfo, pair CurrencyPair, contractType string) []FutureOrder {
+	ords := make([]FutureOrder, 0, len(orders))
+	for _, ord := range orders {
+		ords = append(ords, dm.adaptTriggerOrder(ord, pair, contractType))
+	}
+	return ords
+}
+
+func (dm *Hbdm) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord == nil {
+		return nil, errors.New("ord param is nil")
+	}
+
+	if ord.ContractName == "" {
+		return ord, errors.New("contract type is needed")
+	}
+
+	ticker, err := dm.GetFutureTicker(ord.Currency, ord.ContractName)
+	if err != nil {
+		return ord, err
+	}
+
+	param := url.Values{}
+	param.Set("symbol", ord.Currency.CurrencyA.Symbol)
+	param.Set("contract_type", ord.ContractName)
+
+	return ord, dm.placeTriggerOrder(contractTriggerApiPaths.place, param, ord, ticker.Last)
+}
+
+func (dm *Hbdm) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error) {
+	param := url.Values{}
+	param.Set("symbol", currencyPair.CurrencyA.Symbol)
+	return dm.cancelTriggerOrders(contractTriggerApiPaths.cancel, param, orderId)
+}
+
+func (dm *Hbdm) GetFutureAlgoOrders(algoId string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error) {
+	param := url.Values{}
+	param.Set("symbol", currencyPair.CurrencyA.Symbol)
+
+	orders, err := dm.getTriggerOrders(contractTriggerApiPaths, param, algoId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	ords := make([]FutureOrder, 0, len(orders))
+	for _, ord := range orders {
+		if len(contractType) > 0 && contractType[0] != "" && ord.ContractType != contractType[0] {
+			continue
+		}
+		ords = append(ords, dm.adaptTriggerOrder(ord, currencyPair, ord.ContractType))
+	}
+
+	return ords, nil
+}
+
+func (swap *HbdmSwap) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord == nil {
+		return nil, errors.New("ord param is nil")
+	}
+
+	ticker, err := swap.GetFutureTicker(ord.Currency, SWAP_CONTRACT)
+	if err != nil {
+		return ord, err
+	}
+
+	param := url.Values{}
+	param.Set("contract_code", ord.Currency.ToSymbol("-"))
+
+	return ord, swap.base.placeTriggerOrder(swapTriggerApiPaths.place, param, ord, ticker.Last)
+}
+
+func (swap *HbdmSwap) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error) {
+	param := url.Values{}
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+	return swap.base.cancelTriggerOrders(swapTriggerApiPaths.cancel, param, orderId)
+}
+
+func (swap *HbdmSwap) GetFutureAlgoOrders(algoId string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error) {
+	param := url.Values{}
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+
+	orders, err := swap.base.getTriggerOrders(swapTriggerApiPaths, param, algoId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return swap.base.adaptTriggerOrders(orders, currencyPair, SWAP_CONTRACT), nil
+}
+
+func (swap *HbdmLinearSwap) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error) {
+	if ord == nil {
+		return nil, errors.New("ord param is nil")
+	}
+
+	ticker, err := swap.GetFutureTicker(ord.Currency, SWAP_USDT_CONTRACT)
+	if err != nil {
+		return ord, err
+	}
+
+	param := url.Values{}
+	param.Set("contract_code", ord.Currency.ToSymbol("-"))
+
+	return ord, swap.base.placeTriggerOrder(linearSwapTriggerApiPaths.place, param, ord, ticker.Last)
+}
+
+func (swap *HbdmLinearSwap) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error) {
+	param := url.Values{}
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+	return swap.base.cancelTriggerOrders(linearSwapTriggerApiPaths.cancel, param, orderId)
+}
+
+func (swap *HbdmLinearSwap) GetFutureAlgoOrders(algoId string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error) {
+	param := url.Values{}
+	param.Set("contract_code", currencyPair.ToSymbol("-"))
+
+	orders, err := swap.base.getTriggerOrders(linearSwapTriggerApiPaths, param, algoId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return swap.base.adaptTriggerOrders(orders, currencyPair, SWAP_USDT_CONTRACT), nil
+}
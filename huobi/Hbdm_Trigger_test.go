@@ -0,0 +1,57 @@
+package huobi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHbdmLinearSwap_FutureAlgoOrder(t *testing.T) {
+	var placed, canceled url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case linearTickerApiPath:
+			w.Write([]byte(`{"ch":"market.BTC-USDT.detail.merged","status":"ok","tick":{"id":1,"vol":"100","count":1,"open":"29000","close":"30000","low":"28000","high":"31000","amount":"1","ask":[30000.1,1],"bid":[29999.9,1],"ts":1616000000000},"ts":1616000000000}`))
+		case linearSwapTriggerApiPaths.place:
+			placed = r.URL.Query()
+			w.Write([]byte(`{"status":"ok","data":{"order_id":35,"order_id_str":"35"},"ts":1616000000000}`))
+		case linearSwapTriggerApiPaths.cancel:
+			canceled = r.URL.Query()
+			w.Write([]byte(`{"status":"ok","data":{"errors":[{"order_id":"36","err_code":1061,"err_msg":"This order doesnt exist."}],"successes":"35"},"ts":1616000000000}`))
+		case linearSwapTriggerApiPaths.hisOrders:
+			assert.Equal(t, "4", r.URL.Query().Get("status"))
+			w.Write([]byte(`{"status":"ok","data":{"orders":[{"symbol":"BTC","contract_code":"BTC-USDT","trigger_type":"le","volume":1,"direction":"sell","offset":"close","lever_rate":10,"order_id":35,"order_id_str":"35","trigger_price":29000,"order_price_type":"optimal_5","created_at":1616000000000,"update_time":1616000100000,"status":4}]},"ts":1616000000000}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	conf := &goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Lever: 10}
+	swap := &HbdmLinearSwap{base: &Hbdm{config: conf, clock: goex.LocalClock, log: conf.GetLogger()}, c: conf}
+
+	//平多, 触发价低于最新价
+	ord, err := swap.PlaceFutureAlgoOrder(&goex.FutureOrder{Currency: goex.BTC_USDT, OType: goex.CLOSE_BUY, Amount: 1, TriggerPrice: 29000, AlgoType: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "35", ord.OrderID2)
+	assert.Equal(t, "BTC-USDT", placed.Get("contract_code"))
+	assert.Equal(t, "le", placed.Get("trigger_type"))
+	assert.Equal(t, "sell", placed.Get("direction"))
+	assert.Equal(t, "close", placed.Get("offset"))
+	assert.Equal(t, "optimal_5", placed.Get("order_price_type"))
+
+	orders, err := swap.GetFutureAlgoOrders("", goex.ALGO_ORDER_TRIGGERED, goex.BTC_USDT)
+	assert.Nil(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, goex.ORDER_TRIGGERED, orders[0].Status)
+	assert.Equal(t, goex.CLOSE_BUY, orders[0].OType)
+	assert.Equal(t, 2, orders[0].AlgoType)
+
+	_, err = swap.FutureCancelAlgoOrder(goex.BTC_USDT, []string{"35", "36"})
+	assert.EqualError(t, err, "This order doesnt exist.")
+	assert.Equal(t, "35,36", canceled.Get("order_id"))
+}
@@ -68,9 +68,15 @@ type HbdmWs struct {
 }
 
 func NewHbdmWs() *HbdmWs {
+	return NewHbdmWsWithConfig(nil)
+}
+
+// NewHbdmWsWithConfig config 为 nil 时使用默认配置
+func NewHbdmWsWithConfig(config *WsClientConfig) *HbdmWs {
 	hbdmWs := &HbdmWs{WsBuilder: NewWsBuilder()}
 	hbdmWs.WsBuilder = hbdmWs.WsBuilder.
 		WsUrl("wss://api.hbdm.com/ws").
+		ClientConfig(config).
 		AutoReconnect().
 		//Heartbeat([]byte("{\"event\": \"ping\"} "), 30*time.Second).
 		//Heartbeat(func() []byte { return []byte("{\"op\":\"ping\"}") }(), 5*time.Second).
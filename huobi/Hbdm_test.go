@@ -64,3 +64,12 @@ func TestHbdm_GetKlineRecords(t *testing.T) {
 		t.Log(k.Pair, tt, k.Open, k.Close, k.High, k.Low, k.Vol, k.Vol2)
 	}
 }
+
+func TestHbdm_adaptSymbol(t *testing.T) {
+	if s := dm.adaptSymbol(goex.BTC_USD, goex.BI_QUARTER_CONTRACT); s != "BTC_NQ" {
+		t.Error(s)
+	}
+	if s := dm.adaptSymbol(goex.BTC_USD, "BTC210326"); s != "BTC210326" {
+		t.Error(s)
+	}
+}
@@ -0,0 +1,186 @@
+package huobi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+//现货的 client-order-id 为字符串, 合约(交割、币本位永续、U本位永续)的 client_order_id 只支持数字
+
+func (hbpro *HuoBiPro) LimitOrderWithCid(ord *Order) (*Order, error) {
+	var orderTy string
+	switch ord.Side {
+	case BUY:
+		orderTy = "buy"
+	case SELL:
+		orderTy = "sell"
+	default:
+		return nil, errors.New("side must be BUY or SELL")
+	}
+
+	switch ord.OrderType {
+	case ORDER_FEATURE_POST_ONLY:
+		orderTy += "-limit-maker"
+	case ORDER_FEATURE_IOC:
+		orderTy += "-ioc"
+	case ORDER_FEATURE_FOK:
+		orderTy += "-limit-fok"
+	default:
+		orderTy += "-limit"
+	}
+
+	if ord.Cid == "" {
+		ord.Cid = GenerateOrderClientId(32)
+	}
+
+	orderId, err := hbpro.placeOrder(FloatToString(ord.Amount, 8), FloatToString(ord.Price, 8), ord.Currency, orderTy, ord.Cid)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.OrderID = ToInt(orderId)
+	ord.OrderID2 = orderId
+	ret := *ord
+	ret.Status = ORDER_UNFINISH
+	return &ret, nil
+}
+
+func (hbpro *HuoBiPro) GetOneOrderByCid(cid string, currency CurrencyPair) (*Order, error) {
+	path := "/v1/order/orders/getClientOrder"
+	params := url.Values{}
+	params.Set("clientOrderId", cid)
+	hbpro.buildPostForm("GET", path, &params)
+	respmap, err := HttpGet(hbpro.httpClient, hbpro.baseUrl+path+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if status, _ := respmap["status"].(string); status != "ok" {
+		errCode := fmt.Sprint(respmap["err-code"])
+		//订单不存在或已超过查询期限
+		if errCode == "base-record-invalid" || errCode == "base-not-found" {
+			return nil, EX_ERR_NOT_FIND_ORDER.OriginErr(errCode)
+		}
+		return nil, ApiError{ErrCode: errCode, ErrMsg: fmt.Sprint(respmap["err-msg"])}
+	}
+
+	datamap, ok := respmap["data"].(map[string]interface{})
+	if !ok {
+		return nil, EX_ERR_ORDER_STATUS_UNKNOWN.OriginErr(fmt.Sprintf("unexpected response: %v", respmap))
+	}
+	order := hbpro.parseOrder(datamap)
+	order.Currency = currency
+
+	return &order, nil
+}
+
+func (hbpro *HuoBiPro) CancelOrderByCid(cid string, currency CurrencyPair) (bool, error) {
+	path := "/v1/order/orders/submitCancelClientOrder"
+	params := url.Values{}
+	params.Set("client-order-id", cid)
+	hbpro.buildPostForm("POST", path, &params)
+	resp, err := HttpPostForm3(hbpro.httpClient, hbpro.baseUrl+path+"?"+params.Encode(), hbpro.toJson(params),
+		map[string]string{"Content-Type": "application/json", "Accept-Language": "zh-cn"})
+	if err != nil {
+		return false, err
+	}
+
+	var respmap map[string]interface{}
+	err = json.Unmarshal(resp, &respmap)
+	if err != nil {
+		return false, err
+	}
+
+	if respmap["status"] != "ok" {
+		return false, errors.New(string(resp))
+	}
+
+	return true, nil
+}
+
+// adaptHbdmOrderNotFound 1061: 订单不存在
+func adaptHbdmOrderNotFound(err error) error {
+	if err != nil && strings.HasPrefix(err.Error(), "1061:") {
+		return EX_ERR_NOT_FIND_ORDER.OriginErr(err.Error())
+	}
+	return err
+}
+
+func hbdmClientOrderId(cid string) string {
+	if cid == "" {
+		return fmt.Sprint(time.Now().UnixNano())
+	}
+	return cid
+}
+
+func (dm *Hbdm) LimitFuturesOrderWithCid(ord *FutureOrder) (*FutureOrder, error) {
+	ord.ClientOid = hbdmClientOrderId(ord.ClientOid)
+	ret, err := dm.placeFutureOrder(ord.ClientOid, ord.Currency, ord.ContractName, FloatToString(ord.Price, 8),
+		FloatToString(ord.Amount, 8), ord.OType, 0, dm.config.Lever, AdaptOrderFeatureToLimitOpt(ord.OrderType)...)
+	if err != nil {
+		return nil, err
+	}
+	ord.OrderID2 = ret.OrderID2
+	return ret, nil
+}
+
+func (dm *Hbdm) GetFutureOrderByCid(cid string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+	ords, err := dm.getFutureOrders("client_order_id", cid, currencyPair, contractType)
+	if err != nil {
+		return nil, adaptHbdmOrderNotFound(err)
+	}
+
+	if len(ords) == 1 {
+		return &ords[0], nil
+	}
+	return nil, EX_ERR_NOT_FIND_ORDER.OriginErr("not found order")
+}
+
+func (dm *Hbdm) FutureCancelOrderByCid(currencyPair CurrencyPair, contractType, cid string) (bool, error) {
+	return dm.cancelFutureOrder("client_order_id", cid, currencyPair)
+}
+
+func (dm *Hbdm) limitSwapOrderWithCid(ord *FutureOrder, leverRate float64) (*FutureOrder, error) {
+	ord.ClientOid = hbdmClientOrderId(ord.ClientOid)
+	orderId, err := dm.placeSwapOrder(ord.ClientOid, ord.Currency, FloatToString(ord.Price, 8), FloatToString(ord.Amount, 8),
+		ord.OType, 0, leverRate, AdaptOrderFeatureToLimitOpt(ord.OrderType)...)
+	if err != nil {
+		return nil, err
+	}
+	ord.OrderID2 = orderId
+	ret := *ord
+	ret.Status = ORDER_UNFINISH
+	return &ret, nil
+}
+
+func (swap *HbdmSwap) LimitFuturesOrderWithCid(ord *FutureOrder) (*FutureOrder, error) {
+	return swap.base.limitSwapOrderWithCid(ord, swap.c.Lever)
+}
+
+func (swap *HbdmSwap) GetFutureOrderByCid(cid string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+	ord, err := swap.base.getSwapOrder("client_order_id", cid, currencyPair)
+	return ord, adaptHbdmOrderNotFound(err)
+}
+
+func (swap *HbdmSwap) FutureCancelOrderByCid(currencyPair CurrencyPair, contractType, cid string) (bool, error) {
+	return swap.base.cancelSwapOrder("client_order_id", cid, currencyPair)
+}
+
+func (swap *HbdmLinearSwap) LimitFuturesOrderWithCid(ord *FutureOrder) (*FutureOrder, error) {
+	return swap.base.limitSwapOrderWithCid(ord, swap.c.Lever)
+}
+
+func (swap *HbdmLinearSwap) GetFutureOrderByCid(cid string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+	ord, err := swap.base.getSwapOrder("client_order_id", cid, currencyPair)
+	return ord, adaptHbdmOrderNotFound(err)
+}
+
+func (swap *HbdmLinearSwap) FutureCancelOrderByCid(currencyPair CurrencyPair, contractType, cid string) (bool, error) {
+	return swap.base.cancelSwapOrder("client_order_id", cid, currencyPair)
+}
@@ -0,0 +1,44 @@
+package huobi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHuoBiPro_GetOneOrderByCid(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("clientOrderId") {
+		case "exist":
+			w.Write([]byte(`{"status":"ok","data":{"id":59378,"symbol":"btcusdt","account-id":100009,"client-order-id":"exist","amount":"1.0","price":"30000","created-at":1616000000000,"type":"buy-limit","field-amount":"0.5","field-cash-amount":"15000","field-fees":"0.001","state":"partial-filled"}}`))
+		case "absent":
+			w.Write([]byte(`{"status":"error","err-code":"base-record-invalid","err-msg":"record invalid","data":null}`))
+		case "denied":
+			w.Write([]byte(`{"status":"error","err-code":"api-signature-not-valid","err-msg":"Signature not valid","data":null}`))
+		default:
+			w.Write([]byte(`{"code":500,"message":"internal error"}`))
+		}
+	}))
+	defer srv.Close()
+
+	hbpro := &HuoBiPro{httpClient: http.DefaultClient, baseUrl: srv.URL, clock: goex.LocalClock}
+
+	ord, err := hbpro.GetOneOrderByCid("exist", goex.BTC_USDT)
+	assert.Nil(t, err)
+	assert.Equal(t, "59378", ord.OrderID2)
+	assert.Equal(t, goex.ORDER_PART_FINISH, ord.Status)
+
+	_, err = hbpro.GetOneOrderByCid("absent", goex.BTC_USDT)
+	assert.True(t, goex.IsOrderNotFound(err))
+
+	//错误响应和未知格式不会 panic
+	_, err = hbpro.GetOneOrderByCid("denied", goex.BTC_USDT)
+	assert.Equal(t, goex.ApiError{ErrCode: "api-signature-not-valid", ErrMsg: "Signature not valid"}, err)
+
+	_, err = hbpro.GetOneOrderByCid("unknown", goex.BTC_USDT)
+	assert.IsType(t, goex.ApiError{}, err)
+	assert.False(t, goex.IsOrderNotFound(err))
+}
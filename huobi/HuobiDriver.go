@@ -0,0 +1,46 @@
+package huobi
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	spotWs := func(config *APIConfig) SpotWsApi {
+		return NewSpotWsWithConfig(config.Ws)
+	}
+
+	RegisterDriver(HUOBI_PRO, Driver{
+		Spot: func(config *APIConfig) API {
+			return NewHuobiWithConfig(config)
+		},
+		SpotWs: spotWs,
+		Wallet: func(config *APIConfig) WalletApi {
+			return NewWallet(config)
+		},
+	})
+	RegisterDriver(HUOBI, Driver{SpotWs: spotWs})
+	RegisterDriver(HBDM, Driver{
+		Future: func(config *APIConfig) FutureRestAPI {
+			return NewHbdm(config)
+		},
+		FuturesWs: func(config *APIConfig) FuturesWsApi {
+			return NewHbdmWsWithConfig(config.Ws)
+		},
+	})
+	RegisterDriver(HBDM_SWAP, Driver{
+		Future: func(config *APIConfig) FutureRestAPI {
+			return NewHbdmSwap(config)
+		},
+		FuturesWs: func(config *APIConfig) FuturesWsApi {
+			return NewHbdmSwapWsWithConfig(config.Ws)
+		},
+	})
+	RegisterDriver(HBDM_LINEAR_SWAP, Driver{
+		LinearFuture: func(config *APIConfig) FutureRestAPI {
+			return NewHbdmLinearSwap(config)
+		},
+		FuturesWs: func(config *APIConfig) FuturesWsApi {
+			return NewHbdmLinearSwapWsWithConfig(config.Ws)
+		},
+	})
+}
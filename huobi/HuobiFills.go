@@ -0,0 +1,153 @@
+package huobi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+const (
+	swapMatchResultsApiPath       = "/swap-api/v1/swap_matchresults"
+	linearSwapMatchResultsApiPath = "/linear-swap-api/v1/swap_matchresults"
+)
+
+func (hbpro *HuoBiPro) GetMyTrades(pair CurrencyPair, since int64, limit int) ([]Fill, error) {
+	path := "/v1/order/matchresults"
+	params := url.Values{}
+	params.Set("symbol", pair.AdaptUsdToUsdt().ToLower().ToSymbol(""))
+	if since > 0 {
+		params.Set("start-time", fmt.Sprint(since))
+	}
+	if limit > 0 {
+		params.Set("size", fmt.Sprint(limit))
+	}
+
+	hbpro.buildPostForm("GET", path, &params)
+	respmap, err := HttpGet(hbpro.httpClient, hbpro.baseUrl+path+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if respmap["status"] != "ok" {
+		return nil, errors.New(fmt.Sprint(respmap["err-code"]))
+	}
+
+	data, _ := respmap["data"].([]interface{})
+	fills := make([]Fill, 0, len(data))
+	//按时间倒序返回
+	for i := len(data) - 1; i >= 0; i-- {
+		m := data[i].(map[string]interface{})
+		fill := Fill{
+			TradeId:     fmt.Sprint(ToInt64(m["trade-id"])),
+			OrderId:     fmt.Sprint(ToInt64(m["order-id"])),
+			Pair:        pair,
+			Side:        SELL,
+			Price:       ToFloat64(m["price"]),
+			Amount:      ToFloat64(m["filled-amount"]),
+			Fee:         ToFloat64(m["filled-fees"]),
+			FeeCurrency: fmt.Sprint(m["fee-currency"]),
+			IsMaker:     m["role"] == "maker",
+			Time:        ToInt64(m["created-at"]),
+		}
+		if typ, _ := m["type"].(string); len(typ) >= 3 && typ[:3] == "buy" {
+			fill.Side = BUY
+		}
+		fills = append(fills, fill)
+	}
+
+	return fills, nil
+}
+
+type hbdmMatchResult struct {
+	OrderIdStr   string  `json:"order_id_str"`
+	MatchId      int64   `json:"match_id"`
+	ContractCode string  `json:"contract_code"`
+	ContractType string  `json:"contract_type"` //交割合约
+	Direction    string  `json:"direction"`
+	TradeVolume  float64 `json:"trade_volume"`
+	TradePrice   float64 `json:"trade_price"`
+	TradeFee     float64 `json:"trade_fee"`
+	FeeAsset     string  `json:"fee_asset"`
+	Role         string  `json:"role"`
+	CreateDate   int64   `json:"create_date"`
+}
+
+// matchResultsParams 合约成交记录按天数(1~90)查询, since 在本地过滤
+func matchResultsParams(since int64, limit int) url.Values {
+	days := int64(90)
+	if since > 0 {
+		days = (time.Now().UnixNano()/int64(time.Millisecond)-since)/(24*3600*1000) + 1
+		if days > 90 {
+			days = 90
+		}
+	}
+
+	params := url.Values{}
+	params.Set("trade_type", "0")
+	params.Set("create_date", fmt.Sprint(days))
+	if limit > 0 {
+		params.Set("page_size", fmt.Sprint(limit))
+	}
+	return params
+}
+
+// getFills 手续费 trade_fee 负数为支出, 成交记录按时间倒序返回
+// 交割合约返回该品种所有合约的成交, 按 contract_type 过滤
+func (dm *Hbdm) getFills(path string, params url.Values, pair CurrencyPair, contractType string, since int64) ([]Fill, error) {
+	var data struct {
+		Trades []hbdmMatchResult `json:"trades"`
+	}
+
+	err := dm.doRequest(path, &params, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	fills := make([]Fill, 0, len(data.Trades))
+	for i := len(data.Trades) - 1; i >= 0; i-- {
+		t := data.Trades[i]
+		if t.CreateDate < since || (t.ContractType != "" && t.ContractType != contractType) {
+			continue
+		}
+		fill := Fill{
+			TradeId:      fmt.Sprint(t.MatchId),
+			OrderId:      t.OrderIdStr,
+			Pair:         pair,
+			ContractName: contractType,
+			Side:         SELL,
+			Price:        t.TradePrice,
+			Amount:       t.TradeVolume,
+			Fee:          -t.TradeFee,
+			FeeCurrency:  t.FeeAsset,
+			IsMaker:      t.Role == "maker" || t.Role == "Maker",
+			Time:         t.CreateDate,
+		}
+		if t.Direction == "buy" {
+			fill.Side = BUY
+		}
+		fills = append(fills, fill)
+	}
+
+	return fills, nil
+}
+
+func (dm *Hbdm) GetFutureFills(pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	params := matchResultsParams(since, limit)
+	params.Set("symbol", pair.CurrencyA.Symbol)
+	return dm.getFills("/api/v1/contract_matchresults", params, pair, contractType, since)
+}
+
+func (swap *HbdmSwap) GetFutureFills(pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	params := matchResultsParams(since, limit)
+	params.Set("contract_code", pair.ToSymbol("-"))
+	return swap.base.getFills(swapMatchResultsApiPath, params, pair, contractType, since)
+}
+
+func (swap *HbdmLinearSwap) GetFutureFills(pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	params := matchResultsParams(since, limit)
+	params.Set("contract_code", pair.ToSymbol("-"))
+	return swap.base.getFills(linearSwapMatchResultsApiPath, params, pair, contractType, since)
+}
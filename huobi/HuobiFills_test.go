@@ -0,0 +1,98 @@
+package huobi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHuoBiPro_GetMyTrades(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/order/matchresults" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "btcusdt", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "1616000000000", r.URL.Query().Get("start-time"))
+		assert.Equal(t, "2", r.URL.Query().Get("size"))
+		w.Write([]byte(`{"status":"ok","data":[
+{"symbol":"btcusdt","fee-currency":"btc","source":"spot-api","order-id":29,"price":"30100","created-at":1616000200000,"role":"maker","match-id":100,"trade-id":102,"filled-amount":"0.01","filled-fees":"0.00002","filled-points":"0.0","type":"buy-limit","id":2},
+{"symbol":"btcusdt","fee-currency":"usdt","source":"spot-api","order-id":28,"price":"30000","created-at":1616000100000,"role":"taker","match-id":99,"trade-id":101,"filled-amount":"0.02","filled-fees":"1.2","filled-points":"0.0","type":"sell-market","id":1}]}`))
+	}))
+	defer srv.Close()
+
+	hbpro := &HuoBiPro{httpClient: http.DefaultClient, baseUrl: srv.URL, clock: goex.LocalClock}
+
+	fills, err := hbpro.GetMyTrades(goex.BTC_USDT, 1616000000000, 2)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 2)
+	//倒序转为升序
+	assert.Equal(t, "101", fills[0].TradeId)
+	assert.Equal(t, "28", fills[0].OrderId)
+	assert.Equal(t, goex.SELL, fills[0].Side)
+	assert.Equal(t, 0.02, fills[0].Amount)
+	assert.Equal(t, 1.2, fills[0].Fee)
+	assert.Equal(t, "usdt", fills[0].FeeCurrency)
+	assert.False(t, fills[0].IsMaker)
+	assert.Equal(t, int64(1616000100000), fills[0].Time)
+	assert.Equal(t, goex.BUY, fills[1].Side)
+	assert.Equal(t, 30100.0, fills[1].Price)
+	assert.True(t, fills[1].IsMaker)
+}
+
+func TestHbdm_GetFutureFills(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/contract_matchresults":
+			assert.Equal(t, "BTC", r.URL.Query().Get("symbol"))
+			assert.Equal(t, "90", r.URL.Query().Get("create_date"))
+			w.Write([]byte(`{"status":"ok","data":{"trades":[
+{"match_id":3,"order_id":12,"order_id_str":"12","symbol":"BTC","contract_type":"this_week","contract_code":"BTC210319","direction":"buy","offset":"open","trade_volume":1,"trade_price":30000,"trade_turnover":100,"trade_fee":-0.0000006,"fee_asset":"BTC","role":"taker","create_date":1616000300000},
+{"match_id":2,"order_id":11,"order_id_str":"11","symbol":"BTC","contract_type":"quarter","contract_code":"BTC210625","direction":"sell","offset":"close","trade_volume":2,"trade_price":31000,"trade_turnover":200,"trade_fee":0.0000002,"fee_asset":"BTC","role":"Maker","create_date":1616000200000},
+{"match_id":1,"order_id":10,"order_id_str":"10","symbol":"BTC","contract_type":"quarter","contract_code":"BTC210625","direction":"buy","offset":"open","trade_volume":3,"trade_price":30500,"trade_turnover":300,"trade_fee":-0.0000012,"fee_asset":"BTC","role":"taker","create_date":1616000100000}],"total_page":1,"current_page":1,"total_size":3},"ts":1616000400000}`))
+		case swapMatchResultsApiPath:
+			assert.Equal(t, "BTC-USD", r.URL.Query().Get("contract_code"))
+			assert.Equal(t, "20", r.URL.Query().Get("page_size"))
+			w.Write([]byte(`{"status":"ok","data":{"trades":[
+{"match_id":5,"order_id_str":"15","contract_code":"BTC-USD","direction":"sell","trade_volume":4,"trade_price":30000,"trade_fee":-0.0000004,"fee_asset":"BTC","role":"taker","create_date":1616000100000}],"total_page":1,"current_page":1,"total_size":1},"ts":1616000400000}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	conf := &goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL}
+	dm := &Hbdm{config: conf, clock: goex.LocalClock, log: conf.GetLogger()}
+
+	//交割合约按 contract_type 过滤
+	fills, err := dm.GetFutureFills(goex.BTC_USD, goex.QUARTER_CONTRACT, 0, 0)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 2)
+	assert.Equal(t, "1", fills[0].TradeId)
+	assert.Equal(t, "10", fills[0].OrderId)
+	assert.Equal(t, goex.BUY, fills[0].Side)
+	assert.Equal(t, 3.0, fills[0].Amount)
+	assert.Equal(t, 0.0000012, fills[0].Fee)
+	assert.Equal(t, "BTC", fills[0].FeeCurrency)
+	assert.False(t, fills[0].IsMaker)
+	assert.Equal(t, goex.QUARTER_CONTRACT, fills[0].ContractName)
+	assert.Equal(t, goex.SELL, fills[1].Side)
+	assert.Equal(t, -0.0000002, fills[1].Fee)
+	assert.True(t, fills[1].IsMaker)
+
+	fills, err = dm.GetFutureFills(goex.BTC_USD, goex.QUARTER_CONTRACT, 1616000200000, 0)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 1)
+	assert.Equal(t, "2", fills[0].TradeId)
+
+	swap := &HbdmSwap{base: dm, c: conf}
+	fills, err = swap.GetFutureFills(goex.BTC_USD, goex.SWAP_CONTRACT, 0, 20)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 1)
+	assert.Equal(t, "15", fills[0].OrderId)
+	assert.Equal(t, goex.SELL, fills[0].Side)
+	assert.Equal(t, 4.0, fills[0].Amount)
+}
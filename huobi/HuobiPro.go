@@ -46,6 +46,7 @@ type HuoBiPro struct {
 	accountId  string
 	accessKey  string
 	secretKey  string
+	clock      Clock
 	Symbols    map[string]HuoBiProSymbol
 	//ECDSAPrivateKey string
 }
@@ -71,6 +72,7 @@ func NewHuobiWithConfig(config *APIConfig) *HuoBiPro {
 	hbpro.httpClient = config.HttpClient
 	hbpro.accessKey = config.ApiKey
 	hbpro.secretKey = config.ApiSecretKey
+	hbpro.setClock(config)
 
 	if config.ApiKey != "" && config.ApiSecretKey != "" {
 		accinfo, err := hbpro.GetAccountInfo(HB_SPOT_ACCOUNT)
@@ -99,9 +101,15 @@ func NewHuoBiPro(client *http.Client, apikey, secretkey, accountId string) *HuoB
 	hbpro.accessKey = apikey
 	hbpro.secretKey = secretkey
 	hbpro.accountId = accountId
+	hbpro.setClock(&APIConfig{})
 	return hbpro
 }
 
+// setClock 签名时间戳与服务器时间同步
+func (hbpro *HuoBiPro) setClock(config *APIConfig) {
+	hbpro.clock = config.GetClock(hbpro.baseUrl+"/v1/common/timestamp", hbpro.GetTimestamp)
+}
+
 /**
  *现货交易
  */
@@ -227,13 +235,13 @@ func (hbpro *HuoBiPro) GetAccount() (*Account, error) {
 	return acc, nil
 }
 
-func (hbpro *HuoBiPro) placeOrder(amount, price string, pair CurrencyPair, orderType string) (string, error) {
+func (hbpro *HuoBiPro) placeOrder(amount, price string, pair CurrencyPair, orderType, cid string) (string, error) {
 	symbol := hbpro.Symbols[pair.ToLower().ToSymbol("")]
 
 	path := "/v1/order/orders/place"
 	params := url.Values{}
 	params.Set("account-id", hbpro.accountId)
-	params.Set("client-order-id", GenerateOrderClientId(32))
+	params.Set("client-order-id", cid)
 	params.Set("amount", FloatToString(ToFloat64(amount), int(symbol.AmountPrecision)))
 	params.Set("symbol", pair.AdaptUsdToUsdt().ToLower().ToSymbol(""))
 	params.Set("type", orderType)
@@ -278,7 +286,7 @@ func (hbpro *HuoBiPro) LimitBuy(amount, price string, currency CurrencyPair, opt
 			Log.Error("limit order optional parameter error ,opt= ", opt[0])
 		}
 	}
-	orderId, err := hbpro.placeOrder(amount, price, currency, orderTy)
+	orderId, err := hbpro.placeOrder(amount, price, currency, orderTy, GenerateOrderClientId(32))
 	if err != nil {
 		return nil, err
 	}
@@ -305,7 +313,7 @@ func (hbpro *HuoBiPro) LimitSell(amount, price string, currency CurrencyPair, op
 			Log.Error("limit order optional parameter error ,opt= ", opt[0])
 		}
 	}
-	orderId, err := hbpro.placeOrder(amount, price, currency, orderTy)
+	orderId, err := hbpro.placeOrder(amount, price, currency, orderTy, GenerateOrderClientId(32))
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +327,7 @@ func (hbpro *HuoBiPro) LimitSell(amount, price string, currency CurrencyPair, op
 }
 
 func (hbpro *HuoBiPro) MarketBuy(amount, price string, currency CurrencyPair) (*Order, error) {
-	orderId, err := hbpro.placeOrder(amount, price, currency, "buy-market")
+	orderId, err := hbpro.placeOrder(amount, price, currency, "buy-market", GenerateOrderClientId(32))
 	if err != nil {
 		return nil, err
 	}
@@ -333,7 +341,7 @@ func (hbpro *HuoBiPro) MarketBuy(amount, price string, currency CurrencyPair) (*
 }
 
 func (hbpro *HuoBiPro) MarketSell(amount, price string, currency CurrencyPair) (*Order, error) {
-	orderId, err := hbpro.placeOrder(amount, price, currency, "sell-market")
+	orderId, err := hbpro.placeOrder(amount, price, currency, "sell-market", GenerateOrderClientId(32))
 	if err != nil {
 		return nil, err
 	}
@@ -655,7 +663,7 @@ func (hbpro *HuoBiPro) buildPostForm(reqMethod, path string, postForm *url.Value
 	postForm.Set("AccessKeyId", hbpro.accessKey)
 	postForm.Set("SignatureMethod", "HmacSHA256")
 	postForm.Set("SignatureVersion", "2")
-	postForm.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
+	postForm.Set("Timestamp", hbpro.clock.Now().UTC().Format("2006-01-02T15:04:05"))
 	domain := strings.Replace(hbpro.baseUrl, "https://", "", len(hbpro.baseUrl))
 	payload := fmt.Sprintf("%s\n%s\n%s\n%s", reqMethod, domain, path, postForm.Encode())
 	sign, _ := GetParamHmacSHA256Base64Sign(hbpro.secretKey, payload)
@@ -800,9 +808,5 @@ func (hbpro *HuoBiPro) GetTimestamp() (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	data, ok := ret["data"].(int64)
-	if !ok {
-		return 0, err
-	}
-	return data, nil
+	return ToInt64(ret["data"]), nil
 }
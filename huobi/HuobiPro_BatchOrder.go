@@ -0,0 +1,249 @@
+package huobi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+const (
+	batchPlaceOrdersPath  = "/v1/order/batch-orders"
+	batchCancelOrdersPath = "/v1/order/orders/batchcancel"
+	batchPlaceOrdersLimit = 10
+	batchCancelLimit      = 50
+)
+
+var errOrderNotInResponse = errors.New("order not found in response")
+
+func (hbpro *HuoBiPro) adaptBatchOrderType(ord Order) (string, error) {
+	var side string
+	switch ord.Side {
+	case BUY:
+		side = "buy"
+	case SELL:
+		side = "sell"
+	default:
+		return "", errors.New("batch place order only support limit order")
+	}
+
+	switch ord.OrderType {
+	case ORDER_FEATURE_POST_ONLY:
+		return side + "-limit-maker", nil
+	case ORDER_FEATURE_IOC:
+		return side + "-ioc", nil
+	case ORDER_FEATURE_FOK:
+		return side + "-limit-fok", nil
+	}
+	return side + "-limit", nil
+}
+
+// doBatchRequest 批量接口的请求体为 json, 签名参数只放在 url 上
+func (hbpro *HuoBiPro) doBatchRequest(path string, body interface{}, data interface{}) error {
+	params := url.Values{}
+	hbpro.buildPostForm("POST", path, &params)
+
+	reqBody, _ := json.Marshal(body)
+	resp, err := HttpPostForm3(hbpro.httpClient, hbpro.baseUrl+path+"?"+params.Encode(), string(reqBody),
+		map[string]string{"Content-Type": "application/json", "Accept-Language": "zh-cn"})
+	if err != nil {
+		return err
+	}
+
+	var ret struct {
+		Status  string          `json:"status"`
+		ErrCode string          `json:"err-code"`
+		ErrMsg  string          `json:"err-msg"`
+		Data    json.RawMessage `json:"data"`
+	}
+
+	err = json.Unmarshal(resp, &ret)
+	if err != nil {
+		return err
+	}
+
+	if ret.Status != "ok" {
+		return errors.New(fmt.Sprintf("%s:%s", ret.ErrCode, ret.ErrMsg))
+	}
+
+	return json.Unmarshal(ret.Data, data)
+}
+
+func (hbpro *HuoBiPro) BatchLimitOrders(orders []Order) ([]BatchOrderResult, error) {
+	results := make([]BatchOrderResult, len(orders))
+
+	for _, b := range SplitBatch(len(orders), batchPlaceOrdersLimit) {
+		var (
+			param    []map[string]string
+			response []struct {
+				OrderId       int64  `json:"order-id"`
+				ClientOrderId string `json:"client-order-id"`
+				ErrCode       string `json:"err-code"`
+				ErrMsg        string `json:"err-msg"`
+			}
+		)
+
+		for i := b[0]; i < b[1]; i++ {
+			ord := &orders[i]
+			if ord.Cid == "" {
+				ord.Cid = GenerateOrderClientId(32)
+			}
+			results[i].ClientOid = ord.Cid
+
+			orderType, err := hbpro.adaptBatchOrderType(*ord)
+			if err != nil {
+				return nil, err
+			}
+
+			symbol := hbpro.Symbols[ord.Currency.ToLower().ToSymbol("")]
+			param = append(param, map[string]string{
+				"account-id":      hbpro.accountId,
+				"symbol":          ord.Currency.AdaptUsdToUsdt().ToLower().ToSymbol(""),
+				"type":            orderType,
+				"amount":          FloatToString(ord.Amount, int(symbol.AmountPrecision)),
+				"price":           FloatToString(ord.Price, int(symbol.PricePrecision)),
+				"client-order-id": ord.Cid,
+			})
+		}
+
+		err := hbpro.doBatchRequest(batchPlaceOrdersPath, param, &response)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		ret := make(map[string]int, len(response))
+		for j, r := range response {
+			ret[r.ClientOrderId] = j
+		}
+
+		for i := b[0]; i < b[1]; i++ {
+			j, found := ret[orders[i].Cid]
+			if !found {
+				results[i].Err = errOrderNotInResponse
+				continue
+			}
+			if response[j].ErrCode != "" {
+				results[i].Err = errors.New(fmt.Sprintf("%s:%s", response[j].ErrCode, response[j].ErrMsg))
+				continue
+			}
+			orders[i].OrderID2 = fmt.Sprint(response[j].OrderId)
+			orders[i].OrderID = int(response[j].OrderId)
+			results[i].OrderId = orders[i].OrderID2
+		}
+	}
+
+	return results, nil
+}
+
+func (hbpro *HuoBiPro) BatchCancelOrders(currency CurrencyPair, orderIds []string) ([]BatchOrderResult, error) {
+	results := make([]BatchOrderResult, len(orderIds))
+
+	for _, b := range SplitBatch(len(orderIds), batchCancelLimit) {
+		var (
+			param = map[string][]string{}
+			data  struct {
+				Success []string `json:"success"`
+				Failed  []struct {
+					OrderId       string `json:"order-id"`
+					ClientOrderId string `json:"client-order-id"`
+					ErrCode       string `json:"err-code"`
+					ErrMsg        string `json:"err-msg"`
+				} `json:"failed"`
+			}
+		)
+
+		for i := b[0]; i < b[1]; i++ {
+			results[i].OrderId = orderIds[i]
+			if strings.HasPrefix(orderIds[i], "goex") {
+				param["client-order-ids"] = append(param["client-order-ids"], orderIds[i])
+			} else {
+				param["order-ids"] = append(param["order-ids"], orderIds[i])
+			}
+		}
+
+		err := hbpro.doBatchRequest(batchCancelOrdersPath, param, &data)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		ret := make(map[string]error, b[1]-b[0])
+		for _, id := range data.Success {
+			ret[id] = nil
+		}
+		for _, f := range data.Failed {
+			err := errors.New(fmt.Sprintf("%s:%s", f.ErrCode, f.ErrMsg))
+			ret[f.OrderId] = err
+			ret[f.ClientOrderId] = err
+		}
+
+		for i := b[0]; i < b[1]; i++ {
+			err, found := ret[orderIds[i]]
+			if !found {
+				err = errOrderNotInResponse
+			}
+			results[i].Err = err
+		}
+	}
+
+	return results, nil
+}
+
+// BatchAmendOrders 现货不支持改单, 批量撤单成功后再批量下单
+func (hbpro *HuoBiPro) BatchAmendOrders(orders []Order) ([]BatchOrderResult, error) {
+	var (
+		orderIds  = make([]string, len(orders))
+		results   = make([]BatchOrderResult, len(orders))
+		newOrders []Order
+		newIdx    []int
+	)
+
+	if len(orders) == 0 {
+		return results, nil
+	}
+
+	for i, ord := range orders {
+		if ord.Price <= 0 || ord.Amount <= 0 {
+			return nil, ErrBatchAmendParam
+		}
+		orderIds[i] = ord.OrderID2
+	}
+
+	cancelResults, err := hbpro.BatchCancelOrders(orders[0].Currency, orderIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range cancelResults {
+		if r.Err != nil {
+			results[i] = r
+			continue
+		}
+		ord := orders[i]
+		ord.Cid = ""
+		newOrders = append(newOrders, ord)
+		newIdx = append(newIdx, i)
+	}
+
+	if len(newOrders) == 0 {
+		return results, nil
+	}
+
+	placeResults, err := hbpro.BatchLimitOrders(newOrders)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range newIdx {
+		results[i] = placeResults[j]
+		orders[i].OrderID2 = newOrders[j].OrderID2
+		orders[i].OrderID = newOrders[j].OrderID
+		orders[i].Cid = newOrders[j].Cid
+	}
+
+	return results, nil
+}
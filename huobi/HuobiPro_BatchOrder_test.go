@@ -0,0 +1,56 @@
+package huobi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHuoBiPro_BatchOrders(t *testing.T) {
+	var param []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.URL.Query().Get("Signature"))
+		switch r.URL.Path {
+		case batchPlaceOrdersPath:
+			json.NewDecoder(r.Body).Decode(&param)
+			//第三个订单没有返回
+			w.Write([]byte(`{"status":"ok","data":[
+{"order-id":359711,"client-order-id":"` + param[0]["client-order-id"] + `"},
+{"client-order-id":"` + param[1]["client-order-id"] + `","err-code":"account-frozen-balance-insufficient-error","err-msg":"trade account balance is not enough"}]}`))
+		case batchCancelOrdersPath:
+			w.Write([]byte(`{"status":"ok","data":{"success":["1"],"failed":[{"order-id":"2","err-code":"order-orderstate-error","err-msg":"Incorrect order state"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	hbpro := &HuoBiPro{httpClient: http.DefaultClient, baseUrl: srv.URL, accountId: "100", clock: goex.LocalClock,
+		Symbols: map[string]HuoBiProSymbol{"btcusdt": {PricePrecision: 2, AmountPrecision: 6}}}
+
+	orders := []goex.Order{
+		{Currency: goex.BTC_USDT, Side: goex.BUY, Price: 30000, Amount: 0.01},
+		{Currency: goex.BTC_USDT, Side: goex.SELL, Price: 31000, Amount: 0.01, OrderType: goex.ORDER_FEATURE_POST_ONLY},
+		{Currency: goex.BTC_USDT, Side: goex.BUY, Price: 29000, Amount: 0.01},
+	}
+	results, err := hbpro.BatchLimitOrders(orders)
+	assert.Nil(t, err)
+	assert.Len(t, param, 3)
+	assert.Equal(t, "100", param[0]["account-id"])
+	assert.Equal(t, "sell-limit-maker", param[1]["type"])
+
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "359711", orders[0].OrderID2)
+	assert.EqualError(t, results[1].Err, "account-frozen-balance-insufficient-error:trade account balance is not enough")
+	assert.Equal(t, errOrderNotInResponse, results[2].Err)
+
+	results, err = hbpro.BatchCancelOrders(goex.BTC_USDT, []string{"1", "2", "3"})
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Err)
+	assert.EqualError(t, results[1].Err, "order-orderstate-error:Incorrect order state")
+	assert.Equal(t, errOrderNotInResponse, results[2].Err)
+}
@@ -23,11 +23,17 @@ type SpotWs struct {
 }
 
 func NewSpotWs() *SpotWs {
+	return NewSpotWsWithConfig(nil)
+}
+
+// NewSpotWsWithConfig config 为 nil 时使用默认配置
+func NewSpotWsWithConfig(config *WsClientConfig) *SpotWs {
 	ws := &SpotWs{
 		WsBuilder: NewWsBuilder(),
 	}
 	ws.WsBuilder = ws.WsBuilder.
 		WsUrl("wss://api.huobi.pro/ws").
+		ClientConfig(config).
 		AutoReconnect().
 		DecompressFunc(GzipDecompress).
 		ProtoHandleFunc(ws.handle)
@@ -0,0 +1,105 @@
+package huobi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+const (
+	swapFeeApiPath       = "/swap-api/v1/swap_fee"
+	linearSwapFeeApiPath = "/linear-swap-api/v1/swap_fee"
+)
+
+// GetFeeRate actualMakerRate、actualTakerRate 为 HT 抵扣后的实际费率
+func (hbpro *HuoBiPro) GetFeeRate(pair CurrencyPair) (*FeeRate, error) {
+	path := "/v2/reference/transact-fee-rate"
+	symbol := pair.AdaptUsdToUsdt().ToLower().ToSymbol("")
+	params := url.Values{}
+	params.Set("symbols", symbol)
+
+	hbpro.buildPostForm("GET", path, &params)
+	respmap, err := HttpGet(hbpro.httpClient, hbpro.baseUrl+path+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if ToInt(respmap["code"]) != 200 {
+		return nil, errors.New(fmt.Sprint(respmap["message"]))
+	}
+
+	data, _ := respmap["data"].([]interface{})
+	for _, v := range data {
+		m := v.(map[string]interface{})
+		if m["symbol"] != symbol {
+			continue
+		}
+
+		rate := &FeeRate{
+			Pair:  pair,
+			Maker: ToFloat64(m["makerFeeRate"]),
+			Taker: ToFloat64(m["takerFeeRate"]),
+		}
+		actualTaker := ToFloat64(m["actualTakerRate"])
+		if rate.Taker > 0 && actualTaker > 0 && actualTaker < rate.Taker {
+			rate.Discount = actualTaker / rate.Taker
+			rate.DiscountCurrency = HT.Symbol
+		}
+		return rate, nil
+	}
+
+	return nil, errors.New("not found the fee rate of " + symbol)
+}
+
+type hbdmFee struct {
+	Symbol        string  `json:"symbol"`
+	ContractCode  string  `json:"contract_code"`
+	OpenMakerFee  float64 `json:"open_maker_fee,string"`
+	OpenTakerFee  float64 `json:"open_taker_fee,string"`
+	CloseMakerFee float64 `json:"close_maker_fee,string"`
+	CloseTakerFee float64 `json:"close_taker_fee,string"`
+}
+
+// getFeeRate 开平仓费率目前相同, 取开仓费率
+func (dm *Hbdm) getFeeRate(path string, params url.Values, pair CurrencyPair, contractType string) (*FeeRate, error) {
+	var fees []hbdmFee
+	err := dm.doRequest(path, &params, &fees)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fees {
+		if !strings.EqualFold(f.Symbol, pair.CurrencyA.Symbol) && !strings.EqualFold(f.ContractCode, pair.ToSymbol("-")) {
+			continue
+		}
+		return &FeeRate{
+			Pair:         pair,
+			ContractType: contractType,
+			Maker:        f.OpenMakerFee,
+			Taker:        f.OpenTakerFee,
+		}, nil
+	}
+
+	return nil, errors.New("not found the fee rate of " + pair.String())
+}
+
+func (dm *Hbdm) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	params := url.Values{}
+	params.Set("symbol", pair.CurrencyA.Symbol)
+	return dm.getFeeRate("/api/v1/contract_fee", params, pair, contractType)
+}
+
+func (swap *HbdmSwap) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.ToSymbol("-"))
+	return swap.base.getFeeRate(swapFeeApiPath, params, pair, contractType)
+}
+
+func (swap *HbdmLinearSwap) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.ToSymbol("-"))
+	return swap.base.getFeeRate(linearSwapFeeApiPath, params, pair, contractType)
+}
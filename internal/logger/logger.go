@@ -18,7 +18,7 @@ const (
 	PANIC
 )
 
-type Logger struct {
+type TextLogger struct {
 	*log.Logger
 	level Level
 }
@@ -114,86 +114,102 @@ func Fatalf(format string, args ...interface{}) {
 
 func Panic(args ...interface{}) {
 	if Log.level <= PANIC {
-		Log.output(PANIC, "[P]", fmt.Sprint(args...))
-		panic("")
+		s := fmt.Sprint(args...)
+		Log.output(PANIC, "[P]", s)
+		panic(s)
 	}
 }
 
 func Panicf(format string, args ...interface{}) {
 	if Log.level <= PANIC {
-		Log.output(PANIC, "[P]", fmt.Sprintf(format, args...))
-		panic("")
+		s := fmt.Sprintf(format, args...)
+		Log.output(PANIC, "[P]", s)
+		panic(s)
 	}
 }
 
-func NewLogger() *Logger {
-	return &Logger{
+func NewLogger() *TextLogger {
+	return &TextLogger{
 		Logger: log.New(os.Stderr, "", log.LstdFlags|log.Lshortfile),
 		level:  INFO,
 	}
 }
 
-func (l *Logger) SetLevel(level Level) {
+func (l *TextLogger) SetLevel(level Level) {
 	l.level = level
 }
 
-func (l *Logger) SetOut(out io.Writer) {
+func (l *TextLogger) SetOut(out io.Writer) {
 	l.Logger.SetOutput(out)
 }
 
-func (l *Logger) output(le Level, prefix string, log string) {
+// output 对日志内容脱敏, 设置了 SetStructured 时 Log 的日志转发给结构化日志, 由其决定日志级别
+func (l *TextLogger) output(le Level, prefix string, log string) {
+	if l == Log {
+		if s := Current(); s != nil {
+			forward(s, le, Redact(log))
+			return
+		}
+	}
+	if l.level <= le {
+		l.Output(3, fmt.Sprintf("%s %s", prefix, Redact(log)))
+	}
+}
+
+// print 不转发给结构化日志, 供 textStructured 使用
+func (l *TextLogger) print(le Level, prefix string, log string) {
 	if l.level <= le {
-		l.Output(3, fmt.Sprintf("%s %s", prefix, log))
+		l.Output(3, fmt.Sprintf("%s %s", prefix, Redact(log)))
 	}
 }
 
-func (l *Logger) Debug(args ...interface{}) {
+func (l *TextLogger) Debug(args ...interface{}) {
 	l.output(DEBUG, "[D]", fmt.Sprint(args...))
 }
 
-func (l *Logger) Debugf(format string, args ...interface{}) {
+func (l *TextLogger) Debugf(format string, args ...interface{}) {
 	l.output(DEBUG, "[D]", fmt.Sprintf(format, args...))
 }
 
-func (l *Logger) Info(args ...interface{}) {
+func (l *TextLogger) Info(args ...interface{}) {
 	l.output(INFO, "[I]", fmt.Sprint(args...))
 }
 
-func (l *Logger) Infof(format string, args ...interface{}) {
+func (l *TextLogger) Infof(format string, args ...interface{}) {
 	l.output(INFO, "[I]", fmt.Sprintf(format, args...))
 }
 
-func (l *Logger) Warn(args ...interface{}) {
+func (l *TextLogger) Warn(args ...interface{}) {
 	l.output(WARN, "[W]", fmt.Sprint(args...))
 }
 
-func (l *Logger) Warnf(format string, args ...interface{}) {
+func (l *TextLogger) Warnf(format string, args ...interface{}) {
 	l.output(WARN, "[W]", fmt.Sprintf(format, args...))
 }
 
-func (l *Logger) Error(args ...interface{}) {
+func (l *TextLogger) Error(args ...interface{}) {
 	l.output(ERROR, "[E]", fmt.Sprint(args...))
 }
 
-func (l *Logger) Errorf(format string, args ...interface{}) {
+func (l *TextLogger) Errorf(format string, args ...interface{}) {
 	l.output(ERROR, "[E]", fmt.Sprintf(format, args...))
 }
 
-func (l *Logger) Fatal(args ...interface{}) {
+func (l *TextLogger) Fatal(args ...interface{}) {
 	if l.level <= FATAL {
 		l.output(FATAL, "[F]", fmt.Sprint(args...))
 		os.Exit(1)
 	}
 }
 
-func (l *Logger) Fatalf(format string, args ...interface{}) {
+func (l *TextLogger) Fatalf(format string, args ...interface{}) {
 	if l.level <= FATAL {
 		l.output(FATAL, "[F]", fmt.Sprintf(format, args...))
 		os.Exit(1)
 	}
 }
 
-func (l *Logger) Panic(args ...interface{}) {
+func (l *TextLogger) Panic(args ...interface{}) {
 	if l.level <= PANIC {
 		s := fmt.Sprint(args...)
 		l.output(PANIC, "[P]", s)
@@ -201,7 +217,7 @@ func (l *Logger) Panic(args ...interface{}) {
 	}
 }
 
-func (l *Logger) Panicf(format string, args ...interface{}) {
+func (l *TextLogger) Panicf(format string, args ...interface{}) {
 	if l.level <= PANIC {
 		s := fmt.Sprintf(format, args...)
 		l.output(PANIC, "[P]", s)
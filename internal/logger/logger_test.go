@@ -2,13 +2,25 @@ package logger
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
 func Test_Logger(t *testing.T) {
-	f , _ := os.Create("logger.log")
+	dir, err := ioutil.TempDir("", "logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	f, err := os.Create(filepath.Join(dir, "logger.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
 	Log.SetOut(f)
+	defer Log.SetOut(os.Stderr)
 	Log.SetLevel(DEBUG)
 	Log.Debug("debug log")
 	Log.Debugf("%.8f", 0.2912101221212)
@@ -19,3 +31,13 @@ func Test_Logger(t *testing.T) {
 	Debug("debug log2")
 	Info("info log2")
 }
+
+func Test_Panic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "panic log" {
+			t.Errorf("expected panic message, got %v", r)
+		}
+	}()
+	Log.SetOut(ioutil.Discard)
+	Panicf("%s", "panic log")
+}
@@ -0,0 +1,198 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// Field 结构化日志的键值对
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Structured 结构化日志, 对外为 goex.Logger
+type Structured interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+	With(fields ...Field) Structured
+}
+
+// DebugEnabler 可选接口, 输出 Debug 日志前判断是否开启, 避免格式化和隐藏敏感信息的开销
+type DebugEnabler interface {
+	DebugEnabled() bool
+}
+
+// IsDebugEnabled s 未实现 DebugEnabler 时返回 true
+func IsDebugEnabled(s Structured) bool {
+	if e, ok := s.(DebugEnabler); ok {
+		return e.DebugEnabled()
+	}
+	return true
+}
+
+const redacted = "******"
+
+var (
+	sensitiveKeys = `api[_-]?key|access[_-]?key(?:[_-]?id)?|secret(?:[_-]?key)?|pass[_-]?phrase|password|signature|sign|token|authorization|ok-access-(?:key|sign|passphrase)|x-mbx-apikey|kc-api-(?:key|sign|passphrase)`
+	//query: key=value, json: "key":"value", header: Key: value
+	sensitivePattern = regexp.MustCompile(`(?i)((?:^|[?&\s{,\[])"?(?:` + sensitiveKeys + `)"?\s*[:=]\s*"?)([^&\s",}\]]+)`)
+	sensitiveKey     = regexp.MustCompile(`(?i)^(?:` + sensitiveKeys + `)$`)
+)
+
+// Redact 隐藏日志中的 api key、secret、签名等
+func Redact(s string) string {
+	return sensitivePattern.ReplaceAllString(s, "${1}"+redacted)
+}
+
+// RedactFields 敏感字段的值替换为 ******, 字符串值按 Redact 处理
+func RedactFields(fields []Field, secrets ...string) []Field {
+	if len(fields) == 0 {
+		return fields
+	}
+	ret := make([]Field, len(fields))
+	for i, f := range fields {
+		ret[i] = f
+		if sensitiveKey.MatchString(f.Key) {
+			ret[i].Value = redacted
+			continue
+		}
+		switch v := f.Value.(type) {
+		case string:
+			ret[i].Value = redactSecrets(Redact(v), secrets)
+		case error:
+			ret[i].Value = redactSecrets(Redact(v.Error()), secrets)
+		case fmt.Stringer:
+			ret[i].Value = redactSecrets(Redact(v.String()), secrets)
+		}
+	}
+	return ret
+}
+
+func redactSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, redacted, -1)
+		}
+	}
+	return s
+}
+
+type redactor struct {
+	next    Structured
+	secrets []string
+}
+
+// NewRedactor 输出前隐藏敏感字段和 secrets 中的值(比如 api key)
+func NewRedactor(next Structured, secrets ...string) Structured {
+	return &redactor{next: next, secrets: secrets}
+}
+
+func (r *redactor) msg(msg string) string {
+	return redactSecrets(Redact(msg), r.secrets)
+}
+
+func (r *redactor) DebugEnabled() bool {
+	return IsDebugEnabled(r.next)
+}
+
+func (r *redactor) Debug(msg string, fields ...Field) {
+	if !IsDebugEnabled(r.next) {
+		return
+	}
+	r.next.Debug(r.msg(msg), RedactFields(fields, r.secrets...)...)
+}
+
+func (r *redactor) Info(msg string, fields ...Field) {
+	r.next.Info(r.msg(msg), RedactFields(fields, r.secrets...)...)
+}
+
+func (r *redactor) Warn(msg string, fields ...Field) {
+	r.next.Warn(r.msg(msg), RedactFields(fields, r.secrets...)...)
+}
+
+func (r *redactor) Error(msg string, fields ...Field) {
+	r.next.Error(r.msg(msg), RedactFields(fields, r.secrets...)...)
+}
+
+func (r *redactor) With(fields ...Field) Structured {
+	return &redactor{next: r.next.With(RedactFields(fields, r.secrets...)...), secrets: r.secrets}
+}
+
+// textStructured 使用 TextLogger 输出, 格式: msg key=value ...
+type textStructured struct {
+	l      *TextLogger
+	fields []Field
+}
+
+// NewTextStructured 基于 TextLogger 的结构化日志, 日志级别由 TextLogger 控制
+func NewTextStructured(l *TextLogger) Structured {
+	return &textStructured{l: l}
+}
+
+func (t *textStructured) format(msg string, fields []Field) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, f := range append(t.fields, fields...) {
+		fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+	return b.String()
+}
+
+func (t *textStructured) DebugEnabled() bool {
+	return t.l.level <= DEBUG
+}
+
+func (t *textStructured) Debug(msg string, fields ...Field) {
+	t.l.print(DEBUG, "[D]", t.format(msg, fields))
+}
+
+func (t *textStructured) Info(msg string, fields ...Field) {
+	t.l.print(INFO, "[I]", t.format(msg, fields))
+}
+
+func (t *textStructured) Warn(msg string, fields ...Field) {
+	t.l.print(WARN, "[W]", t.format(msg, fields))
+}
+
+func (t *textStructured) Error(msg string, fields ...Field) {
+	t.l.print(ERROR, "[E]", t.format(msg, fields))
+}
+
+func (t *textStructured) With(fields ...Field) Structured {
+	return &textStructured{l: t.l, fields: append(append([]Field(nil), t.fields...), fields...)}
+}
+
+type structuredHolder struct {
+	s Structured
+}
+
+var current atomic.Value
+
+// SetStructured 包内日志(Log 和 Debug、Info 等函数)转发给 s, s 为 nil 时恢复为文本日志
+func SetStructured(s Structured) {
+	current.Store(structuredHolder{s})
+}
+
+// Current SetStructured 设置的结构化日志, 未设置时为 nil
+func Current() Structured {
+	h, _ := current.Load().(structuredHolder)
+	return h.s
+}
+
+func forward(s Structured, le Level, log string) {
+	switch le {
+	case DEBUG:
+		s.Debug(log)
+	case INFO:
+		s.Info(log)
+	case WARN:
+		s.Warn(log)
+	default:
+		s.Error(log)
+	}
+}
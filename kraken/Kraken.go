@@ -30,6 +30,7 @@ type Kraken struct {
 	httpClient *http.Client
 	accessKey,
 	secretKey string
+	symbols *SymbolMapper
 }
 
 var (
@@ -41,21 +42,27 @@ var (
 )
 
 func New(client *http.Client, accesskey, secretkey string) *Kraken {
-	return &Kraken{client, accesskey, secretkey}
+	k := &Kraken{httpClient: client, accessKey: accesskey, secretKey: secretkey}
+	k.symbols = NewSymbolMapper(k.loadMarkets, currencyAliases)
+	return k
 }
 
 func (k *Kraken) placeOrder(orderType, side, amount, price string, pair CurrencyPair) (*Order, error) {
 	apiuri := "private/AddOrder"
+	symbol, err := k.symbol(pair)
+	if err != nil {
+		return nil, err
+	}
 
 	params := url.Values{}
-	params.Set("pair", k.convertPair(pair).ToSymbol(""))
+	params.Set("pair", symbol)
 	params.Set("type", side)
 	params.Set("ordertype", orderType)
 	params.Set("price", price)
 	params.Set("volume", amount)
 
 	var resp NewOrderResponse
-	err := k.doAuthenticatedRequest("POST", apiuri, params, &resp)
+	err = k.doAuthenticatedRequest("POST", apiuri, params, &resp)
 	//log.Println
 	if err != nil {
 		return nil, err
@@ -105,6 +112,52 @@ func (k *Kraken) CancelOrder(orderId string, currency CurrencyPair) (bool, error
 	return true, nil
 }
 
+// AmendOrder EditOrder 会撤销原订单并生成新订单, 返回的 OrderID2 为新订单ID
+func (k *Kraken) AmendOrder(ord *Order) (*Order, error) {
+	if ord.OrderID2 == "" {
+		return nil, ErrAmendOrderId
+	}
+
+	symbol, err := k.symbol(ord.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("txid", ord.OrderID2)
+	params.Set("pair", symbol)
+	if ord.Price > 0 {
+		params.Set("price", FloatToString(ord.Price, 8))
+	}
+	if ord.Amount > 0 {
+		params.Set("volume", FloatToString(ord.Amount, 8))
+	}
+
+	var resp struct {
+		TxId         string `json:"txid"`
+		OriginalTxId string `json:"originaltxid"`
+		Volume       string `json:"volume"`
+		Price        string `json:"price"`
+		Status       string `json:"status"`
+		ErrorMessage string `json:"error_message"`
+	}
+	err = k.doAuthenticatedRequest("POST", "private/EditOrder", params, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status != "ok" {
+		return nil, errors.New(resp.ErrorMessage)
+	}
+
+	newOrd := *ord
+	newOrd.OrderID2 = resp.TxId
+	newOrd.Price = ToFloat64(resp.Price)
+	newOrd.Amount = ToFloat64(resp.Volume)
+	newOrd.Status = ORDER_UNFINISH
+	return &newOrd, nil
+}
+
 func (k *Kraken) toOrder(orderinfo interface{}) Order {
 	omap := orderinfo.(map[string]interface{})
 	descmap := omap["descr"].(map[string]interface{})
@@ -197,13 +250,13 @@ func (k *Kraken) GetAccount() (*Account, error) {
 	acc.SubAccounts = make(map[Currency]SubAccount)
 
 	for key, v := range resustmap {
-		currency := k.convertCurrency(key)
+		currency := k.symbols.Currency(key)
 		amount := ToFloat64(v)
 		//log.Println(symbol, amount)
 		acc.SubAccounts[currency] = SubAccount{Currency: currency, Amount: amount, ForzenAmount: 0, LoanAmount: 0}
 
-		if currency.Symbol == "XBT" { // adapt to btc
-			acc.SubAccounts[BTC] = SubAccount{Currency: BTC, Amount: amount, ForzenAmount: 0, LoanAmount: 0}
+		if currency == BTC { // 兼容以前的 XBT
+			acc.SubAccounts[XBT] = SubAccount{Currency: XBT, Amount: amount, ForzenAmount: 0, LoanAmount: 0}
 		}
 	}
 
@@ -218,8 +271,13 @@ func (k *Kraken) GetAccount() (*Account, error) {
 //}
 
 func (k *Kraken) GetTicker(currency CurrencyPair) (*Ticker, error) {
+	symbol, err := k.symbol(currency)
+	if err != nil {
+		return nil, err
+	}
+
 	var resultmap map[string]interface{}
-	err := k.doAuthenticatedRequest("GET", "public/Ticker?pair="+k.convertPair(currency).ToSymbol(""), url.Values{}, &resultmap)
+	err = k.doAuthenticatedRequest("GET", "public/Ticker?pair="+symbol, url.Values{}, &resultmap)
 	if err != nil {
 		return nil, err
 	}
@@ -240,9 +298,14 @@ func (k *Kraken) GetTicker(currency CurrencyPair) (*Ticker, error) {
 }
 
 func (k *Kraken) GetDepth(size int, currency CurrencyPair) (*Depth, error) {
-	apiuri := fmt.Sprintf("public/Depth?pair=%s&count=%d", k.convertPair(currency).ToSymbol(""), size)
+	symbol, err := k.symbol(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	apiuri := fmt.Sprintf("public/Depth?pair=%s&count=%d", symbol, size)
 	var resultmap map[string]interface{}
-	err := k.doAuthenticatedRequest("GET", apiuri, url.Values{}, &resultmap)
+	err = k.doAuthenticatedRequest("GET", apiuri, url.Values{}, &resultmap)
 	if err != nil {
 		return nil, err
 	}
@@ -279,6 +342,38 @@ func (k *Kraken) GetTrades(currencyPair CurrencyPair, since int64) ([]Trade, err
 	panic("")
 }
 
+func (k *Kraken) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	if err := k.symbols.Load(); err != nil {
+		return nil, err
+	}
+	markets, err := k.symbols.Markets()
+	if err != nil {
+		return nil, err
+	}
+
+	var pairs []CurrencyPair
+	seen := make(map[string]bool, len(markets))
+	for _, m := range markets {
+		if !seen[m.CanonicalId()] {
+			seen[m.CanonicalId()] = true
+			pairs = append(pairs, m.Pair)
+		}
+	}
+
+	return pairs, nil
+}
+
+func (k *Kraken) GetTimestamp() (int64, error) {
+	var result struct {
+		UnixTime int64 `json:"unixtime"`
+	}
+	err := k.doAuthenticatedRequest("GET", "public/Time", url.Values{}, &result)
+	if err != nil {
+		return 0, err
+	}
+	return result.UnixTime * 1000, nil
+}
+
 func (k *Kraken) GetExchangeName() string {
 	return KRAKEN
 }
@@ -338,26 +433,6 @@ func (k *Kraken) doAuthenticatedRequest(method, apiuri string, params url.Values
 	return nil
 }
 
-func (k *Kraken) convertCurrency(currencySymbol string) Currency {
-	if len(currencySymbol) >= 4 {
-		currencySymbol = strings.Replace(currencySymbol, "X", "", 1)
-		currencySymbol = strings.Replace(currencySymbol, "Z", "", 1)
-	}
-	return NewCurrency(currencySymbol, "")
-}
-
-func (k *Kraken) convertPair(pair CurrencyPair) CurrencyPair {
-	if "BTC" == pair.CurrencyA.Symbol {
-		return NewCurrencyPair(XBT, pair.CurrencyB)
-	}
-
-	if "BTC" == pair.CurrencyB.Symbol {
-		return NewCurrencyPair(pair.CurrencyA, XBT)
-	}
-
-	return pair
-}
-
 func (k *Kraken) convertOrderStatus(status string) TradeStatus {
 	switch status {
 	case "open", "pending":
@@ -0,0 +1,77 @@
+package kraken
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+type krakenTrade struct {
+	OrderTxId string  `json:"ordertxid"`
+	Pair      string  `json:"pair"`
+	Time      float64 `json:"time"`
+	Type      string  `json:"type"`
+	Price     float64 `json:"price,string"`
+	Fee       float64 `json:"fee,string"`
+	Vol       float64 `json:"vol,string"`
+	Maker     bool    `json:"maker"`
+}
+
+// matchPair 成交记录里的交易对可能是 XBTUSD 或 XXBTZUSD 这种带前缀的格式
+func (k *Kraken) matchPair(symbol string, pair CurrencyPair) bool {
+	market, err := k.symbols.Market(symbol)
+	if err != nil {
+		return false
+	}
+	return market.Pair.Eq(NewCurrencyPair(k.symbols.Currency(pair.CurrencyA.Symbol), k.symbols.Currency(pair.CurrencyB.Symbol)))
+}
+
+// GetMyTrades TradesHistory 返回所有交易对的成交, 按 pair 过滤, 手续费默认以计价币收取
+func (k *Kraken) GetMyTrades(pair CurrencyPair, since int64, limit int) ([]Fill, error) {
+	params := url.Values{}
+	if since > 0 {
+		params.Set("start", fmt.Sprint(since/1000))
+	}
+
+	var result struct {
+		Trades map[string]krakenTrade `json:"trades"`
+	}
+	err := k.doAuthenticatedRequest("POST", "private/TradesHistory", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	var fills []Fill
+	for txid, t := range result.Trades {
+		if !k.matchPair(t.Pair, pair) {
+			continue
+		}
+		fill := Fill{
+			TradeId:     txid,
+			OrderId:     t.OrderTxId,
+			Pair:        pair,
+			Side:        AdaptTradeSide(t.Type),
+			Price:       t.Price,
+			Amount:      t.Vol,
+			Fee:         t.Fee,
+			FeeCurrency: pair.CurrencyB.Symbol,
+			IsMaker:     t.Maker,
+			Time:        int64(t.Time * 1000),
+		}
+		if fill.Time < since {
+			continue
+		}
+		fills = append(fills, fill)
+	}
+
+	sort.Slice(fills, func(i, j int) bool {
+		return fills[i].Time < fills[j].Time
+	})
+	if limit > 0 && len(fills) > limit {
+		fills = fills[len(fills)-limit:]
+	}
+
+	return fills, nil
+}
@@ -0,0 +1,63 @@
+package kraken
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKraken_GetMyTrades(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/0/public/AssetPairs":
+			w.Write([]byte(`{"error":[],"result":{
+"XXBTZUSD":{"altname":"XBTUSD","wsname":"XBT/USD","base":"XXBT","quote":"ZUSD"},
+"XETHZUSD":{"altname":"ETHUSD","wsname":"ETH/USD","base":"XETH","quote":"ZUSD"}}}`))
+		case "/0/private/TradesHistory":
+			assert.NotEmpty(t, r.Header.Get("API-Sign"))
+			w.Write([]byte(`{"error":[],"result":{"count":3,"trades":{
+"THVRQM-33VKH-UCI7BS":{"ordertxid":"OQCLML-BW3P3-BUCMWZ","postxid":"TKH2SE-M7IF5-CFI7LT","pair":"XXBTZUSD","time":1616000200.5,"type":"sell","ordertype":"limit","price":"30100.0","cost":"3010.0","fee":"4.816","vol":"0.1","margin":"0.0","maker":true,"misc":""},
+"TCWJEG-FL4SZ-3FKGH6":{"ordertxid":"OQCLML-BW3P3-BUCMWY","postxid":"TKH2SE-M7IF5-CFI7LS","pair":"XXBTZUSD","time":1616000100.25,"type":"buy","ordertype":"market","price":"30000.0","cost":"6000.0","fee":"15.6","vol":"0.2","margin":"0.0","misc":""},
+"TDLH43-DVQXD-2KHVYY":{"ordertxid":"OQCLML-BW3P3-BUCMWX","postxid":"TKH2SE-M7IF5-CFI7LR","pair":"XETHZUSD","time":1616000150.0,"type":"buy","ordertype":"limit","price":"1800.0","cost":"180.0","fee":"0.288","vol":"0.1","margin":"0.0","misc":""}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	domain := API_DOMAIN
+	API_DOMAIN = srv.URL + API_V0
+	defer func() { API_DOMAIN = domain }()
+
+	k := New(http.DefaultClient, "key", "c2VjcmV0")
+
+	//只返回 BTC_USD 的成交, 按时间升序
+	fills, err := k.GetMyTrades(goex.BTC_USD, 0, 0)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 2)
+	assert.Equal(t, "TCWJEG-FL4SZ-3FKGH6", fills[0].TradeId)
+	assert.Equal(t, "OQCLML-BW3P3-BUCMWY", fills[0].OrderId)
+	assert.Equal(t, goex.BUY, fills[0].Side)
+	assert.Equal(t, 0.2, fills[0].Amount)
+	assert.Equal(t, 30000.0, fills[0].Price)
+	assert.Equal(t, 15.6, fills[0].Fee)
+	assert.Equal(t, "USD", fills[0].FeeCurrency)
+	assert.False(t, fills[0].IsMaker)
+	assert.Equal(t, int64(1616000100250), fills[0].Time)
+	assert.Equal(t, goex.SELL, fills[1].Side)
+	assert.True(t, fills[1].IsMaker)
+
+	//limit 保留最近的成交
+	fills, err = k.GetMyTrades(goex.BTC_USD, 0, 1)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 1)
+	assert.Equal(t, "THVRQM-33VKH-UCI7BS", fills[0].TradeId)
+
+	fills, err = k.GetMyTrades(goex.BTC_USD, 1616000100500, 0)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 1)
+	assert.Equal(t, "THVRQM-33VKH-UCI7BS", fills[0].TradeId)
+}
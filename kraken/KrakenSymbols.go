@@ -0,0 +1,72 @@
+package kraken
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// currencyAliases kraken 老币种带 X(数字货币)、Z(法币)前缀
+var currencyAliases = map[string]string{
+	"XXBT": "BTC",
+	"XXDG": "DOGE",
+	"XETH": "ETH",
+	"XETC": "ETC",
+	"XLTC": "LTC",
+	"XXRP": "XRP",
+	"XXLM": "XLM",
+	"XXMR": "XMR",
+	"XZEC": "ZEC",
+	"XREP": "REP",
+	"XMLN": "MLN",
+	"ZUSD": "USD",
+	"ZEUR": "EUR",
+	"ZGBP": "GBP",
+	"ZJPY": "JPY",
+	"ZCAD": "CAD",
+}
+
+// Symbols symbol 与统一交易对的转换, XXBTZUSD、XBTUSD、XBT/USD 都对应 BTC_USD, 请求时使用 XBTUSD
+func (k *Kraken) Symbols() *SymbolMapper {
+	return k.symbols
+}
+
+func (k *Kraken) loadMarkets() ([]Market, error) {
+	var resultmap map[string]struct {
+		AltName string `json:"altname"`
+		WsName  string `json:"wsname"`
+		Base    string `json:"base"`
+		Quote   string `json:"quote"`
+	}
+	err := k.doAuthenticatedRequest("GET", "public/AssetPairs", url.Values{}, &resultmap)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(resultmap))
+	for key := range resultmap {
+		//.d 为暗池
+		if !strings.HasSuffix(key, ".d") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var markets []Market
+	for _, key := range keys {
+		v := resultmap[key]
+		for _, symbol := range []string{v.AltName, key, v.WsName} {
+			if symbol != "" {
+				markets = append(markets, Market{Symbol: symbol, BaseCurrency: v.Base, QuoteCurrency: v.Quote})
+			}
+		}
+	}
+	return markets, nil
+}
+
+// symbol 下单、行情等接口使用的 pair 参数
+func (k *Kraken) symbol(pair CurrencyPair) (string, error) {
+	return k.symbols.Symbol(pair, "")
+}
@@ -0,0 +1,14 @@
+package kucoin
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(KUCOIN, Driver{
+		Spot: func(config *APIConfig) API {
+			return NewWithConfig(config)
+		},
+		Testnet: []Capability{CAP_SPOT},
+	})
+}
@@ -0,0 +1,60 @@
+package kucoin
+
+import (
+	"fmt"
+
+	. "github.com/BTreeNewBee/goex"
+	log "github.com/BTreeNewBee/goex/internal/logger"
+	"github.com/Kucoin/kucoin-go-sdk"
+)
+
+// GetMyTrades 成交记录按时间倒序分页返回, 只取第一页
+func (kc *KuCoin) GetMyTrades(pair CurrencyPair, since int64, limit int) ([]Fill, error) {
+	params := map[string]string{
+		"symbol": pair.ToSymbol("-"),
+	}
+	if since > 0 {
+		params["startAt"] = fmt.Sprint(since)
+	}
+
+	pagination := kucoin.PaginationParam{CurrentPage: 1, PageSize: 50}
+	if limit > 0 {
+		pagination.PageSize = int64(limit)
+	}
+
+	resp, err := kc.service.Fills(params, &pagination)
+	if err != nil {
+		log.Error("KuCoin GetMyTrades error:", err)
+		return nil, err
+	}
+
+	var model kucoin.FillsModel
+	_, err = resp.ReadPaginationData(&model)
+	if err != nil {
+		log.Error("KuCoin GetMyTrades error:", err)
+		return nil, err
+	}
+
+	fills := make([]Fill, 0, len(model))
+	for i := len(model) - 1; i >= 0; i-- {
+		f := model[i]
+		fill := Fill{
+			TradeId:     f.TradeId,
+			OrderId:     f.OrderId,
+			Pair:        pair,
+			Side:        SELL,
+			Price:       ToFloat64(f.Price),
+			Amount:      ToFloat64(f.Size),
+			Fee:         ToFloat64(f.Fee),
+			FeeCurrency: f.FeeCurrency,
+			IsMaker:     f.Liquidity == "maker",
+			Time:        f.CreatedAt,
+		}
+		if f.Side == "buy" {
+			fill.Side = BUY
+		}
+		fills = append(fills, fill)
+	}
+
+	return fills, nil
+}
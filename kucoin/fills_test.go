@@ -0,0 +1,51 @@
+package kucoin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKuCoin_GetMyTrades(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/fills" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NotEmpty(t, r.Header.Get("KC-API-SIGN"))
+		assert.Equal(t, "BTC-USDT", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "1616000000000", r.URL.Query().Get("startAt"))
+		assert.Equal(t, "20", r.URL.Query().Get("pageSize"))
+		w.Write([]byte(`{"code":"200000","data":{"currentPage":1,"pageSize":20,"totalNum":2,"totalPage":1,"items":[
+{"symbol":"BTC-USDT","tradeId":"5c35c02709e4f67d5266954e","orderId":"5c35c02703aa673ceec2a169","counterOrderId":"5c1ab46003aa676e487fa8e4","side":"buy","liquidity":"maker","forceTaker":false,"price":"30100","size":"0.01","funds":"301","fee":"0.301","feeRate":"0.001","feeCurrency":"USDT","stop":"","type":"limit","createdAt":1616000200000,"tradeType":"TRADE"},
+{"symbol":"BTC-USDT","tradeId":"5c35c02709e4f67d5266954d","orderId":"5c35c02703aa673ceec2a168","counterOrderId":"5c1ab46003aa676e487fa8e3","side":"sell","liquidity":"taker","forceTaker":true,"price":"30000","size":"0.02","funds":"600","fee":"0.6","feeRate":"0.001","feeCurrency":"USDT","stop":"","type":"market","createdAt":1616000100000,"tradeType":"TRADE"}]}}`))
+	}))
+	defer srv.Close()
+
+	kc := NewWithConfig(&goex.APIConfig{
+		Endpoint:      srv.URL,
+		ApiKey:        "key",
+		ApiSecretKey:  "secret",
+		ApiPassphrase: "pass",
+		Clock:         goex.LocalClock,
+	})
+
+	fills, err := kc.GetMyTrades(goex.BTC_USDT, 1616000000000, 20)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 2)
+	//倒序转为升序
+	assert.Equal(t, "5c35c02709e4f67d5266954d", fills[0].TradeId)
+	assert.Equal(t, "5c35c02703aa673ceec2a168", fills[0].OrderId)
+	assert.Equal(t, goex.SELL, fills[0].Side)
+	assert.Equal(t, 0.02, fills[0].Amount)
+	assert.Equal(t, 30000.0, fills[0].Price)
+	assert.Equal(t, 0.6, fills[0].Fee)
+	assert.Equal(t, "USDT", fills[0].FeeCurrency)
+	assert.False(t, fills[0].IsMaker)
+	assert.Equal(t, int64(1616000100000), fills[0].Time)
+	assert.Equal(t, goex.BUY, fills[1].Side)
+	assert.True(t, fills[1].IsMaker)
+}
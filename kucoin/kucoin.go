@@ -4,6 +4,7 @@ import (
 	. "github.com/BTreeNewBee/goex"
 	log "github.com/BTreeNewBee/goex/internal/logger"
 	"github.com/Kucoin/kucoin-go-sdk"
+	"strconv"
 	"time"
 )
 
@@ -19,6 +20,9 @@ func New(apiKey, apiSecret, apiPassphrase string) *KuCoin {
 func NewWithConfig(config *APIConfig) *KuCoin {
 	if config.Endpoint == "" {
 		config.Endpoint = "https://api.kucoin.com"
+		if config.Env == ENV_TESTNET {
+			config.Endpoint = "https://openapi-sandbox.kucoin.com"
+		}
 	}
 
 	kc := &KuCoin{
@@ -28,16 +32,39 @@ func NewWithConfig(config *APIConfig) *KuCoin {
 		apiPassphrase: config.ApiPassphrase,
 	}
 
+	requester := &clockRequester{}
 	kc.service = kucoin.NewApiService(
 		kucoin.ApiBaseURIOption(kc.baseUrl),
 		kucoin.ApiKeyOption(kc.apiKey),
 		kucoin.ApiSecretOption(kc.apiSecret),
 		kucoin.ApiPassPhraseOption(kc.apiPassphrase),
+		kucoin.ApiRequesterOption(requester),
 	)
+	if kc.apiKey != "" {
+		requester.signer = kucoin.NewKcSigner(kc.apiKey, kc.apiSecret, kc.apiPassphrase)
+	}
+	requester.clock = config.GetClock(kc.baseUrl+"/api/v1/timestamp", kc.GetTimestamp)
 
 	return kc
 }
 
+// clockRequester sdk 使用本地时间签名, 发送前用与服务器同步的时间重新签名
+type clockRequester struct {
+	kucoin.BasicRequester
+	signer *kucoin.KcSigner
+	clock  Clock
+}
+
+func (r *clockRequester) Request(request *kucoin.Request, timeout time.Duration) (*kucoin.Response, error) {
+	if r.signer != nil && request.Header.Get("KC-API-SIGN") != "" {
+		t := strconv.FormatInt(r.clock.Now().UnixNano()/int64(time.Millisecond), 10)
+		sign := r.signer.Sign([]byte(t + request.Method + request.RequestURI() + string(request.Body)))
+		request.Header.Set("KC-API-TIMESTAMP", t)
+		request.Header.Set("KC-API-SIGN", string(sign))
+	}
+	return r.BasicRequester.Request(request, timeout)
+}
+
 type KuCoin struct {
 	apiKey        string
 	apiSecret     string
@@ -486,6 +513,48 @@ func (kc *KuCoin) GetTrades(currency CurrencyPair, since int64) ([]Trade, error)
 	return trades, nil
 }
 
+func (kc *KuCoin) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	resp, err := kc.service.Symbols("")
+	if err != nil {
+		log.Error("KuCoin GetAllCurrencyPair error:", err)
+		return nil, err
+	}
+
+	var model kucoin.SymbolsModel
+	err = resp.ReadData(&model)
+	if err != nil {
+		log.Error("KuCoin GetAllCurrencyPair error:", err)
+		return nil, err
+	}
+
+	var pairs []CurrencyPair
+	for _, item := range model {
+		if !item.EnableTrading {
+			continue
+		}
+		pairs = append(pairs, NewCurrencyPair2(item.BaseCurrency+"_"+item.QuoteCurrency))
+	}
+
+	return pairs, nil
+}
+
+func (kc *KuCoin) GetTimestamp() (int64, error) {
+	resp, err := kc.service.ServerTime()
+	if err != nil {
+		log.Error("KuCoin GetTimestamp error:", err)
+		return 0, err
+	}
+
+	var ts int64
+	err = resp.ReadData(&ts)
+	if err != nil {
+		log.Error("KuCoin GetTimestamp error:", err)
+		return 0, err
+	}
+
+	return ts, nil
+}
+
 // Account
 
 // Accounts returns a list of accounts.
@@ -1,8 +1,14 @@
 package kucoin
 
 import (
-	"github.com/BTreeNewBee/goex"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/Kucoin/kucoin-go-sdk"
+	"github.com/stretchr/testify/assert"
 )
 
 var kc = New("", "", "")
@@ -31,3 +37,33 @@ func TestKuCoin_GetAccount(t *testing.T) {
 	acc, _ := kc.GetAccount()
 	t.Log(acc)
 }
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestClockRequester(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{"code":"200000","data":1600000000000}`))
+	}))
+	defer srv.Close()
+
+	kc := NewWithConfig(&goex.APIConfig{
+		Endpoint:      srv.URL,
+		ApiKey:        "key",
+		ApiSecretKey:  "secret",
+		ApiPassphrase: "pass",
+		Clock:         fixedClock(time.Unix(1600000001, 0)),
+	})
+	ts, err := kc.GetTimestamp()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1600000000000), ts)
+
+	sign := kucoin.NewKcSigner("key", "secret", "pass").Sign([]byte("1600000001000GET/api/v1/timestamp"))
+	assert.Equal(t, "1600000001000", header.Get("KC-API-TIMESTAMP"))
+	assert.Equal(t, string(sign), header.Get("KC-API-SIGN"))
+}
@@ -0,0 +1,38 @@
+package logadapter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestZap(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := goex.NewRedactLogger(Zap(zap.New(core)), "k123").With(goex.KV("exchange", goex.BINANCE))
+
+	assert.False(t, goex.DebugEnabled(l))
+	l.Debug("ignored")
+	l.Info("order placed", goex.KV("apiKey", "k123"), goex.KV("amount", 1.5))
+
+	assert.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "order placed", entry.Message)
+	assert.Equal(t, map[string]interface{}{"exchange": goex.BINANCE, "apiKey": "******", "amount": 1.5}, entry.ContextMap())
+}
+
+func TestZerolog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := Zerolog(zerolog.New(buf).Level(zerolog.InfoLevel)).With(goex.KV("exchange", goex.OKEX))
+
+	assert.False(t, goex.DebugEnabled(l))
+	l.Debug("ignored")
+	l.Warn("rate limited", goex.KV("retry", 2))
+
+	assert.Equal(t, `{"level":"warn","exchange":"okex","retry":2,"message":"rate limited"}`+"\n", buf.String())
+}
@@ -0,0 +1,62 @@
+//go:build go1.21
+// +build go1.21
+
+package logadapter
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/BTreeNewBee/goex"
+)
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// Slog 适配 log/slog, l 为 nil 时使用 slog.Default()
+func Slog(l *slog.Logger) goex.Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &slogLogger{l: l}
+}
+
+func slogAttrs(fields []goex.LogField) []any {
+	attrs := make([]any, len(fields))
+	for i, f := range fields {
+		attrs[i] = slog.Any(f.Key, f.Value)
+	}
+	return attrs
+}
+
+func (s *slogLogger) log(level slog.Level, msg string, fields []goex.LogField) {
+	ctx := context.Background()
+	if s.l.Enabled(ctx, level) {
+		s.l.Log(ctx, level, msg, slogAttrs(fields)...)
+	}
+}
+
+func (s *slogLogger) DebugEnabled() bool {
+	return s.l.Enabled(context.Background(), slog.LevelDebug)
+}
+
+func (s *slogLogger) Debug(msg string, fields ...goex.LogField) {
+	s.log(slog.LevelDebug, msg, fields)
+}
+
+func (s *slogLogger) Info(msg string, fields ...goex.LogField) {
+	s.log(slog.LevelInfo, msg, fields)
+}
+
+func (s *slogLogger) Warn(msg string, fields ...goex.LogField) {
+	s.log(slog.LevelWarn, msg, fields)
+}
+
+func (s *slogLogger) Error(msg string, fields ...goex.LogField) {
+	s.log(slog.LevelError, msg, fields)
+}
+
+func (s *slogLogger) With(fields ...goex.LogField) goex.Logger {
+	return &slogLogger{l: s.l.With(slogAttrs(fields)...)}
+}
@@ -0,0 +1,32 @@
+//go:build go1.21
+// +build go1.21
+
+package logadapter
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	handler := slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	l := Slog(slog.New(handler)).With(goex.KV("exchange", goex.HUOBI_PRO))
+
+	assert.False(t, goex.DebugEnabled(l))
+	l.Debug("ignored")
+	l.Error("request failed", goex.KV("status", 500))
+
+	assert.Equal(t, "level=ERROR msg=\"request failed\" exchange=火币 status=500\n", buf.String())
+}
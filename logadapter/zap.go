@@ -0,0 +1,51 @@
+// Package logadapter 将 slog、zap、zerolog 适配为 goex.Logger, 用于 goex.SetLogger 或 APIConfig.Logger
+package logadapter
+
+import (
+	"github.com/BTreeNewBee/goex"
+	"go.uber.org/zap"
+)
+
+type zapLogger struct {
+	l *zap.Logger
+}
+
+// Zap 适配 zap, l 为 nil 时使用 zap.L()
+func Zap(l *zap.Logger) goex.Logger {
+	if l == nil {
+		l = zap.L()
+	}
+	return &zapLogger{l: l.WithOptions(zap.AddCallerSkip(1))}
+}
+
+func zapFields(fields []goex.LogField) []zap.Field {
+	zfs := make([]zap.Field, len(fields))
+	for i, f := range fields {
+		zfs[i] = zap.Any(f.Key, f.Value)
+	}
+	return zfs
+}
+
+func (z *zapLogger) DebugEnabled() bool {
+	return z.l.Core().Enabled(zap.DebugLevel)
+}
+
+func (z *zapLogger) Debug(msg string, fields ...goex.LogField) {
+	z.l.Debug(msg, zapFields(fields)...)
+}
+
+func (z *zapLogger) Info(msg string, fields ...goex.LogField) {
+	z.l.Info(msg, zapFields(fields)...)
+}
+
+func (z *zapLogger) Warn(msg string, fields ...goex.LogField) {
+	z.l.Warn(msg, zapFields(fields)...)
+}
+
+func (z *zapLogger) Error(msg string, fields ...goex.LogField) {
+	z.l.Error(msg, zapFields(fields)...)
+}
+
+func (z *zapLogger) With(fields ...goex.LogField) goex.Logger {
+	return &zapLogger{l: z.l.With(zapFields(fields)...)}
+}
@@ -0,0 +1,57 @@
+package logadapter
+
+import (
+	"github.com/BTreeNewBee/goex"
+	"github.com/rs/zerolog"
+)
+
+type zerologLogger struct {
+	l zerolog.Logger
+}
+
+// Zerolog 适配 zerolog
+func Zerolog(l zerolog.Logger) goex.Logger {
+	return &zerologLogger{l: l}
+}
+
+func zerologFields(fields []goex.LogField) map[string]interface{} {
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.Value
+	}
+	return m
+}
+
+func (z *zerologLogger) log(e *zerolog.Event, msg string, fields []goex.LogField) {
+	if e == nil {
+		return
+	}
+	if len(fields) > 0 {
+		e = e.Fields(zerologFields(fields))
+	}
+	e.Msg(msg)
+}
+
+func (z *zerologLogger) DebugEnabled() bool {
+	return z.l.GetLevel() <= zerolog.DebugLevel && zerolog.GlobalLevel() <= zerolog.DebugLevel
+}
+
+func (z *zerologLogger) Debug(msg string, fields ...goex.LogField) {
+	z.log(z.l.Debug(), msg, fields)
+}
+
+func (z *zerologLogger) Info(msg string, fields ...goex.LogField) {
+	z.log(z.l.Info(), msg, fields)
+}
+
+func (z *zerologLogger) Warn(msg string, fields ...goex.LogField) {
+	z.log(z.l.Warn(), msg, fields)
+}
+
+func (z *zerologLogger) Error(msg string, fields ...goex.LogField) {
+	z.log(z.l.Error(), msg, fields)
+}
+
+func (z *zerologLogger) With(fields ...goex.LogField) goex.Logger {
+	return &zerologLogger{l: z.l.With().Fields(zerologFields(fields)).Logger()}
+}
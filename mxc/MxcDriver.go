@@ -0,0 +1,13 @@
+package mxc
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	RegisterDriver(MXC, Driver{
+		Spot: func(config *APIConfig) API {
+			return NewMxcWithConfig(config)
+		},
+	})
+}
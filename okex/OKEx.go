@@ -6,17 +6,17 @@ import (
 	"errors"
 	"fmt"
 	. "github.com/BTreeNewBee/goex"
-	"github.com/BTreeNewBee/goex/internal/logger"
 	"github.com/google/uuid"
 	"strings"
 	"sync"
-	"time"
 )
 
 const baseUrl = "https://www.okex.com"
 
 type OKEx struct {
 	config          *APIConfig
+	clock           Clock
+	log             Logger
 	OKExSpot        *OKExSpot
 	OKExFuture      *OKExFuture
 	OKExSwap        *OKExSwap
@@ -31,9 +31,11 @@ func NewOKEx(config *APIConfig) *OKEx {
 	if config.Endpoint == "" {
 		config.Endpoint = baseUrl
 	}
-	okex := &OKEx{config: config}
+	okex := &OKEx{config: config, log: config.GetLogger()}
+	okex.setClock()
 	okex.OKExSpot = &OKExSpot{okex}
 	okex.OKExFuture = &OKExFuture{OKEx: okex, Locker: new(sync.Mutex)}
+	okex.OKExFuture.contracts = NewContractResolver(OKEX_FUTURE, okex.OKExFuture.loadContracts, okex.clock)
 	okex.OKExWallet = &OKExWallet{okex}
 	okex.OKExMargin = &OKExMargin{okex}
 	okex.OKExSwap = &OKExSwap{okex, config}
@@ -43,6 +45,11 @@ func NewOKEx(config *APIConfig) *OKEx {
 	return okex
 }
 
+// setClock 签名时间戳与服务器时间同步
+func (ok *OKEx) setClock() {
+	ok.clock = ok.config.GetClock(ok.config.Endpoint+"/api/general/v3/time", (&OKExSpot{ok}).GetTimestamp)
+}
+
 func (ok *OKEx) GetExchangeName() string {
 	return OKEX
 }
@@ -55,19 +62,26 @@ func (ok *OKEx) DoRequest(httpMethod, uri, reqBody string, response interface{})
 	url := ok.config.Endpoint + uri
 	sign, timestamp := ok.doParamSign(httpMethod, uri, reqBody)
 	//logger.Log.Debug("timestamp=", timestamp, ", sign=", sign)
-	resp, err := NewHttpRequest(ok.config.HttpClient, httpMethod, url, reqBody, map[string]string{
+	headers := map[string]string{
 		CONTENT_TYPE: APPLICATION_JSON_UTF8,
 		ACCEPT:       APPLICATION_JSON,
 		//COOKIE:               LOCALE + "en_US",
 		OK_ACCESS_KEY:        ok.config.ApiKey,
 		OK_ACCESS_PASSPHRASE: ok.config.ApiPassphrase,
 		OK_ACCESS_SIGN:       sign,
-		OK_ACCESS_TIMESTAMP:  fmt.Sprint(timestamp)})
+		OK_ACCESS_TIMESTAMP:  fmt.Sprint(timestamp)}
+	//模拟盘与实盘使用相同的地址, 需要使用模拟盘的 api key
+	if ok.config.Env == ENV_TESTNET {
+		headers[OK_SIMULATED_TRADING] = "1"
+	}
+	resp, err := NewHttpRequest(ok.config.HttpClient, httpMethod, url, reqBody, headers)
 	if err != nil {
 		//log.Println(err)
 		return err
 	} else {
-		logger.Log.Debug(string(resp))
+		if DebugEnabled(ok.log) {
+			ok.log.Debug("response", KV("method", httpMethod), KV("uri", uri), KV("body", string(resp)))
+		}
 		return json.Unmarshal(resp, &response)
 	}
 }
@@ -92,6 +106,21 @@ func (ok *OKEx) adaptOrderState(state int) TradeStatus {
 	return ORDER_UNFINISH
 }
 
+//策略委托单状态 1:待生效 2:已生效 3:已撤销 4:部分生效 5:暂停生效 6:委托失败
+func (ok *OKEx) adaptAlgoOrderStatus(status string) TradeStatus {
+	switch status {
+	case "2":
+		return ORDER_TRIGGERED
+	case "3":
+		return ORDER_CANCEL
+	case "4":
+		return ORDER_PART_FINISH
+	case "6":
+		return ORDER_FAIL
+	}
+	return ORDER_UNFINISH
+}
+
 /*
  Get a http request body is a json string and a byte array.
 */
@@ -120,15 +149,11 @@ func (ok *OKEx) doParamSign(httpMethod, uri, requestBody string) (string, string
 }
 
 /*
- Get a iso time
+ Get a iso time, 与服务器时间同步
   eg: 2018-03-16T18:02:48.284Z
 */
 func (ok *OKEx) IsoTime() string {
-	utcTime := time.Now().UTC()
-	iso := utcTime.String()
-	isoBytes := []byte(iso)
-	iso = string(isoBytes[:10]) + "T" + string(isoBytes[11:23]) + "Z"
-	return iso
+	return ok.clock.Now().UTC().Format("2006-01-02T15:04:05.000Z")
 }
 
 func (ok *OKEx) LimitBuy(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
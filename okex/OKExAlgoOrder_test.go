@@ -0,0 +1,58 @@
+package okex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOKExFuture_FutureAlgoOrder(t *testing.T) {
+	var placed, canceled map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/futures/v3/order_algo":
+			json.NewDecoder(r.Body).Decode(&placed)
+			w.Write([]byte(`{"result":true,"error_message":"","error_code":"","algo_id":"1600593327162368","instrument_id":"BTC-USD-210326"}`))
+		case "POST /api/futures/v3/cancel_algos":
+			json.NewDecoder(r.Body).Decode(&canceled)
+			w.Write([]byte(`{"result":true,"error_message":"","error_code":"","instrument_id":"BTC-USD-210326"}`))
+		case "GET /api/futures/v3/order_algo/BTC-USD-210326":
+			assert.Equal(t, "1", r.URL.Query().Get("order_type"))
+			assert.Equal(t, goex.ALGO_ORDER_TRIGGERED, r.URL.Query().Get("status"))
+			w.Write([]byte(`{"orderStrategyVOS":[
+{"algo_id":"1600593327162368","algo_price":"0","instrument_id":"BTC-USD-210326","leverage":"10","order_type":"1","real_amount":"1","real_price":"28990.1","size":"1","status":"2","timestamp":"2021-03-01T08:00:00.000Z","trigger_price":"29000","type":"3"},
+{"algo_id":"1600593327162369","algo_price":"31000","instrument_id":"BTC-USD-210326","leverage":"10","order_type":"1","real_amount":"0","real_price":"0","size":"1","status":"6","timestamp":"2021-03-01T08:00:00.000Z","trigger_price":"31000","type":"1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	api := NewOKEx(&goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Clock: goex.LocalClock}).OKExFuture
+
+	ord, err := api.PlaceFutureAlgoOrder(&goex.FutureOrder{Currency: goex.BTC_USD, ContractName: "BTC-USD-210326",
+		OType: goex.CLOSE_BUY, Amount: 1, TriggerPrice: 29000, AlgoType: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "1600593327162368", ord.OrderID2)
+	assert.Equal(t, "BTC-USD-210326", placed["instrument_id"])
+	assert.Equal(t, float64(1), placed["order_type"])
+	assert.Equal(t, "2", placed["algo_type"])
+
+	orders, err := api.GetFutureAlgoOrders("", goex.ALGO_ORDER_TRIGGERED, goex.BTC_USD, "BTC-USD-210326")
+	assert.Nil(t, err)
+	assert.Len(t, orders, 2)
+	//已触发的策略委托不代表已成交
+	assert.Equal(t, goex.ORDER_TRIGGERED, orders[0].Status)
+	assert.Equal(t, goex.CLOSE_BUY, orders[0].OType)
+	assert.Equal(t, 28990.1, orders[0].AvgPrice)
+	assert.Equal(t, goex.ORDER_FAIL, orders[1].Status)
+
+	ok, err := api.FutureCancelAlgoOrder(goex.BTC_USD, []string{ord.OrderID2}, "BTC-USD-210326")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []interface{}{"1600593327162368"}, canceled["algo_ids"])
+}
@@ -0,0 +1,92 @@
+package okex
+
+import (
+	"fmt"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+const (
+	spotAmendUri   = "/api/spot/v3/amend_order/%s"
+	futureAmendUri = "/api/futures/v3/amend_order/%s"
+	swapAmendUri   = "/api/swap/v3/amend_order/%s"
+)
+
+// amend 现货、交割合约、永续合约的改单参数和返回相同, 改单后订单ID不变
+func (ok *OKEx) amend(uri, orderId, clientOid string, price, amount float64) (*batchOrderInfo, error) {
+	var (
+		param struct {
+			OrderId      string `json:"order_id,omitempty"`
+			ClientOid    string `json:"client_oid,omitempty"`
+			NewSize      string `json:"new_size,omitempty"`
+			NewPrice     string `json:"new_price,omitempty"`
+			CancelOnFail string `json:"cancel_on_fail"` //0:改单失败时不撤单
+		}
+		response batchOrderInfo
+	)
+
+	if orderId == "" && clientOid == "" {
+		return nil, ErrAmendOrderId
+	}
+
+	param.OrderId = orderId
+	if orderId == "" {
+		param.ClientOid = clientOid
+	}
+	param.NewPrice, param.NewSize = formatAmendParam(price, amount)
+	param.CancelOnFail = "0"
+
+	reqBody, _, _ := ok.BuildRequestBody(param)
+	err := ok.DoRequest("POST", uri, reqBody, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.OrderId == "" {
+		response.OrderId = orderId
+	}
+	if response.ClientOid == "" {
+		response.ClientOid = clientOid
+	}
+
+	return &response, response.err()
+}
+
+func (ok *OKExSpot) AmendOrder(ord *Order) (*Order, error) {
+	uri := fmt.Sprintf(spotAmendUri, ord.Currency.AdaptUsdToUsdt().ToSymbol("-"))
+	ret, err := ok.amend(uri, ord.OrderID2, ord.Cid, ord.Price, ord.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	newOrd := *ord
+	newOrd.OrderID2 = ret.OrderId
+	newOrd.Cid = ret.ClientOid
+	return &newOrd, nil
+}
+
+func (ok *OKExFuture) AmendFutureOrder(ord *FutureOrder) (*FutureOrder, error) {
+	uri := fmt.Sprintf(futureAmendUri, ok.GetFutureContractId(ord.Currency, ord.ContractName))
+	ret, err := ok.amend(uri, ord.OrderID2, ord.ClientOid, ord.Price, ord.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	newOrd := *ord
+	newOrd.OrderID2 = ret.OrderId
+	newOrd.ClientOid = ret.ClientOid
+	return &newOrd, nil
+}
+
+func (ok *OKExSwap) AmendFutureOrder(ord *FutureOrder) (*FutureOrder, error) {
+	uri := fmt.Sprintf(swapAmendUri, ok.adaptContractType(ord.Currency))
+	ret, err := ok.amend(uri, ord.OrderID2, ord.ClientOid, ord.Price, ord.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	newOrd := *ord
+	newOrd.OrderID2 = ret.OrderId
+	newOrd.ClientOid = ret.ClientOid
+	return &newOrd, nil
+}
@@ -0,0 +1,509 @@
+package okex
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+const (
+	batchOrderLimit = 10 //v3 批量下单/撤单/改单每个币对(合约)最多10个
+
+	spotBatchPlaceUri    = "/api/spot/v3/batch_orders"
+	spotBatchCancelUri   = "/api/spot/v3/cancel_batch_orders"
+	spotBatchAmendUri    = "/api/spot/v3/amend_batch_orders"
+	futureBatchPlaceUri  = "/api/futures/v3/orders"
+	futureBatchCancelUri = "/api/futures/v3/cancel_batch_orders/%s"
+	futureBatchAmendUri  = "/api/futures/v3/amend_batch_orders/%s"
+	swapBatchPlaceUri    = "/api/swap/v3/orders"
+	swapBatchCancelUri   = "/api/swap/v3/cancel_batch_orders/%s"
+	swapBatchAmendUri    = "/api/swap/v3/amend_batch_orders/%s"
+)
+
+type amendOrderParam struct {
+	OrderId      string `json:"order_id"`
+	InstrumentId string `json:"instrument_id,omitempty"`
+	NewSize      string `json:"new_size,omitempty"`
+	NewPrice     string `json:"new_price,omitempty"`
+}
+
+type batchOrderInfo struct {
+	OrderId      string `json:"order_id"`
+	ClientOid    string `json:"client_oid"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+func (info batchOrderInfo) err() error {
+	if (info.ErrorCode == "" || info.ErrorCode == "0") && info.ErrorMessage == "" {
+		return nil
+	}
+	return errors.New(fmt.Sprintf("%s:%s", info.ErrorCode, info.ErrorMessage))
+}
+
+// groupBatch 按币对(合约)分组后再按 batchOrderLimit 拆分, 返回每批订单的下标
+func groupBatch(instrumentIds []string) [][]int {
+	var (
+		keys   []string
+		groups = make(map[string][]int, 1)
+	)
+
+	for i, id := range instrumentIds {
+		if _, ok := groups[id]; !ok {
+			keys = append(keys, id)
+		}
+		groups[id] = append(groups[id], i)
+	}
+
+	var batches [][]int
+	for _, k := range keys {
+		for _, b := range SplitBatch(len(groups[k]), batchOrderLimit) {
+			batches = append(batches, groups[k][b[0]:b[1]])
+		}
+	}
+
+	return batches
+}
+
+var errOrderNotInResponse = errors.New("order not found in response")
+
+func failBatch(results []BatchOrderResult, idx []int, err error) {
+	for _, i := range idx {
+		results[i].Err = err
+	}
+}
+
+func formatAmendParam(price, amount float64) (newPrice string, newSize string) {
+	if price > 0 {
+		newPrice = fmt.Sprint(price)
+	}
+	if amount > 0 {
+		newSize = fmt.Sprint(amount)
+	}
+	return
+}
+
+// BatchLimitOrders 实现 BatchOrderAPI, Cid 为空时自动生成, 用于匹配返回结果
+// 与 BatchPlaceOrders 不同, 超过10笔时自动拆分, 结果与入参一一对应
+func (ok *OKExSpot) BatchLimitOrders(orders []Order) ([]BatchOrderResult, error) {
+	var (
+		results       = make([]BatchOrderResult, len(orders))
+		instrumentIds = make([]string, len(orders))
+	)
+
+	for i := range orders {
+		if orders[i].Cid == "" {
+			orders[i].Cid = GenerateOrderClientId(32)
+		}
+		instrumentIds[i] = orders[i].Currency.AdaptUsdToUsdt().ToSymbol("-")
+		results[i].ClientOid = orders[i].Cid
+	}
+
+	for _, idx := range groupBatch(instrumentIds) {
+		var (
+			param    []PlaceOrderParam
+			response map[string][]PlaceOrderResponse
+		)
+
+		for _, i := range idx {
+			ord := orders[i]
+			param = append(param, PlaceOrderParam{
+				InstrumentId: instrumentIds[i],
+				ClientOid:    ord.Cid,
+				Side:         strings.ToLower(ord.Side.String()),
+				Size:         ord.Amount,
+				Price:        ord.Price,
+				Type:         "limit",
+				OrderType:    ord.OrderType})
+		}
+
+		reqBody, _, _ := ok.BuildRequestBody(param)
+		err := ok.DoRequest("POST", spotBatchPlaceUri, reqBody, &response)
+		if err != nil {
+			failBatch(results, idx, err)
+			continue
+		}
+
+		ret := make(map[string]PlaceOrderResponse, len(idx))
+		for _, v := range response {
+			for _, r := range v {
+				ret[r.ClientOid] = r
+			}
+		}
+
+		for _, i := range idx {
+			r, found := ret[orders[i].Cid]
+			if !found {
+				results[i].Err = errOrderNotInResponse
+				continue
+			}
+			if !r.Result {
+				results[i].Err = errors.New(fmt.Sprintf("%s:%s", r.ErrorCode, r.ErrorMessage))
+				continue
+			}
+			orders[i].OrderID2 = r.OrderId
+			results[i].OrderId = r.OrderId
+		}
+	}
+
+	return results, nil
+}
+
+func (ok *OKExSpot) BatchCancelOrders(currency CurrencyPair, orderIds []string) ([]BatchOrderResult, error) {
+	instrumentId := currency.AdaptUsdToUsdt().ToSymbol("-")
+	results := make([]BatchOrderResult, len(orderIds))
+
+	for _, b := range SplitBatch(len(orderIds), batchOrderLimit) {
+		var (
+			param []struct {
+				InstrumentId string   `json:"instrument_id"`
+				OrderIds     []string `json:"order_ids"`
+			}
+			response map[string][]batchOrderInfo
+		)
+
+		param = append(param, struct {
+			InstrumentId string   `json:"instrument_id"`
+			OrderIds     []string `json:"order_ids"`
+		}{instrumentId, orderIds[b[0]:b[1]]})
+
+		reqBody, _, _ := ok.BuildRequestBody(param)
+		err := ok.DoRequest("POST", spotBatchCancelUri, reqBody, &response)
+		if err != nil {
+			FailBatch(results, b[0], b[1], err)
+			continue
+		}
+
+		ret := make(map[string]batchOrderInfo, b[1]-b[0])
+		for _, v := range response {
+			for _, r := range v {
+				ret[r.OrderId] = r
+			}
+		}
+
+		for i := b[0]; i < b[1]; i++ {
+			results[i].OrderId = orderIds[i]
+			r, found := ret[orderIds[i]]
+			if !found {
+				results[i].Err = errOrderNotInResponse
+				continue
+			}
+			results[i].ClientOid = r.ClientOid
+			results[i].Err = r.err()
+		}
+	}
+
+	return results, nil
+}
+
+func (ok *OKExSpot) BatchAmendOrders(orders []Order) ([]BatchOrderResult, error) {
+	var (
+		results       = make([]BatchOrderResult, len(orders))
+		instrumentIds = make([]string, len(orders))
+	)
+
+	for i := range orders {
+		instrumentIds[i] = orders[i].Currency.AdaptUsdToUsdt().ToSymbol("-")
+		results[i].OrderId = orders[i].OrderID2
+	}
+
+	for _, idx := range groupBatch(instrumentIds) {
+		var (
+			param    []amendOrderParam
+			response map[string][]batchOrderInfo
+		)
+
+		for _, i := range idx {
+			newPrice, newSize := formatAmendParam(orders[i].Price, orders[i].Amount)
+			param = append(param, amendOrderParam{
+				OrderId:      orders[i].OrderID2,
+				InstrumentId: instrumentIds[i],
+				NewPrice:     newPrice,
+				NewSize:      newSize,
+			})
+		}
+
+		reqBody, _, _ := ok.BuildRequestBody(param)
+		err := ok.DoRequest("POST", spotBatchAmendUri, reqBody, &response)
+		if err != nil {
+			failBatch(results, idx, err)
+			continue
+		}
+
+		ret := make(map[string]batchOrderInfo, len(idx))
+		for _, v := range response {
+			for _, r := range v {
+				ret[r.OrderId] = r
+			}
+		}
+
+		for _, i := range idx {
+			r, found := ret[orders[i].OrderID2]
+			if !found {
+				results[i].Err = errOrderNotInResponse
+				continue
+			}
+			results[i].ClientOid = r.ClientOid
+			results[i].Err = r.err()
+		}
+	}
+
+	return results, nil
+}
+
+func (ok *OKExFuture) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	var (
+		results       = make([]BatchOrderResult, len(orders))
+		instrumentIds = make([]string, len(orders))
+	)
+
+	for i := range orders {
+		if orders[i].ClientOid == "" {
+			orders[i].ClientOid = GenerateOrderClientId(32)
+		}
+		instrumentIds[i] = ok.GetFutureContractId(orders[i].Currency, orders[i].ContractName)
+		results[i].ClientOid = orders[i].ClientOid
+	}
+
+	for _, idx := range groupBatch(instrumentIds) {
+		var (
+			param struct {
+				InstrumentId string                `json:"instrument_id"`
+				OrdersData   []*BasePlaceOrderInfo `json:"orders_data"`
+			}
+			response struct {
+				Result    bool             `json:"result"`
+				OrderInfo []batchOrderInfo `json:"order_info"`
+			}
+		)
+
+		param.InstrumentId = instrumentIds[idx[0]]
+		for _, i := range idx {
+			ord := orders[i]
+			param.OrdersData = append(param.OrdersData, &BasePlaceOrderInfo{
+				ClientOid:  ord.ClientOid,
+				Price:      ok.normalizePrice(ord.Price, ord.Currency),
+				MatchPrice: "0",
+				Type:       fmt.Sprint(ord.OType),
+				Size:       fmt.Sprint(ord.Amount),
+				OrderType:  fmt.Sprint(ord.OrderType),
+			})
+		}
+
+		reqBody, _, _ := ok.BuildRequestBody(param)
+		err := ok.DoRequest("POST", futureBatchPlaceUri, reqBody, &response)
+		if err != nil {
+			failBatch(results, idx, err)
+			continue
+		}
+
+		ok.fillBatchPlaceResult(results, idx, response.OrderInfo)
+		for _, i := range idx {
+			orders[i].OrderID2 = results[i].OrderId
+		}
+	}
+
+	return results, nil
+}
+
+// fillBatchPlaceResult 交割合约与永续合约批量下单的返回结果按下单顺序排列
+func (ok *OKEx) fillBatchPlaceResult(results []BatchOrderResult, idx []int, orderInfo []batchOrderInfo) {
+	for j, i := range idx {
+		if j >= len(orderInfo) {
+			results[i].Err = errOrderNotInResponse
+			continue
+		}
+		results[i].OrderId = orderInfo[j].OrderId
+		results[i].Err = orderInfo[j].err()
+	}
+}
+
+func (ok *OKExFuture) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	uri := fmt.Sprintf(futureBatchCancelUri, ok.GetFutureContractId(currencyPair, contractType))
+	results := make([]BatchOrderResult, len(orderIds))
+
+	for _, b := range SplitBatch(len(orderIds), batchOrderLimit) {
+		var (
+			param struct {
+				OrderIds []string `json:"order_ids"`
+			}
+			response struct {
+				Result bool `json:"result"`
+			}
+		)
+
+		param.OrderIds = orderIds[b[0]:b[1]]
+
+		reqBody, _, _ := ok.BuildRequestBody(param)
+		err := ok.DoRequest("POST", uri, reqBody, &response)
+		if err == nil && !response.Result {
+			err = errors.New("cancel batch orders failure")
+		}
+
+		for i := b[0]; i < b[1]; i++ {
+			results[i] = BatchOrderResult{OrderId: orderIds[i], Err: err}
+		}
+	}
+
+	return results, nil
+}
+
+func (ok *OKExFuture) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	var (
+		results       = make([]BatchOrderResult, len(orders))
+		instrumentIds = make([]string, len(orders))
+	)
+
+	for i := range orders {
+		instrumentIds[i] = ok.GetFutureContractId(orders[i].Currency, orders[i].ContractName)
+	}
+
+	for _, idx := range groupBatch(instrumentIds) {
+		ok.batchAmend(fmt.Sprintf(futureBatchAmendUri, instrumentIds[idx[0]]), orders, idx, results)
+	}
+
+	return results, nil
+}
+
+// batchAmend 交割合约与永续合约的批量改单参数相同
+func (ok *OKEx) batchAmend(uri string, orders []FutureOrder, idx []int, results []BatchOrderResult) {
+	var (
+		param struct {
+			AmendData []amendOrderParam `json:"amend_data"`
+		}
+		response struct {
+			Result    bool             `json:"result"`
+			AmendInfo []batchOrderInfo `json:"amend_info"`
+		}
+	)
+
+	for _, i := range idx {
+		newPrice, newSize := formatAmendParam(orders[i].Price, orders[i].Amount)
+		param.AmendData = append(param.AmendData, amendOrderParam{
+			OrderId:  orders[i].OrderID2,
+			NewPrice: newPrice,
+			NewSize:  newSize,
+		})
+		results[i].OrderId = orders[i].OrderID2
+	}
+
+	reqBody, _, _ := ok.BuildRequestBody(param)
+	err := ok.DoRequest("POST", uri, reqBody, &response)
+	if err != nil {
+		failBatch(results, idx, err)
+		return
+	}
+
+	ret := make(map[string]batchOrderInfo, len(idx))
+	for _, r := range response.AmendInfo {
+		ret[r.OrderId] = r
+	}
+
+	for _, i := range idx {
+		r, found := ret[orders[i].OrderID2]
+		if !found {
+			results[i].Err = errOrderNotInResponse
+			continue
+		}
+		results[i].ClientOid = r.ClientOid
+		results[i].Err = r.err()
+	}
+}
+
+func (ok *OKExSwap) BatchPlaceFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	var (
+		results       = make([]BatchOrderResult, len(orders))
+		instrumentIds = make([]string, len(orders))
+	)
+
+	for i := range orders {
+		if orders[i].ClientOid == "" {
+			orders[i].ClientOid = GenerateOrderClientId(32)
+		}
+		instrumentIds[i] = ok.adaptContractType(orders[i].Currency)
+		results[i].ClientOid = orders[i].ClientOid
+	}
+
+	for _, idx := range groupBatch(instrumentIds) {
+		var (
+			param    = PlaceOrdersInfo{InstrumentId: instrumentIds[idx[0]]}
+			response struct {
+				BizWarmTips
+				OrderInfo []batchOrderInfo `json:"order_info"`
+			}
+		)
+
+		for _, i := range idx {
+			ord := orders[i]
+			param.OrderData = append(param.OrderData, &BasePlaceOrderInfo{
+				ClientOid:  ord.ClientOid,
+				Price:      fmt.Sprint(ord.Price),
+				MatchPrice: "0",
+				Type:       fmt.Sprint(ord.OType),
+				Size:       fmt.Sprint(ord.Amount),
+				OrderType:  fmt.Sprint(ord.OrderType),
+			})
+		}
+
+		reqBody, _, _ := ok.BuildRequestBody(param)
+		err := ok.DoRequest("POST", swapBatchPlaceUri, reqBody, &response)
+		if err != nil {
+			failBatch(results, idx, err)
+			continue
+		}
+
+		ok.fillBatchPlaceResult(results, idx, response.OrderInfo)
+		for _, i := range idx {
+			orders[i].OrderID2 = results[i].OrderId
+		}
+	}
+
+	return results, nil
+}
+
+func (ok *OKExSwap) BatchCancelFutureOrders(currencyPair CurrencyPair, contractType string, orderIds []string) ([]BatchOrderResult, error) {
+	uri := fmt.Sprintf(swapBatchCancelUri, ok.adaptContractType(currencyPair))
+	results := make([]BatchOrderResult, len(orderIds))
+
+	for _, b := range SplitBatch(len(orderIds), batchOrderLimit) {
+		var (
+			param struct {
+				Ids []string `json:"ids"`
+			}
+			response SwapBatchCancelOrderResult
+		)
+
+		param.Ids = orderIds[b[0]:b[1]]
+
+		reqBody, _, _ := ok.BuildRequestBody(param)
+		err := ok.DoRequest("POST", uri, reqBody, &response)
+		if err == nil && response.Result != "true" {
+			err = errors.New(fmt.Sprintf("%d:%s", response.Code, response.Message))
+		}
+
+		for i := b[0]; i < b[1]; i++ {
+			results[i] = BatchOrderResult{OrderId: orderIds[i], Err: err}
+		}
+	}
+
+	return results, nil
+}
+
+func (ok *OKExSwap) BatchAmendFutureOrders(orders []FutureOrder) ([]BatchOrderResult, error) {
+	var (
+		results       = make([]BatchOrderResult, len(orders))
+		instrumentIds = make([]string, len(orders))
+	)
+
+	for i := range orders {
+		instrumentIds[i] = ok.adaptContractType(orders[i].Currency)
+	}
+
+	for _, idx := range groupBatch(instrumentIds) {
+		ok.batchAmend(fmt.Sprintf(swapBatchAmendUri, instrumentIds[idx[0]]), orders, idx, results)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,81 @@
+package okex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOKExSpot_BatchLimitOrders(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != spotBatchPlaceUri {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NotEmpty(t, r.Header.Get("OK-ACCESS-SIGN"))
+		requests++
+
+		var param []PlaceOrderParam
+		json.NewDecoder(r.Body).Decode(&param)
+		assert.True(t, len(param) <= batchOrderLimit)
+
+		var ret []PlaceOrderResponse
+		for i, p := range param {
+			//每批最后一个订单没有返回
+			if i == len(param)-1 {
+				break
+			}
+			ret = append(ret, PlaceOrderResponse{OrderId: fmt.Sprint(requests*100 + i), ClientOid: p.ClientOid, Result: true})
+		}
+		if len(ret) > 1 {
+			ret[1] = PlaceOrderResponse{ClientOid: param[1].ClientOid, ErrorCode: "33017", ErrorMessage: "Greater than the maximum available balance"}
+		}
+		json.NewEncoder(w).Encode(map[string][]PlaceOrderResponse{"btc-usdt": ret})
+	}))
+	defer srv.Close()
+
+	api := NewOKEx(&goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Clock: goex.LocalClock}).OKExSpot
+
+	orders := make([]goex.Order, batchOrderLimit+2)
+	for i := range orders {
+		orders[i] = goex.Order{Currency: goex.BTC_USDT, Side: goex.BUY, Price: 30000, Amount: 0.01}
+	}
+
+	results, err := api.BatchLimitOrders(orders)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Len(t, results, len(orders))
+
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "100", results[0].OrderId)
+	assert.Equal(t, "100", orders[0].OrderID2)
+	assert.Equal(t, orders[0].Cid, results[0].ClientOid)
+	assert.EqualError(t, results[1].Err, "33017:Greater than the maximum available balance")
+	assert.Equal(t, errOrderNotInResponse, results[batchOrderLimit-1].Err)
+	assert.Equal(t, "200", results[batchOrderLimit].OrderId)
+	assert.Equal(t, errOrderNotInResponse, results[batchOrderLimit+1].Err)
+}
+
+func TestOKExSwap_BatchCancelFutureOrders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/swap/v3/cancel_batch_orders/BTC-USDT-SWAP" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"result":"true","client_oids":[],"ids":["1","2"],"instrument_id":"BTC-USDT-SWAP"}`))
+	}))
+	defer srv.Close()
+
+	api := NewOKEx(&goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Clock: goex.LocalClock}).OKExSwap
+	results, err := api.BatchCancelFutureOrders(goex.BTC_USDT, goex.SWAP_USDT_CONTRACT, []string{"1", "2"})
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "2", results[1].OrderId)
+}
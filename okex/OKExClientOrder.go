@@ -0,0 +1,81 @@
+package okex
+
+import (
+	"strings"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+//v3 接口的订单查询和撤单路径里 order_id 与 client_oid 可以互换
+
+func (ok *OKExSpot) LimitOrderWithCid(ord *Order) (*Order, error) {
+	ty := "limit"
+	switch ord.OrderType {
+	case ORDER_FEATURE_POST_ONLY:
+		ty = "post_only"
+	case ORDER_FEATURE_FOK:
+		ty = "fok"
+	case ORDER_FEATURE_IOC:
+		ty = "ioc"
+	}
+	return ok.PlaceOrder(ty, ord)
+}
+
+// adaptOrderNotFound 订单不存在: 币币 33014, 永续 35029, 交割合约只能按错误信息判断
+func adaptOrderNotFound(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "33014") || strings.Contains(msg, "35029") || strings.Contains(msg, "not exist") {
+		return EX_ERR_NOT_FIND_ORDER.OriginErr(msg)
+	}
+	return err
+}
+
+func (ok *OKExSpot) GetOneOrderByCid(cid string, currency CurrencyPair) (*Order, error) {
+	ord, err := ok.GetOneOrder(cid, currency)
+	return ord, adaptOrderNotFound(err)
+}
+
+func (ok *OKExSpot) CancelOrderByCid(cid string, currency CurrencyPair) (bool, error) {
+	return ok.CancelOrder(cid, currency)
+}
+
+func (ok *OKExFuture) LimitFuturesOrderWithCid(ord *FutureOrder) (*FutureOrder, error) {
+	return ok.PlaceFutureOrder2(0, ord)
+}
+
+func (ok *OKExFuture) GetFutureOrderByCid(cid string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+	ord, err := ok.GetFutureOrder(cid, currencyPair, contractType)
+	return ord, adaptOrderNotFound(err)
+}
+
+func (ok *OKExFuture) FutureCancelOrderByCid(currencyPair CurrencyPair, contractType, cid string) (bool, error) {
+	return ok.FutureCancelOrder(currencyPair, contractType, cid)
+}
+
+func (ok *OKExSwap) LimitFuturesOrderWithCid(ord *FutureOrder) (*FutureOrder, error) {
+	if ord.ClientOid == "" {
+		ord.ClientOid = GenerateOrderClientId(32)
+	}
+
+	ret, err := ok.placeFutureOrder(ord.ClientOid, ord.Currency, ord.ContractName,
+		FloatToString(ord.Price, 8), FloatToString(ord.Amount, 8), ord.OType, 0,
+		AdaptOrderFeatureToLimitOpt(ord.OrderType)...)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.OrderID2 = ret.OrderID2
+	return ret, nil
+}
+
+func (ok *OKExSwap) GetFutureOrderByCid(cid string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+	ord, err := ok.GetFutureOrder(cid, currencyPair, contractType)
+	return ord, adaptOrderNotFound(err)
+}
+
+func (ok *OKExSwap) FutureCancelOrderByCid(currencyPair CurrencyPair, contractType, cid string) (bool, error) {
+	return ok.FutureCancelOrder(currencyPair, contractType, cid)
+}
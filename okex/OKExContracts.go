@@ -0,0 +1,43 @@
+package okex
+
+import (
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// Contracts 交割合约, 合约 id(BTC-USD-210326)可以直接作为 contractType 参数
+func (ok *OKExFuture) Contracts() *ContractResolver {
+	return ok.contracts
+}
+
+func (ok *OKExFuture) loadContracts() ([]Contract, error) {
+	var response []struct {
+		FutureContractInfo
+		IsInverse          string `json:"is_inverse"`
+		SettlementCurrency string `json:"settlement_currency"`
+	}
+	err := ok.DoRequest("GET", "/api/futures/v3/instruments", "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make([]Contract, 0, len(response))
+	for _, info := range response {
+		//交割时间为交割日的 16:00(UTC+8)
+		delivery, err := time.Parse("2006-01-02", info.Delivery)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, Contract{
+			Id:             info.InstrumentID,
+			Underlying:     NewCurrencyPair2(info.UnderlyingIndex + "_" + info.QuoteCurrency),
+			SettleCurrency: NewCurrency(info.SettlementCurrency, ""),
+			ContractType:   info.Alias,
+			Expiry:         delivery.Add(8 * time.Hour),
+			Linear:         info.IsInverse == "false",
+			ContractSize:   ToFloat64(info.ContractVal),
+		})
+	}
+	return contracts, nil
+}
@@ -0,0 +1,32 @@
+package okex
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+func init() {
+	spot := func(config *APIConfig) API {
+		return NewOKEx(config)
+	}
+	future := func(config *APIConfig) FutureRestAPI {
+		return NewOKEx(config).OKExFuture
+	}
+	futuresWs := func(config *APIConfig) FuturesWsApi {
+		return NewOKExV3FuturesWs(NewOKEx(&APIConfig{HttpClient: config.HttpClient, Endpoint: config.Endpoint, Ws: config.Ws}))
+	}
+	wallet := func(config *APIConfig) WalletApi {
+		return NewOKEx(config).OKExWallet
+	}
+	//模拟盘只有 rest 交易接口, ws 没有模拟盘地址
+	testnet := []Capability{CAP_SPOT, CAP_FUTURE}
+
+	RegisterDriver(OKEX, Driver{Spot: spot, FuturesWs: futuresWs, Wallet: wallet, Testnet: testnet})
+	RegisterDriver(OKEX_V3, Driver{Spot: spot, Future: future, FuturesWs: futuresWs, Wallet: wallet, Testnet: testnet})
+	RegisterDriver(OKEX_FUTURE, Driver{Future: future, FuturesWs: futuresWs, Testnet: testnet})
+	RegisterDriver(OKEX_SWAP, Driver{
+		Future: func(config *APIConfig) FutureRestAPI {
+			return NewOKEx(config).OKExSwap
+		},
+		Testnet: testnet,
+	})
+}
@@ -0,0 +1,32 @@
+package okex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOKEx_SimulatedTrading(t *testing.T) {
+	var simulated []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		simulated = append(simulated, r.Header.Get(OK_SIMULATED_TRADING))
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	var response []interface{}
+	demo := NewOKEx(&goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Clock: goex.LocalClock, Env: goex.ENV_TESTNET})
+	assert.Nil(t, demo.DoRequest("GET", "/api/spot/v3/accounts", "", &response))
+	live := NewOKEx(&goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Clock: goex.LocalClock})
+	assert.Nil(t, live.DoRequest("GET", "/api/spot/v3/accounts", "", &response))
+
+	assert.Equal(t, []string{"1", ""}, simulated)
+
+	_, err := goex.GetDriverForEnv(goex.OKEX_SWAP, goex.CAP_FUTURE, goex.ENV_TESTNET)
+	assert.Nil(t, err)
+	_, err = goex.GetDriverForEnv(goex.OKEX_V3, goex.CAP_SPOT_WS, goex.ENV_TESTNET)
+	assert.True(t, goex.IsUnsupportedError(err))
+}
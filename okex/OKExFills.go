@@ -0,0 +1,153 @@
+package okex
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+//v3 fills 接口的手续费负数为支出, 返回的记录按时间倒序, 只能按ID翻页, since 在本地过滤
+
+type okexFill struct {
+	LedgerId     string  `json:"ledger_id"`
+	TradeId      string  `json:"trade_id"`
+	InstrumentId string  `json:"instrument_id"`
+	Currency     string  `json:"currency"` //现货
+	OrderId      string  `json:"order_id"`
+	Price        float64 `json:"price,string"`
+	Size         float64 `json:"size,string"`
+	OrderQty     float64 `json:"order_qty,string"` //合约
+	Fee          float64 `json:"fee,string"`
+	Side         string  `json:"side"`
+	OrderSide    string  `json:"order_side"` //永续: 1开多 2开空 3平多 4平空
+	ExecType     string  `json:"exec_type"`
+	Timestamp    string  `json:"timestamp"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+func (f okexFill) time() int64 {
+	ts := f.Timestamp
+	if ts == "" {
+		ts = f.CreatedAt
+	}
+	t, _ := time.Parse(time.RFC3339, ts)
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func adaptFillSide(side, orderSide string) TradeSide {
+	switch orderSide {
+	case "1", "4":
+		return BUY
+	case "2", "3":
+		return SELL
+	}
+	switch side {
+	case "buy", "long", "open_long", "close_short":
+		return BUY
+	}
+	return SELL
+}
+
+func fillsQuery(instrumentId string, limit int) string {
+	params := url.Values{}
+	params.Set("instrument_id", instrumentId)
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(limit))
+	}
+	return params.Encode()
+}
+
+// reverseFills 倒序转为升序, 同时过滤 since 之前的成交
+func reverseFills(fills []Fill, since int64) []Fill {
+	ret := make([]Fill, 0, len(fills))
+	for i := len(fills) - 1; i >= 0; i-- {
+		if fills[i].Time >= since {
+			ret = append(ret, fills[i])
+		}
+	}
+	return ret
+}
+
+// GetMyTrades 现货每笔成交返回两条记录(base 和 quote 各一条), 按 trade_id 合并, 手续费在收入币种的那条记录上
+func (ok *OKExSpot) GetMyTrades(pair CurrencyPair, since int64, limit int) ([]Fill, error) {
+	var response []okexFill
+	instrumentId := pair.AdaptUsdToUsdt().ToSymbol("-")
+	err := ok.OKEx.DoRequest("GET", "/api/spot/v3/fills?"+fillsQuery(instrumentId, limit), "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fills []Fill
+		index = make(map[string]int, len(response)/2)
+	)
+	for _, f := range response {
+		i, exist := index[f.TradeId]
+		if !exist {
+			i = len(fills)
+			index[f.TradeId] = i
+			fills = append(fills, Fill{
+				TradeId: f.TradeId,
+				OrderId: f.OrderId,
+				Pair:    pair,
+				Price:   f.Price,
+				IsMaker: f.ExecType == "M",
+				Time:    f.time(),
+			})
+		}
+
+		if f.Currency == pair.CurrencyA.Symbol {
+			fills[i].Amount = f.Size
+			fills[i].Side = adaptFillSide(f.Side, "")
+		}
+		if f.Fee != 0 {
+			fills[i].Fee = -f.Fee
+			fills[i].FeeCurrency = f.Currency
+		}
+	}
+
+	return reverseFills(fills, since), nil
+}
+
+func (ok *OKEx) getFutureFills(uri, instrumentId string, pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	var response []okexFill
+	err := ok.DoRequest("GET", uri+"?"+fillsQuery(instrumentId, limit), "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	//币本位合约手续费为 base 币种, U本位为 USDT
+	feeCurrency := pair.CurrencyA.Symbol
+	if pair.CurrencyB.Symbol == USDT.Symbol {
+		feeCurrency = USDT.Symbol
+	}
+
+	fills := make([]Fill, 0, len(response))
+	for _, f := range response {
+		fills = append(fills, Fill{
+			TradeId:      f.TradeId,
+			OrderId:      f.OrderId,
+			Pair:         pair,
+			ContractName: contractType,
+			Side:         adaptFillSide(f.Side, f.OrderSide),
+			Price:        f.Price,
+			Amount:       f.OrderQty,
+			Fee:          -f.Fee,
+			FeeCurrency:  feeCurrency,
+			IsMaker:      f.ExecType == "M",
+			Time:         f.time(),
+		})
+	}
+
+	return reverseFills(fills, since), nil
+}
+
+func (ok *OKExFuture) GetFutureFills(pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	return ok.OKEx.getFutureFills("/api/futures/v3/fills", ok.GetFutureContractId(pair, contractType), pair, contractType, since, limit)
+}
+
+func (ok *OKExSwap) GetFutureFills(pair CurrencyPair, contractType string, since int64, limit int) ([]Fill, error) {
+	return ok.OKEx.getFutureFills("/api/swap/v3/fills", ok.adaptContractType(pair), pair, contractType, since, limit)
+}
@@ -0,0 +1,72 @@
+package okex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOKEx_Fills(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/spot/v3/fills":
+			assert.Equal(t, "BTC-USDT", r.URL.Query().Get("instrument_id"))
+			assert.Equal(t, "10", r.URL.Query().Get("limit"))
+			w.Write([]byte(`[
+{"ledger_id":"3963052724","trade_id":"18551602","instrument_id":"BTC-USDT","currency":"BTC","order_id":"2482659399697409","price":"3900","size":"0.001","fee":"-0.000001","side":"buy","exec_type":"M","timestamp":"2019-03-15T02:53:56.000Z","created_at":"2019-03-15T02:53:56.000Z"},
+{"ledger_id":"3963052723","trade_id":"18551602","instrument_id":"BTC-USDT","currency":"USDT","order_id":"2482659399697409","price":"3900","size":"3.9","fee":"0","side":"sell","exec_type":"M","timestamp":"2019-03-15T02:53:56.000Z","created_at":"2019-03-15T02:53:56.000Z"},
+{"ledger_id":"3963052722","trade_id":"18551601","instrument_id":"BTC-USDT","currency":"BTC","order_id":"2482659399697408","price":"3888.6","size":"0.002","fee":"0","side":"sell","exec_type":"T","timestamp":"2019-03-15T02:52:56.000Z","created_at":"2019-03-15T02:52:56.000Z"},
+{"ledger_id":"3963052721","trade_id":"18551601","instrument_id":"BTC-USDT","currency":"USDT","order_id":"2482659399697408","price":"3888.6","size":"7.7772","fee":"-0.0077772","side":"buy","exec_type":"T","timestamp":"2019-03-15T02:52:56.000Z","created_at":"2019-03-15T02:52:56.000Z"}]`))
+		case "/api/swap/v3/fills":
+			assert.Equal(t, "BTC-USD-SWAP", r.URL.Query().Get("instrument_id"))
+			w.Write([]byte(`[
+{"trade_id":"197429674631450625","instrument_id":"BTC-USD-SWAP","order_id":"6a-7-54d663a28-0","price":"3633.9","order_qty":"2","fee":"-0.00000275","timestamp":"2019-03-21T04:41:58.123Z","exec_type":"T","side":"short","order_side":"3"},
+{"trade_id":"197429674631450624","instrument_id":"BTC-USD-SWAP","order_id":"6a-7-54d663a28-1","price":"3632.1","order_qty":"3","fee":"0.0000004","timestamp":"2019-03-21T04:40:58.123Z","exec_type":"M","side":"long","order_side":"1"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ok := NewOKEx(&goex.APIConfig{HttpClient: http.DefaultClient, Endpoint: srv.URL, Clock: goex.LocalClock})
+
+	fills, err := ok.OKExSpot.GetMyTrades(goex.BTC_USDT, 0, 10)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 2)
+	//倒序转为升序, base 和 quote 两条记录合并为一条
+	assert.Equal(t, "18551601", fills[0].TradeId)
+	assert.Equal(t, "2482659399697408", fills[0].OrderId)
+	assert.Equal(t, goex.SELL, fills[0].Side)
+	assert.Equal(t, 0.002, fills[0].Amount)
+	assert.Equal(t, 3888.6, fills[0].Price)
+	assert.Equal(t, 0.0077772, fills[0].Fee)
+	assert.Equal(t, "USDT", fills[0].FeeCurrency)
+	assert.False(t, fills[0].IsMaker)
+	assert.Equal(t, int64(1552618376000), fills[0].Time)
+	assert.Equal(t, goex.BUY, fills[1].Side)
+	assert.Equal(t, 0.001, fills[1].Amount)
+	assert.Equal(t, 0.000001, fills[1].Fee)
+	assert.Equal(t, "BTC", fills[1].FeeCurrency)
+	assert.True(t, fills[1].IsMaker)
+
+	fills, err = ok.OKExSpot.GetMyTrades(goex.BTC_USDT, 1552618436000, 10)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 1)
+	assert.Equal(t, "18551602", fills[0].TradeId)
+
+	fills, err = ok.OKExSwap.GetFutureFills(goex.BTC_USD, goex.SWAP_CONTRACT, 0, 0)
+	assert.Nil(t, err)
+	assert.Len(t, fills, 2)
+	assert.Equal(t, "197429674631450624", fills[0].TradeId)
+	assert.Equal(t, goex.BUY, fills[0].Side)
+	assert.Equal(t, 3.0, fills[0].Amount)
+	assert.Equal(t, -0.0000004, fills[0].Fee)
+	assert.True(t, fills[0].IsMaker)
+	assert.Equal(t, goex.SELL, fills[1].Side)
+	assert.Equal(t, 0.00000275, fills[1].Fee)
+	assert.Equal(t, "BTC", fills[1].FeeCurrency)
+	assert.Equal(t, goex.SWAP_CONTRACT, fills[1].ContractName)
+}
@@ -34,6 +34,7 @@ type OKExFuture struct {
 	*OKEx
 	sync.Locker
 	allContractInfo AllFutureContractInfo
+	contracts       *ContractResolver
 }
 
 func (ok *OKExFuture) GetExchangeName() string {
@@ -112,38 +113,12 @@ func (ok *OKExFuture) GetFutureContractId(pair CurrencyPair, contractAlias strin
 		return contractAlias
 	}
 
-	now := time.Now()
-	hour := now.Hour()
-	minute := now.Minute()
-
-	if ok.allContractInfo.uTime.IsZero() || (ok.allContractInfo.uTime.Hour() < 16 && hour == 16 && minute <= 11) {
-		ok.Lock()
-		defer ok.Unlock()
-
-		contractInfo, err := ok.GetAllFutureContractInfo()
-		if err == nil {
-			ok.allContractInfo.uTime = time.Now()
-			ok.allContractInfo.contractInfos = contractInfo
-		} else {
-			time.Sleep(120 * time.Millisecond) //retry
-			contractInfo, err = ok.GetAllFutureContractInfo()
-			if err != nil {
-				logger.Errorf(fmt.Sprintf("Get Futures Contract Id Error [%s] ???", err.Error()))
-			}
-		}
-	}
-
-	contractId := ""
-	for _, itm := range ok.allContractInfo.contractInfos {
-		if itm.Alias == contractAlias &&
-			itm.UnderlyingIndex == pair.CurrencyA.Symbol &&
-			itm.QuoteCurrency == pair.CurrencyB.Symbol {
-			contractId = itm.InstrumentID
-			break
-		}
+	contract, err := ok.contracts.Resolve(pair, contractAlias)
+	if err != nil {
+		logger.Errorf("Get Futures Contract Id Error [%s]", err.Error())
+		return ""
 	}
-
-	return contractId
+	return contract.Id
 }
 
 type tickerResponse struct {
@@ -372,7 +347,10 @@ func (ok *OKExFuture) PlaceFutureOrder2(matchPrice int, ord *FutureOrder) (*Futu
 		return nil, errors.New("ord param is nil")
 	}
 	param.InstrumentId = ok.GetFutureContractId(ord.Currency, ord.ContractName)
-	param.ClientOid = GenerateOrderClientId(32)
+	if ord.ClientOid == "" {
+		ord.ClientOid = GenerateOrderClientId(32)
+	}
+	param.ClientOid = ord.ClientOid
 	param.Type = ord.OType
 	param.OrderType = ord.OrderType
 	param.Price = ok.normalizePrice(ord.Price, ord.Currency)
@@ -458,36 +436,42 @@ func (ok *OKExFuture) FutureCancelOrder(currencyPair CurrencyPair, contractType,
 	return response.Result, nil
 }
 
+type futurePositionResponse struct {
+	Result     bool   `json:"result"`
+	MarginMode string `json:"margin_mode"`
+	Holding    []struct {
+		InstrumentId         string    `json:"instrument_id"`
+		LongQty              float64   `json:"long_qty,string"` //多
+		LongAvailQty         float64   `json:"long_avail_qty,string"`
+		LongAvgCost          float64   `json:"long_avg_cost,string"`
+		LongSettlementPrice  float64   `json:"long_settlement_price,string"`
+		LongMargin           float64   `json:"long_margin,string"`
+		LongPnl              float64   `json:"long_pnl,string"`
+		LongPnlRatio         float64   `json:"long_pnl_ratio,string"`
+		LongUnrealisedPnl    float64   `json:"long_unrealised_pnl,string"`
+		RealisedPnl          float64   `json:"realised_pnl,string"`
+		Leverage             float64   `json:"leverage,string"`
+		ShortQty             float64   `json:"short_qty,string"`
+		ShortAvailQty        float64   `json:"short_avail_qty,string"`
+		ShortAvgCost         float64   `json:"short_avg_cost,string"`
+		ShortSettlementPrice float64   `json:"short_settlement_price,string"`
+		ShortMargin          float64   `json:"short_margin,string"`
+		ShortPnl             float64   `json:"short_pnl,string"`
+		ShortPnlRatio        float64   `json:"short_pnl_ratio,string"`
+		ShortUnrealisedPnl   float64   `json:"short_unrealised_pnl,string"`
+		LiquidationPrice     float64   `json:"liquidation_price,string"`
+		LongLiquiPrice       string    `json:"long_liqui_price"` //逐仓
+		ShortLiquiPrice      string    `json:"short_liqui_price"`
+		LongLeverage         string    `json:"long_leverage"`
+		ShortLeverage        string    `json:"short_leverage"`
+		CreatedAt            time.Time `json:"created_at,string"`
+		UpdatedAt            time.Time `json:"updated_at"`
+	}
+}
+
 func (ok *OKExFuture) GetFuturePosition(currencyPair CurrencyPair, contractType string) ([]FuturePosition, error) {
 	urlPath := fmt.Sprintf("/api/futures/v3/%s/position", ok.GetFutureContractId(currencyPair, contractType))
-	var response struct {
-		Result     bool   `json:"result"`
-		MarginMode string `json:"margin_mode"`
-		Holding    []struct {
-			InstrumentId         string    `json:"instrument_id"`
-			LongQty              float64   `json:"long_qty,string"` //多
-			LongAvailQty         float64   `json:"long_avail_qty,string"`
-			LongAvgCost          float64   `json:"long_avg_cost,string"`
-			LongSettlementPrice  float64   `json:"long_settlement_price,string"`
-			LongMargin           float64   `json:"long_margin,string"`
-			LongPnl              float64   `json:"long_pnl,string"`
-			LongPnlRatio         float64   `json:"long_pnl_ratio,string"`
-			LongUnrealisedPnl    float64   `json:"long_unrealised_pnl,string"`
-			RealisedPnl          float64   `json:"realised_pnl,string"`
-			Leverage             float64   `json:"leverage,string"`
-			ShortQty             float64   `json:"short_qty,string"`
-			ShortAvailQty        float64   `json:"short_avail_qty,string"`
-			ShortAvgCost         float64   `json:"short_avg_cost,string"`
-			ShortSettlementPrice float64   `json:"short_settlement_price,string"`
-			ShortMargin          float64   `json:"short_margin,string"`
-			ShortPnl             float64   `json:"short_pnl,string"`
-			ShortPnlRatio        float64   `json:"short_pnl_ratio,string"`
-			ShortUnrealisedPnl   float64   `json:"short_unrealised_pnl,string"`
-			LiquidationPrice     float64   `json:"liquidation_price,string"`
-			CreatedAt            time.Time `json:"created_at,string"`
-			UpdatedAt            time.Time `json:"updated_at"`
-		}
-	}
+	var response futurePositionResponse
 	err := ok.DoRequest("GET", urlPath, "", &response)
 	if err != nil {
 		return nil, err
@@ -639,7 +623,9 @@ func (ok *OKExFuture) GetUnfinishFutureOrders(currencyPair CurrencyPair, contrac
 	return ords, nil
 }
 
-func (ok *OKExFuture) GetFee() (float64, error) { panic("") }
+func (ok *OKExFuture) GetFee() (float64, error) {
+	return FutureTakerFee(ok, BTC_USD, QUARTER_CONTRACT)
+}
 
 func (ok *OKExFuture) GetContractValue(currencyPair CurrencyPair) (float64, error) {
 	//for _, info := range ok.allContractInfo.contractInfos {
@@ -771,3 +757,159 @@ func (ok *OKExFuture) MarketCloseAllPosition(currency CurrencyPair, contract str
 
 	return true, nil
 }
+
+//委托策略下单 algo_type 1:限价 2:市场价；触发价格类型，默认是限价；为市场价时，委托价格不必填；
+func (ok *OKExFuture) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error) {
+	urlPath := "/api/futures/v3/order_algo"
+	var param struct {
+		InstrumentId string `json:"instrument_id"`
+		Type         int    `json:"type"`
+		OrderType    int    `json:"order_type"` //1：计划委托 2：跟踪委托 3：冰山委托 4：时间加权 5：止盈止损
+		Size         string `json:"size"`
+		TriggerPrice string `json:"trigger_price"`
+		AlgoPrice    string `json:"algo_price"`
+		AlgoType     string `json:"algo_type"`
+	}
+
+	var response struct {
+		Result       bool   `json:"result"`
+		ErrorMessage string `json:"error_message"`
+		ErrorCode    string `json:"error_code"`
+		AlgoId       string `json:"algo_id"`
+		InstrumentId string `json:"instrument_id"`
+	}
+
+	if ord == nil {
+		return nil, errors.New("ord param is nil")
+	}
+
+	param.InstrumentId = ok.GetFutureContractId(ord.Currency, ord.ContractName)
+	param.Type = ord.OType
+	param.OrderType = ord.OrderType
+	if param.OrderType == 0 {
+		param.OrderType = 1
+	}
+	param.AlgoType = fmt.Sprint(ord.AlgoType)
+	param.TriggerPrice = ok.normalizePrice(ord.TriggerPrice, ord.Currency)
+	param.AlgoPrice = ok.normalizePrice(ord.Price, ord.Currency)
+	param.Size = fmt.Sprint(ord.Amount)
+
+	reqBody, _, _ := ok.BuildRequestBody(param)
+	err := ok.DoRequest("POST", urlPath, reqBody, &response)
+	if err != nil {
+		return ord, err
+	}
+
+	if response.AlgoId == "" {
+		return ord, errors.New(response.ErrorMessage)
+	}
+
+	ord.OrderID2 = response.AlgoId
+	ord.OrderTime = time.Now().UnixNano() / int64(time.Millisecond)
+
+	return ord, nil
+}
+
+//委托策略撤单
+func (ok *OKExFuture) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error) {
+	urlPath := "/api/futures/v3/cancel_algos"
+	if len(orderId) == 0 {
+		return false, errors.New("invalid order id")
+	}
+
+	if len(contractType) == 0 {
+		return false, errors.New("contract type is required")
+	}
+
+	var param struct {
+		InstrumentId string   `json:"instrument_id"`
+		AlgoIds      []string `json:"algo_ids"`
+		OrderType    string   `json:"order_type"`
+	}
+
+	var response struct {
+		Result       bool   `json:"result"`
+		ErrorMessage string `json:"error_message"`
+		ErrorCode    string `json:"error_code"`
+		InstrumentId string `json:"instrument_id"`
+	}
+
+	param.InstrumentId = ok.GetFutureContractId(currencyPair, contractType[0])
+	param.AlgoIds = orderId
+	param.OrderType = "1"
+
+	reqBody, _, _ := ok.BuildRequestBody(param)
+	err := ok.DoRequest("POST", urlPath, reqBody, &response)
+	if err != nil {
+		return false, err
+	}
+
+	if !response.Result {
+		return false, errors.New(response.ErrorMessage)
+	}
+
+	return true, nil
+}
+
+//获取委托单列表, status和algo_id必填且只能填其一
+func (ok *OKExFuture) GetFutureAlgoOrders(algoId string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error) {
+	if len(contractType) == 0 {
+		return nil, errors.New("contract type is required")
+	}
+
+	params := url.Values{}
+	params.Set("order_type", "1")
+	if algoId != "" {
+		params.Set("algo_id", algoId)
+	} else if status != "" {
+		params.Set("status", status)
+	} else {
+		return nil, errors.New("status or algo_id is needed")
+	}
+
+	urlPath := fmt.Sprintf("/api/futures/v3/order_algo/%s?%s", ok.GetFutureContractId(currencyPair, contractType[0]), params.Encode())
+
+	var response struct {
+		OrderStrategyVOS []struct {
+			AlgoId       string `json:"algo_id"`
+			AlgoPrice    string `json:"algo_price"`
+			InstrumentId string `json:"instrument_id"`
+			Leverage     string `json:"leverage"`
+			OrderType    string `json:"order_type"`
+			RealAmount   string `json:"real_amount"`
+			RealPrice    string `json:"real_price"`
+			Size         string `json:"size"`
+			Status       string `json:"status"`
+			Timestamp    string `json:"timestamp"`
+			TriggerPrice string `json:"trigger_price"`
+			Type         string `json:"type"`
+		} `json:"orderStrategyVOS"`
+	}
+
+	err := ok.DoRequest("GET", urlPath, "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []FutureOrder
+	for _, info := range response.OrderStrategyVOS {
+		oTime, _ := time.Parse(time.RFC3339, info.Timestamp)
+		orders = append(orders, FutureOrder{
+			OrderID2:     info.AlgoId,
+			Price:        ToFloat64(info.AlgoPrice),
+			Amount:       ToFloat64(info.Size),
+			AvgPrice:     ToFloat64(info.RealPrice),
+			DealAmount:   ToFloat64(info.RealAmount),
+			OrderTime:    oTime.UnixNano() / int64(time.Millisecond),
+			Status:       ok.adaptAlgoOrderStatus(info.Status),
+			Currency:     currencyPair,
+			OrderType:    ToInt(info.OrderType),
+			OType:        ToInt(info.Type),
+			LeverRate:    ToFloat64(info.Leverage),
+			TriggerPrice: ToFloat64(info.TriggerPrice),
+			ContractName: info.InstrumentId,
+		})
+	}
+
+	return orders, nil
+}
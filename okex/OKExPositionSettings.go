@@ -0,0 +1,240 @@
+package okex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// okex v3 合约只支持双向持仓; 交割合约的保证金模式按标的(underlying)设置, 永续按合约设置
+
+func adaptOKExMarginMode(mode string) MarginMode {
+	if mode == "fixed" {
+		return MARGIN_MODE_ISOLATED
+	}
+	return MARGIN_MODE_CROSSED
+}
+
+func adaptPositionSideToDirection(side PositionSide) (string, error) {
+	switch side {
+	case POSITION_SIDE_LONG:
+		return "long", nil
+	case POSITION_SIDE_SHORT:
+		return "short", nil
+	}
+	return "", errors.New("okex only support hedge position mode, side must be long or short")
+}
+
+func (ok *OKEx) adjustMargin(uri, instrumentId string, side PositionSide, amount float64) error {
+	direction, err := adaptPositionSideToDirection(side)
+	if err != nil {
+		return err
+	}
+
+	if amount == 0 {
+		return errors.New("amount is zero")
+	}
+
+	param := map[string]string{
+		"instrument_id": instrumentId,
+		"direction":     direction,
+		"amount":        fmt.Sprint(math.Abs(amount)),
+		"type":          "1",
+	}
+	if amount < 0 {
+		param["type"] = "2"
+	}
+
+	reqBody, _, _ := ok.BuildRequestBody(param)
+
+	var resp struct {
+		BizWarmTips
+		Result interface{} `json:"result"` //部分接口为字符串
+	}
+	err = ok.DoRequest("POST", uri, reqBody, &resp)
+	if err != nil {
+		return err
+	}
+
+	if fmt.Sprint(resp.Result) != "true" {
+		return errors.New(resp.Message)
+	}
+
+	return nil
+}
+
+func getOKExPositionMode() (PositionMode, error) {
+	return POSITION_MODE_HEDGE, nil
+}
+
+func setOKExPositionMode(mode PositionMode) error {
+	if mode != POSITION_MODE_HEDGE {
+		return EX_ERR_NOT_SUPPORT
+	}
+	return nil
+}
+
+func (ok *OKExSwap) GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	margin, err := ok.GetMarginLevel(currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FutureLeverage{
+		Pair:          currencyPair,
+		ContractType:  SWAP_CONTRACT,
+		MarginMode:    adaptOKExMarginMode(margin.MarginMode),
+		LongLeverage:  margin.LongLeverage,
+		ShortLeverage: margin.ShortLeverage,
+	}, nil
+}
+
+// setLeverage 全仓 side=3, 逐仓需多空(side=1,2)分别设置
+func (ok *OKExSwap) setLeverage(currencyPair CurrencyPair, leverage float64, mode MarginMode) error {
+	if mode == MARGIN_MODE_CROSSED {
+		_, err := ok.SetMarginLevel(currencyPair, int(leverage), 3)
+		return err
+	}
+
+	for _, side := range []int{1, 2} {
+		_, err := ok.SetMarginLevel(currencyPair, int(leverage), side)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ok *OKExSwap) SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error {
+	lev, err := ok.GetLeverage(currencyPair, contractType)
+	if err != nil {
+		return err
+	}
+	return ok.setLeverage(currencyPair, leverage, lev.MarginMode)
+}
+
+// SetMarginMode 永续通过设置杠杆切换保证金模式, 沿用当前的杠杆倍数
+func (ok *OKExSwap) SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error {
+	lev, err := ok.GetLeverage(currencyPair, contractType)
+	if err != nil {
+		return err
+	}
+	return ok.setLeverage(currencyPair, lev.LongLeverage, mode)
+}
+
+func (ok *OKExSwap) GetPositionMode(contractType string) (PositionMode, error) {
+	return getOKExPositionMode()
+}
+
+func (ok *OKExSwap) SetPositionMode(contractType string, mode PositionMode) error {
+	return setOKExPositionMode(mode)
+}
+
+func (ok *OKExSwap) AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error {
+	return ok.adjustMargin("/api/swap/v3/position/margin", ok.adaptContractType(currencyPair), side, amount)
+}
+
+func (ok *OKExFuture) GetLeverage(currencyPair CurrencyPair, contractType string) (*FutureLeverage, error) {
+	var resp map[string]interface{}
+	uri := fmt.Sprintf("/api/futures/v3/accounts/%s/leverage", currencyPair.ToSymbol("-"))
+	err := ok.DoRequest("GET", uri, "", &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	lev := &FutureLeverage{
+		Pair:         currencyPair,
+		ContractType: contractType,
+		MarginMode:   adaptOKExMarginMode(fmt.Sprint(resp["margin_mode"])),
+	}
+
+	//全仓: {"margin_mode":"crossed","leverage":10}, 逐仓: {"margin_mode":"fixed","BTC-USD-200925":{"long_leverage":10,"short_leverage":10}}
+	if lev.MarginMode == MARGIN_MODE_CROSSED {
+		lev.LongLeverage = ToFloat64(resp["leverage"])
+		lev.ShortLeverage = lev.LongLeverage
+		return lev, nil
+	}
+
+	contract, isOk := resp[ok.GetFutureContractId(currencyPair, contractType)].(map[string]interface{})
+	if !isOk {
+		return nil, errors.New("not found leverage of " + contractType)
+	}
+	lev.LongLeverage = ToFloat64(contract["long_leverage"])
+	lev.ShortLeverage = ToFloat64(contract["short_leverage"])
+
+	return lev, nil
+}
+
+func (ok *OKExFuture) SetLeverage(currencyPair CurrencyPair, contractType string, leverage float64) error {
+	lev, err := ok.GetLeverage(currencyPair, contractType)
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("/api/futures/v3/accounts/%s/leverage", currencyPair.ToSymbol("-"))
+	params := []map[string]string{{"leverage": strconv.Itoa(int(leverage))}}
+	if lev.MarginMode == MARGIN_MODE_ISOLATED {
+		contractId := ok.GetFutureContractId(currencyPair, contractType)
+		params = []map[string]string{
+			{"instrument_id": contractId, "direction": "long", "leverage": strconv.Itoa(int(leverage))},
+			{"instrument_id": contractId, "direction": "short", "leverage": strconv.Itoa(int(leverage))},
+		}
+	}
+
+	for _, param := range params {
+		reqBody, _, _ := ok.BuildRequestBody(param)
+		var resp struct {
+			BizWarmTips
+			Result interface{} `json:"result"`
+		}
+		err = ok.DoRequest("POST", uri, reqBody, &resp)
+		if err != nil {
+			return err
+		}
+		if fmt.Sprint(resp.Result) != "true" {
+			return errors.New(resp.Message)
+		}
+	}
+
+	return nil
+}
+
+func (ok *OKExFuture) SetMarginMode(currencyPair CurrencyPair, contractType string, mode MarginMode) error {
+	param := map[string]string{"underlying": currencyPair.ToSymbol("-"), "margin_mode": "crossed"}
+	if mode == MARGIN_MODE_ISOLATED {
+		param["margin_mode"] = "fixed"
+	}
+
+	reqBody, _, _ := ok.BuildRequestBody(param)
+
+	var resp struct {
+		BizWarmTips
+		Result interface{} `json:"result"`
+	}
+	err := ok.DoRequest("POST", "/api/futures/v3/accounts/margin_mode", reqBody, &resp)
+	if err != nil {
+		return err
+	}
+
+	if fmt.Sprint(resp.Result) != "true" {
+		return errors.New(resp.Message)
+	}
+
+	return nil
+}
+
+func (ok *OKExFuture) GetPositionMode(contractType string) (PositionMode, error) {
+	return getOKExPositionMode()
+}
+
+func (ok *OKExFuture) SetPositionMode(contractType string, mode PositionMode) error {
+	return setOKExPositionMode(mode)
+}
+
+func (ok *OKExFuture) AdjustMargin(currencyPair CurrencyPair, contractType string, side PositionSide, amount float64) error {
+	return ok.adjustMargin("/api/futures/v3/position/margin", ok.GetFutureContractId(currencyPair, contractType), side, amount)
+}
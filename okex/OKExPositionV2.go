@@ -0,0 +1,119 @@
+package okex
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// okex v3 合约只支持双向持仓, 多空各一条记录
+
+func toMillisecond(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func (ok *OKExSwap) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	var resp SwapPosition
+	err := ok.DoRequest("GET", fmt.Sprintf(GET_POSITION, ok.adaptContractType(currencyPair)), "", &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []FuturePositionV2
+	for _, h := range resp.Holding {
+		if h.Position == 0 {
+			continue
+		}
+
+		side := POSITION_SIDE_LONG
+		if h.Side == "short" {
+			side = POSITION_SIDE_SHORT
+		}
+
+		ts, _ := time.Parse(time.RFC3339, h.Timestamp)
+
+		positions = append(positions, FuturePositionV2{
+			Pair:             currencyPair,
+			ContractType:     contractType,
+			ContractId:       h.InstrumentId,
+			Side:             side,
+			Amount:           h.Position,
+			AvailAmount:      h.AvailPosition,
+			AvgPrice:         h.AvgCost,
+			MarginMode:       adaptOKExMarginMode(resp.MarginMode),
+			Leverage:         ToFloat64(h.Leverage),
+			InitialMargin:    ToFloat64(h.Margin),
+			LiquidationPrice: h.LiquidationPrice,
+			UnrealizedPnl:    ToFloat64(h.UnrealizedPnl),
+			RealizedPnl:      h.RealizedPnl,
+			UpdateTime:       toMillisecond(ts),
+		})
+	}
+
+	return positions, nil
+}
+
+func (ok *OKExFuture) GetFuturePositionV2(currencyPair CurrencyPair, contractType string) ([]FuturePositionV2, error) {
+	var response futurePositionResponse
+	urlPath := fmt.Sprintf("/api/futures/v3/%s/position", ok.GetFutureContractId(currencyPair, contractType))
+	err := ok.DoRequest("GET", urlPath, "", &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Result {
+		return nil, errors.New("unknown error")
+	}
+
+	marginMode := adaptOKExMarginMode(response.MarginMode)
+
+	var positions []FuturePositionV2
+	for _, h := range response.Holding {
+		long := FuturePositionV2{
+			Pair:             currencyPair,
+			ContractType:     contractType,
+			ContractId:       h.InstrumentId,
+			Side:             POSITION_SIDE_LONG,
+			Amount:           h.LongQty,
+			AvailAmount:      h.LongAvailQty,
+			AvgPrice:         h.LongAvgCost,
+			MarginMode:       marginMode,
+			Leverage:         h.Leverage,
+			InitialMargin:    h.LongMargin,
+			LiquidationPrice: h.LiquidationPrice,
+			UnrealizedPnl:    h.LongUnrealisedPnl,
+			CreateTime:       toMillisecond(h.CreatedAt),
+			UpdateTime:       toMillisecond(h.UpdatedAt),
+		}
+
+		short := long
+		short.Side = POSITION_SIDE_SHORT
+		short.Amount = h.ShortQty
+		short.AvailAmount = h.ShortAvailQty
+		short.AvgPrice = h.ShortAvgCost
+		short.InitialMargin = h.ShortMargin
+		short.UnrealizedPnl = h.ShortUnrealisedPnl
+
+		//逐仓多空分别有杠杆和强平价
+		if marginMode == MARGIN_MODE_ISOLATED {
+			long.Leverage = ToFloat64(h.LongLeverage)
+			long.LiquidationPrice = ToFloat64(h.LongLiquiPrice)
+			short.Leverage = ToFloat64(h.ShortLeverage)
+			short.LiquidationPrice = ToFloat64(h.ShortLiquiPrice)
+		}
+
+		if long.Amount > 0 {
+			positions = append(positions, long)
+		}
+		if short.Amount > 0 {
+			positions = append(positions, short)
+		}
+	}
+
+	return positions, nil
+}
@@ -111,8 +111,11 @@ func (ok *OKExSpot) BatchPlaceOrders(orders []Order) ([]PlaceOrderResponse, erro
 
 func (ok *OKExSpot) PlaceOrder(ty string, ord *Order) (*Order, error) {
 	urlPath := "/api/spot/v3/orders"
+	if ord.Cid == "" {
+		ord.Cid = GenerateOrderClientId(32)
+	}
 	param := PlaceOrderParam{
-		ClientOid:    GenerateOrderClientId(32),
+		ClientOid:    ord.Cid,
 		InstrumentId: ord.Currency.AdaptUsdToUsdt().ToLower().ToSymbol("-"),
 	}
 
@@ -537,8 +540,14 @@ func (ok *OKExSpot) GetAllCurrencyPair() ([]CurrencyPair, error) {
 	return currencyPairs, nil
 }
 
+// GetTimestamp 服务器时间(毫秒), 公共接口不签名
 func (ok *OKExSpot) GetTimestamp() (int64, error) {
-	return 0, errors.NotImplemented("no implemented")
+	respmap, err := HttpGet(ok.config.HttpClient, ok.config.Endpoint+"/api/general/v3/time")
+	if err != nil {
+		return 0, err
+	}
+	//epoch: 1420674445.201
+	return int64(ToFloat64(respmap["epoch"])*1000 + 0.5), nil
 }
 
 type OKExSpotSymbol struct {
@@ -21,6 +21,7 @@ const (
 	OK_ACCESS_SIGN       = "OK-ACCESS-SIGN"
 	OK_ACCESS_TIMESTAMP  = "OK-ACCESS-TIMESTAMP"
 	OK_ACCESS_PASSPHRASE = "OK-ACCESS-PASSPHRASE"
+	OK_SIMULATED_TRADING = "x-simulated-trading" //模拟盘
 
 	/**
 	  paging params
@@ -95,7 +96,9 @@ type OKExSwap struct {
 }
 
 func NewOKExSwap(config *APIConfig) *OKExSwap {
-	return &OKExSwap{OKEx: &OKEx{config: config}, config: config}
+	okex := &OKEx{config: config, log: config.GetLogger()}
+	okex.setClock()
+	return &OKExSwap{OKEx: okex, config: config}
 }
 
 func (ok *OKExSwap) GetExchangeName() string {
@@ -276,7 +279,10 @@ func (ok *OKExSwap) PlaceFutureOrder(currencyPair CurrencyPair, contractType, pr
 }
 
 func (ok *OKExSwap) PlaceFutureOrder2(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
-	cid := GenerateOrderClientId(32)
+	return ok.placeFutureOrder(GenerateOrderClientId(32), currencyPair, contractType, price, amount, openType, matchPrice, opt...)
+}
+
+func (ok *OKExSwap) placeFutureOrder(cid string, currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
 	param := PlaceOrderInfo{
 		BasePlaceOrderInfo{
 			ClientOid:  cid,
@@ -548,7 +554,7 @@ func (ok *OKExSwap) GetContractValue(currencyPair CurrencyPair) (float64, error)
 }
 
 func (ok *OKExSwap) GetFee() (float64, error) {
-	panic("not support")
+	return FutureTakerFee(ok, BTC_USDT, SWAP_USDT_CONTRACT)
 }
 
 func (ok *OKExSwap) GetFutureEstimatedPrice(currencyPair CurrencyPair) (float64, error) {
@@ -760,7 +766,7 @@ func (ok *OKExSwap) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error)
 	var param struct {
 		InstrumentId string `json:"instrument_id"`
 		Type         int    `json:"type"`
-		OrderType    int    `json:"order_type"` //1：止盈止损 2：跟踪委托 3：冰山委托 4：时间加权
+		OrderType    int    `json:"order_type"` //1：计划委托 2：跟踪委托 3：冰山委托 4：时间加权 5：止盈止损
 		Size         string `json:"size"`
 		TriggerPrice string `json:"trigger_price"`
 		AlgoPrice    string `json:"algo_price"`
@@ -787,6 +793,9 @@ func (ok *OKExSwap) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error)
 	param.InstrumentId = ok.adaptContractType(ord.Currency)
 	param.Type = ord.OType
 	param.OrderType = ord.OrderType
+	if param.OrderType == 0 {
+		param.OrderType = 1
+	}
 	param.AlgoType = fmt.Sprint(ord.AlgoType)
 	param.TriggerPrice = fmt.Sprint(ord.TriggerPrice)
 	param.AlgoPrice = fmt.Sprint(ToFloat64(ord.Price))
@@ -806,7 +815,7 @@ func (ok *OKExSwap) PlaceFutureAlgoOrder(ord *FutureOrder) (*FutureOrder, error)
 }
 
 //委托策略撤单
-func (ok *OKExSwap) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string) (bool, error) {
+func (ok *OKExSwap) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []string, contractType ...string) (bool, error) {
 	if len(orderId) == 0 {
 		return false, errors.New("invalid order id")
 	}
@@ -843,7 +852,7 @@ func (ok *OKExSwap) FutureCancelAlgoOrder(currencyPair CurrencyPair, orderId []s
 }
 
 //获取委托单列表, status和algo_id必填且只能填其一
-func (ok *OKExSwap) GetFutureAlgoOrders(algo_id string, status string, currencyPair CurrencyPair) ([]FutureOrder, error) {
+func (ok *OKExSwap) GetFutureAlgoOrders(algo_id string, status string, currencyPair CurrencyPair, contractType ...string) ([]FutureOrder, error) {
 	uri := fmt.Sprintf(GET_ALGO_ORDER, ok.adaptContractType(currencyPair), 1)
 	if algo_id != "" {
 		uri += "algo_id=" + algo_id
@@ -886,7 +895,7 @@ func (ok *OKExSwap) GetFutureAlgoOrders(algo_id string, status string, currencyP
 			AvgPrice:     ToFloat64(info.RealPrice),
 			DealAmount:   ToFloat64(info.RealAmount),
 			OrderTime:    oTime.UnixNano() / int64(time.Millisecond),
-			Status:       ok.AdaptTradeStatus(ToInt(info.Status)),
+			Status:       ok.adaptAlgoOrderStatus(info.Status),
 			Currency:     CurrencyPair{},
 			OrderType:    ToInt(info.OrderType),
 			OType:        ToInt(info.Type),
@@ -0,0 +1,97 @@
+package okex
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+const (
+	GET_FUNDING_TIME            = "/api/swap/v3/instruments/%s/funding_time"
+	GET_HISTORICAL_FUNDING_RATE = "/api/swap/v3/instruments/%s/historical_funding_rate?%s"
+	GET_MARK_PRICE              = "/api/swap/v3/instruments/%s/mark_price"
+	GET_SWAP_INDEX              = "/api/swap/v3/instruments/%s/index"
+	GET_OPEN_INTEREST           = "/api/swap/v3/instruments/%s/open_interest"
+)
+
+// okex 永续只区分币本位与U本位两种合约, contractType 由交易对的计价币决定
+
+func (ok *OKExSwap) GetFundingRate(currencyPair CurrencyPair, contractType string) (*FundingRate, error) {
+	var resp struct {
+		SwapFundingTime
+		FundingRate   float64 `json:"funding_rate,string"`
+		EstimatedRate float64 `json:"estimated_rate,string"`
+	}
+
+	err := ok.DoRequest("GET", fmt.Sprintf(GET_FUNDING_TIME, ok.adaptContractType(currencyPair)), "", &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	fundingTime, _ := time.Parse(time.RFC3339, resp.FundingTime)
+
+	return &FundingRate{
+		Pair:            currencyPair,
+		ContractType:    contractType,
+		FundingRate:     resp.FundingRate,
+		PredictedRate:   resp.EstimatedRate,
+		FundingTime:     fundingTime,
+		NextFundingTime: fundingTime.Add(8 * time.Hour),
+	}, nil
+}
+
+func (ok *OKExSwap) GetFundingRateHistory(currencyPair CurrencyPair, contractType string, size int, opt ...OptionalParameter) ([]HistoricalFunding, error) {
+	params := url.Values{}
+	if size > 0 {
+		params.Set("limit", fmt.Sprint(size))
+	}
+	MergeOptionalParameter(&params, opt...)
+
+	var resp []HistoricalFunding
+	err := ok.DoRequest("GET", fmt.Sprintf(GET_HISTORICAL_FUNDING_RATE, ok.adaptContractType(currencyPair), params.Encode()), "", &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (ok *OKExSwap) GetMarkPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	var resp SwapMarkPrice
+	err := ok.DoRequest("GET", fmt.Sprintf(GET_MARK_PRICE, ok.adaptContractType(currencyPair)), "", &resp)
+	if err != nil {
+		return 0, err
+	}
+	return ToFloat64(resp.MarkPrice), nil
+}
+
+func (ok *OKExSwap) GetIndexPrice(currencyPair CurrencyPair, contractType string) (float64, error) {
+	var resp struct {
+		InstrumentId string  `json:"instrument_id"`
+		Index        float64 `json:"index,string"`
+		Timestamp    string  `json:"timestamp"`
+	}
+	err := ok.DoRequest("GET", fmt.Sprintf(GET_SWAP_INDEX, ok.adaptContractType(currencyPair)), "", &resp)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Index, nil
+}
+
+func (ok *OKExSwap) GetOpenInterest(currencyPair CurrencyPair, contractType string) (*OpenInterest, error) {
+	var resp SwapOpenInterest
+	err := ok.DoRequest("GET", fmt.Sprintf(GET_OPEN_INTEREST, ok.adaptContractType(currencyPair)), "", &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, _ := time.Parse(time.RFC3339, resp.Timestamp)
+
+	return &OpenInterest{
+		Pair:         currencyPair,
+		ContractType: contractType,
+		Amount:       ToFloat64(resp.Amount),
+		Timestamp:    ts.UnixNano() / int64(time.Millisecond),
+	}, nil
+}
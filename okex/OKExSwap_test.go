@@ -106,7 +106,7 @@ func TestOKExSwap_PlaceFutureAlgoOrder(t *testing.T) {
 		ContractName: goex.SWAP_CONTRACT,
 		Currency:     goex.BTC_USD,
 		OType:        2, //开空
-		OrderType:    1, //1：止盈止损 2：跟踪委托 3：冰山委托 4：时间加权
+		OrderType:    1, //1：计划委托 2：跟踪委托 3：冰山委托 4：时间加权 5：止盈止损
 		Price:        9877,
 		Amount:       1,
 
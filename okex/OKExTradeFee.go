@@ -0,0 +1,51 @@
+package okex
+
+import (
+	"net/url"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+//v3 trade_fee 接口返回的是账户等级(category)对应的费率, 不包含 OKB 抵扣
+
+type okexTradeFee struct {
+	Category string  `json:"category"`
+	Maker    float64 `json:"maker,string"`
+	Taker    float64 `json:"taker,string"`
+}
+
+func (ok *OKEx) getTradeFee(uri, key, value string) (*okexTradeFee, error) {
+	params := url.Values{}
+	params.Set(key, value)
+
+	var fee okexTradeFee
+	err := ok.DoRequest("GET", uri+"?"+params.Encode(), "", &fee)
+	if err != nil {
+		return nil, err
+	}
+	return &fee, nil
+}
+
+func (ok *OKExSpot) GetFeeRate(pair CurrencyPair) (*FeeRate, error) {
+	fee, err := ok.OKEx.getTradeFee("/api/spot/v3/trade_fee", "instrument_id", pair.AdaptUsdToUsdt().ToSymbol("-"))
+	if err != nil {
+		return nil, err
+	}
+	return &FeeRate{Pair: pair, Maker: fee.Maker, Taker: fee.Taker, Tier: fee.Category}, nil
+}
+
+func (ok *OKExFuture) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	fee, err := ok.OKEx.getTradeFee("/api/futures/v3/trade_fee", "underlying", pair.ToSymbol("-"))
+	if err != nil {
+		return nil, err
+	}
+	return &FeeRate{Pair: pair, ContractType: contractType, Maker: fee.Maker, Taker: fee.Taker, Tier: fee.Category}, nil
+}
+
+func (ok *OKExSwap) GetFutureFeeRate(pair CurrencyPair, contractType string) (*FeeRate, error) {
+	fee, err := ok.OKEx.getTradeFee("/api/swap/v3/trade_fee", "underlying", pair.ToSymbol("-"))
+	if err != nil {
+		return nil, err
+	}
+	return &FeeRate{Pair: pair, ContractType: contractType, Maker: fee.Maker, Taker: fee.Taker, Tier: fee.Category}, nil
+}
@@ -8,7 +8,7 @@ package okex
 */
 
 type SwapPositionHolding struct {
-	LiquidationPrice float64 `json:"liquidation_price , string"`
+	LiquidationPrice float64 `json:"liquidation_price,string"`
 	Position         float64 `json:"position,string"`
 	AvailPosition    float64 `json:"avail_position,string"`
 	AvgCost          float64 `json:"avg_cost,string"`
@@ -19,6 +19,8 @@ type SwapPositionHolding struct {
 	Side             string  `json:"side"`
 	Timestamp        string  `json:"timestamp"`
 	Margin           string  `json:"margin";default:""`
+	UnrealizedPnl    string  `json:"unrealized_pnl"`
+	MaintMarginRatio string  `json:"maint_margin_ratio"`
 }
 
 type BizWarmTips struct {
@@ -0,0 +1,411 @@
+package oms
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+)
+
+// OrderRecord 本地记录的订单, 现货与合约共用, Spot 与 Future 二选一
+type OrderRecord struct {
+	Tag        string // 策略标识
+	Exchange   string
+	Spot       *Order
+	Future     *FutureOrder
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+func (r *OrderRecord) OrderId() string {
+	if r.Future != nil {
+		return r.Future.OrderID2
+	}
+	return r.Spot.OrderID2
+}
+
+func (r *OrderRecord) Cid() string {
+	if r.Future != nil {
+		return r.Future.ClientOid
+	}
+	return r.Spot.Cid
+}
+
+func (r *OrderRecord) Status() TradeStatus {
+	if r.Future != nil {
+		return r.Future.Status
+	}
+	return r.Spot.Status
+}
+
+func (r *OrderRecord) DealAmount() float64 {
+	if r.Future != nil {
+		return r.Future.DealAmount
+	}
+	return r.Spot.DealAmount
+}
+
+func (r *OrderRecord) AvgPrice() float64 {
+	if r.Future != nil {
+		return r.Future.AvgPrice
+	}
+	return r.Spot.AvgPrice
+}
+
+// IsFinal 订单已完成、已撤销、被拒绝或失败, 状态不会再变化
+func (r *OrderRecord) IsFinal() bool {
+	return isFinal(r.Status())
+}
+
+func (r *OrderRecord) snapshot() OrderRecord {
+	s := *r
+	if r.Spot != nil {
+		ord := *r.Spot
+		s.Spot = &ord
+	}
+	if r.Future != nil {
+		ord := *r.Future
+		s.Future = &ord
+	}
+	return s
+}
+
+// FillEvent 一次增量成交, 由前后两次 DealAmount、AvgPrice 的变化计算
+// 不叫 Fill, 避免与点导入的 goex.Fill(个人成交记录)冲突
+type FillEvent struct {
+	Record OrderRecord
+	Amount float64 // 本次成交数量
+	Price  float64 // 本次成交均价
+}
+
+func isFinal(status TradeStatus) bool {
+	switch status {
+	case ORDER_FINISH, ORDER_CANCEL, ORDER_REJECT, ORDER_FAIL:
+		return true
+	case ORDER_TRIGGERED: //条件单已触发, 还在成交
+		return false
+	}
+	return false
+}
+
+// statusRank 状态只能前进: 未成交 -> 已触发 -> 部分成交 -> 撤单中 -> 终态
+// 终态中完全成交优先, 撤单时已全部成交的订单迟到的撤单推送不会覆盖 FINISH
+func statusRank(status TradeStatus) int {
+	switch status {
+	case ORDER_UNFINISH:
+		return 0
+	case ORDER_TRIGGERED:
+		return 1
+	case ORDER_PART_FINISH:
+		return 2
+	case ORDER_CANCEL_ING:
+		return 3
+	case ORDER_FINISH:
+		return 5
+	}
+	return 4
+}
+
+type callback struct {
+	tag      string
+	onUpdate func(r OrderRecord)
+	onFill   func(f FillEvent)
+}
+
+// Manager 本地订单管理, 合并下单结果、REST 轮询和私有 ws 推送的订单状态
+// 更新到来的顺序不确定, 合并时 DealAmount 只增不减, 状态只前进不后退, 过期的更新会被丢弃
+type Manager struct {
+	lock      sync.RWMutex
+	orders    map[string]*OrderRecord // exchange:OrderID2
+	cidIndex  map[string]string       // exchange:cid -> exchange:OrderID2
+	callbacks []callback
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		orders:   make(map[string]*OrderRecord, 16),
+		cidIndex: make(map[string]string, 16),
+	}
+}
+
+func key(exchange, id string) string {
+	return exchange + ":" + id
+}
+
+// OnUpdate 订单状态变化时回调, tag 为空时接收所有订单
+func (m *Manager) OnUpdate(tag string, f func(r OrderRecord)) {
+	m.lock.Lock()
+	m.callbacks = append(m.callbacks, callback{tag: tag, onUpdate: f})
+	m.lock.Unlock()
+}
+
+// OnFill 订单有新成交时回调, tag 为空时接收所有订单
+func (m *Manager) OnFill(tag string, f func(fill FillEvent)) {
+	m.lock.Lock()
+	m.callbacks = append(m.callbacks, callback{tag: tag, onFill: f})
+	m.lock.Unlock()
+}
+
+// Add 记录新下的现货订单
+func (m *Manager) Add(tag, exchange string, ord *Order) {
+	if ord == nil {
+		return
+	}
+	o := *ord
+	m.merge(tag, exchange, &OrderRecord{Spot: &o})
+}
+
+// AddFuture 记录新下的合约订单
+func (m *Manager) AddFuture(tag, exchange string, ord *FutureOrder) {
+	if ord == nil {
+		return
+	}
+	o := *ord
+	m.merge(tag, exchange, &OrderRecord{Future: &o})
+}
+
+// Update 合并 REST 查询或 ws 推送的现货订单, 未记录过的订单不带策略标识
+func (m *Manager) Update(exchange string, ord *Order) {
+	m.Add("", exchange, ord)
+}
+
+// UpdateFuture 同 Update
+func (m *Manager) UpdateFuture(exchange string, ord *FutureOrder) {
+	m.AddFuture("", exchange, ord)
+}
+
+func (m *Manager) find(exchange, orderId, cid string) (string, *OrderRecord) {
+	if orderId != "" {
+		if r, ok := m.orders[key(exchange, orderId)]; ok {
+			return key(exchange, orderId), r
+		}
+	}
+	if cid != "" {
+		if k, ok := m.cidIndex[key(exchange, cid)]; ok {
+			return k, m.orders[k]
+		}
+	}
+	return "", nil
+}
+
+func (m *Manager) merge(tag, exchange string, update *OrderRecord) {
+	now := time.Now()
+	update.Exchange = exchange
+	update.UpdateTime = now
+	orderId, cid := update.OrderId(), update.Cid()
+	if orderId == "" && cid == "" {
+		return
+	}
+
+	var (
+		changed bool
+		fill    *FillEvent
+	)
+
+	m.lock.Lock()
+	k, r := m.find(exchange, orderId, cid)
+	if r == nil {
+		r = update
+		r.Tag = tag
+		r.CreateTime = now
+		changed = true
+		if r.DealAmount() > 0 {
+			fill = &FillEvent{Amount: r.DealAmount(), Price: r.AvgPrice()}
+		}
+	} else {
+		if r.Tag == "" {
+			r.Tag = tag
+		}
+		changed, fill = mergeRecord(r, update)
+		if changed {
+			r.UpdateTime = now
+		}
+		//下单时只有 cid, 之后拿到了订单ID
+		if orderId != "" && k != key(exchange, orderId) {
+			delete(m.orders, k)
+		}
+	}
+
+	if id := r.OrderId(); id != "" {
+		m.orders[key(exchange, id)] = r
+		if c := r.Cid(); c != "" {
+			m.cidIndex[key(exchange, c)] = key(exchange, id)
+		}
+	} else {
+		m.orders[key(exchange, cid)] = r
+		m.cidIndex[key(exchange, cid)] = key(exchange, cid)
+	}
+
+	snapshot := r.snapshot()
+	callbacks := m.callbacks
+	m.lock.Unlock()
+
+	if !changed {
+		return
+	}
+
+	for _, cb := range callbacks {
+		if cb.tag != "" && cb.tag != snapshot.Tag {
+			continue
+		}
+		if cb.onUpdate != nil {
+			cb.onUpdate(snapshot)
+		}
+		if cb.onFill != nil && fill != nil {
+			fill.Record = snapshot
+			cb.onFill(*fill)
+		}
+	}
+}
+
+// mergeRecord 把 update 合并到 r, 返回是否有变化以及新增的成交
+func mergeRecord(r, update *OrderRecord) (bool, *FillEvent) {
+	oldDeal, oldAvg, oldStatus := r.DealAmount(), r.AvgPrice(), r.Status()
+	newDeal, newAvg, newStatus := update.DealAmount(), update.AvgPrice(), update.Status()
+
+	//过期的更新
+	if newDeal < oldDeal || (isFinal(oldStatus) && !isFinal(newStatus)) {
+		return false, nil
+	}
+
+	status := oldStatus
+	if statusRank(newStatus) >= statusRank(oldStatus) {
+		status = newStatus
+	}
+	if (status == ORDER_UNFINISH || status == ORDER_TRIGGERED) && newDeal > 0 {
+		status = ORDER_PART_FINISH
+	}
+
+	if r.Future != nil && update.Future != nil {
+		mergeFutureOrder(r.Future, update.Future, status)
+	} else if r.Spot != nil && update.Spot != nil {
+		mergeOrder(r.Spot, update.Spot, status)
+	} else {
+		return false, nil
+	}
+
+	var fill *FillEvent
+	if newDeal > oldDeal {
+		fill = &FillEvent{Amount: newDeal - oldDeal, Price: newAvg}
+		if oldDeal > 0 && newAvg > 0 && oldAvg > 0 {
+			fill.Price = (newAvg*newDeal - oldAvg*oldDeal) / fill.Amount
+		}
+	}
+
+	return fill != nil || status != oldStatus, fill
+}
+
+func mergeOrder(ord, update *Order, status TradeStatus) {
+	if ord.OrderID2 == "" {
+		ord.OrderID2, ord.OrderID = update.OrderID2, update.OrderID
+	}
+	if ord.Cid == "" {
+		ord.Cid = update.Cid
+	}
+	if update.Price > 0 {
+		ord.Price = update.Price
+	}
+	if update.Amount > 0 {
+		ord.Amount = update.Amount
+	}
+	if update.AvgPrice > 0 {
+		ord.AvgPrice = update.AvgPrice
+	}
+	if update.Fee != 0 {
+		ord.Fee = update.Fee
+	}
+	if update.FinishedTime > 0 {
+		ord.FinishedTime = update.FinishedTime
+	}
+	ord.DealAmount = update.DealAmount
+	ord.Status = status
+}
+
+func mergeFutureOrder(ord, update *FutureOrder, status TradeStatus) {
+	if ord.OrderID2 == "" {
+		ord.OrderID2, ord.OrderID = update.OrderID2, update.OrderID
+	}
+	if ord.ClientOid == "" {
+		ord.ClientOid = update.ClientOid
+	}
+	if update.Price > 0 {
+		ord.Price = update.Price
+	}
+	if update.Amount > 0 {
+		ord.Amount = update.Amount
+	}
+	if update.AvgPrice > 0 {
+		ord.AvgPrice = update.AvgPrice
+	}
+	if update.Fee != 0 {
+		ord.Fee = update.Fee
+	}
+	if update.FinishedTime > 0 {
+		ord.FinishedTime = update.FinishedTime
+	}
+	ord.DealAmount = update.DealAmount
+	ord.Status = status
+}
+
+func (m *Manager) Get(exchange, orderId string) (OrderRecord, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	_, r := m.find(exchange, orderId, "")
+	if r == nil {
+		return OrderRecord{}, false
+	}
+	return r.snapshot(), true
+}
+
+func (m *Manager) GetByCid(exchange, cid string) (OrderRecord, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	_, r := m.find(exchange, "", cid)
+	if r == nil {
+		return OrderRecord{}, false
+	}
+	return r.snapshot(), true
+}
+
+// Orders 按策略标识查询订单, tag 为空时返回所有订单
+func (m *Manager) Orders(tag string) []OrderRecord {
+	return m.filter(func(r *OrderRecord) bool {
+		return tag == "" || r.Tag == tag
+	})
+}
+
+// OpenOrders 按策略标识查询未完成的订单, tag 为空时返回所有未完成订单
+func (m *Manager) OpenOrders(tag string) []OrderRecord {
+	return m.filter(func(r *OrderRecord) bool {
+		return (tag == "" || r.Tag == tag) && !r.IsFinal()
+	})
+}
+
+func (m *Manager) filter(f func(r *OrderRecord) bool) []OrderRecord {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var records []OrderRecord
+	for _, r := range m.orders {
+		if f(r) {
+			records = append(records, r.snapshot())
+		}
+	}
+	return records
+}
+
+// Remove 清理 before 之前已结束的订单, 返回清理的数量
+func (m *Manager) Remove(before time.Time) int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	n := 0
+	for k, r := range m.orders {
+		if r.IsFinal() && r.UpdateTime.Before(before) {
+			delete(m.orders, k)
+			if c := r.Cid(); c != "" {
+				delete(m.cidIndex, key(r.Exchange, c))
+			}
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,122 @@
+package oms
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSpot struct {
+	API //未实现的方法不会被调用
+
+	orders map[string]Order
+}
+
+func (m *mockSpot) GetExchangeName() string {
+	return "mock"
+}
+
+func (m *mockSpot) LimitBuy(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	ord := Order{OrderID2: "1", Cid: "goexabc", Currency: currency, Side: BUY, Price: ToFloat64(price), Amount: ToFloat64(amount)}
+	m.orders[ord.OrderID2] = ord
+	return &ord, nil
+}
+
+func (m *mockSpot) GetOneOrder(orderId string, currency CurrencyPair) (*Order, error) {
+	ord := m.orders[orderId]
+	return &ord, nil
+}
+
+func TestManager_Merge(t *testing.T) {
+	m := NewManager()
+
+	var (
+		fills   []FillEvent
+		updates []OrderRecord
+	)
+	m.OnFill("grid", func(f FillEvent) { fills = append(fills, f) })
+	m.OnUpdate("", func(r OrderRecord) { updates = append(updates, r) })
+
+	//下单时只有 cid
+	m.Add("grid", "mock", &Order{Cid: "c1", Amount: 3, Price: 100})
+	m.Update("mock", &Order{OrderID2: "9", Cid: "c1", Status: ORDER_PART_FINISH, DealAmount: 1, AvgPrice: 100})
+	m.Update("mock", &Order{OrderID2: "9", Status: ORDER_PART_FINISH, DealAmount: 3, AvgPrice: 102})
+	//过期的推送
+	m.Update("mock", &Order{OrderID2: "9", Status: ORDER_PART_FINISH, DealAmount: 1, AvgPrice: 100})
+	m.Update("mock", &Order{OrderID2: "9", Status: ORDER_FINISH, DealAmount: 3, AvgPrice: 102})
+	m.Update("mock", &Order{OrderID2: "9", Status: ORDER_UNFINISH, DealAmount: 3, AvgPrice: 102})
+
+	assert.Len(t, fills, 2)
+	assert.Equal(t, 1.0, fills[0].Amount)
+	assert.Equal(t, 100.0, fills[0].Price)
+	assert.Equal(t, 2.0, fills[1].Amount)
+	assert.InDelta(t, 103, fills[1].Price, 1e-8)
+	assert.Len(t, updates, 4)
+
+	r, ok := m.GetByCid("mock", "c1")
+	assert.True(t, ok)
+	assert.Equal(t, "9", r.OrderId())
+	assert.Equal(t, ORDER_FINISH, r.Status())
+	assert.Equal(t, 3.0, r.Spot.Amount)
+	assert.Len(t, m.Orders("grid"), 1)
+	assert.Len(t, m.OpenOrders("grid"), 0)
+
+	assert.Equal(t, 1, m.Remove(time.Now().Add(time.Second)))
+	_, ok = m.Get("mock", "9")
+	assert.False(t, ok)
+}
+
+func TestManager_FinishWinsOverCancel(t *testing.T) {
+	m := NewManager()
+
+	m.Add("grid", "mock", &Order{OrderID2: "5", Amount: 1, Price: 100})
+	m.Update("mock", &Order{OrderID2: "5", Status: ORDER_FINISH, DealAmount: 1, AvgPrice: 100})
+	//撤单请求与成交同时发生, 撤单推送晚到
+	m.Update("mock", &Order{OrderID2: "5", Status: ORDER_CANCEL, DealAmount: 1, AvgPrice: 100})
+	r, _ := m.Get("mock", "5")
+	assert.Equal(t, ORDER_FINISH, r.Status())
+}
+
+func TestManager_Triggered(t *testing.T) {
+	m := NewManager()
+
+	m.AddFuture("stop", "mock", &FutureOrder{OrderID2: "7", Amount: 2})
+	m.UpdateFuture("mock", &FutureOrder{OrderID2: "7", Status: ORDER_TRIGGERED})
+	r, _ := m.Get("mock", "7")
+	assert.Equal(t, ORDER_TRIGGERED, r.Status())
+	assert.Len(t, m.OpenOrders("stop"), 1)
+
+	//已触发的推送晚于成交到达
+	m.UpdateFuture("mock", &FutureOrder{OrderID2: "7", Status: ORDER_PART_FINISH, DealAmount: 1, AvgPrice: 100})
+	m.UpdateFuture("mock", &FutureOrder{OrderID2: "7", Status: ORDER_TRIGGERED, DealAmount: 1, AvgPrice: 100})
+	r, _ = m.Get("mock", "7")
+	assert.Equal(t, ORDER_PART_FINISH, r.Status())
+
+	m.AddFuture("stop", "mock", &FutureOrder{OrderID2: "9", Amount: 2})
+	m.UpdateFuture("mock", &FutureOrder{OrderID2: "9", Status: ORDER_TRIGGERED, DealAmount: 1, AvgPrice: 100})
+	r, _ = m.Get("mock", "9")
+	assert.Equal(t, ORDER_PART_FINISH, r.Status())
+}
+
+func TestSpotTracker(t *testing.T) {
+	m := NewManager()
+	mock := &mockSpot{orders: map[string]Order{}}
+	api := NewSpotTracker(m, mock, "grid")
+
+	var filled float64
+	m.OnFill("grid", func(f FillEvent) { filled += f.Amount })
+
+	_, err := api.LimitBuy("2", "100", BTC_USDT)
+	assert.Nil(t, err)
+	assert.Len(t, m.OpenOrders("grid"), 1)
+
+	ord := mock.orders["1"]
+	ord.DealAmount, ord.AvgPrice, ord.Status = 2, 100, ORDER_FINISH
+	mock.orders["1"] = ord
+
+	api.Sync()
+	assert.Equal(t, 2.0, filled)
+	assert.Len(t, m.OpenOrders("grid"), 0)
+}
@@ -0,0 +1,140 @@
+package oms
+
+import (
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+// SpotTracker 包装 API, 通过它下单、撤单、查询的订单都会记录到 Manager
+type SpotTracker struct {
+	API
+	m   *Manager
+	tag string
+}
+
+func NewSpotTracker(m *Manager, api API, tag string) *SpotTracker {
+	return &SpotTracker{API: api, m: m, tag: tag}
+}
+
+func (t *SpotTracker) record(ord *Order, err error) (*Order, error) {
+	if err == nil {
+		t.m.Add(t.tag, t.GetExchangeName(), ord)
+	}
+	return ord, err
+}
+
+func (t *SpotTracker) LimitBuy(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	return t.record(t.API.LimitBuy(amount, price, currency, opt...))
+}
+
+func (t *SpotTracker) LimitSell(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	return t.record(t.API.LimitSell(amount, price, currency, opt...))
+}
+
+func (t *SpotTracker) MarketBuy(amount, price string, currency CurrencyPair) (*Order, error) {
+	return t.record(t.API.MarketBuy(amount, price, currency))
+}
+
+func (t *SpotTracker) MarketSell(amount, price string, currency CurrencyPair) (*Order, error) {
+	return t.record(t.API.MarketSell(amount, price, currency))
+}
+
+func (t *SpotTracker) GetOneOrder(orderId string, currency CurrencyPair) (*Order, error) {
+	ord, err := t.API.GetOneOrder(orderId, currency)
+	if err == nil {
+		t.m.Update(t.GetExchangeName(), ord)
+	}
+	return ord, err
+}
+
+func (t *SpotTracker) GetUnfinishOrders(currency CurrencyPair) ([]Order, error) {
+	orders, err := t.API.GetUnfinishOrders(currency)
+	if err == nil {
+		for i := range orders {
+			t.m.Update(t.GetExchangeName(), &orders[i])
+		}
+	}
+	return orders, err
+}
+
+// Sync REST 轮询本策略所有未完成的订单, 可以定时调用, 也可以在 ws 断线重连后调用
+func (t *SpotTracker) Sync() {
+	for _, r := range t.m.OpenOrders(t.tag) {
+		if r.Exchange != t.GetExchangeName() || r.Spot == nil || r.Spot.OrderID2 == "" {
+			continue
+		}
+		if _, err := t.GetOneOrder(r.Spot.OrderID2, r.Spot.Currency); err != nil {
+			logger.Errorf("[oms] sync order %s error: %s", r.Spot.OrderID2, err.Error())
+		}
+	}
+}
+
+// FutureTracker 包装 FutureRestAPI, 约定同 SpotTracker
+type FutureTracker struct {
+	FutureRestAPI
+	m   *Manager
+	tag string
+}
+
+func NewFutureTracker(m *Manager, api FutureRestAPI, tag string) *FutureTracker {
+	return &FutureTracker{FutureRestAPI: api, m: m, tag: tag}
+}
+
+func (t *FutureTracker) record(ord *FutureOrder, err error) (*FutureOrder, error) {
+	if err == nil {
+		t.m.AddFuture(t.tag, t.GetExchangeName(), ord)
+	}
+	return ord, err
+}
+
+func (t *FutureTracker) PlaceFutureOrder(currencyPair CurrencyPair, contractType, price, amount string, openType, matchPrice int, leverRate float64) (string, error) {
+	orderId, err := t.FutureRestAPI.PlaceFutureOrder(currencyPair, contractType, price, amount, openType, matchPrice, leverRate)
+	t.record(&FutureOrder{
+		OrderID2:     orderId,
+		Currency:     currencyPair,
+		ContractName: contractType,
+		Price:        ToFloat64(price),
+		Amount:       ToFloat64(amount),
+		OType:        openType,
+		LeverRate:    leverRate,
+	}, err)
+	return orderId, err
+}
+
+func (t *FutureTracker) LimitFuturesOrder(currencyPair CurrencyPair, contractType, price, amount string, openType int, opt ...LimitOrderOptionalParameter) (*FutureOrder, error) {
+	return t.record(t.FutureRestAPI.LimitFuturesOrder(currencyPair, contractType, price, amount, openType, opt...))
+}
+
+func (t *FutureTracker) MarketFuturesOrder(currencyPair CurrencyPair, contractType, amount string, openType int) (*FutureOrder, error) {
+	return t.record(t.FutureRestAPI.MarketFuturesOrder(currencyPair, contractType, amount, openType))
+}
+
+func (t *FutureTracker) GetFutureOrder(orderId string, currencyPair CurrencyPair, contractType string) (*FutureOrder, error) {
+	ord, err := t.FutureRestAPI.GetFutureOrder(orderId, currencyPair, contractType)
+	if err == nil {
+		t.m.UpdateFuture(t.GetExchangeName(), ord)
+	}
+	return ord, err
+}
+
+func (t *FutureTracker) GetUnfinishFutureOrders(currencyPair CurrencyPair, contractType string) ([]FutureOrder, error) {
+	orders, err := t.FutureRestAPI.GetUnfinishFutureOrders(currencyPair, contractType)
+	if err == nil {
+		for i := range orders {
+			t.m.UpdateFuture(t.GetExchangeName(), &orders[i])
+		}
+	}
+	return orders, err
+}
+
+// Sync 同 SpotTracker.Sync
+func (t *FutureTracker) Sync() {
+	for _, r := range t.m.OpenOrders(t.tag) {
+		if r.Exchange != t.GetExchangeName() || r.Future == nil || r.Future.OrderID2 == "" {
+			continue
+		}
+		if _, err := t.GetFutureOrder(r.Future.OrderID2, r.Future.Currency, r.Future.ContractName); err != nil {
+			logger.Errorf("[oms] sync future order %s error: %s", r.Future.OrderID2, err.Error())
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package oteladapter 将 OpenTelemetry 的 trace.Tracer 适配为 goex.Tracer, 用于 APIBuilder.Instrument
+package oteladapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BTreeNewBee/goex"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracer struct {
+	t trace.Tracer
+}
+
+// Tracer 每次 api 调用创建一个 client 类型的 span
+func Tracer(t trace.Tracer) goex.Tracer {
+	return &tracer{t: t}
+}
+
+func (t *tracer) Start(name string, fields ...goex.LogField) goex.Span {
+	_, span := t.t.Start(context.Background(), name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes(fields)...))
+	return &otelSpan{span: span}
+}
+
+func attributes(fields []goex.LogField) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(fields))
+	for _, f := range fields {
+		switch v := f.Value.(type) {
+		case string:
+			attrs = append(attrs, attribute.String(f.Key, v))
+		case int:
+			attrs = append(attrs, attribute.Int(f.Key, v))
+		case int64:
+			attrs = append(attrs, attribute.Int64(f.Key, v))
+		case float64:
+			attrs = append(attrs, attribute.Float64(f.Key, v))
+		case bool:
+			attrs = append(attrs, attribute.Bool(f.Key, v))
+		default:
+			attrs = append(attrs, attribute.String(f.Key, fmt.Sprint(v)))
+		}
+	}
+	return attrs
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s *otelSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
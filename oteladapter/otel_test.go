@@ -0,0 +1,58 @@
+package oteladapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type fakeSpan struct {
+	trace.Span
+	name   string
+	config trace.SpanConfig
+	errs   []error
+	status codes.Code
+	ended  bool
+}
+
+func (s *fakeSpan) RecordError(err error, options ...trace.EventOption) { s.errs = append(s.errs, err) }
+func (s *fakeSpan) SetStatus(code codes.Code, description string)       { s.status = code }
+func (s *fakeSpan) End(options ...trace.SpanEndOption)                  { s.ended = true }
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	_, noop := trace.NewNoopTracerProvider().Tracer("").Start(ctx, name)
+	span := &fakeSpan{Span: noop, name: name, config: trace.NewSpanStartConfig(opts...)}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestTracer(t *testing.T) {
+	ft := &fakeTracer{}
+	tracer := Tracer(ft)
+
+	tracer.Start("binance.com/GetTicker", goex.KV("exchange", "binance.com"), goex.KV("size", 5)).End(nil)
+	tracer.Start("binance.com/LimitBuy").End(errors.New("timeout"))
+
+	assert.Len(t, ft.spans, 2)
+	ok := ft.spans[0]
+	assert.Equal(t, "binance.com/GetTicker", ok.name)
+	assert.Equal(t, trace.SpanKindClient, ok.config.SpanKind())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("exchange", "binance.com"), attribute.Int("size", 5)}, ok.config.Attributes())
+	assert.True(t, ok.ended)
+	assert.Equal(t, codes.Unset, ok.status)
+
+	failed := ft.spans[1]
+	assert.True(t, failed.ended)
+	assert.Equal(t, codes.Error, failed.status)
+	assert.EqualError(t, failed.errs[0], "timeout")
+}
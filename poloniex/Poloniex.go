@@ -37,6 +37,16 @@ func (poloniex *Poloniex) GetExchangeName() string {
 	return POLONIEX
 }
 
+// GetAllCurrencyPair 未对接交易对列表接口
+func (poloniex *Poloniex) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	return nil, EX_ERR_NOT_SUPPORT
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (poloniex *Poloniex) GetTimestamp() (int64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
+}
+
 func (poloniex *Poloniex) GetTicker(currency CurrencyPair) (*Ticker, error) {
 	//log.Println(poloniex.adaptCurrencyPair(currency).ToSymbol2("_"))
 	respmap, err := HttpGet(poloniex.client, PUBLIC_URL+TICKER_API)
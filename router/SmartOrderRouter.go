@@ -0,0 +1,477 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+var (
+	ErrNoVenue       = errors.New("router: no venue")
+	ErrNoLiquidity   = errors.New("router: no liquidity within limit price")
+	ErrUnsupportSide = errors.New("router: only BUY / SELL supported")
+)
+
+// Venue 一个可下单的交易所
+type Venue struct {
+	Api API
+	Fee float64 // taker费率, 比如: 0.001
+
+	// 不为空时优先通过 FeeApi 查询抵扣后的实际 taker 费率, 查询失败时使用 Fee
+	// 建议使用 NewCachedFeeAPI 包装, 避免每次路由都请求接口
+	FeeApi FeeAPI
+
+	// 可用余额, <0 表示不检查; =0 时在路由前通过 GetAccount 查询
+	// 买单对应计价币(CurrencyB), 卖单对应基础币(CurrencyA)
+	Balance float64
+
+	DepthSize int // GetDepth 档位数, 默认 20
+}
+
+// ChildOrder 拆分到单个交易所的子订单
+type ChildOrder struct {
+	Venue *Venue
+	Order *Order
+	Err   error
+}
+
+// RouteResult 一次路由的结果, Parent 汇总所有子订单的成交
+type RouteResult struct {
+	Parent   *Order
+	Children []*ChildOrder
+}
+
+// Allocation 拆单方案中分配到单个交易所的数量
+type Allocation struct {
+	Venue  *Venue
+	Price  float64 // 最差成交档位价格, 作为子订单的限价
+	Amount float64
+}
+
+type level struct {
+	venue    *Venue
+	price    float64
+	effPrice float64 // 含手续费价格
+	amount   float64
+}
+
+// SmartOrderRouter 现货跨交易所智能下单路由
+// 按含手续费后的最优价格以及各交易所可用余额将母单拆分为子订单
+type SmartOrderRouter struct {
+	venues []*Venue
+
+	depthLock   sync.RWMutex
+	depths      map[string]*Depth
+	depthMaxAge time.Duration
+}
+
+func NewSmartOrderRouter(venues ...*Venue) *SmartOrderRouter {
+	return &SmartOrderRouter{
+		venues:      venues,
+		depths:      make(map[string]*Depth, len(venues)),
+		depthMaxAge: 2 * time.Second,
+	}
+}
+
+// DepthMaxAge 推送深度的有效期, 过期后重新通过 GetDepth 获取
+func (r *SmartOrderRouter) DepthMaxAge(d time.Duration) *SmartOrderRouter {
+	r.depthMaxAge = d
+	return r
+}
+
+// UpdateDepth 供 SpotWsApi.DepthCallback 推送深度, 比如:
+//
+//	ws.DepthCallback(func(dep *Depth) { r.UpdateDepth(ws.GetExchangeName(), dep) })
+func (r *SmartOrderRouter) UpdateDepth(exchangeName string, dep *Depth) {
+	if dep == nil {
+		return
+	}
+	if dep.UTime.IsZero() {
+		dep.UTime = time.Now()
+	}
+	r.depthLock.Lock()
+	r.depths[r.depthKey(exchangeName, dep.Pair)] = dep
+	r.depthLock.Unlock()
+}
+
+func (r *SmartOrderRouter) depthKey(exchangeName string, pair CurrencyPair) string {
+	return exchangeName + "_" + pair.String()
+}
+
+func (r *SmartOrderRouter) getDepth(v *Venue, pair CurrencyPair) (*Depth, error) {
+	r.depthLock.RLock()
+	dep := r.depths[r.depthKey(v.Api.GetExchangeName(), pair)]
+	r.depthLock.RUnlock()
+
+	if dep != nil && time.Since(dep.UTime) <= r.depthMaxAge {
+		return dep, nil
+	}
+
+	size := v.DepthSize
+	if size <= 0 {
+		size = 20
+	}
+
+	return v.Api.GetDepth(size, pair)
+}
+
+func (r *SmartOrderRouter) getFee(v *Venue, pair CurrencyPair) float64 {
+	if v.FeeApi == nil {
+		return v.Fee
+	}
+
+	rate, err := v.FeeApi.GetFeeRate(pair)
+	if err != nil {
+		logger.Warnf("[router] %s get fee rate error: %s", v.Api.GetExchangeName(), err.Error())
+		return v.Fee
+	}
+
+	return rate.ActualTaker()
+}
+
+func (r *SmartOrderRouter) getBalance(v *Venue, pair CurrencyPair, side TradeSide) (float64, error) {
+	if v.Balance != 0 {
+		return v.Balance, nil
+	}
+
+	acc, err := v.Api.GetAccount()
+	if err != nil {
+		return 0, err
+	}
+
+	currency := pair.CurrencyA
+	if side == BUY {
+		currency = pair.CurrencyB
+	}
+
+	return acc.SubAccounts[currency].Amount, nil
+}
+
+// Plan 只计算拆单方案, 不下单
+// limitPrice <= 0 表示不限价
+func (r *SmartOrderRouter) Plan(pair CurrencyPair, side TradeSide, amount, limitPrice float64) ([]Allocation, error) {
+	if len(r.venues) == 0 {
+		return nil, ErrNoVenue
+	}
+
+	if side != BUY && side != SELL {
+		return nil, ErrUnsupportSide
+	}
+
+	var (
+		levels  []level
+		budgets = make(map[*Venue]float64, len(r.venues))
+	)
+
+	for _, v := range r.venues {
+		dep, err := r.getDepth(v, pair)
+		if err != nil {
+			logger.Warnf("[router] %s get depth error: %s", v.Api.GetExchangeName(), err.Error())
+			continue
+		}
+
+		balance, err := r.getBalance(v, pair, side)
+		if err != nil {
+			logger.Warnf("[router] %s get balance error: %s", v.Api.GetExchangeName(), err.Error())
+			continue
+		}
+		budgets[v] = balance
+		fee := r.getFee(v, pair)
+
+		records := dep.BidList
+		if side == BUY {
+			records = dep.AskList
+		}
+
+		for _, rec := range records {
+			if rec.Amount <= 0 {
+				continue
+			}
+			if limitPrice > 0 && ((side == BUY && rec.Price > limitPrice) || (side == SELL && rec.Price < limitPrice)) {
+				continue
+			}
+			lv := level{venue: v, price: rec.Price, amount: rec.Amount}
+			if side == BUY {
+				lv.effPrice = rec.Price * (1 + fee)
+			} else {
+				lv.effPrice = rec.Price * (1 - fee)
+			}
+			levels = append(levels, lv)
+		}
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		if side == BUY {
+			return levels[i].effPrice < levels[j].effPrice
+		}
+		return levels[i].effPrice > levels[j].effPrice
+	})
+
+	var (
+		remain = amount
+		allocs = make(map[*Venue]*Allocation, len(r.venues))
+		result []Allocation
+	)
+
+	for _, lv := range levels {
+		if remain <= 0 {
+			break
+		}
+
+		fill := math.Min(remain, lv.amount)
+
+		budget := budgets[lv.venue]
+		if budget >= 0 {
+			if side == BUY {
+				fill = math.Min(fill, budget/lv.effPrice)
+			} else {
+				fill = math.Min(fill, budget)
+			}
+		}
+
+		if fill <= 0 {
+			continue
+		}
+
+		if budget >= 0 {
+			if side == BUY {
+				budgets[lv.venue] = budget - fill*lv.effPrice
+			} else {
+				budgets[lv.venue] = budget - fill
+			}
+		}
+
+		a := allocs[lv.venue]
+		if a == nil {
+			a = &Allocation{Venue: lv.venue}
+			allocs[lv.venue] = a
+		}
+		a.Amount += fill
+		a.Price = lv.price //按价格优先排序, 最后一档即是该交易所最差价格
+		remain -= fill
+	}
+
+	for _, v := range r.venues {
+		if a := allocs[v]; a != nil {
+			result = append(result, *a)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, ErrNoLiquidity
+	}
+
+	return result, nil
+}
+
+// Execute 拆分母单并通过 LimitBuy / LimitSell 下子订单
+// limitPrice <= 0 表示不限价
+func (r *SmartOrderRouter) Execute(pair CurrencyPair, side TradeSide, amount, limitPrice float64) (*RouteResult, error) {
+	allocs, err := r.Plan(pair, side, amount, limitPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RouteResult{
+		Parent: &Order{
+			Cid:       GenerateOrderClientId(32),
+			Price:     limitPrice,
+			Amount:    amount,
+			Currency:  pair,
+			Side:      side,
+			Type:      "limit",
+			Status:    ORDER_UNFINISH,
+			OrderTime: int(time.Now().Unix()),
+		},
+	}
+
+	amountPrecision, pricePrecision := precision(pair)
+
+	for i := range allocs {
+		a := allocs[i]
+
+		childAmount := truncate(a.Amount, amountPrecision)
+		if childAmount <= 0 {
+			continue
+		}
+
+		var (
+			ord      *Order
+			err      error
+			amountS  = FloatToString(childAmount, amountPrecision)
+			priceS   = FloatToString(a.Price, pricePrecision)
+			exchange = a.Venue.Api.GetExchangeName()
+		)
+
+		if side == BUY {
+			ord, err = a.Venue.Api.LimitBuy(amountS, priceS, pair)
+		} else {
+			ord, err = a.Venue.Api.LimitSell(amountS, priceS, pair)
+		}
+
+		if err != nil {
+			logger.Errorf("[router] %s place %s order error: %s", exchange, side, err.Error())
+		} else {
+			logger.Debugf("[router] %s place %s order %s, price=%s, amount=%s", exchange, side, ord.OrderID2, priceS, amountS)
+		}
+
+		result.Children = append(result.Children, &ChildOrder{Venue: a.Venue, Order: ord, Err: err})
+	}
+
+	result.aggregate()
+
+	if len(result.Children) == 0 {
+		return result, ErrNoLiquidity
+	}
+
+	if result.placedCount() == 0 {
+		return result, fmt.Errorf("router: all child orders failed, %v", result.Children[0].Err)
+	}
+
+	return result, nil
+}
+
+// Sync 通过 GetOneOrder 刷新子订单状态并重新汇总母单
+func (r *SmartOrderRouter) Sync(result *RouteResult) error {
+	var lastErr error
+
+	for _, child := range result.Children {
+		if child.Order == nil || isFinalStatus(child.Order.Status) {
+			continue
+		}
+
+		ord, err := child.Venue.Api.GetOneOrder(child.Order.OrderID2, result.Parent.Currency)
+		if err != nil {
+			logger.Warnf("[router] %s get order %s error: %s", child.Venue.Api.GetExchangeName(), child.Order.OrderID2, err.Error())
+			lastErr = err
+			continue
+		}
+
+		child.Order = ord
+	}
+
+	result.aggregate()
+
+	return lastErr
+}
+
+// Cancel 撤销所有未完成的子订单
+func (r *SmartOrderRouter) Cancel(result *RouteResult) error {
+	var lastErr error
+
+	for _, child := range result.Children {
+		if child.Order == nil || isFinalStatus(child.Order.Status) {
+			continue
+		}
+
+		_, err := child.Venue.Api.CancelOrder(child.Order.OrderID2, result.Parent.Currency)
+		if err != nil {
+			logger.Warnf("[router] %s cancel order %s error: %s", child.Venue.Api.GetExchangeName(), child.Order.OrderID2, err.Error())
+			lastErr = err
+		}
+	}
+
+	if err := r.Sync(result); err != nil {
+		return err
+	}
+
+	return lastErr
+}
+
+func (result *RouteResult) placedCount() int {
+	n := 0
+	for _, child := range result.Children {
+		if child.Err == nil && child.Order != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (result *RouteResult) aggregate() {
+	var (
+		parent       = result.Parent
+		dealAmount   float64
+		dealValue    float64
+		fee          float64
+		allFinal     = true
+		placed       = 0
+		finishedTime int64
+	)
+
+	for _, child := range result.Children {
+		if child.Err != nil || child.Order == nil {
+			continue
+		}
+		placed++
+
+		ord := child.Order
+		dealAmount += ord.DealAmount
+		dealValue += ord.DealAmount * ord.AvgPrice
+		fee += ord.Fee
+
+		if !isFinalStatus(ord.Status) {
+			allFinal = false
+		}
+		if ord.FinishedTime > finishedTime {
+			finishedTime = ord.FinishedTime
+		}
+	}
+
+	parent.DealAmount = dealAmount
+	parent.Fee = fee
+	if dealAmount > 0 {
+		parent.AvgPrice = dealValue / dealAmount
+	}
+
+	switch {
+	case placed == 0:
+		parent.Status = ORDER_FAIL
+	case dealAmount >= parent.Amount:
+		parent.Status = ORDER_FINISH
+	case allFinal:
+		// 子订单都已结束, 但母单未完全成交(撤单、余额或流动性不足)
+		parent.Status = ORDER_CANCEL
+	case dealAmount > 0:
+		parent.Status = ORDER_PART_FINISH
+	default:
+		parent.Status = ORDER_UNFINISH
+	}
+
+	if allFinal && placed > 0 {
+		parent.FinishedTime = finishedTime
+	}
+}
+
+func isFinalStatus(status TradeStatus) bool {
+	switch status {
+	case ORDER_FINISH, ORDER_CANCEL, ORDER_REJECT, ORDER_FAIL:
+		return true
+	case ORDER_TRIGGERED: //条件单已触发, 还在成交
+		return false
+	}
+	return false
+}
+
+func precision(pair CurrencyPair) (amountPrecision, pricePrecision int) {
+	amountPrecision, pricePrecision = pair.AmountTickSize, pair.PriceTickSize
+	if amountPrecision <= 0 {
+		amountPrecision = 8
+	}
+	if pricePrecision <= 0 {
+		pricePrecision = 8
+	}
+	return
+}
+
+// 向下截断, 避免超出可用余额
+func truncate(v float64, precision int) float64 {
+	p := math.Pow(10, float64(precision))
+	return math.Floor(v*p+1e-9) / p
+}
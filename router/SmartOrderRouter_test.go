@@ -0,0 +1,170 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSpot struct {
+	name   string
+	depth  *Depth
+	orders map[string]*Order
+	seq    int
+}
+
+func newMockSpot(name string, asks, bids DepthRecords) *mockSpot {
+	return &mockSpot{
+		name:   name,
+		depth:  &Depth{Pair: BTC_USDT, AskList: asks, BidList: bids},
+		orders: map[string]*Order{},
+	}
+}
+
+func (m *mockSpot) place(amount, price string, pair CurrencyPair, side TradeSide) (*Order, error) {
+	m.seq++
+	ord := &Order{
+		OrderID2: fmt.Sprint(m.seq),
+		Amount:   ToFloat64(amount),
+		Price:    ToFloat64(price),
+		Currency: pair,
+		Side:     side,
+		Status:   ORDER_UNFINISH,
+	}
+	m.orders[ord.OrderID2] = ord
+	return ord, nil
+}
+
+func (m *mockSpot) LimitBuy(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	return m.place(amount, price, currency, BUY)
+}
+
+func (m *mockSpot) LimitSell(amount, price string, currency CurrencyPair, opt ...LimitOrderOptionalParameter) (*Order, error) {
+	return m.place(amount, price, currency, SELL)
+}
+
+func (m *mockSpot) MarketBuy(amount, price string, currency CurrencyPair) (*Order, error) {
+	return nil, errors.New("not supported")
+}
+
+func (m *mockSpot) MarketSell(amount, price string, currency CurrencyPair) (*Order, error) {
+	return nil, errors.New("not supported")
+}
+
+func (m *mockSpot) CancelOrder(orderId string, currency CurrencyPair) (bool, error) {
+	m.orders[orderId].Status = ORDER_CANCEL
+	return true, nil
+}
+
+func (m *mockSpot) GetOneOrder(orderId string, currency CurrencyPair) (*Order, error) {
+	ord := *m.orders[orderId]
+	return &ord, nil
+}
+
+func (m *mockSpot) GetUnfinishOrders(currency CurrencyPair) ([]Order, error) { return nil, nil }
+
+func (m *mockSpot) GetOrderHistorys(currency CurrencyPair, opt ...OptionalParameter) ([]Order, error) {
+	return nil, nil
+}
+
+func (m *mockSpot) GetAccount() (*Account, error) {
+	return &Account{SubAccounts: map[Currency]SubAccount{
+		USDT: {Currency: USDT, Amount: 100000},
+		BTC:  {Currency: BTC, Amount: 0.5},
+	}}, nil
+}
+
+func (m *mockSpot) GetTicker(currency CurrencyPair) (*Ticker, error) { return nil, nil }
+
+func (m *mockSpot) GetDepth(size int, currency CurrencyPair) (*Depth, error) { return m.depth, nil }
+
+func (m *mockSpot) GetKlineRecords(currency CurrencyPair, period KlinePeriod, size int, optional ...OptionalParameter) ([]Kline, error) {
+	return nil, nil
+}
+
+func (m *mockSpot) GetTrades(currencyPair CurrencyPair, since int64) ([]Trade, error) {
+	return nil, nil
+}
+
+func (m *mockSpot) GetExchangeName() string { return m.name }
+
+func (m *mockSpot) GetAllCurrencyPair() ([]CurrencyPair, error) { return nil, nil }
+
+func (m *mockSpot) GetTimestamp() (int64, error) { return 0, nil }
+
+func TestSmartOrderRouter_Plan(t *testing.T) {
+	a := newMockSpot("a", DepthRecords{{Price: 101, Amount: 1}, {Price: 100, Amount: 1}}, nil)
+	b := newMockSpot("b", DepthRecords{{Price: 100.5, Amount: 2}}, nil)
+
+	// a 的手续费更高, 含手续费后 100 * 1.01 = 101 > 100.5 * 1.001
+	r := NewSmartOrderRouter(&Venue{Api: a, Fee: 0.01, Balance: -1}, &Venue{Api: b, Fee: 0.001, Balance: -1})
+
+	allocs, err := r.Plan(BTC_USDT, BUY, 2.5, 0)
+	assert.Nil(t, err)
+	assert.Len(t, allocs, 2)
+	assert.Equal(t, 0.5, allocs[0].Amount)
+	assert.Equal(t, 100.0, allocs[0].Price)
+	assert.Equal(t, 2.0, allocs[1].Amount)
+
+	_, err = r.Plan(BTC_USDT, BUY, 1, 99)
+	assert.Equal(t, ErrNoLiquidity, err)
+}
+
+type mockFee struct {
+	taker float64
+}
+
+func (m mockFee) GetFeeRate(pair CurrencyPair) (*FeeRate, error) {
+	return &FeeRate{Pair: pair, Taker: m.taker}, nil
+}
+
+func TestSmartOrderRouter_PlanFeeApi(t *testing.T) {
+	a := newMockSpot("a", DepthRecords{{Price: 100, Amount: 1}}, nil)
+	b := newMockSpot("b", DepthRecords{{Price: 100.5, Amount: 1}}, nil)
+
+	// FeeApi 优先于 Fee, a 实际费率 1% 后价格高于 b
+	r := NewSmartOrderRouter(&Venue{Api: a, FeeApi: mockFee{taker: 0.01}, Balance: -1}, &Venue{Api: b, Fee: 0.001, Balance: -1})
+
+	allocs, err := r.Plan(BTC_USDT, BUY, 1, 0)
+	assert.Nil(t, err)
+	assert.Len(t, allocs, 1)
+	assert.Equal(t, "b", allocs[0].Venue.Api.GetExchangeName())
+}
+
+func TestSmartOrderRouter_PlanBalance(t *testing.T) {
+	a := newMockSpot("a", nil, DepthRecords{{Price: 100, Amount: 5}})
+	b := newMockSpot("b", nil, DepthRecords{{Price: 99, Amount: 5}})
+
+	// a 只有 0.5 个 BTC (GetAccount), 剩余的卖到 b
+	r := NewSmartOrderRouter(&Venue{Api: a}, &Venue{Api: b, Balance: -1})
+
+	allocs, err := r.Plan(BTC_USDT, SELL, 2, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, allocs[0].Amount)
+	assert.Equal(t, 1.5, allocs[1].Amount)
+}
+
+func TestSmartOrderRouter_Execute(t *testing.T) {
+	a := newMockSpot("a", DepthRecords{{Price: 100, Amount: 1}}, nil)
+	b := newMockSpot("b", DepthRecords{{Price: 101, Amount: 1}}, nil)
+	r := NewSmartOrderRouter(&Venue{Api: a, Balance: -1}, &Venue{Api: b, Balance: -1})
+
+	ret, err := r.Execute(BTC_USDT, BUY, 1.5, 0)
+	assert.Nil(t, err)
+	assert.Len(t, ret.Children, 2)
+	assert.Equal(t, ORDER_UNFINISH, ret.Parent.Status)
+
+	a.orders["1"].DealAmount, a.orders["1"].AvgPrice, a.orders["1"].Status = 1, 100, ORDER_FINISH
+	b.orders["1"].DealAmount, b.orders["1"].AvgPrice = 0.25, 101
+
+	assert.Nil(t, r.Sync(ret))
+	assert.Equal(t, ORDER_PART_FINISH, ret.Parent.Status)
+	assert.Equal(t, 1.25, ret.Parent.DealAmount)
+	assert.InDelta(t, 100.2, ret.Parent.AvgPrice, 1e-9)
+
+	assert.Nil(t, r.Cancel(ret))
+	assert.Equal(t, ORDER_CANCEL, ret.Parent.Status)
+}
@@ -0,0 +1,404 @@
+package trigger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/BTreeNewBee/goex/internal/logger"
+)
+
+type ConditionalType int
+
+const (
+	STOP_LOSS     ConditionalType = iota + 1 // 止损
+	TAKE_PROFIT                              // 止盈
+	TRAILING_STOP                            // 跟踪止损
+)
+
+func (t ConditionalType) String() string {
+	switch t {
+	case STOP_LOSS:
+		return "STOP_LOSS"
+	case TAKE_PROFIT:
+		return "TAKE_PROFIT"
+	case TRAILING_STOP:
+		return "TRAILING_STOP"
+	}
+	return "UNKNOWN"
+}
+
+type ConditionalStatus int
+
+const (
+	CONDITIONAL_PENDING   ConditionalStatus = iota + 1 // 等待触发
+	CONDITIONAL_TRIGGERED                              // 已触发并下单
+	CONDITIONAL_CANCELED
+	CONDITIONAL_FAILED // 触发后下单失败
+)
+
+var (
+	ErrNativeUnsupported = errors.New("trigger: native conditional order unsupported")
+	ErrOrderNotFound     = errors.New("trigger: conditional order not found")
+	ErrOrderNotPending   = errors.New("trigger: conditional order is not pending")
+	ErrInvalidOrder      = errors.New("trigger: invalid conditional order")
+)
+
+// ConditionalOrder 条件单
+// 止损: 卖单价格 <= TriggerPrice 触发, 买单价格 >= TriggerPrice 触发
+// 止盈: 卖单价格 >= TriggerPrice 触发, 买单价格 <= TriggerPrice 触发
+// 跟踪止损: 卖单从最高价回撤 TrailingDistance(或 TrailingRatio) 触发, 买单从最低价反弹触发
+type ConditionalOrder struct {
+	Id     string
+	Type   ConditionalType
+	Side   TradeSide // BUY / SELL
+	Close  bool      // 合约平仓单
+	Amount float64
+	Price  float64 // 触发后的委托价格, <=0 为市价
+
+	TriggerPrice     float64
+	TrailingDistance float64 // 跟踪止损回撤价差
+	TrailingRatio    float64 // 跟踪止损回撤比例, 比如 0.01, 与 TrailingDistance 二选一
+	ActivationPrice  float64 // 跟踪止损激活价格, <=0 表示立即激活
+
+	GroupId string // OCO 组, 同组任一条件单触发后撤销其它条件单
+
+	Status   ConditionalStatus
+	OrderId  string  // 触发后的委托单ID, 原生单触发后为 NativeId
+	NativeId string  // 委托给交易所的原生条件单ID
+	Extreme  float64 // 跟踪止损记录的最高(卖)/最低(买)价
+	Err      error
+
+	activated bool
+}
+
+func (o *ConditionalOrder) validate() error {
+	if o.Amount <= 0 || (o.Side != BUY && o.Side != SELL) {
+		return ErrInvalidOrder
+	}
+	switch o.Type {
+	case STOP_LOSS, TAKE_PROFIT:
+		if o.TriggerPrice <= 0 {
+			return ErrInvalidOrder
+		}
+	case TRAILING_STOP:
+		if o.TrailingDistance <= 0 && o.TrailingRatio <= 0 {
+			return ErrInvalidOrder
+		}
+	default:
+		return ErrInvalidOrder
+	}
+	return nil
+}
+
+// stopPrice 跟踪止损当前的触发价
+func (o *ConditionalOrder) stopPrice() float64 {
+	distance := o.TrailingDistance
+	if distance <= 0 {
+		distance = o.Extreme * o.TrailingRatio
+	}
+	if o.Side == SELL {
+		return o.Extreme - distance
+	}
+	return o.Extreme + distance
+}
+
+// hit 用最新价判断是否触发
+func (o *ConditionalOrder) hit(price float64) bool {
+	switch o.Type {
+	case STOP_LOSS:
+		if o.Side == SELL {
+			return price <= o.TriggerPrice
+		}
+		return price >= o.TriggerPrice
+	case TAKE_PROFIT:
+		if o.Side == SELL {
+			return price >= o.TriggerPrice
+		}
+		return price <= o.TriggerPrice
+	case TRAILING_STOP:
+		if !o.activated {
+			if o.ActivationPrice > 0 && ((o.Side == SELL && price < o.ActivationPrice) || (o.Side == BUY && price > o.ActivationPrice)) {
+				return false
+			}
+			o.activated = true
+			o.Extreme = price
+		}
+		if (o.Side == SELL && price > o.Extreme) || (o.Side == BUY && price < o.Extreme) {
+			o.Extreme = price
+		}
+		o.TriggerPrice = o.stopPrice()
+		if o.Side == SELL {
+			return price <= o.TriggerPrice
+		}
+		return price >= o.TriggerPrice
+	}
+	return false
+}
+
+// Engine 本地条件单引擎, 通过 OnTicker/OnTrade 接入行情, 现货与合约共用
+type Engine struct {
+	trader Trader
+
+	lock        sync.Mutex
+	orders      map[string]*ConditionalOrder
+	onTriggered func(o *ConditionalOrder)
+}
+
+func NewEngine(trader Trader) *Engine {
+	return &Engine{
+		trader: trader,
+		orders: make(map[string]*ConditionalOrder, 8),
+	}
+}
+
+// OnTriggered 条件单触发(或下单失败)后回调
+func (e *Engine) OnTriggered(f func(o *ConditionalOrder)) {
+	e.lock.Lock()
+	e.onTriggered = f
+	e.lock.Unlock()
+}
+
+// WatchSpot 订阅现货 ticker 驱动引擎, 会覆盖 ws 原有的 TickerCallback
+func (e *Engine) WatchSpot(ws SpotWsApi, pair CurrencyPair) error {
+	ws.TickerCallback(e.OnTicker)
+	return ws.SubscribeTicker(pair)
+}
+
+// WatchFutures 订阅合约 ticker 驱动引擎, 会覆盖 ws 原有的 TickerCallback
+func (e *Engine) WatchFutures(ws FuturesWsApi, pair CurrencyPair, contractType string) error {
+	ws.TickerCallback(e.OnFutureTicker)
+	return ws.SubscribeTicker(pair, contractType)
+}
+
+// Submit 提交条件单, 交易所支持时委托给原生条件单, OCO 组内的条件单在本地模拟
+// 原生单的触发、撤销需要调用 SyncNative 同步
+func (e *Engine) Submit(o *ConditionalOrder) (string, error) {
+	if err := o.validate(); err != nil {
+		return "", err
+	}
+
+	if o.Id == "" {
+		o.Id = GenerateOrderClientId(32)
+	}
+	o.Status = CONDITIONAL_PENDING
+
+	if native, ok := e.trader.(NativeTrader); ok && o.GroupId == "" {
+		nativeId, supported, err := native.PlaceNative(o)
+		if supported {
+			if err != nil {
+				return "", err
+			}
+			o.NativeId = nativeId
+			logger.Debugf("[trigger] %s %s delegate to native order %s", o.Id, o.Type, nativeId)
+		}
+	}
+
+	e.lock.Lock()
+	e.orders[o.Id] = o
+	e.lock.Unlock()
+
+	return o.Id, nil
+}
+
+// OCO 提交一组二选一条件单, 比如同时挂止盈和止损
+func (e *Engine) OCO(orders ...*ConditionalOrder) (string, error) {
+	if len(orders) < 2 {
+		return "", ErrInvalidOrder
+	}
+
+	groupId := GenerateOrderClientId(32)
+	for _, o := range orders {
+		if err := o.validate(); err != nil {
+			return "", err
+		}
+		o.GroupId = groupId
+	}
+
+	for _, o := range orders {
+		if _, err := e.Submit(o); err != nil {
+			e.CancelGroup(groupId)
+			return "", err
+		}
+	}
+
+	return groupId, nil
+}
+
+func (e *Engine) Cancel(id string) error {
+	e.lock.Lock()
+	o := e.orders[id]
+	if o == nil {
+		e.lock.Unlock()
+		return ErrOrderNotFound
+	}
+	if o.Status != CONDITIONAL_PENDING {
+		e.lock.Unlock()
+		return ErrOrderNotPending
+	}
+	o.Status = CONDITIONAL_CANCELED
+	e.lock.Unlock()
+
+	return e.cancelNative(o)
+}
+
+func (e *Engine) CancelGroup(groupId string) {
+	for _, o := range e.Orders() {
+		if o.GroupId == groupId {
+			e.Cancel(o.Id)
+		}
+	}
+}
+
+func (e *Engine) cancelNative(o *ConditionalOrder) error {
+	if o.NativeId == "" {
+		return nil
+	}
+	native, ok := e.trader.(NativeTrader)
+	if !ok {
+		return ErrNativeUnsupported
+	}
+	return native.CancelNative(o)
+}
+
+func (e *Engine) Get(id string) *ConditionalOrder {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.orders[id]
+}
+
+// Orders 所有条件单(含已触发和已撤销)
+func (e *Engine) Orders() []*ConditionalOrder {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	orders := make([]*ConditionalOrder, 0, len(e.orders))
+	for _, o := range e.orders {
+		orders = append(orders, o)
+	}
+	return orders
+}
+
+func (e *Engine) OnTicker(ticker *Ticker) {
+	if ticker != nil {
+		e.OnPrice(ticker.Last)
+	}
+}
+
+func (e *Engine) OnFutureTicker(ticker *FutureTicker) {
+	if ticker != nil && ticker.Ticker != nil {
+		e.OnPrice(ticker.Last)
+	}
+}
+
+func (e *Engine) OnTrade(trade *Trade) {
+	if trade != nil {
+		e.OnPrice(trade.Price)
+	}
+}
+
+// OnPrice 用最新成交价检查所有待触发的条件单
+func (e *Engine) OnPrice(price float64) {
+	if price <= 0 {
+		return
+	}
+
+	var (
+		fired       []*ConditionalOrder
+		firedGroups = make(map[string]bool, 1)
+	)
+
+	e.lock.Lock()
+	for _, o := range e.orders {
+		if o.Status != CONDITIONAL_PENDING || o.NativeId != "" || firedGroups[o.GroupId] {
+			continue
+		}
+		if o.hit(price) {
+			o.Status = CONDITIONAL_TRIGGERED
+			fired = append(fired, o)
+			if o.GroupId != "" {
+				firedGroups[o.GroupId] = true
+			}
+		}
+	}
+
+	// OCO: 同组其它条件单直接撤销, 组内没有原生单
+	for _, o := range e.orders {
+		if o.Status == CONDITIONAL_PENDING && firedGroups[o.GroupId] {
+			o.Status = CONDITIONAL_CANCELED
+		}
+	}
+	f := e.onTriggered
+	e.lock.Unlock()
+
+	for _, o := range fired {
+		logger.Infof("[trigger] %s %s triggered at %f", o.Id, o.Type, price)
+
+		orderId, err := e.trader.PlaceOrder(o.Side, o.Close, o.Amount, o.Price)
+
+		e.lock.Lock()
+		if err != nil {
+			logger.Errorf("[trigger] %s place order error: %s", o.Id, err.Error())
+			o.Status = CONDITIONAL_FAILED
+			o.Err = err
+		} else {
+			o.OrderId = orderId
+		}
+		e.lock.Unlock()
+
+		if f != nil {
+			f(o)
+		}
+	}
+}
+
+// SyncNative 查询待触发的原生单, 交易所已触发、撤销或失败时更新状态, 触发和失败时回调 OnTriggered
+// 需要定时调用, 返回最后一个查询错误
+func (e *Engine) SyncNative() error {
+	native, ok := e.trader.(NativeTrader)
+	if !ok {
+		return nil
+	}
+
+	var lastErr error
+	for _, o := range e.Orders() {
+		e.lock.Lock()
+		pending := o.Status == CONDITIONAL_PENDING && o.NativeId != ""
+		e.lock.Unlock()
+		if !pending {
+			continue
+		}
+
+		status, err := native.GetNative(o)
+		if err != nil {
+			logger.Warnf("[trigger] %s get native order %s error: %s", o.Id, o.NativeId, err.Error())
+			lastErr = err
+			continue
+		}
+
+		e.lock.Lock()
+		//查询期间可能已被撤销
+		if o.Status != CONDITIONAL_PENDING {
+			e.lock.Unlock()
+			continue
+		}
+		switch status {
+		case ORDER_TRIGGERED, ORDER_PART_FINISH, ORDER_FINISH:
+			o.Status = CONDITIONAL_TRIGGERED
+			o.OrderId = o.NativeId
+		case ORDER_CANCEL:
+			o.Status = CONDITIONAL_CANCELED
+		case ORDER_REJECT, ORDER_FAIL:
+			o.Status = CONDITIONAL_FAILED
+			o.Err = fmt.Errorf("trigger: native order %s %s", o.NativeId, status)
+		}
+		notify, f := o.Status == CONDITIONAL_TRIGGERED || o.Status == CONDITIONAL_FAILED, e.onTriggered
+		e.lock.Unlock()
+
+		if notify && f != nil {
+			f(o)
+		}
+	}
+
+	return lastErr
+}
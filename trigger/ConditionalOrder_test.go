@@ -0,0 +1,245 @@
+package trigger
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/BTreeNewBee/goex"
+	"github.com/stretchr/testify/assert"
+)
+
+type placed struct {
+	side   TradeSide
+	close  bool
+	amount float64
+	price  float64
+}
+
+type mockTrader struct {
+	orders []placed
+	native map[string]*ConditionalOrder
+}
+
+func (m *mockTrader) PlaceOrder(side TradeSide, close bool, amount, price float64) (string, error) {
+	m.orders = append(m.orders, placed{side, close, amount, price})
+	return fmt.Sprint(len(m.orders)), nil
+}
+
+func (m *mockTrader) CancelOrder(orderId string) error { return nil }
+
+type mockNativeTrader struct {
+	mockTrader
+	status map[string]TradeStatus //原生单在交易所的状态
+}
+
+func (m *mockNativeTrader) PlaceNative(o *ConditionalOrder) (string, bool, error) {
+	if o.Type == TRAILING_STOP {
+		return "", false, nil
+	}
+	m.native[o.Id] = o
+	return "native-" + o.Id, true, nil
+}
+
+func (m *mockNativeTrader) CancelNative(o *ConditionalOrder) error {
+	delete(m.native, o.Id)
+	return nil
+}
+
+func (m *mockNativeTrader) GetNative(o *ConditionalOrder) (TradeStatus, error) {
+	return m.status[o.NativeId], nil
+}
+
+func TestEngine_StopLossTakeProfit(t *testing.T) {
+	trader := &mockTrader{}
+	engine := NewEngine(trader)
+
+	sl, err := engine.Submit(&ConditionalOrder{Type: STOP_LOSS, Side: SELL, Close: true, Amount: 1, TriggerPrice: 90})
+	assert.Nil(t, err)
+	tp, err := engine.Submit(&ConditionalOrder{Type: TAKE_PROFIT, Side: SELL, Amount: 1, Price: 111, TriggerPrice: 110})
+	assert.Nil(t, err)
+
+	engine.OnPrice(100)
+	assert.Len(t, trader.orders, 0)
+
+	engine.OnTicker(&Ticker{Last: 89})
+	assert.Equal(t, CONDITIONAL_TRIGGERED, engine.Get(sl).Status)
+	assert.Equal(t, "1", engine.Get(sl).OrderId)
+	assert.Equal(t, placed{SELL, true, 1, 0}, trader.orders[0])
+
+	engine.OnTrade(&Trade{Price: 110})
+	assert.Equal(t, CONDITIONAL_TRIGGERED, engine.Get(tp).Status)
+	assert.Equal(t, 111.0, trader.orders[1].price)
+
+	engine.OnPrice(80)
+	assert.Len(t, trader.orders, 2)
+
+	_, err = engine.Submit(&ConditionalOrder{Type: STOP_LOSS, Side: SELL, Amount: 1})
+	assert.Equal(t, ErrInvalidOrder, err)
+}
+
+func TestEngine_TrailingStop(t *testing.T) {
+	trader := &mockTrader{}
+	engine := NewEngine(trader)
+
+	id, _ := engine.Submit(&ConditionalOrder{Type: TRAILING_STOP, Side: SELL, Amount: 1, TrailingDistance: 5, ActivationPrice: 100})
+
+	engine.OnPrice(95) //未激活
+	engine.OnPrice(90)
+	assert.Len(t, trader.orders, 0)
+
+	engine.OnPrice(100)
+	engine.OnPrice(120)
+	engine.OnPrice(116)
+	assert.Len(t, trader.orders, 0)
+	assert.Equal(t, 115.0, engine.Get(id).TriggerPrice)
+
+	engine.OnPrice(115)
+	assert.Len(t, trader.orders, 1)
+	assert.Equal(t, 120.0, engine.Get(id).Extreme)
+
+	buyId, _ := engine.Submit(&ConditionalOrder{Type: TRAILING_STOP, Side: BUY, Amount: 1, TrailingRatio: 0.1})
+	engine.OnPrice(100)
+	engine.OnPrice(80)
+	engine.OnPrice(87)
+	assert.Equal(t, CONDITIONAL_PENDING, engine.Get(buyId).Status)
+	engine.OnPrice(88)
+	assert.Equal(t, CONDITIONAL_TRIGGERED, engine.Get(buyId).Status)
+}
+
+func TestEngine_OCO(t *testing.T) {
+	trader := &mockTrader{}
+	engine := NewEngine(trader)
+
+	var triggered []*ConditionalOrder
+	engine.OnTriggered(func(o *ConditionalOrder) { triggered = append(triggered, o) })
+
+	sl := &ConditionalOrder{Type: STOP_LOSS, Side: SELL, Amount: 1, TriggerPrice: 90}
+	tp := &ConditionalOrder{Type: TAKE_PROFIT, Side: SELL, Amount: 1, TriggerPrice: 110}
+	groupId, err := engine.OCO(sl, tp)
+	assert.Nil(t, err)
+	assert.Equal(t, groupId, tp.GroupId)
+
+	engine.OnPrice(111)
+	assert.Equal(t, CONDITIONAL_TRIGGERED, tp.Status)
+	assert.Equal(t, CONDITIONAL_CANCELED, sl.Status)
+	assert.Len(t, triggered, 1)
+
+	engine.OnPrice(80)
+	assert.Len(t, trader.orders, 1)
+	assert.Equal(t, ErrOrderNotPending, engine.Cancel(sl.Id))
+}
+
+func TestEngine_Native(t *testing.T) {
+	trader := &mockNativeTrader{mockTrader: mockTrader{native: map[string]*ConditionalOrder{}}}
+	engine := NewEngine(trader)
+
+	id, err := engine.Submit(&ConditionalOrder{Type: STOP_LOSS, Side: SELL, Amount: 1, TriggerPrice: 90})
+	assert.Nil(t, err)
+	assert.Equal(t, "native-"+id, engine.Get(id).NativeId)
+
+	engine.OnPrice(80) //交易所负责触发
+	assert.Len(t, trader.orders, 0)
+
+	assert.Nil(t, engine.Cancel(id))
+	assert.Len(t, trader.native, 0)
+	assert.Equal(t, CONDITIONAL_CANCELED, engine.Get(id).Status)
+}
+
+func TestEngine_OCONative(t *testing.T) {
+	trader := &mockNativeTrader{mockTrader: mockTrader{native: map[string]*ConditionalOrder{}}}
+	engine := NewEngine(trader)
+
+	sl := &ConditionalOrder{Type: STOP_LOSS, Side: SELL, Amount: 1, TriggerPrice: 90}
+	ts := &ConditionalOrder{Type: TRAILING_STOP, Side: SELL, Amount: 1, TrailingDistance: 5}
+	_, err := engine.OCO(sl, ts)
+	assert.Nil(t, err)
+	//OCO 组内的条件单不委托给交易所
+	assert.Empty(t, sl.NativeId)
+	assert.Len(t, trader.native, 0)
+
+	engine.OnPrice(100)
+	engine.OnPrice(94)
+	assert.Equal(t, CONDITIONAL_TRIGGERED, ts.Status)
+	assert.Equal(t, CONDITIONAL_CANCELED, sl.Status)
+	assert.Len(t, trader.orders, 1)
+}
+
+func TestEngine_SyncNative(t *testing.T) {
+	trader := &mockNativeTrader{mockTrader: mockTrader{native: map[string]*ConditionalOrder{}}, status: map[string]TradeStatus{}}
+	engine := NewEngine(trader)
+
+	var triggered []*ConditionalOrder
+	engine.OnTriggered(func(o *ConditionalOrder) { triggered = append(triggered, o) })
+
+	sl, _ := engine.Submit(&ConditionalOrder{Type: STOP_LOSS, Side: SELL, Amount: 1, TriggerPrice: 90})
+	tp, _ := engine.Submit(&ConditionalOrder{Type: TAKE_PROFIT, Side: SELL, Amount: 1, TriggerPrice: 110})
+	rj, _ := engine.Submit(&ConditionalOrder{Type: TAKE_PROFIT, Side: SELL, Amount: 1, TriggerPrice: 120})
+
+	assert.Nil(t, engine.SyncNative())
+	assert.Equal(t, CONDITIONAL_PENDING, engine.Get(sl).Status)
+	assert.Len(t, triggered, 0)
+
+	trader.status["native-"+sl] = ORDER_TRIGGERED
+	trader.status["native-"+tp] = ORDER_CANCEL
+	trader.status["native-"+rj] = ORDER_FAIL
+	assert.Nil(t, engine.SyncNative())
+	assert.Equal(t, CONDITIONAL_TRIGGERED, engine.Get(sl).Status)
+	assert.Equal(t, "native-"+sl, engine.Get(sl).OrderId)
+	assert.Equal(t, CONDITIONAL_CANCELED, engine.Get(tp).Status)
+	assert.Equal(t, CONDITIONAL_FAILED, engine.Get(rj).Status)
+	assert.NotNil(t, engine.Get(rj).Err)
+	assert.Len(t, triggered, 2)
+
+	//已同步的不再回调
+	assert.Nil(t, engine.SyncNative())
+	assert.Len(t, triggered, 2)
+	assert.Len(t, trader.orders, 0)
+}
+
+type mockStopAPI struct {
+	API
+	stops int
+}
+
+func (m *mockStopAPI) GetExchangeName() string {
+	return "mock"
+}
+
+func (m *mockStopAPI) StopSell(amount, price string, currencyPair CurrencyPair) (*Order, error) {
+	m.stops++
+	return &Order{OrderID2: "1"}, nil
+}
+
+func (m *mockStopAPI) StopBuy(amount, price string, currencyPair CurrencyPair) (*Order, error) {
+	m.stops++
+	return &Order{OrderID2: "1"}, nil
+}
+
+func TestSpotTrader_PlaceNative(t *testing.T) {
+	api := &mockStopAPI{}
+	trader := NewSpotTrader(api, BTC_USDT)
+
+	_, ok, err := trader.PlaceNative(&ConditionalOrder{Type: STOP_LOSS, Side: SELL, Amount: 1, TriggerPrice: 90})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	//限价止损在本地模拟
+	_, ok, _ = trader.PlaceNative(&ConditionalOrder{Type: STOP_LOSS, Side: SELL, Amount: 1, TriggerPrice: 90, Price: 89})
+	assert.False(t, ok)
+	assert.Equal(t, 1, api.stops)
+
+	//InstrumentAPI 包装后仍委托给交易所
+	trader = NewSpotTrader(InstrumentAPI(api, Instrumentation{Tracer: noopTracer{}}), BTC_USDT)
+	_, ok, err = trader.PlaceNative(&ConditionalOrder{Type: STOP_LOSS, Side: SELL, Amount: 1, TriggerPrice: 90})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, api.stops)
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(name string, fields ...LogField) Span { return noopSpan{} }
+
+type noopSpan struct{}
+
+func (noopSpan) End(err error) {}
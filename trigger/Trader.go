@@ -0,0 +1,222 @@
+package trigger
+
+import (
+	. "github.com/BTreeNewBee/goex"
+)
+
+// Trader 条件单触发后的下单接口, 屏蔽现货与合约的差异
+type Trader interface {
+	// price<=0 时市价下单
+	// close 只对合约有效, 表示平仓单: SELL 平多, BUY 平空
+	PlaceOrder(side TradeSide, close bool, amount, price float64) (orderId string, err error)
+	CancelOrder(orderId string) error
+}
+
+// NativeTrader 交易所支持原生止盈止损时实现, 条件单直接委托给交易所
+// OCO 组内的条件单始终在本地模拟, 引擎不会为其调用 PlaceNative
+type NativeTrader interface {
+	// ok=false 表示该条件单不支持原生委托, 需要本地模拟
+	PlaceNative(o *ConditionalOrder) (orderId string, ok bool, err error)
+	CancelNative(o *ConditionalOrder) error
+	// GetNative 查询原生单的状态, 用于 Engine.SyncNative
+	GetNative(o *ConditionalOrder) (TradeStatus, error)
+}
+
+// 现货原生止损单, 比如: bitfinex.Bitfinex
+type spotStopAPI interface {
+	StopBuy(amount, price string, currencyPair CurrencyPair) (*Order, error)
+	StopSell(amount, price string, currencyPair CurrencyPair) (*Order, error)
+}
+
+type SpotTrader struct {
+	api  API
+	pair CurrencyPair
+}
+
+func NewSpotTrader(api API, pair CurrencyPair) *SpotTrader {
+	return &SpotTrader{api: api, pair: pair}
+}
+
+func (t *SpotTrader) format(amount, price float64) (string, string) {
+	amountPrecision, pricePrecision := t.pair.AmountTickSize, t.pair.PriceTickSize
+	if amountPrecision <= 0 {
+		amountPrecision = 8
+	}
+	if pricePrecision <= 0 {
+		pricePrecision = 8
+	}
+	return FloatToString(amount, amountPrecision), FloatToString(price, pricePrecision)
+}
+
+func (t *SpotTrader) PlaceOrder(side TradeSide, close bool, amount, price float64) (string, error) {
+	var (
+		ord             *Order
+		err             error
+		amountS, priceS = t.format(amount, price)
+	)
+
+	switch {
+	case side == BUY && price > 0:
+		ord, err = t.api.LimitBuy(amountS, priceS, t.pair)
+	case side == BUY:
+		ord, err = t.api.MarketBuy(amountS, priceS, t.pair)
+	case price > 0:
+		ord, err = t.api.LimitSell(amountS, priceS, t.pair)
+	default:
+		ord, err = t.api.MarketSell(amountS, priceS, t.pair)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return ord.OrderID2, nil
+}
+
+func (t *SpotTrader) CancelOrder(orderId string) error {
+	_, err := t.api.CancelOrder(orderId, t.pair)
+	return err
+}
+
+// PlaceNative 仅市价止损单委托给交易所的 StopBuy/StopSell, OCO 仍在本地模拟
+func (t *SpotTrader) PlaceNative(o *ConditionalOrder) (string, bool, error) {
+	stopApi, ok := UnwrapAPI(t.api).(spotStopAPI)
+	if !ok || o.Type != STOP_LOSS || o.Price > 0 {
+		return "", false, nil
+	}
+
+	var (
+		ord                    *Order
+		err                    error
+		amountS, triggerPriceS = t.format(o.Amount, o.TriggerPrice)
+	)
+
+	if o.Side == BUY {
+		ord, err = stopApi.StopBuy(amountS, triggerPriceS, t.pair)
+	} else {
+		ord, err = stopApi.StopSell(amountS, triggerPriceS, t.pair)
+	}
+
+	if err != nil {
+		return "", true, err
+	}
+
+	return ord.OrderID2, true, nil
+}
+
+func (t *SpotTrader) CancelNative(o *ConditionalOrder) error {
+	return t.CancelOrder(o.NativeId)
+}
+
+func (t *SpotTrader) GetNative(o *ConditionalOrder) (TradeStatus, error) {
+	ord, err := t.api.GetOneOrder(o.NativeId, t.pair)
+	if err != nil {
+		return ORDER_UNFINISH, err
+	}
+	return ord.Status, nil
+}
+
+type FutureTrader struct {
+	api          FutureRestAPI
+	pair         CurrencyPair
+	contractType string
+}
+
+func NewFutureTrader(api FutureRestAPI, pair CurrencyPair, contractType string) *FutureTrader {
+	return &FutureTrader{api: api, pair: pair, contractType: contractType}
+}
+
+func (t *FutureTrader) openType(side TradeSide, close bool) int {
+	switch {
+	case side == BUY && close:
+		return CLOSE_SELL
+	case side == BUY:
+		return OPEN_BUY
+	case close:
+		return CLOSE_BUY
+	default:
+		return OPEN_SELL
+	}
+}
+
+func (t *FutureTrader) PlaceOrder(side TradeSide, close bool, amount, price float64) (string, error) {
+	var (
+		ord      *FutureOrder
+		err      error
+		openType = t.openType(side, close)
+	)
+
+	if price > 0 {
+		pricePrecision := t.pair.PriceTickSize
+		if pricePrecision <= 0 {
+			pricePrecision = 8
+		}
+		ord, err = t.api.LimitFuturesOrder(t.pair, t.contractType, FloatToString(price, pricePrecision), FloatToString(amount, 0), openType)
+	} else {
+		ord, err = t.api.MarketFuturesOrder(t.pair, t.contractType, FloatToString(amount, 0), openType)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return ord.OrderID2, nil
+}
+
+func (t *FutureTrader) CancelOrder(orderId string) error {
+	_, err := t.api.FutureCancelOrder(t.pair, t.contractType, orderId)
+	return err
+}
+
+// PlaceNative 交易所实现 FutureAlgoOrderAPI 时止盈止损单委托给计划委托, 跟踪止损与OCO仍在本地模拟
+func (t *FutureTrader) PlaceNative(o *ConditionalOrder) (string, bool, error) {
+	algoApi, ok := UnwrapFutureRestAPI(t.api).(FutureAlgoOrderAPI)
+	if !ok || o.Type == TRAILING_STOP {
+		return "", false, nil
+	}
+
+	ord := &FutureOrder{
+		Currency:     t.pair,
+		ContractName: t.contractType,
+		OType:        t.openType(o.Side, o.Close),
+		OrderType:    1, //okex 计划委托
+		TriggerPrice: o.TriggerPrice,
+		Price:        o.Price,
+		Amount:       o.Amount,
+		AlgoType:     1,
+	}
+	if o.Price <= 0 {
+		ord.AlgoType = 2
+	}
+
+	ord, err := algoApi.PlaceFutureAlgoOrder(ord)
+	if err != nil {
+		return "", true, err
+	}
+
+	return ord.OrderID2, true, nil
+}
+
+func (t *FutureTrader) CancelNative(o *ConditionalOrder) error {
+	algoApi, ok := UnwrapFutureRestAPI(t.api).(FutureAlgoOrderAPI)
+	if !ok {
+		return ErrNativeUnsupported
+	}
+	_, err := algoApi.FutureCancelAlgoOrder(t.pair, []string{o.NativeId}, t.contractType)
+	return err
+}
+
+func (t *FutureTrader) GetNative(o *ConditionalOrder) (TradeStatus, error) {
+	algoApi, ok := UnwrapFutureRestAPI(t.api).(FutureAlgoOrderAPI)
+	if !ok {
+		return ORDER_UNFINISH, ErrNativeUnsupported
+	}
+	orders, err := algoApi.GetFutureAlgoOrders(o.NativeId, "", t.pair, t.contractType)
+	if err != nil {
+		return ORDER_UNFINISH, err
+	}
+	if len(orders) == 0 {
+		return ORDER_UNFINISH, ErrOrderNotFound
+	}
+	return orders[0].Status, nil
+}
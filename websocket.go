@@ -1,11 +1,13 @@
 package goex
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	. "github.com/BTreeNewBee/goex/internal/logger"
 	"github.com/gorilla/websocket"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -26,16 +28,14 @@ type WsConfig struct {
 	ConnectSuccessAfterSendMessage func() []byte //for reconnect
 	IsDump                         bool
 	DisableEnableCompression       bool
+	TLSConfig                      *tls.Config
+	HandshakeTimeout               time.Duration   //默认30秒
+	LocalAddr                      string          //绑定的本地ip,多出口ip时使用
+	Metrics                        MetricsRecorder //为 nil 时不收集
 	readDeadLineTime               time.Duration
 	reconnectInterval              time.Duration
 }
 
-var dialer = &websocket.Dialer{
-	Proxy:             http.ProxyFromEnvironment,
-	HandshakeTimeout:  30 * time.Second,
-	EnableCompression: true,
-}
-
 type WsConn struct {
 	c *websocket.Conn
 	WsConfig
@@ -105,6 +105,52 @@ func (b *WsBuilder) DisableEnableCompression() *WsBuilder {
 	return b
 }
 
+func (b *WsBuilder) TLSConfig(c *tls.Config) *WsBuilder {
+	b.wsConfig.TLSConfig = c
+	return b
+}
+
+func (b *WsBuilder) HandshakeTimeout(t time.Duration) *WsBuilder {
+	b.wsConfig.HandshakeTimeout = t
+	return b
+}
+
+func (b *WsBuilder) Metrics(m MetricsRecorder) *WsBuilder {
+	b.wsConfig.Metrics = m
+	return b
+}
+
+func (b *WsBuilder) LocalAddr(ip string) *WsBuilder {
+	b.wsConfig.LocalAddr = ip
+	return b
+}
+
+// ClientConfig 应用 WsClientConfig 中的非零值字段, c 为 nil 时不做修改
+func (b *WsBuilder) ClientConfig(c *WsClientConfig) *WsBuilder {
+	if c == nil {
+		return b
+	}
+	if c.WsUrl != "" {
+		b.wsConfig.WsUrl = c.WsUrl
+	}
+	if c.ProxyUrl != "" {
+		b.wsConfig.ProxyUrl = c.ProxyUrl
+	}
+	if c.TLSConfig != nil {
+		b.wsConfig.TLSConfig = c.TLSConfig
+	}
+	if c.HandshakeTimeout > 0 {
+		b.wsConfig.HandshakeTimeout = c.HandshakeTimeout
+	}
+	if c.LocalAddr != "" {
+		b.wsConfig.LocalAddr = c.LocalAddr
+	}
+	if c.Metrics != nil {
+		b.wsConfig.Metrics = c.Metrics
+	}
+	return b
+}
+
 func (b *WsBuilder) DecompressFunc(f func([]byte) ([]byte, error)) *WsBuilder {
 	b.wsConfig.DecompressFunc = f
 	return b
@@ -155,7 +201,19 @@ func (ws *WsConn) NewWs() *WsConn {
 	return ws
 }
 
-func (ws *WsConn) connect() error {
+// newDialer 每个连接使用独立的 dialer, 多个连接的代理等配置互不影响
+func (ws *WsConn) newDialer() (*websocket.Dialer, error) {
+	dialer := &websocket.Dialer{
+		Proxy:             http.ProxyFromEnvironment,
+		HandshakeTimeout:  30 * time.Second,
+		EnableCompression: !ws.DisableEnableCompression,
+		TLSClientConfig:   ws.TLSConfig,
+	}
+
+	if ws.HandshakeTimeout > 0 {
+		dialer.HandshakeTimeout = ws.HandshakeTimeout
+	}
+
 	if ws.ProxyUrl != "" {
 		proxy, err := url.Parse(ws.ProxyUrl)
 		if err == nil {
@@ -166,8 +224,23 @@ func (ws *WsConn) connect() error {
 		}
 	}
 
-	if ws.DisableEnableCompression {
-		dialer.EnableCompression = false
+	if ws.LocalAddr != "" {
+		ip := net.ParseIP(ws.LocalAddr)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid local addr [%s]", ws.LocalAddr)
+		}
+		netDialer := &net.Dialer{Timeout: dialer.HandshakeTimeout, LocalAddr: &net.TCPAddr{IP: ip}}
+		dialer.NetDial = netDialer.Dial
+	}
+
+	return dialer, nil
+}
+
+func (ws *WsConn) connect() error {
+	dialer, err := ws.newDialer()
+	if err != nil {
+		Log.Errorf("[ws][%s] %s", ws.WsUrl, err.Error())
+		return err
 	}
 
 	wsConn, resp, err := dialer.Dial(ws.WsUrl, http.Header(ws.ReqHeaders))
@@ -196,6 +269,9 @@ func (ws *WsConn) reconnect() {
 	defer ws.reConnectLock.Unlock()
 
 	ws.c.Close() //主动关闭一次
+	if ws.Metrics != nil {
+		ws.Metrics.IncWsReconnect(ws.WsUrl)
+	}
 	var err error
 	for retry := 1; retry <= 100; retry++ {
 		err = ws.connect()
@@ -348,6 +424,9 @@ func (ws *WsConn) receiveMessage() {
 				return
 			}
 			//			Log.Debug(string(msg))
+			if ws.Metrics != nil {
+				ws.Metrics.IncWsMessage(ws.WsUrl)
+			}
 			ws.c.SetReadDeadline(time.Now().Add(ws.readDeadLineTime))
 			switch t {
 			case websocket.TextMessage:
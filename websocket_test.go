@@ -3,6 +3,11 @@ package goex
 import (
 	"encoding/json"
 	. "github.com/BTreeNewBee/goex/internal/logger"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -49,3 +54,34 @@ func TestNewWsConn(t *testing.T) {
 	ws.c.Close()
 	time.Sleep(time.Second * 120)
 }
+
+func TestWsConn_newDialer(t *testing.T) {
+	var upgrader websocket.Upgrader
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			c.Close()
+		}
+	}))
+	defer srv.Close()
+	wsUrl := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	proxied := &WsConn{WsConfig: *NewWsBuilder().WsUrl(wsUrl).ProxyUrl("http://127.0.0.1:1").DisableEnableCompression().wsConfig}
+	direct := &WsConn{WsConfig: *NewWsBuilder().ClientConfig(&WsClientConfig{WsUrl: wsUrl, LocalAddr: "127.0.0.1", HandshakeTimeout: 3 * time.Second}).wsConfig}
+
+	//另一个连接的代理不影响当前连接
+	_, err := proxied.newDialer()
+	assert.Nil(t, err)
+	assert.Nil(t, direct.connect())
+	direct.c.Close()
+
+	dialer, _ := direct.newDialer()
+	assert.True(t, dialer.EnableCompression)
+	assert.Equal(t, 3*time.Second, dialer.HandshakeTimeout)
+	assert.NotNil(t, dialer.NetDial)
+
+	assert.NotNil(t, proxied.connect())
+
+	direct.LocalAddr = "localhost"
+	assert.NotNil(t, direct.connect())
+}
@@ -42,6 +42,16 @@ func (zb *Zb) GetExchangeName() string {
 	return ZB
 }
 
+// GetAllCurrencyPair 未对接交易对列表接口
+func (zb *Zb) GetAllCurrencyPair() ([]CurrencyPair, error) {
+	return nil, EX_ERR_NOT_SUPPORT
+}
+
+// GetTimestamp 未对接服务器时间接口
+func (zb *Zb) GetTimestamp() (int64, error) {
+	return 0, EX_ERR_NOT_SUPPORT
+}
+
 func (zb *Zb) GetTicker(currency CurrencyPair) (*Ticker, error) {
 	symbol := currency.ToSymbol("_")
 	resp, err := HttpGet(zb.httpClient, MARKET_URL+fmt.Sprintf(TICKER_API, symbol))